@@ -0,0 +1,90 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package debugserver
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+// newSignerAccountValue returns a new account with the given address,
+// which can be used as a signer of a transaction.
+//
+// The storage of the account is fully functional, but the account has no balance,
+// and keys and contracts cannot be managed
+//
+func newSignerAccountValue(address interpreter.AddressValue) interpreter.Value {
+
+	unsupportedFunction := func(name string) *interpreter.HostFunctionValue {
+		return interpreter.NewHostFunctionValue(
+			func(invocation interpreter.Invocation) interpreter.Value {
+				panic(fmt.Errorf("`%s` is not supported by the debugger", name))
+			},
+			stdlib.PanicFunction.Type,
+		)
+	}
+
+	returnZeroUFix64 := func() interpreter.UFix64Value {
+		return 0
+	}
+
+	return interpreter.NewAuthAccountValue(
+		address,
+		returnZeroUFix64,
+		returnZeroUFix64,
+		func(_ *interpreter.Interpreter) interpreter.UInt64Value {
+			return 0
+		},
+		func() interpreter.UInt64Value {
+			return 0
+		},
+		unsupportedFunction(sema.AuthAccountAddPublicKeyField),
+		unsupportedFunction(sema.AuthAccountRemovePublicKeyField),
+		func() interpreter.Value {
+			return interpreter.NewAuthAccountContractsValue(
+				address,
+				unsupportedFunction(sema.AuthAccountContractsTypeAddFunctionName),
+				unsupportedFunction(sema.AuthAccountContractsTypeUpdateExperimentalFunctionName),
+				unsupportedFunction(sema.AuthAccountContractsTypeGetFunctionName),
+				unsupportedFunction(sema.AuthAccountContractsTypeRemoveFunctionName),
+				func(inter *interpreter.Interpreter) *interpreter.ArrayValue {
+					return interpreter.NewArrayValue(
+						inter,
+						interpreter.VariableSizedStaticType{
+							Type: interpreter.PrimitiveStaticTypeString,
+						},
+						common.Address{},
+					)
+				},
+			)
+		},
+		func() interpreter.Value {
+			return interpreter.NewAuthAccountKeysValue(
+				address,
+				unsupportedFunction(sema.AccountKeysAddFunctionName),
+				unsupportedFunction(sema.AccountKeysGetFunctionName),
+				unsupportedFunction(sema.AccountKeysRevokeFunctionName),
+			)
+		},
+	)
+}
@@ -0,0 +1,44 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/onflow/cadence/debugserver"
+)
+
+// A debug adapter for Cadence scripts and transactions.
+//
+// It implements the Debug Adapter Protocol (DAP) over standard input and output,
+// and is started by editors, e.g. Visual Studio Code.
+//
+// Usage: debugserver
+//
+func main() {
+	server := debugserver.NewServer()
+
+	err := server.Serve(os.Stdin, os.Stdout)
+	if err != nil {
+		// Standard output is used for the protocol
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
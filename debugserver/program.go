@@ -0,0 +1,299 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package debugserver
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/pretty"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+var typeDeclarations = append(
+	stdlib.FlowBuiltInTypes,
+	stdlib.BuiltinTypes...,
+).ToTypeDeclarations()
+
+// program is a script or transaction which is debugged,
+// together with the programs it imports.
+//
+// The program and the imported programs are files.
+// Imports are resolved relative to the importing file
+//
+type program struct {
+	location common.StringLocation
+	codes    map[common.LocationID]string
+	checkers map[common.LocationID]*sema.Checker
+	// checking are the locations of the programs which are currently checked,
+	// and is used to detect cyclic imports
+	checking map[common.LocationID]struct{}
+	// valueDeclarations are the predeclared values,
+	// e.g. the function `log`, which reports its output using the given function
+	valueDeclarations stdlib.StandardLibraryFunctions
+}
+
+// loadProgram parses and checks the program in the file at the given path,
+// and the programs it imports.
+// The given function is called with the output of the program, e.g. logs.
+//
+// If the program or an imported program is invalid, the program is returned together with the error,
+// so the error can be formatted
+//
+func loadProgram(path string, output func(string)) (*program, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	impls := stdlib.DefaultFlowBuiltinImpls()
+	impls.Log = func(invocation interpreter.Invocation) interpreter.Value {
+		output(invocation.Arguments[0].String() + "\n")
+		return interpreter.VoidValue{}
+	}
+
+	p := &program{
+		location: common.StringLocation(path),
+		codes:    map[common.LocationID]string{},
+		checkers: map[common.LocationID]*sema.Checker{},
+		checking: map[common.LocationID]struct{}{},
+		valueDeclarations: append(
+			stdlib.FlowBuiltInFunctions(impls),
+			stdlib.BuiltinFunctions...,
+		),
+	}
+
+	_, err = p.check(p.location)
+	if err != nil {
+		// Return the program, so the error can be formatted
+		return p, err
+	}
+
+	return p, nil
+}
+
+func (p *program) check(location common.StringLocation) (*sema.Checker, error) {
+	locationID := location.ID()
+
+	if checker, ok := p.checkers[locationID]; ok {
+		return checker, nil
+	}
+
+	if _, ok := p.checking[locationID]; ok {
+		return nil, fmt.Errorf("cyclic import of `%s`", location)
+	}
+	p.checking[locationID] = struct{}{}
+	defer delete(p.checking, locationID)
+
+	code, err := ioutil.ReadFile(string(location))
+	if err != nil {
+		return nil, err
+	}
+	p.codes[locationID] = string(code)
+
+	parsed, err := parser2.ParseProgram(string(code))
+	if err != nil {
+		return nil, err
+	}
+
+	checker, err := sema.NewChecker(
+		parsed,
+		location,
+		sema.WithPredeclaredValues(p.valueDeclarations.ToSemaValueDeclarations()),
+		sema.WithPredeclaredTypes(typeDeclarations),
+		sema.WithImportHandler(
+			func(checker *sema.Checker, importedLocation common.Location, _ ast.Range) (sema.Import, error) {
+				importedFileLocation, err := p.resolveLocation(checker.Location, importedLocation)
+				if err != nil {
+					return nil, err
+				}
+
+				importedChecker, err := p.check(importedFileLocation)
+				if err != nil {
+					return nil, err
+				}
+
+				return sema.ElaborationImport{
+					Elaboration: importedChecker.Elaboration,
+				}, nil
+			},
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checker.Check()
+	if err != nil {
+		return nil, err
+	}
+
+	p.checkers[locationID] = checker
+
+	return checker, nil
+}
+
+// resolveLocation returns the location of the file imported by the program at the given location.
+// Only files can be imported, relative paths are resolved relative to the importing file
+//
+func (p *program) resolveLocation(importingLocation, importedLocation common.Location) (common.StringLocation, error) {
+	stringLocation, ok := importedLocation.(common.StringLocation)
+	if !ok {
+		return "", fmt.Errorf("cannot import `%s`. only files are supported", importedLocation)
+	}
+
+	path := string(stringLocation)
+	if !filepath.IsAbs(path) {
+		importingPath := string(importingLocation.(common.StringLocation))
+		path = filepath.Join(filepath.Dir(importingPath), path)
+	}
+
+	return common.StringLocation(path), nil
+}
+
+// run interprets the program with the given debugger.
+//
+// If the program is a transaction, the transaction is executed.
+// The transaction may not have parameters, and each signer is a new account with an empty storage.
+//
+// Otherwise, if the program is a script, i.e. it declares a function `main`,
+// the function is invoked and the result is returned
+//
+func (p *program) run(debugger *interpreter.Debugger) (interpreter.Value, error) {
+	checker := p.checkers[p.location.ID()]
+
+	var uuid uint64
+
+	contracts := map[common.TypeID]*interpreter.CompositeValue{}
+
+	inter, err := interpreter.NewInterpreter(
+		interpreter.ProgramFromChecker(checker),
+		checker.Location,
+		interpreter.WithStorage(interpreter.NewInMemoryStorage()),
+		interpreter.WithPredeclaredValues(p.valueDeclarations.ToInterpreterValueDeclarations()),
+		interpreter.WithUUIDHandler(func() (uint64, error) {
+			defer func() { uuid++ }()
+			return uuid, nil
+		}),
+		interpreter.WithContractValueHandler(
+			func(
+				inter *interpreter.Interpreter,
+				compositeType *sema.CompositeType,
+				constructorGenerator func(common.Address) *interpreter.HostFunctionValue,
+				invocationRange ast.Range,
+			) *interpreter.CompositeValue {
+
+				// Contracts are initialized when they are used first.
+				// The initializer may not have parameters
+
+				typeID := compositeType.ID()
+				if contract, ok := contracts[typeID]; ok {
+					return contract
+				}
+
+				value, err := inter.InvokeFunctionValue(
+					constructorGenerator(common.Address{}),
+					nil,
+					nil,
+					nil,
+					invocationRange,
+				)
+				if err != nil {
+					panic(err)
+				}
+
+				contract := value.(*interpreter.CompositeValue)
+				contracts[typeID] = contract
+				return contract
+			},
+		),
+		interpreter.WithImportLocationHandler(
+			func(inter *interpreter.Interpreter, location common.Location) interpreter.Import {
+				importedLocation, err := p.resolveLocation(inter.Location, location)
+				if err != nil {
+					panic(err)
+				}
+
+				importedChecker := p.checkers[importedLocation.ID()]
+
+				subInterpreter, err := inter.NewSubInterpreter(
+					interpreter.ProgramFromChecker(importedChecker),
+					importedLocation,
+				)
+				if err != nil {
+					panic(err)
+				}
+
+				return interpreter.InterpreterImport{
+					Interpreter: subInterpreter,
+				}
+			},
+		),
+		interpreter.WithDebugger(debugger),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = inter.Interpret()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inter.Transactions) > 0 {
+		transactionType := checker.Elaboration.TransactionTypes[0]
+		if len(transactionType.Parameters) > 0 {
+			return nil, fmt.Errorf("transaction parameters are not supported")
+		}
+
+		signers := make([]interpreter.Value, 0, len(transactionType.PrepareParameters))
+		for i := range transactionType.PrepareParameters {
+			var address common.Address
+			address[len(address)-1] = byte(i + 1)
+			signers = append(signers, newSignerAccountValue(interpreter.AddressValue(address)))
+		}
+
+		return nil, inter.InvokeTransaction(0, signers...)
+	}
+
+	if inter.Globals.Contains("main") {
+		return inter.Invoke("main")
+	}
+
+	return nil, nil
+}
+
+// formatError returns the pretty-printed error, including excerpts of the affected code
+//
+func (p *program) formatError(err error) string {
+	var buffer bytes.Buffer
+	printErr := pretty.NewErrorPrettyPrinter(&buffer, false).
+		PrettyPrintError(err, p.location, p.codes)
+	if printErr != nil {
+		return err.Error()
+	}
+	return buffer.String()
+}
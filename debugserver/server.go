@@ -0,0 +1,769 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package debugserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+
+	"github.com/google/go-dap"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+// threadID is the ID of the only thread, the thread which executes the program
+//
+const threadID = 1
+
+// The reasons for stops, as defined by the protocol
+//
+const (
+	stopReasonEntry      = "entry"
+	stopReasonStep       = "step"
+	stopReasonPause      = "pause"
+	stopReasonBreakpoint = "breakpoint"
+)
+
+// launchArguments are the arguments of the launch request
+//
+type launchArguments struct {
+	// Program is the path of the script or transaction file
+	Program string `json:"program"`
+	// StopOnEntry stops the program before the first statement is executed
+	StopOnEntry bool `json:"stopOnEntry"`
+}
+
+// Server is a debug adapter, which implements the Debug Adapter Protocol (DAP)
+// for Cadence scripts and transactions, using an interpreter.Debugger.
+//
+// A server handles a single debug session
+//
+type Server struct {
+	debugger *interpreter.Debugger
+	done     chan struct{}
+
+	writeMutex sync.Mutex
+	writer     io.Writer
+	seq        int
+
+	// mutex protects the state of the session below
+	mutex      sync.Mutex
+	program    *program
+	configured bool
+	started    bool
+	// stopReason is the reason for the next stop which is not caused by a breakpoint
+	stopReason string
+	// stop is the current stop of the program, if it is stopped
+	stop       *interpreter.Stop
+	frames     []interpreter.StackFrame
+	references variableReferences
+}
+
+func NewServer() *Server {
+	return &Server{
+		debugger: interpreter.NewDebugger(),
+		done:     make(chan struct{}),
+	}
+}
+
+// Serve handles the requests read from the given reader,
+// and writes the responses and events to the given writer.
+//
+// Serve returns when the session is disconnected or terminated, or the reader is closed
+//
+func (s *Server) Serve(reader io.Reader, writer io.Writer) error {
+	s.writer = writer
+
+	go s.handleStops()
+	defer close(s.done)
+
+	bufferedReader := bufio.NewReader(reader)
+
+	for {
+		message, err := dap.ReadProtocolMessage(bufferedReader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			// Requests with unsupported commands are answered with an error response
+
+			var fieldErr *dap.DecodeProtocolMessageFieldError
+			if errors.As(err, &fieldErr) && fieldErr.SubType == "Request" {
+				s.send(s.newErrorResponse(
+					&dap.Request{
+						ProtocolMessage: dap.ProtocolMessage{
+							Seq: fieldErr.Seq,
+						},
+						Command: fieldErr.FieldValue,
+					},
+					fmt.Errorf("unsupported request: %s", fieldErr.FieldValue),
+				))
+				continue
+			}
+
+			return err
+		}
+
+		request, ok := message.(dap.RequestMessage)
+		if !ok {
+			continue
+		}
+
+		response, err := s.handle(request)
+		if err != nil {
+			s.send(s.newErrorResponse(request.GetRequest(), err))
+			continue
+		}
+
+		s.send(response)
+
+		switch request.(type) {
+		case *dap.InitializeRequest:
+			s.send(&dap.InitializedEvent{
+				Event: s.newEvent("initialized"),
+			})
+
+		case *dap.LaunchRequest, *dap.ConfigurationDoneRequest:
+			s.startIfReady()
+
+		// The program is only resumed after the response was sent,
+		// so the response is sent before the next stop is reported
+
+		case *dap.ContinueRequest:
+			s.debugger.Continue()
+
+		case *dap.NextRequest:
+			s.debugger.RequestStepOver()
+
+		case *dap.StepInRequest:
+			s.debugger.RequestStepIn()
+
+		case *dap.StepOutRequest:
+			s.debugger.RequestStepOut()
+
+		case *dap.DisconnectRequest, *dap.TerminateRequest:
+			return nil
+		}
+	}
+}
+
+func (s *Server) handle(request dap.RequestMessage) (dap.ResponseMessage, error) {
+	switch request := request.(type) {
+	case *dap.InitializeRequest:
+		return s.onInitialize(request)
+	case *dap.LaunchRequest:
+		return s.onLaunch(request)
+	case *dap.SetBreakpointsRequest:
+		return s.onSetBreakpoints(request)
+	case *dap.SetExceptionBreakpointsRequest:
+		return s.onSetExceptionBreakpoints(request)
+	case *dap.ConfigurationDoneRequest:
+		return s.onConfigurationDone(request)
+	case *dap.ThreadsRequest:
+		return s.onThreads(request)
+	case *dap.StackTraceRequest:
+		return s.onStackTrace(request)
+	case *dap.ScopesRequest:
+		return s.onScopes(request)
+	case *dap.VariablesRequest:
+		return s.onVariables(request)
+	case *dap.EvaluateRequest:
+		return s.onEvaluate(request)
+	case *dap.ContinueRequest:
+		return s.onContinue(request)
+	case *dap.NextRequest:
+		return s.onNext(request)
+	case *dap.StepInRequest:
+		return s.onStepIn(request)
+	case *dap.StepOutRequest:
+		return s.onStepOut(request)
+	case *dap.PauseRequest:
+		return s.onPause(request)
+	case *dap.DisconnectRequest:
+		return &dap.DisconnectResponse{
+			Response: s.newResponse(request.GetRequest()),
+		}, nil
+	case *dap.TerminateRequest:
+		return &dap.TerminateResponse{
+			Response: s.newResponse(request.GetRequest()),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported request: %s", request.GetRequest().Command)
+	}
+}
+
+func (s *Server) onInitialize(request *dap.InitializeRequest) (dap.ResponseMessage, error) {
+	return &dap.InitializeResponse{
+		Response: s.newResponse(&request.Request),
+		Body: dap.Capabilities{
+			SupportsConfigurationDoneRequest: true,
+			SupportsConditionalBreakpoints:   true,
+			SupportsEvaluateForHovers:        true,
+			SupportsTerminateRequest:         true,
+		},
+	}, nil
+}
+
+func (s *Server) onLaunch(request *dap.LaunchRequest) (dap.ResponseMessage, error) {
+	var arguments launchArguments
+	err := json.Unmarshal(request.Arguments, &arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if arguments.Program == "" {
+		return nil, fmt.Errorf("missing program")
+	}
+
+	program, err := loadProgram(arguments.Program, func(output string) {
+		s.sendOutput("stdout", output)
+	})
+	if err != nil {
+		if program == nil {
+			return nil, err
+		}
+		return nil, errors.New(program.formatError(err))
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.program != nil {
+		return nil, fmt.Errorf("program already launched")
+	}
+
+	s.program = program
+
+	if arguments.StopOnEntry {
+		s.stopReason = stopReasonEntry
+		s.debugger.RequestPause()
+	}
+
+	return &dap.LaunchResponse{
+		Response: s.newResponse(&request.Request),
+	}, nil
+}
+
+func (s *Server) onSetBreakpoints(request *dap.SetBreakpointsRequest) (dap.ResponseMessage, error) {
+	location, err := sourceLocation(request.Arguments.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	s.debugger.ClearBreakpoints(location)
+
+	breakpoints := make([]dap.Breakpoint, 0, len(request.Arguments.Breakpoints))
+
+	for _, sourceBreakpoint := range request.Arguments.Breakpoints {
+
+		var condition ast.Expression
+		if sourceBreakpoint.Condition != "" {
+			var errs []error
+			condition, errs = parser2.ParseExpression(sourceBreakpoint.Condition)
+			if len(errs) > 0 {
+				conditionErr := parser2.Error{
+					Code:   sourceBreakpoint.Condition,
+					Errors: errs,
+				}
+				breakpoints = append(breakpoints, dap.Breakpoint{
+					Verified: false,
+					Message:  fmt.Sprintf("invalid condition: %s", conditionErr),
+					Source:   request.Arguments.Source,
+					Line:     sourceBreakpoint.Line,
+				})
+				continue
+			}
+		}
+
+		breakpoint := s.debugger.AddBreakpoint(location, sourceBreakpoint.Line, condition)
+
+		breakpoints = append(breakpoints, dap.Breakpoint{
+			Id:       breakpoint.ID,
+			Verified: true,
+			Source:   request.Arguments.Source,
+			Line:     breakpoint.Line,
+		})
+	}
+
+	return &dap.SetBreakpointsResponse{
+		Response: s.newResponse(&request.Request),
+		Body: dap.SetBreakpointsResponseBody{
+			Breakpoints: breakpoints,
+		},
+	}, nil
+}
+
+func (s *Server) onSetExceptionBreakpoints(request *dap.SetExceptionBreakpointsRequest) (dap.ResponseMessage, error) {
+	// Exception breakpoints are not supported, but clients might still set an empty list
+	return &dap.SetExceptionBreakpointsResponse{
+		Response: s.newResponse(&request.Request),
+	}, nil
+}
+
+func (s *Server) onConfigurationDone(request *dap.ConfigurationDoneRequest) (dap.ResponseMessage, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.configured = true
+
+	return &dap.ConfigurationDoneResponse{
+		Response: s.newResponse(&request.Request),
+	}, nil
+}
+
+func (s *Server) onThreads(request *dap.ThreadsRequest) (dap.ResponseMessage, error) {
+	return &dap.ThreadsResponse{
+		Response: s.newResponse(&request.Request),
+		Body: dap.ThreadsResponseBody{
+			Threads: []dap.Thread{
+				{
+					Id:   threadID,
+					Name: "main",
+				},
+			},
+		},
+	}, nil
+}
+
+func (s *Server) onStackTrace(request *dap.StackTraceRequest) (dap.ResponseMessage, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop == nil {
+		return nil, errNotStopped
+	}
+
+	stackFrames := make([]dap.StackFrame, 0, len(s.frames))
+
+	for i, frame := range s.frames {
+		name := frame.FunctionName
+		if name == "" {
+			name = "<anonymous>"
+		}
+
+		stackFrame := dap.StackFrame{
+			Id:   i + 1,
+			Name: name,
+		}
+
+		if location, ok := frame.Interpreter.Location.(common.StringLocation); ok {
+			stackFrame.Source = dap.Source{
+				Name: filepath.Base(string(location)),
+				Path: string(location),
+			}
+		}
+
+		if frame.Statement != nil {
+			startPosition := frame.Statement.StartPosition()
+			endPosition := frame.Statement.EndPosition()
+
+			// Lines and columns start at 1 in the protocol,
+			// but only lines start at 1 in Cadence
+
+			stackFrame.Line = startPosition.Line
+			stackFrame.Column = startPosition.Column + 1
+			stackFrame.EndLine = endPosition.Line
+			stackFrame.EndColumn = endPosition.Column + 2
+		}
+
+		stackFrames = append(stackFrames, stackFrame)
+	}
+
+	return &dap.StackTraceResponse{
+		Response: s.newResponse(&request.Request),
+		Body: dap.StackTraceResponseBody{
+			StackFrames: stackFrames,
+			TotalFrames: len(stackFrames),
+		},
+	}, nil
+}
+
+func (s *Server) onScopes(request *dap.ScopesRequest) (dap.ResponseMessage, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	frame, err := s.frame(request.Arguments.FrameId)
+	if err != nil {
+		return nil, err
+	}
+
+	locals := frameVariables(frame)
+
+	return &dap.ScopesResponse{
+		Response: s.newResponse(&request.Request),
+		Body: dap.ScopesResponseBody{
+			Scopes: []dap.Scope{
+				{
+					Name:               "Locals",
+					PresentationHint:   "locals",
+					VariablesReference: s.references.add(locals),
+					NamedVariables:     len(locals),
+				},
+			},
+		},
+	}, nil
+}
+
+func (s *Server) onVariables(request *dap.VariablesRequest) (dap.ResponseMessage, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop == nil {
+		return nil, errNotStopped
+	}
+
+	children, ok := s.references.get(request.Arguments.VariablesReference)
+	if !ok {
+		return nil, fmt.Errorf("unknown variables reference: %d", request.Arguments.VariablesReference)
+	}
+
+	variables := make([]dap.Variable, 0, len(children))
+	for _, child := range children {
+		variables = append(variables, s.newVariable(child.name, child.value))
+	}
+
+	return &dap.VariablesResponse{
+		Response: s.newResponse(&request.Request),
+		Body: dap.VariablesResponseBody{
+			Variables: variables,
+		},
+	}, nil
+}
+
+func (s *Server) onEvaluate(request *dap.EvaluateRequest) (dap.ResponseMessage, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	frameID := request.Arguments.FrameId
+	if frameID == 0 {
+		frameID = 1
+	}
+
+	frame, err := s.frame(frameID)
+	if err != nil {
+		return nil, err
+	}
+
+	code := request.Arguments.Expression
+
+	expression, errs := parser2.ParseExpression(code)
+	if len(errs) > 0 {
+		return nil, parser2.Error{
+			Code:   code,
+			Errors: errs,
+		}
+	}
+
+	value, err := s.debugger.Evaluate(frame, expression)
+	if err != nil {
+		return nil, err
+	}
+
+	variable := s.newVariable(code, value)
+
+	return &dap.EvaluateResponse{
+		Response: s.newResponse(&request.Request),
+		Body: dap.EvaluateResponseBody{
+			Result:             variable.Value,
+			Type:               variable.Type,
+			VariablesReference: variable.VariablesReference,
+			NamedVariables:     variable.NamedVariables,
+			IndexedVariables:   variable.IndexedVariables,
+		},
+	}, nil
+}
+
+func (s *Server) onContinue(request *dap.ContinueRequest) (dap.ResponseMessage, error) {
+	err := s.prepareResume(stopReasonPause)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dap.ContinueResponse{
+		Response: s.newResponse(&request.Request),
+		Body: dap.ContinueResponseBody{
+			AllThreadsContinued: true,
+		},
+	}, nil
+}
+
+func (s *Server) onNext(request *dap.NextRequest) (dap.ResponseMessage, error) {
+	err := s.prepareResume(stopReasonStep)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dap.NextResponse{
+		Response: s.newResponse(&request.Request),
+	}, nil
+}
+
+func (s *Server) onStepIn(request *dap.StepInRequest) (dap.ResponseMessage, error) {
+	err := s.prepareResume(stopReasonStep)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dap.StepInResponse{
+		Response: s.newResponse(&request.Request),
+	}, nil
+}
+
+func (s *Server) onStepOut(request *dap.StepOutRequest) (dap.ResponseMessage, error) {
+	err := s.prepareResume(stopReasonStep)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dap.StepOutResponse{
+		Response: s.newResponse(&request.Request),
+	}, nil
+}
+
+func (s *Server) onPause(request *dap.PauseRequest) (dap.ResponseMessage, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop == nil {
+		s.stopReason = stopReasonPause
+		s.debugger.RequestPause()
+	}
+
+	return &dap.PauseResponse{
+		Response: s.newResponse(&request.Request),
+	}, nil
+}
+
+var errNotStopped = errors.New("program is not stopped")
+
+// prepareResume prepares the resumption of the stopped program:
+// The state of the stop is discarded, and the reason for the next stop is set
+//
+func (s *Server) prepareResume(stopReason string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop == nil {
+		return errNotStopped
+	}
+
+	s.stop = nil
+	s.frames = nil
+	s.references.reset()
+	s.stopReason = stopReason
+
+	return nil
+}
+
+// frame returns the stack frame with the given ID of the stopped program
+//
+func (s *Server) frame(id int) (interpreter.StackFrame, error) {
+	if s.stop == nil {
+		return interpreter.StackFrame{}, errNotStopped
+	}
+
+	if id < 1 || id > len(s.frames) {
+		return interpreter.StackFrame{}, fmt.Errorf("unknown stack frame: %d", id)
+	}
+
+	return s.frames[id-1], nil
+}
+
+// startIfReady starts the execution of the program,
+// if it was launched and the configuration is done
+//
+func (s *Server) startIfReady() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.program == nil || !s.configured || s.started {
+		return
+	}
+
+	s.started = true
+
+	go s.run(s.program)
+}
+
+func (s *Server) run(program *program) {
+	exitCode := 0
+
+	result, err := program.run(s.debugger)
+	if err != nil {
+		exitCode = 1
+		s.sendOutput("stderr", program.formatError(err))
+	} else if result != nil {
+		if _, ok := result.(interpreter.VoidValue); !ok {
+			s.sendOutput("console", fmt.Sprintf("Result: %s\n", result))
+		}
+	}
+
+	s.send(&dap.ExitedEvent{
+		Event: s.newEvent("exited"),
+		Body: dap.ExitedEventBody{
+			ExitCode: exitCode,
+		},
+	})
+
+	s.send(&dap.TerminatedEvent{
+		Event: s.newEvent("terminated"),
+	})
+}
+
+// handleStops reports the stops of the program
+//
+func (s *Server) handleStops() {
+	for {
+		select {
+		case stop := <-s.debugger.Stops():
+			s.onStop(stop)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *Server) onStop(stop interpreter.Stop) {
+	s.mutex.Lock()
+
+	s.stop = &stop
+	s.frames = s.debugger.StackFrames(stop)
+
+	body := dap.StoppedEventBody{
+		Reason:            s.stopReason,
+		ThreadId:          threadID,
+		AllThreadsStopped: true,
+	}
+
+	if stop.Breakpoint != nil {
+		body.Reason = stopReasonBreakpoint
+		body.HitBreakpointIds = []int{stop.Breakpoint.ID}
+	}
+
+	if body.Reason == "" {
+		body.Reason = stopReasonPause
+	}
+
+	s.stopReason = ""
+
+	s.mutex.Unlock()
+
+	if stop.ConditionError != nil {
+		s.sendOutput(
+			"stderr",
+			fmt.Sprintf("failed to evaluate breakpoint condition: %s\n", stop.ConditionError),
+		)
+	}
+
+	s.send(&dap.StoppedEvent{
+		Event: s.newEvent("stopped"),
+		Body:  body,
+	})
+}
+
+// sourceLocation returns the location of the program for the given source
+//
+func sourceLocation(source dap.Source) (common.StringLocation, error) {
+	if source.Path == "" {
+		return "", fmt.Errorf("missing source path")
+	}
+
+	path, err := filepath.Abs(source.Path)
+	if err != nil {
+		return "", err
+	}
+
+	return common.StringLocation(path), nil
+}
+
+func (s *Server) newResponse(request *dap.Request) dap.Response {
+	return dap.Response{
+		ProtocolMessage: dap.ProtocolMessage{
+			Type: "response",
+		},
+		RequestSeq: request.Seq,
+		Success:    true,
+		Command:    request.Command,
+	}
+}
+
+func (s *Server) newErrorResponse(request *dap.Request, err error) *dap.ErrorResponse {
+	response := s.newResponse(request)
+	response.Success = false
+	response.Message = err.Error()
+
+	return &dap.ErrorResponse{
+		Response: response,
+		Body: dap.ErrorResponseBody{
+			Error: dap.ErrorMessage{
+				Format:   err.Error(),
+				ShowUser: true,
+			},
+		},
+	}
+}
+
+func (s *Server) newEvent(event string) dap.Event {
+	return dap.Event{
+		ProtocolMessage: dap.ProtocolMessage{
+			Type: "event",
+		},
+		Event: event,
+	}
+}
+
+func (s *Server) sendOutput(category string, output string) {
+	s.send(&dap.OutputEvent{
+		Event: s.newEvent("output"),
+		Body: dap.OutputEventBody{
+			Category: category,
+			Output:   output,
+		},
+	})
+}
+
+// send writes the given response or event.
+// The sequence number of the message is set
+//
+func (s *Server) send(message dap.Message) {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	s.seq++
+
+	switch message := message.(type) {
+	case dap.ResponseMessage:
+		message.GetResponse().Seq = s.seq
+	case dap.EventMessage:
+		message.GetEvent().Seq = s.seq
+	}
+
+	// Errors cannot be reported to the client,
+	// and the next read fails if the connection is broken
+	_ = dap.WriteProtocolMessage(s.writer, message)
+}
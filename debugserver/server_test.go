@@ -0,0 +1,386 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package debugserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-dap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testClient struct {
+	t      *testing.T
+	writer io.Writer
+	reader *bufio.Reader
+	seq    int
+	// events are the events which were read while waiting for a response
+	events []dap.EventMessage
+	// output is the output reported by the server so far
+	output []dap.OutputEventBody
+}
+
+// newTestClient starts a server and returns a client connected to it
+//
+func newTestClient(t *testing.T) *testClient {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+
+	server := NewServer()
+
+	go func() {
+		err := server.Serve(serverReader, serverWriter)
+		_ = serverWriter.CloseWithError(err)
+	}()
+
+	return &testClient{
+		t:      t,
+		writer: clientWriter,
+		reader: bufio.NewReader(clientReader),
+	}
+}
+
+func (c *testClient) request(command string, arguments interface{}) {
+	c.seq++
+
+	message := map[string]interface{}{
+		"seq":     c.seq,
+		"type":    "request",
+		"command": command,
+	}
+	if arguments != nil {
+		message["arguments"] = arguments
+	}
+
+	content, err := json.Marshal(message)
+	require.NoError(c.t, err)
+
+	err = dap.WriteBaseMessage(c.writer, content)
+	require.NoError(c.t, err)
+}
+
+// read reads the next message.
+// Output events are recorded
+//
+func (c *testClient) read() dap.Message {
+	message, err := dap.ReadProtocolMessage(c.reader)
+	require.NoError(c.t, err)
+
+	if outputEvent, ok := message.(*dap.OutputEvent); ok {
+		c.output = append(c.output, outputEvent.Body)
+	}
+
+	return message
+}
+
+// call sends a request and returns the successful response
+//
+func (c *testClient) call(command string, arguments interface{}) dap.ResponseMessage {
+	c.request(command, arguments)
+
+	response := c.expectResponse()
+	require.True(c.t,
+		response.GetResponse().Success,
+		"%s failed: %s", command, response.GetResponse().Message,
+	)
+
+	return response
+}
+
+// expectResponse returns the response for the last request.
+// Events which are read before the response are queued
+//
+func (c *testClient) expectResponse() dap.ResponseMessage {
+	for {
+		switch message := c.read().(type) {
+		case dap.ResponseMessage:
+			require.Equal(c.t, c.seq, message.GetResponse().RequestSeq)
+			return message
+
+		case dap.EventMessage:
+			c.events = append(c.events, message)
+		}
+	}
+}
+
+// expectEvent returns the next event with the given name.
+// Other events are skipped
+//
+func (c *testClient) expectEvent(event string) dap.EventMessage {
+	for len(c.events) > 0 {
+		message := c.events[0]
+		c.events = c.events[1:]
+		if message.GetEvent().Event == event {
+			return message
+		}
+	}
+
+	for {
+		message, ok := c.read().(dap.EventMessage)
+		if ok && message.GetEvent().Event == event {
+			return message
+		}
+	}
+}
+
+func (c *testClient) expectStop(reason string) *dap.StoppedEvent {
+	event := c.expectEvent("stopped").(*dap.StoppedEvent)
+	assert.Equal(c.t, reason, event.Body.Reason)
+	return event
+}
+
+func (c *testClient) stackTrace() []dap.StackFrame {
+	response := c.call("stackTrace", dap.StackTraceArguments{ThreadId: threadID}).(*dap.StackTraceResponse)
+	return response.Body.StackFrames
+}
+
+func (c *testClient) variables(reference int) map[string]dap.Variable {
+	response := c.call("variables", dap.VariablesArguments{VariablesReference: reference}).(*dap.VariablesResponse)
+
+	variables := map[string]dap.Variable{}
+	for _, variable := range response.Body.Variables {
+		variables[variable.Name] = variable
+	}
+	return variables
+}
+
+func (c *testClient) locals(frameID int) map[string]dap.Variable {
+	response := c.call("scopes", dap.ScopesArguments{FrameId: frameID}).(*dap.ScopesResponse)
+	require.Len(c.t, response.Body.Scopes, 1)
+
+	return c.variables(response.Body.Scopes[0].VariablesReference)
+}
+
+func writeTestProgram(t *testing.T, code string) string {
+	path := filepath.Join(t.TempDir(), "test.cdc")
+	err := ioutil.WriteFile(path, []byte(code), 0600)
+	require.NoError(t, err)
+	return path
+}
+
+func (c *testClient) initialize() {
+	response := c.call("initialize", dap.InitializeRequestArguments{AdapterID: "cadence"}).(*dap.InitializeResponse)
+	assert.True(c.t, response.Body.SupportsConfigurationDoneRequest)
+	assert.True(c.t, response.Body.SupportsConditionalBreakpoints)
+
+	c.expectEvent("initialized")
+}
+
+func TestServerScript(t *testing.T) {
+
+	t.Parallel()
+
+	path := writeTestProgram(t, `
+      pub struct S {
+          pub let values: [Int]
+
+          init(values: [Int]) {
+              self.values = values
+          }
+      }
+
+      pub fun sum(_ s: S): Int {
+          var total = 0
+          for value in s.values {
+              total = total + value
+          }
+          return total
+      }
+
+      pub fun main(): Int {
+          let s = S(values: [1, 2, 3])
+          let dict = {"a": 1}
+          log("summing")
+          let total = sum(s)
+          return total
+      }
+    `)
+
+	client := newTestClient(t)
+	client.initialize()
+
+	response := client.call("setBreakpoints", dap.SetBreakpointsArguments{
+		Source: dap.Source{Path: path},
+		Breakpoints: []dap.SourceBreakpoint{
+			{Line: 21},
+			{Line: 13, Condition: "value == 2"},
+			{Line: 14, Condition: "total =="},
+		},
+	}).(*dap.SetBreakpointsResponse)
+
+	breakpoints := response.Body.Breakpoints
+	require.Len(t, breakpoints, 3)
+	assert.True(t, breakpoints[0].Verified)
+	assert.True(t, breakpoints[1].Verified)
+	assert.False(t, breakpoints[2].Verified)
+
+	client.call("launch", launchArguments{Program: path})
+	client.call("configurationDone", nil)
+
+	// Breakpoint in the function `main`
+
+	stop := client.expectStop(stopReasonBreakpoint)
+	assert.Equal(t, []int{breakpoints[0].Id}, stop.Body.HitBreakpointIds)
+
+	frames := client.stackTrace()
+	require.Len(t, frames, 1)
+	assert.Equal(t, "main", frames[0].Name)
+	assert.Equal(t, 21, frames[0].Line)
+	assert.Equal(t, path, frames[0].Source.Path)
+
+	locals := client.locals(frames[0].Id)
+	require.Contains(t, locals, "s")
+	require.Contains(t, locals, "dict")
+	assert.NotContains(t, locals, "log")
+
+	// Composites, arrays, and dictionaries can be expanded
+
+	fields := client.variables(locals["s"].VariablesReference)
+	require.Contains(t, fields, "values")
+	assert.Equal(t, "[1, 2, 3]", fields["values"].Value)
+
+	elements := client.variables(fields["values"].VariablesReference)
+	assert.Len(t, elements, 3)
+	assert.Equal(t, "2", elements["[1]"].Value)
+
+	entries := client.variables(locals["dict"].VariablesReference)
+	assert.Equal(t, "1", entries[`"a"`].Value)
+
+	// Step into the invoked function
+
+	client.call("next", dap.NextArguments{ThreadId: threadID})
+	client.expectStop(stopReasonStep)
+
+	client.call("stepIn", dap.StepInArguments{ThreadId: threadID})
+	client.expectStop(stopReasonStep)
+
+	frames = client.stackTrace()
+	require.Len(t, frames, 2)
+	assert.Equal(t, "sum", frames[0].Name)
+	assert.Equal(t, 11, frames[0].Line)
+	assert.Equal(t, "main", frames[1].Name)
+	assert.Equal(t, 22, frames[1].Line)
+
+	// The conditional breakpoint is hit
+
+	client.call("continue", dap.ContinueArguments{ThreadId: threadID})
+	client.expectStop(stopReasonBreakpoint)
+
+	evaluateResponse := client.call("evaluate", dap.EvaluateArguments{
+		Expression: "total + value",
+		FrameId:    frames[0].Id,
+	}).(*dap.EvaluateResponse)
+	assert.Equal(t, "3", evaluateResponse.Body.Result)
+
+	client.call("stepOut", dap.StepOutArguments{ThreadId: threadID})
+	client.expectStop(stopReasonStep)
+
+	frames = client.stackTrace()
+	require.Len(t, frames, 1)
+	assert.Equal(t, 23, frames[0].Line)
+
+	client.call("continue", dap.ContinueArguments{ThreadId: threadID})
+
+	exited := client.expectEvent("exited").(*dap.ExitedEvent)
+	assert.Equal(t, 0, exited.Body.ExitCode)
+
+	client.expectEvent("terminated")
+
+	assert.Equal(t,
+		[]dap.OutputEventBody{
+			{Category: "stdout", Output: "\"summing\"\n"},
+			{Category: "console", Output: "Result: 6\n"},
+		},
+		client.output,
+	)
+
+	client.call("disconnect", nil)
+}
+
+func TestServerTransaction(t *testing.T) {
+
+	t.Parallel()
+
+	path := writeTestProgram(t, `
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.save(42, to: /storage/answer)
+              let answer = signer.load<Int>(from: /storage/answer)!
+              log(answer)
+          }
+      }
+    `)
+
+	client := newTestClient(t)
+	client.initialize()
+
+	client.call("launch", launchArguments{
+		Program:     path,
+		StopOnEntry: true,
+	})
+	client.call("configurationDone", nil)
+
+	client.expectStop(stopReasonEntry)
+
+	frames := client.stackTrace()
+	require.Len(t, frames, 1)
+	assert.Equal(t, "prepare", frames[0].Name)
+	assert.Equal(t, 4, frames[0].Line)
+
+	locals := client.locals(frames[0].Id)
+	require.Contains(t, locals, "signer")
+	assert.Equal(t, "AuthAccount", locals["signer"].Type)
+
+	client.call("continue", dap.ContinueArguments{ThreadId: threadID})
+
+	client.expectEvent("terminated")
+
+	assert.Equal(t,
+		[]dap.OutputEventBody{
+			{Category: "stdout", Output: "42\n"},
+		},
+		client.output,
+	)
+}
+
+func TestServerInvalidProgram(t *testing.T) {
+
+	t.Parallel()
+
+	path := writeTestProgram(t, `
+      pub fun main(): Int {
+          return true
+      }
+    `)
+
+	client := newTestClient(t)
+	client.initialize()
+
+	client.request("launch", launchArguments{Program: path})
+
+	response := client.expectResponse().(*dap.ErrorResponse)
+	assert.False(t, response.Success)
+	assert.Contains(t, response.Message, "mismatched types")
+}
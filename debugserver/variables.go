@@ -0,0 +1,155 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package debugserver
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/go-dap"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+type namedValue struct {
+	name  string
+	value interpreter.Value
+}
+
+// variableReferences are the expandable variables of a stopped program,
+// i.e. scopes and values with children, e.g. arrays.
+//
+// A reference is only valid while the program is stopped
+//
+type variableReferences struct {
+	children [][]namedValue
+}
+
+// add adds the given children and returns the reference for them
+//
+func (r *variableReferences) add(children []namedValue) int {
+	r.children = append(r.children, children)
+	return len(r.children)
+}
+
+func (r *variableReferences) get(reference int) ([]namedValue, bool) {
+	if reference < 1 || reference > len(r.children) {
+		return nil, false
+	}
+	return r.children[reference-1], true
+}
+
+func (r *variableReferences) reset() {
+	r.children = nil
+}
+
+// newVariable returns the variable for the given value.
+// If the value has children, e.g. the value is an array,
+// a reference for the children is added, so the variable can be expanded
+//
+func (s *Server) newVariable(name string, value interpreter.Value) dap.Variable {
+	variable := dap.Variable{
+		Name:  name,
+		Value: value.String(),
+	}
+
+	if staticType := value.StaticType(); staticType != nil {
+		variable.Type = staticType.String()
+	}
+
+	children, indexed := valueChildren(value)
+	if len(children) > 0 {
+		variable.VariablesReference = s.references.add(children)
+		if indexed {
+			variable.IndexedVariables = len(children)
+		} else {
+			variable.NamedVariables = len(children)
+		}
+	}
+
+	return variable
+}
+
+// valueChildren returns the children of the given value:
+// the elements of arrays, the entries of dictionaries, and the fields of composites.
+// Optionals are expanded to the children of the wrapped value.
+//
+// Returns true if the children are indexed, i.e. the value is an array
+//
+func valueChildren(value interpreter.Value) (children []namedValue, indexed bool) {
+	switch value := value.(type) {
+	case *interpreter.SomeValue:
+		value.Walk(func(innerValue interpreter.Value) {
+			children, indexed = valueChildren(innerValue)
+		})
+
+	case *interpreter.ArrayValue:
+		indexed = true
+		value.Walk(func(element interpreter.Value) {
+			children = append(children, namedValue{
+				name:  fmt.Sprintf("[%d]", len(children)),
+				value: element,
+			})
+		})
+
+	case *interpreter.DictionaryValue:
+		value.Iterate(func(key, value interpreter.Value) (resume bool) {
+			children = append(children, namedValue{
+				name:  key.String(),
+				value: value,
+			})
+			return true
+		})
+
+	case *interpreter.CompositeValue:
+		value.ForEachField(func(name string, value interpreter.Value) {
+			children = append(children, namedValue{
+				name:  name,
+				value: value,
+			})
+		})
+	}
+
+	return
+}
+
+// frameVariables returns the variables of the given frame, sorted by name.
+// Host functions, e.g. built-in functions, are not included
+//
+func frameVariables(frame interpreter.StackFrame) []namedValue {
+	var variables []namedValue
+
+	for name, variable := range frame.Activation.FunctionValues() { //nolint:maprangecheck
+		value := variable.GetValue()
+		if _, ok := value.(*interpreter.HostFunctionValue); ok {
+			continue
+		}
+
+		variables = append(variables, namedValue{
+			name:  name,
+			value: value,
+		})
+	}
+
+	sort.Slice(variables, func(i, j int) bool {
+		return variables[i].name < variables[j].name
+	})
+
+	return variables
+}
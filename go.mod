@@ -8,6 +8,8 @@ require (
 	github.com/cheekybits/genny v1.0.0
 	github.com/fxamacker/cbor/v2 v2.3.1-0.20211029162100-5d5d7c3edd41
 	github.com/go-test/deep v1.0.5
+	github.com/google/go-dap v0.6.0
+	github.com/google/pprof v0.0.0-20210407192527-94a9f03dee38
 	github.com/logrusorgru/aurora v0.0.0-20200102142835-e9ef32dff381
 	github.com/onflow/atree v0.2.0
 	github.com/opentracing/opentracing-go v1.2.0
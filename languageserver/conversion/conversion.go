@@ -52,6 +52,25 @@ func ProtocolToSemaPosition(pos protocol.Position) sema.Position {
 	}
 }
 
+// SemaToProtocolPosition converts a sema position to a LSP position
+//
+func SemaToProtocolPosition(pos sema.Position) protocol.Position {
+	return protocol.Position{
+		Line:      float64(pos.Line - 1),
+		Character: float64(pos.Column),
+	}
+}
+
+// SemaToProtocolRange converts a sema range to a LSP range
+//
+func SemaToProtocolRange(startPos, endPos sema.Position) protocol.Range {
+	endPos.Column++
+	return protocol.Range{
+		Start: SemaToProtocolPosition(startPos),
+		End:   SemaToProtocolPosition(endPos),
+	}
+}
+
 func DeclarationKindToSymbolKind(kind common.DeclarationKind) protocol.SymbolKind {
 
 	switch kind {
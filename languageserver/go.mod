@@ -12,4 +12,5 @@ require (
 	github.com/sourcegraph/jsonrpc2 v0.0.0-20191222043438-96c4efab7ee2
 	github.com/spf13/afero v1.6.0
 	github.com/stretchr/testify v1.7.0
+	github.com/turbolent/prettier v0.0.0-20210613180524-3a3f5a5b49ba
 )
@@ -28,6 +28,7 @@ import (
 
 	"github.com/onflow/cadence/languageserver/integration"
 	"github.com/onflow/cadence/languageserver/server"
+	"github.com/onflow/cadence/runtime/lint"
 )
 
 func RunWithStdio(enableFlowClient bool) {
@@ -45,6 +46,16 @@ func RunWithStdio(enableFlowClient bool) {
 		panic(err)
 	}
 
+	analyzers, err := lint.SelectAnalyzers(nil, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	err = languageServer.SetOptions(server.WithAnalyzers(analyzers...))
+	if err != nil {
+		panic(err)
+	}
+
 	_, err = integration.NewFlowIntegration(languageServer, enableFlowClient)
 	if err != nil {
 		panic(err)
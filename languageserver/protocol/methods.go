@@ -85,6 +85,15 @@ func (s *Server) handleDocumentHighlight(req *json.RawMessage) (interface{}, err
 	return s.Handler.DocumentHighlight(s.conn, &params)
 }
 
+func (s *Server) handleReferences(req *json.RawMessage) (interface{}, error) {
+	var params ReferenceParams
+	if err := json.Unmarshal(*req, &params); err != nil {
+		return nil, err
+	}
+
+	return s.Handler.References(s.conn, &params)
+}
+
 func (s *Server) handleRename(req *json.RawMessage) (interface{}, error) {
 	var params RenameParams
 	if err := json.Unmarshal(*req, &params); err != nil {
@@ -94,6 +103,24 @@ func (s *Server) handleRename(req *json.RawMessage) (interface{}, error) {
 	return s.Handler.Rename(s.conn, &params)
 }
 
+func (s *Server) handleDocumentFormatting(req *json.RawMessage) (interface{}, error) {
+	var params DocumentFormattingParams
+	if err := json.Unmarshal(*req, &params); err != nil {
+		return nil, err
+	}
+
+	return s.Handler.DocumentFormatting(s.conn, &params)
+}
+
+func (s *Server) handleDocumentRangeFormatting(req *json.RawMessage) (interface{}, error) {
+	var params DocumentRangeFormattingParams
+	if err := json.Unmarshal(*req, &params); err != nil {
+		return nil, err
+	}
+
+	return s.Handler.DocumentRangeFormatting(s.conn, &params)
+}
+
 func (s *Server) handleCodeAction(req *json.RawMessage) (interface{}, error) {
 	var params CodeActionParams
 	if err := json.Unmarshal(*req, &params); err != nil {
@@ -85,7 +85,10 @@ type Handler interface {
 	Definition(conn Conn, params *TextDocumentPositionParams) (*Location, error)
 	SignatureHelp(conn Conn, params *TextDocumentPositionParams) (*SignatureHelp, error)
 	DocumentHighlight(conn Conn, params *TextDocumentPositionParams) ([]*DocumentHighlight, error)
+	References(conn Conn, params *ReferenceParams) ([]*Location, error)
 	Rename(conn Conn, params *RenameParams) (*WorkspaceEdit, error)
+	DocumentFormatting(conn Conn, params *DocumentFormattingParams) ([]*TextEdit, error)
+	DocumentRangeFormatting(conn Conn, params *DocumentRangeFormattingParams) ([]*TextEdit, error)
 	CodeAction(conn Conn, params *CodeActionParams) ([]*CodeAction, error)
 	CodeLens(conn Conn, params *CodeLensParams) ([]*CodeLens, error)
 	Completion(conn Conn, params *CompletionParams) ([]*CompletionItem, error)
@@ -133,9 +136,18 @@ func NewServer(handler Handler) *Server {
 	jsonrpc2Server.Methods["textDocument/documentHighlight"] =
 		server.handleDocumentHighlight
 
+	jsonrpc2Server.Methods["textDocument/references"] =
+		server.handleReferences
+
 	jsonrpc2Server.Methods["textDocument/rename"] =
 		server.handleRename
 
+	jsonrpc2Server.Methods["textDocument/formatting"] =
+		server.handleDocumentFormatting
+
+	jsonrpc2Server.Methods["textDocument/rangeFormatting"] =
+		server.handleDocumentRangeFormatting
+
 	jsonrpc2Server.Methods["textDocument/codeAction"] =
 		server.handleCodeAction
 
@@ -66,3 +66,15 @@ func uriToLocation(uri protocol.DocumentUri) common.StringLocation {
 		strings.TrimPrefix(string(uri), filePrefix),
 	)
 }
+
+// locationToURI returns the URI of the document for the given location.
+// Only path locations have documents, for other locations it returns false
+//
+func locationToURI(location common.Location) (protocol.DocumentUri, bool) {
+	path := locationToPath(location)
+	if path == "" {
+		return "", false
+	}
+
+	return protocol.DocumentUri(filePrefix + path), true
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -31,12 +32,15 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/analysis"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/parser2/lexer"
 	"github.com/onflow/cadence/runtime/sema"
 	"github.com/onflow/cadence/runtime/stdlib"
+	"github.com/turbolent/prettier"
 
 	"github.com/onflow/cadence/languageserver/conversion"
 	"github.com/onflow/cadence/languageserver/jsonrpc2"
@@ -73,6 +77,24 @@ func (d Document) Offset(line, column int) (offset int) {
 	return offset + column
 }
 
+// Position returns the protocol position of the given offset
+//
+func (d Document) Position(offset int) protocol.Position {
+	var line, character float64
+	for _, r := range d.Text[:offset] {
+		if r == '\n' {
+			line++
+			character = 0
+		} else {
+			character++
+		}
+	}
+	return protocol.Position{
+		Line:      line,
+		Character: character,
+	}
+}
+
 func (d Document) HasAnyPrecedingStringsAtPosition(options []string, line, column int) bool {
 	endOffset := d.Offset(line, column)
 	if endOffset >= len(d.Text) {
@@ -172,6 +194,9 @@ type Server struct {
 	memberResolvers      map[protocol.DocumentUri]map[string]sema.MemberResolver
 	ranges               map[protocol.DocumentUri]map[string]sema.Range
 	codeActionsResolvers map[protocol.DocumentUri]map[uuid.UUID]func() []*protocol.CodeAction
+	// importedLocations are the locations imported by each checked program,
+	// which are used to find the programs that depend on a changed document
+	importedLocations map[common.LocationID]map[common.LocationID]struct{}
 	// commands is the registry of custom commands we support
 	commands map[string]CommandHandler
 	// resolveAddressImport is the optional function that is used to resolve address imports
@@ -189,6 +214,8 @@ type Server struct {
 	// initializationOptionsHandlers are the functions that are used to handle initialization options sent by the client
 	initializationOptionsHandlers []InitializationOptionsHandler
 	accessCheckMode               sema.AccessCheckMode
+	// analyzers are the analyzers that are run on checked programs, e.g. lint rules
+	analyzers []*analysis.Analyzer
 }
 
 type Option func(*Server) error
@@ -273,6 +300,17 @@ func WithInitializationOptionsHandler(handler InitializationOptionsHandler) Opti
 	}
 }
 
+// WithAnalyzers returns a server option that adds the given analyzers
+// to the analyzers that are run on checked programs.
+// The diagnostics of the analyzers are reported, and their suggested fixes are provided as code actions
+//
+func WithAnalyzers(analyzers ...*analysis.Analyzer) Option {
+	return func(s *Server) error {
+		s.analyzers = append(s.analyzers, analyzers...)
+		return nil
+	}
+}
+
 const GetEntryPointParametersCommand = "cadence.server.getEntryPointParameters"
 const GetContractInitializerParametersCommand = "cadence.server.getContractInitializerParameters"
 const ParseEntryPointArgumentsCommand = "cadence.server.parseEntryPointArguments"
@@ -280,6 +318,7 @@ const ParseEntryPointArgumentsCommand = "cadence.server.parseEntryPointArguments
 func NewServer() (*Server, error) {
 	server := &Server{
 		checkers:             make(map[common.LocationID]*sema.Checker),
+		importedLocations:    make(map[common.LocationID]map[common.LocationID]struct{}),
 		documents:            make(map[protocol.DocumentUri]Document),
 		memberResolvers:      make(map[protocol.DocumentUri]map[string]sema.MemberResolver),
 		ranges:               make(map[protocol.DocumentUri]map[string]sema.Range),
@@ -342,9 +381,12 @@ func (s *Server) Initialize(
 				TriggerCharacters: []string{"."},
 				ResolveProvider:   true,
 			},
-			DocumentHighlightProvider: true,
-			DocumentSymbolProvider:    true,
-			RenameProvider:            true,
+			DocumentHighlightProvider:       true,
+			DocumentSymbolProvider:          true,
+			ReferencesProvider:              true,
+			RenameProvider:                  true,
+			DocumentFormattingProvider:      true,
+			DocumentRangeFormattingProvider: true,
 			SignatureHelpProvider: &protocol.SignatureHelpOptions{
 				TriggerCharacters: []string{"("},
 			},
@@ -476,6 +518,7 @@ func (s *Server) DidOpenTextDocument(conn protocol.Conn, params *protocol.DidOpe
 	}
 
 	s.checkAndPublishDiagnostics(conn, uri, text, version)
+	s.checkDependents(conn, uri)
 
 	return nil
 }
@@ -497,10 +540,100 @@ func (s *Server) DidChangeTextDocument(
 	}
 
 	s.checkAndPublishDiagnostics(conn, uri, text, version)
+	s.checkDependents(conn, uri)
 
 	return nil
 }
 
+// checkDependents re-checks the open documents which import the document with the given URI,
+// directly or indirectly, and publishes their diagnostics.
+// The checkers of the other programs which import the document are removed,
+// so the programs are checked again when they are imported.
+//
+// Otherwise the dependents would refer to the outdated declarations of the document,
+// e.g. references to the declarations would not be found in the dependents.
+//
+func (s *Server) checkDependents(conn protocol.Conn, uri protocol.DocumentUri) {
+	locationID := uriToLocation(uri).ID()
+
+	// Find the dependents
+
+	dependents := map[common.LocationID]struct{}{}
+
+	var addDependents func(importedLocationID common.LocationID)
+	addDependents = func(importedLocationID common.LocationID) {
+		for importerLocationID, importedLocations := range s.importedLocations {
+			if _, ok := importedLocations[importedLocationID]; !ok {
+				continue
+			}
+
+			if _, ok := dependents[importerLocationID]; ok || importerLocationID == locationID {
+				continue
+			}
+
+			dependents[importerLocationID] = struct{}{}
+			addDependents(importerLocationID)
+		}
+	}
+
+	addDependents(locationID)
+
+	if len(dependents) == 0 {
+		return
+	}
+
+	openDocuments := make(map[common.LocationID]protocol.DocumentUri, len(s.documents))
+	for documentURI := range s.documents {
+		openDocuments[uriToLocation(documentURI).ID()] = documentURI
+	}
+
+	sortedDependents := make([]common.LocationID, 0, len(dependents))
+
+	for dependent := range dependents {
+		sortedDependents = append(sortedDependents, dependent)
+
+		if _, ok := openDocuments[dependent]; !ok {
+			delete(s.checkers, dependent)
+		}
+	}
+
+	sort.Slice(sortedDependents, func(i, j int) bool {
+		return sortedDependents[i] < sortedDependents[j]
+	})
+
+	// Re-check the open dependents.
+	// A dependent is checked after the dependents it imports,
+	// so it does not import an outdated program
+
+	checked := map[common.LocationID]struct{}{}
+
+	var check func(dependent common.LocationID)
+	check = func(dependent common.LocationID) {
+		if _, ok := checked[dependent]; ok {
+			return
+		}
+		checked[dependent] = struct{}{}
+
+		for importedLocationID := range s.importedLocations[dependent] {
+			if _, ok := dependents[importedLocationID]; ok {
+				check(importedLocationID)
+			}
+		}
+
+		documentURI, ok := openDocuments[dependent]
+		if !ok {
+			return
+		}
+
+		document := s.documents[documentURI]
+		s.checkAndPublishDiagnostics(conn, documentURI, document.Text, document.Version)
+	}
+
+	for _, dependent := range sortedDependents {
+		check(dependent)
+	}
+}
+
 type CadenceCheckCompletedParams struct {
 
 	/*URI defined:
@@ -566,10 +699,18 @@ func (s *Server) Hover(
 
 	var markup strings.Builder
 
+	// Display the type using the type alias, if any,
+	// as the type of the origin is the aliased type
+
+	typeString := occurrence.Origin.TypeAlias
+	if typeString == "" {
+		typeString = documentType(occurrence.Origin.Type)
+	}
+
 	_, _ = fmt.Fprintf(
 		&markup,
 		"**Type**\n\n```cadence\n%s\n```\n",
-		documentType(occurrence.Origin.Type),
+		typeString,
 	)
 
 	docString := occurrence.Origin.DocString
@@ -738,20 +879,15 @@ func (s *Server) SignatureHelp(
 	}, nil
 }
 
-func (s *Server) DocumentHighlight(
-	_ protocol.Conn,
-	params *protocol.TextDocumentPositionParams,
-) (
-	[]*protocol.DocumentHighlight,
-	error,
-) {
-	uri := params.TextDocument.URI
+// originsAtPosition returns the origins of the occurrences at the given position in the given document.
+//
+func (s *Server) originsAtPosition(uri protocol.DocumentUri, protocolPosition protocol.Position) []*sema.Origin {
 	checker := s.checkerForDocument(uri)
 	if checker == nil {
-		return nil, nil
+		return nil
 	}
 
-	position := conversion.ProtocolToSemaPosition(params.Position)
+	position := conversion.ProtocolToSemaPosition(protocolPosition)
 	occurrences := checker.Occurrences.FindAll(position)
 	// If there are no occurrences,
 	// then try the preceding position
@@ -761,30 +897,165 @@ func (s *Server) DocumentHighlight(
 		occurrences = checker.Occurrences.FindAll(previousPosition)
 	}
 
-	documentHighlights := make([]*protocol.DocumentHighlight, 0)
+	origins := make([]*sema.Origin, 0, len(occurrences))
 
 	for _, occurrence := range occurrences {
-
 		origin := occurrence.Origin
 		if origin == nil || origin.StartPos == nil || origin.EndPos == nil {
 			continue
 		}
 
-		for _, occurrenceRange := range origin.Occurrences {
-			documentHighlights = append(documentHighlights,
-				&protocol.DocumentHighlight{
-					Range: conversion.ASTToProtocolRange(
-						occurrenceRange.StartPos,
-						occurrenceRange.EndPos,
-					),
-				},
-			)
+		origins = append(origins, origin)
+	}
+
+	return origins
+}
+
+// reference is an occurrence of an origin in a document.
+//
+type reference struct {
+	uri           protocol.DocumentUri
+	rng           protocol.Range
+	isDeclaration bool
+}
+
+// findReferences returns all occurrences of the given origins in the checked documents,
+// i.e. the open documents and the documents they import, which have a path location.
+//
+// If a URI is given, only the occurrences in the document with this URI are returned.
+//
+// The references are sorted by document and position.
+//
+func (s *Server) findReferences(origins []*sema.Origin, onlyURI protocol.DocumentUri) []reference {
+	if len(origins) == 0 {
+		return nil
+	}
+
+	originSet := make(map[*sema.Origin]struct{}, len(origins))
+	for _, origin := range origins {
+		originSet[origin] = struct{}{}
+	}
+
+	type referenceKey struct {
+		uri              protocol.DocumentUri
+		startPos, endPos sema.Position
+	}
+
+	seen := map[referenceKey]struct{}{}
+
+	var references []reference
+
+	for _, checker := range s.checkers {
+		uri, ok := locationToURI(checker.Location)
+		if !ok || (onlyURI != "" && uri != onlyURI) {
+			continue
+		}
+
+		for _, occurrence := range checker.Occurrences.All() {
+			origin := occurrence.Origin
+			if _, ok := originSet[origin]; !ok {
+				continue
+			}
+
+			key := referenceKey{
+				uri:      uri,
+				startPos: occurrence.StartPos,
+				endPos:   occurrence.EndPos,
+			}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			// The occurrence is the declaration if it is in the document which contains the declaration,
+			// at the position of the declaration
+
+			originURI, ok := locationToURI(origin.Location)
+
+			isDeclaration := ok &&
+				uri == originURI &&
+				occurrence.StartPos == sema.ASTToSemaPosition(*origin.StartPos) &&
+				occurrence.EndPos == sema.ASTToSemaPosition(*origin.EndPos)
+
+			references = append(references, reference{
+				uri:           uri,
+				rng:           conversion.SemaToProtocolRange(occurrence.StartPos, occurrence.EndPos),
+				isDeclaration: isDeclaration,
+			})
 		}
 	}
 
+	sort.Slice(references, func(i, j int) bool {
+		a := references[i]
+		b := references[j]
+		if a.uri != b.uri {
+			return a.uri < b.uri
+		}
+		if a.rng.Start.Line != b.rng.Start.Line {
+			return a.rng.Start.Line < b.rng.Start.Line
+		}
+		return a.rng.Start.Character < b.rng.Start.Character
+	})
+
+	return references
+}
+
+func (s *Server) DocumentHighlight(
+	_ protocol.Conn,
+	params *protocol.TextDocumentPositionParams,
+) (
+	[]*protocol.DocumentHighlight,
+	error,
+) {
+	uri := params.TextDocument.URI
+	origins := s.originsAtPosition(uri, params.Position)
+
+	documentHighlights := make([]*protocol.DocumentHighlight, 0)
+
+	for _, reference := range s.findReferences(origins, uri) {
+		documentHighlights = append(documentHighlights,
+			&protocol.DocumentHighlight{
+				Range: reference.rng,
+			},
+		)
+	}
+
 	return documentHighlights, nil
 }
 
+// References returns the locations of all occurrences of the symbol at the given position,
+// in all open documents and the documents they import.
+//
+func (s *Server) References(
+	_ protocol.Conn,
+	params *protocol.ReferenceParams,
+) (
+	[]*protocol.Location,
+	error,
+) {
+	origins := s.originsAtPosition(params.TextDocument.URI, params.Position)
+
+	locations := make([]*protocol.Location, 0)
+
+	for _, reference := range s.findReferences(origins, "") {
+		if reference.isDeclaration && !params.Context.IncludeDeclaration {
+			continue
+		}
+
+		locations = append(locations,
+			&protocol.Location{
+				URI:   reference.uri,
+				Range: reference.rng,
+			},
+		)
+	}
+
+	return locations, nil
+}
+
+// Rename renames the symbol at the given position,
+// in all open documents and the documents they import.
+//
 func (s *Server) Rename(
 	_ protocol.Conn,
 	params *protocol.RenameParams,
@@ -793,48 +1064,228 @@ func (s *Server) Rename(
 	error,
 ) {
 	uri := params.TextDocument.URI
-	checker := s.checkerForDocument(uri)
-	if checker == nil {
-		return nil, nil
+	origins := s.originsAtPosition(uri, params.Position)
+
+	changes := map[string][]protocol.TextEdit{
+		string(uri): {},
 	}
 
-	position := conversion.ProtocolToSemaPosition(params.Position)
-	occurrences := checker.Occurrences.FindAll(position)
-	// If there are no occurrences,
-	// then try the preceding position
-	if len(occurrences) == 0 && position.Column > 0 {
-		previousPosition := position
-		previousPosition.Column -= 1
-		occurrences = checker.Occurrences.FindAll(previousPosition)
+	for _, reference := range s.findReferences(origins, "") {
+		changes[string(reference.uri)] = append(
+			changes[string(reference.uri)],
+			protocol.TextEdit{
+				Range:   reference.rng,
+				NewText: params.NewName,
+			},
+		)
 	}
 
-	textEdits := make([]protocol.TextEdit, 0)
+	return &protocol.WorkspaceEdit{
+		Changes: &changes,
+	}, nil
+}
 
-	for _, occurrence := range occurrences {
+// formattingMaxLineWidth is the maximum line width of formatted documents
+//
+const formattingMaxLineWidth = 80
 
-		origin := occurrence.Origin
-		if origin == nil || origin.StartPos == nil || origin.EndPos == nil {
+// DocumentFormatting formats the whole document, including its comments.
+//
+// No edits are returned if the document cannot be parsed,
+// or if formatting would lose comments
+//
+func (s *Server) DocumentFormatting(
+	_ protocol.Conn,
+	params *protocol.DocumentFormattingParams,
+) (
+	[]*protocol.TextEdit,
+	error,
+) {
+	// NOTE: Always initialize to an empty slice, i.e DON'T use nil:
+	// The later will be ignored instead of being treated as no items
+	edits := []*protocol.TextEdit{}
+
+	document, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return edits, nil
+	}
+
+	program, err := parser2.ParseProgramWithComments(document.Text)
+	if err != nil {
+		return edits, nil
+	}
+
+	formatted := formatDoc(program.Doc(), params.Options) + "\n"
+	if !preservesComments(document.Text, formatted) {
+		return edits, nil
+	}
+
+	if formatted == document.Text {
+		return edits, nil
+	}
+
+	edits = append(edits, &protocol.TextEdit{
+		Range: protocol.Range{
+			Start: protocol.Position{},
+			End:   document.Position(len(document.Text)),
+		},
+		NewText: formatted,
+	})
+
+	return edits, nil
+}
+
+// DocumentRangeFormatting formats the top-level declarations
+// which overlap the given range, including their comments.
+//
+// No edits are returned if the document cannot be parsed,
+// or if formatting would lose comments
+//
+func (s *Server) DocumentRangeFormatting(
+	_ protocol.Conn,
+	params *protocol.DocumentRangeFormattingParams,
+) (
+	[]*protocol.TextEdit,
+	error,
+) {
+	// NOTE: Always initialize to an empty slice, i.e DON'T use nil:
+	// The later will be ignored instead of being treated as no items
+	edits := []*protocol.TextEdit{}
+
+	document, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return edits, nil
+	}
+
+	program, err := parser2.ParseProgramWithComments(document.Text)
+	if err != nil {
+		return edits, nil
+	}
+
+	// Find the declarations which overlap the range.
+	// The replaced text includes the leading comments of the first formatted declaration,
+	// e.g. its doc string, and the trailing comments of the last formatted declaration
+
+	var declarations []ast.Declaration
+	startOffset := 0
+	endOffset := 0
+
+	for _, declaration := range program.Declarations() {
+		startLine := float64(declaration.StartPosition().Line - 1)
+		endLine := float64(declaration.EndPosition().Line - 1)
+
+		if endLine < params.Range.Start.Line {
 			continue
 		}
 
-		for _, occurrenceRange := range origin.Occurrences {
-			textEdits = append(textEdits,
-				protocol.TextEdit{
-					Range: conversion.ASTToProtocolRange(
-						occurrenceRange.StartPos,
-						occurrenceRange.EndPos,
-					),
-					NewText: params.NewName,
-				},
-			)
+		if startLine > params.Range.End.Line {
+			break
+		}
+
+		if len(declarations) == 0 {
+			startOffset = declaration.StartPosition().Offset
+			if leading := declaration.ElementComments().Leading; len(leading) > 0 {
+				startOffset = leading[0].StartPos.Offset
+			}
+		}
+
+		declarations = append(declarations, declaration)
+
+		endOffset = declaration.EndPosition().Offset + 1
+		if trailing := declaration.ElementComments().Trailing; len(trailing) > 0 {
+			endOffset = trailing[len(trailing)-1].EndPos.Offset + 1
 		}
 	}
 
-	return &protocol.WorkspaceEdit{
-		Changes: &map[string][]protocol.TextEdit{
-			string(uri): textEdits,
+	if len(declarations) == 0 {
+		return edits, nil
+	}
+
+	text := document.Text[startOffset:endOffset]
+
+	formatted := formatDoc(ast.NewProgram(declarations).Doc(), params.Options)
+	if !preservesComments(text, formatted) {
+		return edits, nil
+	}
+
+	if formatted == text {
+		return edits, nil
+	}
+
+	edits = append(edits, &protocol.TextEdit{
+		Range: protocol.Range{
+			Start: document.Position(startOffset),
+			End:   document.Position(endOffset),
 		},
-	}, nil
+		NewText: formatted,
+	})
+
+	return edits, nil
+}
+
+// formatDoc renders the given document,
+// indenting with tabs or spaces as given in the formatting options
+//
+func formatDoc(doc prettier.Doc, options protocol.FormattingOptions) string {
+	indent := "\t"
+	if options.InsertSpaces {
+		tabSize := int(options.TabSize)
+		if tabSize <= 0 {
+			tabSize = 4
+		}
+		indent = strings.Repeat(" ", tabSize)
+	}
+
+	var builder strings.Builder
+	prettier.Prettier(&builder, doc, formattingMaxLineWidth, indent)
+	return builder.String()
+}
+
+// preservesComments returns true if the formatted code
+// has the same comments as the original code.
+//
+// Formatting should never lose comments,
+// but refusing to format is better than removing code
+//
+func preservesComments(code, formatted string) bool {
+	originalComments := sourceComments(code)
+	formattedComments := sourceComments(formatted)
+
+	if len(originalComments) != len(formattedComments) {
+		return false
+	}
+
+	for i, comment := range originalComments {
+		if comment != formattedComments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sourceComments returns the comment tokens in the given code.
+// Line comments are returned without trailing whitespace
+//
+func sourceComments(code string) (comments []string) {
+	tokens := lexer.Lex(code)
+	for {
+		token := tokens.Next()
+		switch token.Type {
+		case lexer.TokenEOF:
+			return comments
+
+		case lexer.TokenLineComment:
+			comment := code[token.StartPos.Offset : token.EndPos.Offset+1]
+			comments = append(comments, strings.TrimRight(comment, " \t\r"))
+
+		case lexer.TokenBlockCommentStart,
+			lexer.TokenBlockCommentContent,
+			lexer.TokenBlockCommentEnd:
+
+			comments = append(comments, code[token.StartPos.Offset:token.EndPos.Offset+1])
+		}
+	}
 }
 
 func (s *Server) CodeAction(
@@ -1416,7 +1867,8 @@ func convertDeclarationKindToCompletionItemType(kind common.DeclarationKind) pro
 		common.DeclarationKindResource,
 		common.DeclarationKindEvent,
 		common.DeclarationKindContract,
-		common.DeclarationKindType:
+		common.DeclarationKindType,
+		common.DeclarationKindTypeAlias:
 		return protocol.ClassCompletion
 
 	case common.DeclarationKindStructureInterface,
@@ -1691,6 +2143,9 @@ func (s *Server) getDiagnostics(
 		return
 	}
 
+	// The imports of the program are recorded by the import handler
+	delete(s.importedLocations, location.ID())
+
 	var checker *sema.Checker
 	checker, diagnosticsErr = sema.NewChecker(
 		program,
@@ -1788,6 +2243,8 @@ func (s *Server) getDiagnostics(
 
 					importedLocationID := importedLocation.ID()
 
+					s.recordImport(checker.Location, importedLocationID)
+
 					importedChecker, ok := s.checkers[importedLocationID]
 					if !ok {
 						importedProgram, err := s.resolveImport(importedLocation)
@@ -1805,6 +2262,7 @@ func (s *Server) getDiagnostics(
 							return nil, err
 						}
 						s.checkers[importedLocationID] = importedChecker
+						delete(s.importedLocations, importedLocationID)
 						err = importedChecker.Check()
 						if err != nil {
 							return nil, err
@@ -1818,6 +2276,7 @@ func (s *Server) getDiagnostics(
 			},
 		),
 		sema.WithAccessCheckMode(s.accessCheckMode),
+		sema.WithLintingEnabled(len(s.analyzers) > 0),
 	)
 	if diagnosticsErr != nil {
 		return
@@ -1851,19 +2310,38 @@ func (s *Server) getDiagnostics(
 		diagnostics = append(diagnostics, extraDiagnostics...)
 	}
 
-	for _, hint := range checker.Hints() {
-		diagnostic, codeActionsResolver := convertHint(hint, uri)
-		if codeActionsResolver != nil {
-			codeActionsResolverID := uuid.New()
-			diagnostic.Data = codeActionsResolverID
-			codeActionsResolvers[codeActionsResolverID] = codeActionsResolver
-		}
-		diagnostics = append(diagnostics, diagnostic)
+	if checkError == nil && len(s.analyzers) > 0 {
+		analysis.NewProgramFromChecker(checker, text).Run(
+			s.analyzers,
+			func(analysisDiagnostic analysis.Diagnostic) {
+				diagnostic, codeActionsResolver := convertAnalysisDiagnostic(analysisDiagnostic, uri)
+				if codeActionsResolver != nil {
+					codeActionsResolverID := uuid.New()
+					diagnostic.Data = codeActionsResolverID
+					codeActionsResolvers[codeActionsResolverID] = codeActionsResolver
+				}
+				diagnostics = append(diagnostics, diagnostic)
+			},
+		)
 	}
 
 	return
 }
 
+// recordImport records that the program at the given location imports the given location
+//
+func (s *Server) recordImport(location common.Location, importedLocationID common.LocationID) {
+	locationID := location.ID()
+
+	importedLocations, ok := s.importedLocations[locationID]
+	if !ok {
+		importedLocations = map[common.LocationID]struct{}{}
+		s.importedLocations[locationID] = importedLocations
+	}
+
+	importedLocations[importedLocationID] = struct{}{}
+}
+
 // getDiagnosticsForParentError unpacks all child errors and converts each to
 // a diagnostic. Both parser and checker errors can be unpacked.
 //
@@ -2939,76 +3417,75 @@ func fieldDeclarationCodeActions(
 	return codeActions
 }
 
-// convertHint converts a checker hint to a diagnostic
-// and an optional code action to resolve the hint.
+// convertAnalysisDiagnostic converts a diagnostic reported by an analyzer to a diagnostic
+// and an optional code action to apply the suggested fixes of the diagnostic.
 //
-func convertHint(
-	hint sema.Hint,
+func convertAnalysisDiagnostic(
+	analysisDiagnostic analysis.Diagnostic,
 	uri protocol.DocumentUri,
 ) (
 	protocol.Diagnostic,
 	func() []*protocol.CodeAction,
 ) {
-	startPosition := hint.StartPosition()
-	endPosition := hint.EndPosition()
+	protocolRange := conversion.ASTToProtocolRange(
+		analysisDiagnostic.StartPos,
+		analysisDiagnostic.EndPos,
+	)
 
-	protocolRange := conversion.ASTToProtocolRange(startPosition, endPosition)
+	message := analysisDiagnostic.Message
+	if analysisDiagnostic.SecondaryMessage != "" {
+		message = fmt.Sprintf("%s: %s", message, analysisDiagnostic.SecondaryMessage)
+	}
 
-	diagnostic := protocol.Diagnostic{
-		Message: hint.Hint(),
+	var severity protocol.DiagnosticSeverity
+	switch analysisDiagnostic.Severity {
+	case analysis.SeverityError:
+		severity = protocol.SeverityError
+	case analysis.SeverityWarning:
+		severity = protocol.SeverityWarning
+	default:
 		// protocol.SeverityHint doesn't look prominent enough in VS Code,
 		// only the first character of the range is highlighted.
-		Severity: protocol.SeverityInformation,
+		severity = protocol.SeverityInformation
+	}
+
+	diagnostic := protocol.Diagnostic{
+		Message:  message,
+		Code:     analysisDiagnostic.Category,
+		Severity: severity,
 		Range:    protocolRange,
 	}
 
-	var codeActionsResolver func() []*protocol.CodeAction
+	if len(analysisDiagnostic.SuggestedFixes) == 0 {
+		return diagnostic, nil
+	}
 
-	switch hint := hint.(type) {
-	case *sema.ReplacementHint:
-		codeActionsResolver = func() []*protocol.CodeAction {
-			replacement := hint.Expression.String()
-			return []*protocol.CodeAction{
-				{
-					Title:       fmt.Sprintf("Replace with suggestion `%s`", replacement),
-					Kind:        protocol.QuickFix,
-					Diagnostics: []protocol.Diagnostic{diagnostic},
-					Edit: &protocol.WorkspaceEdit{
-						Changes: &map[string][]protocol.TextEdit{
-							string(uri): {
-								{
-									Range:   protocolRange,
-									NewText: replacement,
-								},
-							},
-						},
-					},
-					IsPreferred: true,
-				},
+	codeActionsResolver := func() []*protocol.CodeAction {
+		codeActions := make([]*protocol.CodeAction, 0, len(analysisDiagnostic.SuggestedFixes))
+
+		for i, suggestedFix := range analysisDiagnostic.SuggestedFixes {
+			textEdits := make([]protocol.TextEdit, 0, len(suggestedFix.TextEdits))
+			for _, textEdit := range suggestedFix.TextEdits {
+				textEdits = append(textEdits, protocol.TextEdit{
+					Range:   conversion.ASTToProtocolRange(textEdit.StartPos, textEdit.EndPos),
+					NewText: textEdit.Replacement,
+				})
 			}
-		}
 
-	case *sema.RemovalHint:
-		codeActionsResolver = func() []*protocol.CodeAction {
-			return []*protocol.CodeAction{
-				{
-					Title:       "Remove unnecessary code",
-					Kind:        protocol.QuickFix,
-					Diagnostics: []protocol.Diagnostic{diagnostic},
-					Edit: &protocol.WorkspaceEdit{
-						Changes: &map[string][]protocol.TextEdit{
-							string(uri): {
-								{
-									Range:   protocolRange,
-									NewText: "",
-								},
-							},
-						},
+			codeActions = append(codeActions, &protocol.CodeAction{
+				Title:       suggestedFix.Message,
+				Kind:        protocol.QuickFix,
+				Diagnostics: []protocol.Diagnostic{diagnostic},
+				Edit: &protocol.WorkspaceEdit{
+					Changes: &map[string][]protocol.TextEdit{
+						string(uri): textEdits,
 					},
-					IsPreferred: true,
 				},
-			}
+				IsPreferred: i == 0,
+			})
 		}
+
+		return codeActions
 	}
 
 	return diagnostic, codeActionsResolver
@@ -0,0 +1,593 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/languageserver/protocol"
+	"github.com/onflow/cadence/runtime/lint"
+)
+
+type testConn struct{}
+
+var _ protocol.Conn = testConn{}
+
+func (testConn) Notify(_ string, _ interface{}) error {
+	return nil
+}
+
+func (testConn) ShowMessage(_ *protocol.ShowMessageParams) {}
+
+func (testConn) LogMessage(_ *protocol.LogMessageParams) {}
+
+func (testConn) PublishDiagnostics(_ *protocol.PublishDiagnosticsParams) error {
+	return nil
+}
+
+func (testConn) RegisterCapability(_ *protocol.RegistrationParams) error {
+	return nil
+}
+
+func newTestServer(t *testing.T, documents map[protocol.DocumentUri]string, open ...protocol.DocumentUri) *Server {
+	server, err := NewServer()
+	require.NoError(t, err)
+
+	for _, uri := range open {
+		err = server.DidOpenTextDocument(
+			testConn{},
+			&protocol.DidOpenTextDocumentParams{
+				TextDocument: protocol.TextDocumentItem{
+					URI:     uri,
+					Text:    documents[uri],
+					Version: 1,
+				},
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	return server
+}
+
+const testContractURI protocol.DocumentUri = "file:///test/Counter.cdc"
+const testTransactionURI protocol.DocumentUri = "file:///test/increment.cdc"
+
+var testReferencesDocuments = map[protocol.DocumentUri]string{
+	testContractURI: `
+pub contract Counter {
+    pub var count: Int
+
+    pub fun increment() {
+        self.count = self.count + 1
+    }
+
+    init() {
+        self.count = 0
+    }
+}
+`,
+	testTransactionURI: `
+import Counter from "./Counter.cdc"
+
+transaction {
+    execute {
+        Counter.increment()
+        log(Counter.count)
+    }
+}
+`,
+}
+
+func protocolRange(line, startCharacter, endCharacter float64) protocol.Range {
+	return protocol.Range{
+		Start: protocol.Position{Line: line, Character: startCharacter},
+		End:   protocol.Position{Line: line, Character: endCharacter},
+	}
+}
+
+func TestServerReferences(t *testing.T) {
+
+	t.Parallel()
+
+	server := newTestServer(t, testReferencesDocuments, testContractURI, testTransactionURI)
+
+	// Find references of the field `count`, at its use in the transaction
+
+	params := &protocol.ReferenceParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: testTransactionURI},
+			Position:     protocol.Position{Line: 6, Character: 22},
+		},
+	}
+
+	locations, err := server.References(testConn{}, params)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]*protocol.Location{
+			{URI: testContractURI, Range: protocolRange(5, 13, 18)},
+			{URI: testContractURI, Range: protocolRange(5, 26, 31)},
+			{URI: testContractURI, Range: protocolRange(9, 13, 18)},
+			{URI: testTransactionURI, Range: protocolRange(6, 20, 25)},
+		},
+		locations,
+	)
+
+	params.Context.IncludeDeclaration = true
+
+	locations, err = server.References(testConn{}, params)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]*protocol.Location{
+			{URI: testContractURI, Range: protocolRange(2, 12, 17)},
+			{URI: testContractURI, Range: protocolRange(5, 13, 18)},
+			{URI: testContractURI, Range: protocolRange(5, 26, 31)},
+			{URI: testContractURI, Range: protocolRange(9, 13, 18)},
+			{URI: testTransactionURI, Range: protocolRange(6, 20, 25)},
+		},
+		locations,
+	)
+}
+
+func TestServerReferencesAtDeclarationPositionInOtherDocument(t *testing.T) {
+
+	t.Parallel()
+
+	const contractURI protocol.DocumentUri = "file:///test/C.cdc"
+	const scriptURI protocol.DocumentUri = "file:///test/main.cdc"
+
+	// The occurrence of the field `count` in the script
+	// is at the same line and column as the declaration of the field in the contract
+
+	documents := map[protocol.DocumentUri]string{
+		contractURI: `
+pub contract C {
+
+    pub let count: Int
+    init() { self.count = 0 }
+}
+`,
+		scriptURI: `
+import C from "./C.cdc"
+pub fun main() {
+          C.count
+}
+`,
+	}
+
+	server := newTestServer(t, documents, contractURI, scriptURI)
+
+	locations, err := server.References(
+		testConn{},
+		&protocol.ReferenceParams{
+			TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: contractURI},
+				Position:     protocol.Position{Line: 3, Character: 14},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]*protocol.Location{
+			{URI: contractURI, Range: protocolRange(4, 18, 23)},
+			{URI: scriptURI, Range: protocolRange(3, 12, 17)},
+		},
+		locations,
+	)
+}
+
+func TestServerReferencesAfterImportedDocumentChanged(t *testing.T) {
+
+	t.Parallel()
+
+	server := newTestServer(t, testReferencesDocuments, testContractURI, testTransactionURI)
+
+	// Change the contract, which is imported by the transaction
+
+	err := server.DidChangeTextDocument(
+		testConn{},
+		&protocol.DidChangeTextDocumentParams{
+			TextDocument: protocol.VersionedTextDocumentIdentifier{
+				Version:                2,
+				TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: testContractURI},
+			},
+			ContentChanges: []protocol.TextDocumentContentChangeEvent{
+				{Text: "\n" + testReferencesDocuments[testContractURI]},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	// Find references of the field `count`, at its declaration in the changed contract
+
+	locations, err := server.References(
+		testConn{},
+		&protocol.ReferenceParams{
+			TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: testContractURI},
+				Position:     protocol.Position{Line: 3, Character: 14},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]*protocol.Location{
+			{URI: testContractURI, Range: protocolRange(6, 13, 18)},
+			{URI: testContractURI, Range: protocolRange(6, 26, 31)},
+			{URI: testContractURI, Range: protocolRange(10, 13, 18)},
+			{URI: testTransactionURI, Range: protocolRange(6, 20, 25)},
+		},
+		locations,
+	)
+}
+
+func TestServerRename(t *testing.T) {
+
+	t.Parallel()
+
+	server := newTestServer(t, testReferencesDocuments, testContractURI, testTransactionURI)
+
+	// Rename the function `increment`, at its declaration in the contract
+
+	edit, err := server.Rename(
+		testConn{},
+		&protocol.RenameParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: testContractURI},
+			Position:     protocol.Position{Line: 4, Character: 14},
+			NewName:      "inc",
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		map[string][]protocol.TextEdit{
+			string(testContractURI): {
+				{Range: protocolRange(4, 12, 21), NewText: "inc"},
+			},
+			string(testTransactionURI): {
+				{Range: protocolRange(5, 16, 25), NewText: "inc"},
+			},
+		},
+		*edit.Changes,
+	)
+}
+
+func TestServerDocumentHighlight(t *testing.T) {
+
+	t.Parallel()
+
+	server := newTestServer(t, testReferencesDocuments, testContractURI, testTransactionURI)
+
+	// Highlights are only reported for the current document
+
+	highlights, err := server.DocumentHighlight(
+		testConn{},
+		&protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: testTransactionURI},
+			Position:     protocol.Position{Line: 6, Character: 22},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]*protocol.DocumentHighlight{
+			{Range: protocolRange(6, 20, 25)},
+		},
+		highlights,
+	)
+}
+
+func TestServerHoverTypeAlias(t *testing.T) {
+
+	t.Parallel()
+
+	const uri protocol.DocumentUri = "file:///test/alias.cdc"
+
+	documents := map[protocol.DocumentUri]string{
+		uri: `
+typealias Quantity = Int
+let x: Quantity = 1
+let y = x
+`,
+	}
+
+	server := newTestServer(t, documents, uri)
+
+	hover := func(line, character float64) string {
+		result, err := server.Hover(
+			testConn{},
+			&protocol.TextDocumentPositionParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: uri},
+				Position:     protocol.Position{Line: line, Character: character},
+			},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		return result.Contents.Value
+	}
+
+	// The declared variable is displayed with the type alias,
+	// at its declaration and at its use
+
+	assert.Equal(t, "**Type**\n\n```cadence\nQuantity\n```\n", hover(2, 4))
+	assert.Equal(t, "**Type**\n\n```cadence\nQuantity\n```\n", hover(3, 8))
+
+	// The inferred type is the aliased type
+
+	assert.Equal(t, "**Type**\n\n```cadence\nInt\n```\n", hover(3, 4))
+}
+
+const testFormattingURI protocol.DocumentUri = "file:///test/format.cdc"
+
+func TestServerDocumentFormatting(t *testing.T) {
+
+	t.Parallel()
+
+	formattingOptions := protocol.FormattingOptions{
+		TabSize:      4,
+		InsertSpaces: true,
+	}
+
+	t.Run("document", func(t *testing.T) {
+
+		t.Parallel()
+
+		server := newTestServer(
+			t,
+			map[protocol.DocumentUri]string{
+				testFormattingURI: "import Counter from 0x01\n" +
+					"\n" +
+					"/// Test\n" +
+					"pub fun test( a:Int ):Int {return a*2}",
+			},
+			testFormattingURI,
+		)
+
+		edits, err := server.DocumentFormatting(
+			testConn{},
+			&protocol.DocumentFormattingParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: testFormattingURI},
+				Options:      formattingOptions,
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]*protocol.TextEdit{
+				{
+					Range: protocol.Range{
+						Start: protocol.Position{Line: 0, Character: 0},
+						End:   protocol.Position{Line: 3, Character: 38},
+					},
+					NewText: `import Counter from 0x1
+
+/// Test
+pub fun test(a: Int): Int {
+    return a * 2
+}
+`,
+				},
+			},
+			edits,
+		)
+	})
+
+	t.Run("range", func(t *testing.T) {
+
+		t.Parallel()
+
+		server := newTestServer(
+			t,
+			map[protocol.DocumentUri]string{
+				testFormattingURI: "fun a() {}\n" +
+					"\n" +
+					"/// Test\n" +
+					"pub  let b=2\n" +
+					"let c=3\n",
+			},
+			testFormattingURI,
+		)
+
+		edits, err := server.DocumentRangeFormatting(
+			testConn{},
+			&protocol.DocumentRangeFormattingParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: testFormattingURI},
+				Range:        protocolRange(3, 0, 4),
+				Options: protocol.FormattingOptions{
+					TabSize:      2,
+					InsertSpaces: true,
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]*protocol.TextEdit{
+				{
+					Range: protocol.Range{
+						Start: protocol.Position{Line: 2, Character: 0},
+						End:   protocol.Position{Line: 3, Character: 12},
+					},
+					NewText: "/// Test\npub let b = 2",
+				},
+			},
+			edits,
+		)
+	})
+
+	t.Run("comments", func(t *testing.T) {
+
+		t.Parallel()
+
+		server := newTestServer(
+			t,
+			map[protocol.DocumentUri]string{
+				testFormattingURI: "// comment\n" +
+					"let a=1 /* one */\n" +
+					"\n\n" +
+					"// end\n",
+			},
+			testFormattingURI,
+		)
+
+		edits, err := server.DocumentFormatting(
+			testConn{},
+			&protocol.DocumentFormattingParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: testFormattingURI},
+				Options:      formattingOptions,
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]*protocol.TextEdit{
+				{
+					Range: protocol.Range{
+						Start: protocol.Position{Line: 0, Character: 0},
+						End:   protocol.Position{Line: 5, Character: 0},
+					},
+					NewText: "// comment\n" +
+						"let a = 1 /* one */\n" +
+						"\n" +
+						"// end\n",
+				},
+			},
+			edits,
+		)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+
+		t.Parallel()
+
+		server := newTestServer(
+			t,
+			map[protocol.DocumentUri]string{
+				testFormattingURI: "let a = ",
+			},
+			testFormattingURI,
+		)
+
+		edits, err := server.DocumentFormatting(
+			testConn{},
+			&protocol.DocumentFormattingParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: testFormattingURI},
+				Options:      formattingOptions,
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Empty(t, edits)
+	})
+}
+
+// diagnosticsConn is a connection which records the published diagnostics
+//
+type diagnosticsConn struct {
+	testConn
+	diagnostics map[protocol.DocumentUri][]protocol.Diagnostic
+}
+
+func (c *diagnosticsConn) PublishDiagnostics(params *protocol.PublishDiagnosticsParams) error {
+	c.diagnostics[params.URI] = params.Diagnostics
+	return nil
+}
+
+const testAnalyzersURI protocol.DocumentUri = "file:///test/lint.cdc"
+
+func TestServerAnalyzers(t *testing.T) {
+
+	t.Parallel()
+
+	server, err := NewServer()
+	require.NoError(t, err)
+
+	err = server.SetOptions(WithAnalyzers(lint.UnnecessaryForceAnalyzer))
+	require.NoError(t, err)
+
+	conn := &diagnosticsConn{
+		diagnostics: map[protocol.DocumentUri][]protocol.Diagnostic{},
+	}
+
+	err = server.DidOpenTextDocument(
+		conn,
+		&protocol.DidOpenTextDocumentParams{
+			TextDocument: protocol.TextDocumentItem{
+				URI: testAnalyzersURI,
+				Text: "pub fun test(): Int {\n" +
+					"    let x = 1\n" +
+					"    return x!\n" +
+					"}\n",
+				Version: 1,
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	diagnostics := conn.diagnostics[testAnalyzersURI]
+	require.Len(t, diagnostics, 1)
+
+	diagnostic := diagnostics[0]
+	assert.Equal(t, protocol.SeverityInformation, diagnostic.Severity)
+	assert.Equal(t, "unnecessary-force", diagnostic.Code)
+	assert.Equal(t, protocolRange(2, 12, 13), diagnostic.Range)
+
+	// The code action is resolved using the data of the diagnostic,
+	// which the client sends as a string
+
+	diagnostic.Data = diagnostic.Data.(uuid.UUID).String()
+
+	codeActions, err := server.CodeAction(
+		conn,
+		&protocol.CodeActionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: testAnalyzersURI},
+			Context: protocol.CodeActionContext{
+				Diagnostics: []protocol.Diagnostic{diagnostic},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, codeActions, 1)
+
+	codeAction := codeActions[0]
+	assert.Equal(t, "Remove unnecessary code", codeAction.Title)
+	assert.Equal(t, protocol.QuickFix, codeAction.Kind)
+	assert.True(t, codeAction.IsPreferred)
+	assert.Equal(t,
+		&map[string][]protocol.TextEdit{
+			string(testAnalyzersURI): {
+				{
+					Range:   protocolRange(2, 12, 13),
+					NewText: "",
+				},
+			},
+		},
+		codeAction.Edit.Changes,
+	)
+}
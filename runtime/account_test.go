@@ -1876,6 +1876,193 @@ func TestGetAuthAccount(t *testing.T) {
 
 		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
 	})
+
+	t.Run("writable by default", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime()
+
+		script := []byte(`
+            pub fun main() {
+                let acc = getAuthAccount(0x02)
+                acc.save(1, to: /storage/number)
+            }
+        `)
+
+		writeCount := 0
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, func(_, _, _ []byte) {
+				writeCount++
+			}),
+		}
+
+		_, err := rt.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{0x1},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.NotZero(t, writeCount)
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTestInterpreterRuntime(WithScriptAuthAccountReadOnly(true))
+
+		address := common.Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}
+
+		writeCount := 0
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, func(_, _, _ []byte) {
+				writeCount++
+			}),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			getAccountContractCode: func(_ Address, _ string) ([]byte, error) {
+				return nil, nil
+			},
+		}
+
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.save([1, 2], to: /storage/numbers)
+                          signer.link<&[Int]>(/public/numbers, target: /storage/numbers)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		writeCount = 0
+
+		executeScript := func(code string) (cadence.Value, error) {
+			return rt.ExecuteScript(
+				Script{
+					Source: []byte(code),
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  common.ScriptLocation{0x1},
+				},
+			)
+		}
+
+		// Reading is allowed
+
+		result, err := executeScript(`
+          pub fun main(): [AnyStruct] {
+              let acc = getAuthAccount(0x1)
+              let numbers = acc.borrow<&[Int]>(from: /storage/numbers)!
+              return [
+                  numbers.length,
+                  acc.copy<[Int]>(from: /storage/numbers),
+                  acc.getLinkTarget(/public/numbers),
+                  acc.borrow<&Int>(from: /storage/missing),
+                  acc.getCapability<&Int>(/private/missing).borrow()
+              ]
+          }
+        `)
+		require.NoError(t, err)
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.NewInt(2),
+				cadence.NewOptional(
+					cadence.NewArray([]cadence.Value{
+						cadence.NewInt(1),
+						cadence.NewInt(2),
+					}),
+				),
+				cadence.NewOptional(
+					cadence.Path{
+						Domain:     "storage",
+						Identifier: "numbers",
+					},
+				),
+				cadence.NewOptional(nil),
+				cadence.NewOptional(nil),
+			}),
+			result,
+		)
+
+		// Functions which modify the account fail
+
+		for _, operation := range []struct {
+			name string
+			code string
+		}{
+			{"save", `acc.save(1, to: /storage/one)`},
+			{"load", `acc.load<[Int]>(from: /storage/numbers)`},
+			{"link", `acc.link<&[Int]>(/private/numbers, target: /storage/numbers)`},
+			{"unlink", `acc.unlink(/public/numbers)`},
+			{"addPublicKey", `acc.addPublicKey([])`},
+			{"removePublicKey", `acc.removePublicKey(0)`},
+			{"contracts.add", `acc.contracts.add(name: "C", code: [])`},
+			{"contracts.update__experimental", `acc.contracts.update__experimental(name: "C", code: [])`},
+			{"contracts.remove", `acc.contracts.remove(name: "C")`},
+			{"keys.revoke", `acc.keys.revoke(keyIndex: 0)`},
+		} {
+			_, err = executeScript(fmt.Sprintf(
+				`
+                  pub fun main() {
+                      let acc = getAuthAccount(0x1)
+                      %s
+                  }
+                `,
+				operation.code,
+			))
+			require.Error(t, err)
+
+			var readOnlyErr interpreter.ReadOnlyAuthAccountError
+			require.ErrorAs(t, err, &readOnlyErr)
+			assert.Equal(t, operation.name, readOnlyErr.Operation)
+		}
+
+		// Modifications through references are rejected when committing the storage
+
+		_, err = executeScript(`
+          pub fun main() {
+              let acc = getAuthAccount(0x1)
+              acc.borrow<&[Int]>(from: /storage/numbers)!.append(3)
+          }
+        `)
+		require.Error(t, err)
+		require.ErrorAs(t, err, &ReadOnlyStorageWriteError{})
+
+		assert.Equal(t, 0, writeCount)
+
+		result, err = executeScript(`
+          pub fun main(): [Int] {
+              return getAuthAccount(0x1).copy<[Int]>(from: /storage/numbers)!
+          }
+        `)
+		require.NoError(t, err)
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.NewInt(1),
+				cadence.NewInt(2),
+			}),
+			result,
+		)
+	})
 }
 
 type fakeError struct{}
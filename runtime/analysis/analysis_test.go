@@ -0,0 +1,356 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/analysis"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func testConfig(codes map[common.Location]string) *analysis.Config {
+	return &analysis.Config{
+		ResolveCode: func(
+			location common.Location,
+			_ common.Location,
+			_ ast.Range,
+		) (
+			string,
+			error,
+		) {
+			code, ok := codes[location]
+			if !ok {
+				return "", fmt.Errorf("unknown location: %s", location)
+			}
+			return code, nil
+		},
+	}
+}
+
+func TestLoad(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("imports", func(t *testing.T) {
+
+		t.Parallel()
+
+		const locationA = common.StringLocation("A")
+		const locationB = common.StringLocation("B")
+
+		programs, err := analysis.Load(
+			testConfig(map[common.Location]string{
+				locationA: `
+                  import "B"
+
+                  pub let a = b
+                `,
+				locationB: `
+                  pub let b = 1 as Int
+                `,
+			}),
+			locationA,
+		)
+		require.NoError(t, err)
+
+		require.Len(t, programs, 2)
+
+		programA := programs[locationA.ID()]
+		require.NotNil(t, programA)
+		assert.Equal(t, locationA, programA.Location)
+		assert.NotNil(t, programA.Program)
+		assert.NotNil(t, programA.Elaboration)
+		assert.Empty(t, programA.Hints)
+
+		programB := programs[locationB.ID()]
+		require.NotNil(t, programB)
+		require.Len(t, programB.Hints, 1)
+		assert.IsType(t, &sema.UnnecessaryCastHint{}, programB.Hints[0])
+	})
+
+	t.Run("checking error", func(t *testing.T) {
+
+		t.Parallel()
+
+		const location = common.StringLocation("A")
+
+		programs, err := analysis.Load(
+			testConfig(map[common.Location]string{
+				location: `
+                  pub let a: Bool = 1
+                `,
+			}),
+			location,
+		)
+		require.Error(t, err)
+
+		var checkerError *sema.CheckerError
+		require.ErrorAs(t, err, &checkerError)
+
+		// The code of the failed program is available for reporting
+
+		assert.Contains(t, programs.Codes(), location.ID())
+	})
+
+	t.Run("cyclic import", func(t *testing.T) {
+
+		t.Parallel()
+
+		const locationA = common.StringLocation("A")
+		const locationB = common.StringLocation("B")
+
+		_, err := analysis.Load(
+			testConfig(map[common.Location]string{
+				locationA: `import "B"`,
+				locationB: `import "A"`,
+			}),
+			locationA,
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cyclic import")
+	})
+}
+
+func TestProgram_Run(t *testing.T) {
+
+	t.Parallel()
+
+	const location = common.StringLocation("test")
+
+	programs, err := analysis.Load(
+		testConfig(map[common.Location]string{
+			location: `
+              pub let x = 1
+            `,
+		}),
+		location,
+	)
+	require.NoError(t, err)
+
+	program := programs[location.ID()]
+
+	declarationRange := ast.NewRangeFromPositioned(program.Program.Declarations()[0])
+
+	runs := map[string]int{}
+
+	countingAnalyzer := &analysis.Analyzer{
+		Name: "counting",
+		Run: func(pass *analysis.Pass) interface{} {
+			runs["counting"]++
+
+			pass.Report(analysis.Diagnostic{
+				Message: "counted",
+				Range:   declarationRange,
+			})
+
+			return len(pass.Program.Program.Declarations())
+		},
+	}
+
+	var reportingAnalyzers []*analysis.Analyzer
+
+	for _, name := range []string{"first", "second"} {
+		name := name
+
+		reportingAnalyzers = append(
+			reportingAnalyzers,
+			&analysis.Analyzer{
+				Name:     name,
+				Requires: []*analysis.Analyzer{countingAnalyzer},
+				Run: func(pass *analysis.Pass) interface{} {
+					runs[name]++
+
+					count := pass.ResultOf[countingAnalyzer].(int)
+
+					category := ""
+					if name == "second" {
+						category = "custom"
+					}
+
+					pass.Report(analysis.Diagnostic{
+						Category: category,
+						Message:  fmt.Sprintf("%s: %d", name, count),
+						Range:    declarationRange,
+					})
+
+					return nil
+				},
+			},
+		)
+	}
+
+	var diagnostics []analysis.Diagnostic
+
+	program.Run(reportingAnalyzers, func(diagnostic analysis.Diagnostic) {
+		diagnostics = append(diagnostics, diagnostic)
+	})
+
+	// The required analyzer is only run once,
+	// and its diagnostics are not reported
+
+	assert.Equal(t,
+		map[string]int{
+			"counting": 1,
+			"first":    1,
+			"second":   1,
+		},
+		runs,
+	)
+
+	assert.Equal(t,
+		[]analysis.Diagnostic{
+			{
+				Location: location,
+				Category: "first",
+				Message:  "first: 1",
+				Range:    declarationRange,
+			},
+			{
+				Location: location,
+				Category: "custom",
+				Message:  "second: 1",
+				Range:    declarationRange,
+			},
+		},
+		diagnostics,
+	)
+}
+
+func TestApplyTextEdits(t *testing.T) {
+
+	t.Parallel()
+
+	edit := func(startOffset, endOffset int, replacement string) analysis.TextEdit {
+		return analysis.TextEdit{
+			Replacement: replacement,
+			Range: ast.Range{
+				StartPos: ast.Position{Offset: startOffset},
+				EndPos:   ast.Position{Offset: endOffset},
+			},
+		}
+	}
+
+	code := "let x = 1 as Int"
+
+	assert.Equal(t,
+		"var y = 1",
+		analysis.ApplyTextEdits(
+			code,
+			[]analysis.TextEdit{
+				// Edits are not required to be sorted
+				edit(9, 15, ""),
+				edit(0, 2, "var"),
+				edit(4, 4, "y"),
+			},
+		),
+	)
+
+	// The given edits are not modified
+
+	edits := []analysis.TextEdit{
+		edit(4, 4, "y"),
+		edit(0, 2, "var"),
+	}
+	_ = analysis.ApplyTextEdits(code, edits)
+	assert.Equal(t, 4, edits[0].StartPos.Offset)
+}
+
+func TestProgram_Run_Suppression(t *testing.T) {
+
+	t.Parallel()
+
+	const location = common.StringLocation("test")
+
+	test := func(t *testing.T, pragmas string) []string {
+
+		programs, err := analysis.Load(
+			testConfig(map[common.Location]string{
+				location: pragmas + `
+                  pub let x = 1
+                `,
+			}),
+			location,
+		)
+		require.NoError(t, err)
+
+		var analyzers []*analysis.Analyzer
+		for _, name := range []string{"first", "second"} {
+			analyzers = append(analyzers, &analysis.Analyzer{
+				Name: name,
+				Run: func(pass *analysis.Pass) interface{} {
+					pass.Report(analysis.Diagnostic{
+						Message: "reported",
+					})
+					return nil
+				},
+			})
+		}
+
+		var categories []string
+		programs[location.ID()].Run(analyzers, func(diagnostic analysis.Diagnostic) {
+			categories = append(categories, diagnostic.Category)
+		})
+
+		return categories
+	}
+
+	t.Run("no pragma", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Equal(t,
+			[]string{"first", "second"},
+			test(t, ``),
+		)
+	})
+
+	t.Run("categories", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Equal(t,
+			[]string{"second"},
+			test(t, `#disableLint("first", "third")`),
+		)
+	})
+
+	t.Run("all", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Empty(t, test(t, `#disableLint`))
+	})
+
+	t.Run("other pragma", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Equal(t,
+			[]string{"first", "second"},
+			test(t, `#allowAll("first")`),
+		)
+	})
+}
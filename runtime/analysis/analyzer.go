@@ -0,0 +1,51 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+// Analyzer describes an analysis of a checked program,
+// e.g. a lint rule.
+//
+// An analyzer reports diagnostics, and may produce a result,
+// which is available to the analyzers which require it
+//
+type Analyzer struct {
+	// Name is the unique name of the analyzer, e.g. `redundant-cast`.
+	// It is used to enable or disable the analyzer,
+	// and is the default category of the reported diagnostics
+	Name string
+	// Description is a short description of the analyzer
+	Description string
+	// Requires are the analyzers which must be run before this analyzer,
+	// and whose results are available in Pass.ResultOf
+	Requires []*Analyzer
+	// Run applies the analyzer to the program of the given pass.
+	// The result is available to the analyzers which require this analyzer
+	Run func(pass *Pass) interface{}
+}
+
+// Pass is the interface of an analyzer to the analyzed program
+//
+type Pass struct {
+	// Program is the analyzed program
+	Program *Program
+	// Report reports a diagnostic
+	Report func(diagnostic Diagnostic)
+	// ResultOf are the results of the required analyzers
+	ResultOf map[*Analyzer]interface{}
+}
@@ -0,0 +1,118 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"sort"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// Diagnostic is a problem in a program reported by an analyzer
+//
+type Diagnostic struct {
+	// Location is the location of the program.
+	// It is set when the diagnostic is reported
+	Location common.Location
+	// Category is the category of the diagnostic, i.e. the ID of the rule which reported it.
+	// It defaults to the name of the analyzer which reported the diagnostic.
+	// Diagnostics can be suppressed by category, see DisableLintPragmaIdentifier
+	Category string
+	// Severity is the severity of the diagnostic
+	Severity Severity
+	// Message is the main message of the diagnostic
+	Message string
+	// SecondaryMessage is an optional message which describes the range of the diagnostic
+	SecondaryMessage string
+	// SuggestedFixes are the optional fixes for the problem
+	SuggestedFixes []SuggestedFix
+	ast.Range
+}
+
+// Severity is the severity of a diagnostic
+//
+type Severity uint8
+
+const (
+	// SeverityInfo is the severity of diagnostics which are suggestions,
+	// e.g. to remove unnecessary code
+	SeverityInfo Severity = iota
+	// SeverityWarning is the severity of diagnostics which are likely problems
+	SeverityWarning
+	// SeverityError is the severity of diagnostics which are almost certainly problems,
+	// e.g. security issues
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+// SuggestedFix is a fix for a diagnostic,
+// consisting of one or more edits of the program
+//
+type SuggestedFix struct {
+	// Message describes the fix, e.g. `Remove unnecessary cast`
+	Message   string
+	TextEdits []TextEdit
+}
+
+// TextEdit is a replacement of a range of the code of the program
+//
+type TextEdit struct {
+	// Replacement is the text which replaces the range.
+	// It is empty if the range is removed
+	Replacement string
+	ast.Range
+}
+
+// ApplyTextEdits returns the given code with the given edits applied.
+// The edits must not overlap
+//
+func ApplyTextEdits(code string, edits []TextEdit) string {
+	sortedEdits := make([]TextEdit, len(edits))
+	copy(sortedEdits, edits)
+
+	// Apply the edits from the end of the code to the start,
+	// so the offsets of the remaining edits stay valid
+
+	sort.Slice(sortedEdits, func(i, j int) bool {
+		return sortedEdits[i].StartPos.Offset < sortedEdits[j].StartPos.Offset
+	})
+
+	for i := len(sortedEdits) - 1; i >= 0; i-- {
+		edit := sortedEdits[i]
+		code = code[:edit.StartPos.Offset] +
+			edit.Replacement +
+			code[edit.EndPos.Offset+1:]
+	}
+
+	return code
+}
@@ -0,0 +1,160 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+// Config configures how programs are loaded
+//
+type Config struct {
+	// ResolveCode returns the code of the program at the given location.
+	// For imported programs, the importing location and the range of the import are given.
+	// For the loaded locations, the importing location is nil
+	ResolveCode func(
+		location common.Location,
+		importingLocation common.Location,
+		importRange ast.Range,
+	) (
+		string,
+		error,
+	)
+}
+
+// Programs are loaded programs, by location
+//
+type Programs map[common.LocationID]*Program
+
+var valueDeclarations = append(
+	stdlib.FlowBuiltInFunctions(stdlib.DefaultFlowBuiltinImpls()),
+	stdlib.BuiltinFunctions...,
+).ToSemaValueDeclarations()
+
+var typeDeclarations = append(
+	stdlib.FlowBuiltInTypes,
+	stdlib.BuiltinTypes...,
+).ToTypeDeclarations()
+
+// Load parses and checks the programs at the given locations, and the programs they import.
+// The programs are checked with linting enabled, so the hints of the checker are available to analyzers.
+//
+// If a program cannot be loaded, the error is returned,
+// together with the programs loaded so far, including the code of the failed program,
+// so the error can be reported
+//
+func Load(config *Config, locations ...common.Location) (Programs, error) {
+	programs := Programs{}
+
+	for _, location := range locations {
+		err := programs.load(config, location, nil, ast.Range{})
+		if err != nil {
+			return programs, err
+		}
+	}
+
+	return programs, nil
+}
+
+// Codes returns the code of each program
+//
+func (programs Programs) Codes() map[common.LocationID]string {
+	codes := make(map[common.LocationID]string, len(programs))
+	for locationID, program := range programs {
+		codes[locationID] = program.Code
+	}
+	return codes
+}
+
+func (programs Programs) load(
+	config *Config,
+	location common.Location,
+	importingLocation common.Location,
+	importRange ast.Range,
+) error {
+	if _, ok := programs[location.ID()]; ok {
+		return nil
+	}
+
+	code, err := config.ResolveCode(location, importingLocation, importRange)
+	if err != nil {
+		return err
+	}
+
+	program := &Program{
+		Location: location,
+		Code:     code,
+	}
+	programs[location.ID()] = program
+
+	program.Program, err = parser2.ParseProgram(code)
+	if err != nil {
+		return err
+	}
+
+	checker, err := sema.NewChecker(
+		program.Program,
+		location,
+		sema.WithPredeclaredValues(valueDeclarations),
+		sema.WithPredeclaredTypes(typeDeclarations),
+		sema.WithLintingEnabled(true),
+		sema.WithImportHandler(
+			func(checker *sema.Checker, importedLocation common.Location, importRange ast.Range) (sema.Import, error) {
+				err := programs.load(config, importedLocation, checker.Location, importRange)
+				if err != nil {
+					return nil, err
+				}
+
+				importedProgram := programs[importedLocation.ID()]
+				if importedProgram.Elaboration == nil {
+					return nil, &sema.CheckerError{
+						Location: checker.Location,
+						Codes:    programs.Codes(),
+						Errors: []error{
+							fmt.Errorf("cyclic import of `%s`", importedLocation),
+						},
+					}
+				}
+
+				return sema.ElaborationImport{
+					Elaboration: importedProgram.Elaboration,
+				}, nil
+			},
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = checker.Check()
+	if err != nil {
+		return err
+	}
+
+	program.Elaboration = checker.Elaboration
+	program.Hints = checker.Hints()
+
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// Program is a parsed and checked program which can be analyzed
+//
+type Program struct {
+	Location    common.Location
+	Code        string
+	Program     *ast.Program
+	Elaboration *sema.Elaboration
+	// Hints are the hints reported by the checker, if linting was enabled
+	Hints []sema.Hint
+}
+
+// NewProgramFromChecker returns the program checked by the given checker.
+// The checker should have been created with linting enabled (see sema.WithLintingEnabled),
+// so the hints of the checker are available to analyzers
+//
+func NewProgramFromChecker(checker *sema.Checker, code string) *Program {
+	return &Program{
+		Location:    checker.Location,
+		Code:        code,
+		Program:     checker.Program,
+		Elaboration: checker.Elaboration,
+		Hints:       checker.Hints(),
+	}
+}
+
+// Run runs the given analyzers on the program,
+// and reports their diagnostics using the given function.
+//
+// The analyzers required by the given analyzers are run first.
+// Each analyzer is run at most once. The diagnostics of analyzers
+// which are only run because they are required are not reported.
+// Diagnostics suppressed by a pragma of the program are not reported,
+// see DisableLintPragmaIdentifier
+//
+func (program *Program) Run(analyzers []*Analyzer, report func(Diagnostic)) {
+
+	reported := make(map[*Analyzer]struct{}, len(analyzers))
+	for _, analyzer := range analyzers {
+		reported[analyzer] = struct{}{}
+	}
+
+	programSuppressions := program.suppressions()
+
+	results := map[*Analyzer]interface{}{}
+	running := map[*Analyzer]struct{}{}
+
+	var run func(analyzer *Analyzer) interface{}
+	run = func(analyzer *Analyzer) interface{} {
+		if result, ok := results[analyzer]; ok {
+			return result
+		}
+
+		if _, ok := running[analyzer]; ok {
+			panic(fmt.Errorf("cyclic analyzer requirement: %s", analyzer.Name))
+		}
+		running[analyzer] = struct{}{}
+
+		resultOf := make(map[*Analyzer]interface{}, len(analyzer.Requires))
+		for _, required := range analyzer.Requires {
+			resultOf[required] = run(required)
+		}
+
+		pass := &Pass{
+			Program:  program,
+			ResultOf: resultOf,
+			Report:   func(Diagnostic) {},
+		}
+
+		if _, ok := reported[analyzer]; ok {
+			pass.Report = func(diagnostic Diagnostic) {
+				diagnostic.Location = program.Location
+				if diagnostic.Category == "" {
+					diagnostic.Category = analyzer.Name
+				}
+				if programSuppressions.suppresses(diagnostic) {
+					return
+				}
+				report(diagnostic)
+			}
+		}
+
+		result := analyzer.Run(pass)
+
+		delete(running, analyzer)
+		results[analyzer] = result
+
+		return result
+	}
+
+	for _, analyzer := range analyzers {
+		run(analyzer)
+	}
+}
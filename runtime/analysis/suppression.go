@@ -0,0 +1,84 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// DisableLintPragmaIdentifier is the identifier of the pragma
+// which suppresses the diagnostics of a program.
+//
+// The pragma `#disableLint("a", "b")` suppresses the diagnostics with the categories `a` and `b`,
+// the pragma `#disableLint` suppresses all diagnostics
+//
+const DisableLintPragmaIdentifier = "disableLint"
+
+// suppressions are the categories of diagnostics which are suppressed in a program
+//
+type suppressions struct {
+	all        bool
+	categories map[string]struct{}
+}
+
+func (s suppressions) suppresses(diagnostic Diagnostic) bool {
+	if s.all {
+		return true
+	}
+	_, ok := s.categories[diagnostic.Category]
+	return ok
+}
+
+// suppressions returns the suppressions declared by the pragmas of the program
+//
+func (program *Program) suppressions() suppressions {
+	result := suppressions{
+		categories: map[string]struct{}{},
+	}
+
+	for _, pragma := range program.Program.PragmaDeclarations() {
+		switch expression := pragma.Expression.(type) {
+		case *ast.IdentifierExpression:
+			if expression.Identifier.Identifier == DisableLintPragmaIdentifier {
+				result.all = true
+			}
+
+		case *ast.InvocationExpression:
+			invokedExpression, ok := expression.InvokedExpression.(*ast.IdentifierExpression)
+			if !ok || invokedExpression.Identifier.Identifier != DisableLintPragmaIdentifier {
+				continue
+			}
+
+			if len(expression.Arguments) == 0 {
+				result.all = true
+				continue
+			}
+
+			for _, argument := range expression.Arguments {
+				stringExpression, ok := argument.Expression.(*ast.StringExpression)
+				if !ok {
+					continue
+				}
+				result.categories[stringExpression.Value] = struct{}{}
+			}
+		}
+	}
+
+	return result
+}
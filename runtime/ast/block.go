@@ -26,6 +26,7 @@ import (
 
 type Block struct {
 	Statements []Statement
+	Comments   *Comments `json:",omitempty"`
 	Range
 }
 
@@ -41,39 +42,77 @@ func (b *Block) Walk(walkChild func(Element)) {
 	walkStatements(walkChild, b.Statements)
 }
 
+func (b *Block) ElementComments() *Comments {
+	return b.Comments
+}
+
+func (b *Block) SetElementComments(comments *Comments) {
+	b.Comments = comments
+}
+
 var blockStartDoc prettier.Doc = prettier.Text("{")
 var blockEndDoc prettier.Doc = prettier.Text("}")
 var blockEmptyDoc prettier.Doc = prettier.Text("{}")
 
 func (b *Block) Doc() prettier.Doc {
-	if b.IsEmpty() {
+	if b.IsEmpty() && !b.hasInnerComments() {
 		return blockEmptyDoc
 	}
 
 	return prettier.Concat{
 		blockStartDoc,
 		prettier.Indent{
-			Doc: StatementsDoc(b.Statements),
+			Doc: b.bodyDoc(),
 		},
 		prettier.HardLine{},
 		blockEndDoc,
 	}
 }
 
+func (b *Block) hasInnerComments() bool {
+	return b != nil &&
+		b.Comments != nil &&
+		len(b.Comments.Inner) > 0
+}
+
+// bodyDoc returns the document for the inner comments and the statements of the block
+//
+func (b *Block) bodyDoc() prettier.Doc {
+	if b == nil {
+		return nil
+	}
+
+	statementsDoc := StatementsDoc(b.Statements)
+
+	innerCommentsDoc := innerCommentsDoc(b.Comments)
+	if innerCommentsDoc == nil {
+		return statementsDoc
+	}
+
+	return prettier.Concat{
+		innerCommentsDoc,
+		statementsDoc,
+	}
+}
+
+// StatementsDoc returns the document for the given statements,
+// each preceded by a line break.
+//
+// If comments are attached to the statements,
+// they are printed, and the empty lines between the statements are preserved
+//
 func StatementsDoc(statements []Statement) prettier.Doc {
 	var statementsDoc prettier.Concat
 
-	for _, statement := range statements {
-		// TODO: replace once Statement implements Doc
-		hasDoc, ok := statement.(interface{ Doc() prettier.Doc })
-		if !ok {
-			continue
+	for i, statement := range statements {
+		var separatorDoc prettier.Doc = prettier.HardLine{}
+		if i > 0 {
+			separatorDoc = elementSeparatorDoc(statement, separatorDoc)
 		}
-
 		statementsDoc = append(
 			statementsDoc,
-			prettier.HardLine{},
-			hasDoc.Doc(),
+			separatorDoc,
+			elementDoc(statement),
 		)
 	}
 
@@ -116,6 +155,55 @@ func (b *FunctionBlock) Walk(walkChild func(Element)) {
 	// TODO: post-conditions
 }
 
+func (b *FunctionBlock) Doc() prettier.Doc {
+	if b.IsEmpty() && !b.Block.hasInnerComments() {
+		return blockEmptyDoc
+	}
+
+	if b.PreConditions == nil && b.PostConditions == nil {
+		return b.Block.Doc()
+	}
+
+	// The inner comments of the block are printed before the conditions,
+	// as they may precede them in the source
+
+	var bodyDoc prettier.Concat
+
+	if innerCommentsDoc := innerCommentsDoc(b.Block.Comments); innerCommentsDoc != nil {
+		bodyDoc = append(bodyDoc, innerCommentsDoc)
+	}
+
+	if b.PreConditions != nil {
+		bodyDoc = append(
+			bodyDoc,
+			prettier.HardLine{},
+			b.PreConditions.Doc(ConditionKindPre),
+		)
+	}
+
+	if b.PostConditions != nil {
+		bodyDoc = append(
+			bodyDoc,
+			prettier.HardLine{},
+			b.PostConditions.Doc(ConditionKindPost),
+		)
+	}
+
+	bodyDoc = append(
+		bodyDoc,
+		StatementsDoc(b.Block.Statements),
+	)
+
+	return prettier.Concat{
+		blockStartDoc,
+		prettier.Indent{
+			Doc: bodyDoc,
+		},
+		prettier.HardLine{},
+		blockEndDoc,
+	}
+}
+
 func (b *FunctionBlock) MarshalJSON() ([]byte, error) {
 	type Alias FunctionBlock
 	return json.Marshal(&struct {
@@ -140,9 +228,29 @@ func (b *FunctionBlock) EndPosition() Position {
 // Condition
 
 type Condition struct {
-	Kind    ConditionKind
-	Test    Expression
-	Message Expression
+	Kind     ConditionKind
+	Test     Expression
+	Message  Expression
+	Comments *Comments `json:",omitempty"`
+}
+
+func (c *Condition) StartPosition() Position {
+	return c.Test.StartPosition()
+}
+
+func (c *Condition) EndPosition() Position {
+	if c.Message != nil {
+		return c.Message.EndPosition()
+	}
+	return c.Test.EndPosition()
+}
+
+func (c *Condition) ElementComments() *Comments {
+	return c.Comments
+}
+
+func (c *Condition) SetElementComments(comments *Comments) {
+	c.Comments = comments
 }
 
 // Conditions
@@ -152,3 +260,62 @@ type Conditions []*Condition
 func (c *Conditions) IsEmpty() bool {
 	return c == nil || len(*c) == 0
 }
+
+const conditionMessageSeparatorDoc = prettier.Text(":")
+
+func (c *Condition) Doc() prettier.Doc {
+	doc := c.Test.Doc()
+	if c.Message != nil {
+		doc = prettier.Concat{
+			doc,
+			conditionMessageSeparatorDoc,
+			prettier.Indent{
+				Doc: prettier.Concat{
+					prettier.Line{},
+					c.Message.Doc(),
+				},
+			},
+		}
+	}
+
+	return prettier.Group{
+		Doc: doc,
+	}
+}
+
+func (c *Conditions) Doc(kind ConditionKind) prettier.Doc {
+	keywordDoc := prettier.Text(kind.Keyword())
+
+	if c.IsEmpty() {
+		return prettier.Concat{
+			keywordDoc,
+			prettier.Space,
+			blockEmptyDoc,
+		}
+	}
+
+	var conditionsDoc prettier.Concat
+
+	for i, condition := range *c {
+		var separatorDoc prettier.Doc = prettier.HardLine{}
+		if i > 0 {
+			separatorDoc = elementSeparatorDoc(condition, separatorDoc)
+		}
+		conditionsDoc = append(
+			conditionsDoc,
+			separatorDoc,
+			commentedDoc(condition.Comments, condition.Doc(), false),
+		)
+	}
+
+	return prettier.Concat{
+		keywordDoc,
+		prettier.Space,
+		blockStartDoc,
+		prettier.Indent{
+			Doc: conditionsDoc,
+		},
+		prettier.HardLine{},
+		blockEndDoc,
+	}
+}
@@ -0,0 +1,250 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"strings"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// Comment is a line comment or a block comment in the source code
+//
+type Comment struct {
+	// Text is the source of the comment, including the delimiters,
+	// e.g. `// comment` or `/* comment */`
+	Text string
+	// OwnLine is true if the comment is not preceded by code on the same line
+	OwnLine bool
+	// BlankLineBefore is true if the comment is preceded by an empty line
+	BlankLineBefore bool
+	Range
+}
+
+// Comments are the comments attached to an element,
+// and the information about the blank lines around it.
+//
+// Comments are only attached when the program is parsed
+// in the comment-preserving parse mode.
+// In that case, doc strings are also part of the leading comments,
+// and the DocString field of declarations is not printed
+//
+type Comments struct {
+	// Leading are the comments before the element
+	Leading []*Comment `json:",omitempty"`
+	// Trailing are the comments after the element.
+	// Comments which are not on their own line are on the same line as the end of the element
+	Trailing []*Comment `json:",omitempty"`
+	// Inner are the comments inside the element
+	// which could not be attached to a nested element,
+	// e.g. the comments in an empty block
+	Inner []*Comment `json:",omitempty"`
+	// BlankLineBefore is true if the element is preceded by an empty line,
+	// i.e. between the leading comments, if any, and the element
+	BlankLineBefore bool
+}
+
+// Commented is a node to which comments can be attached,
+// i.e. declarations, statements, blocks, and switch cases
+//
+type Commented interface {
+	HasPosition
+	ElementComments() *Comments
+	SetElementComments(comments *Comments)
+}
+
+// CommentedElement is an element to which comments can be attached
+//
+type CommentedElement interface {
+	Element
+	Commented
+}
+
+// hasBlankLineBefore returns true if the element with the given comments,
+// including its leading comments, is preceded by an empty line
+//
+func (c *Comments) hasBlankLineBefore() bool {
+	if c == nil {
+		return false
+	}
+	if len(c.Leading) > 0 {
+		return c.Leading[0].BlankLineBefore
+	}
+	return c.BlankLineBefore
+}
+
+func (c *Comment) isLineComment() bool {
+	return strings.HasPrefix(c.Text, "//")
+}
+
+func (c *Comment) Doc() prettier.Doc {
+	return prettier.Text(c.Text)
+}
+
+// lineSeparatorDoc returns the document separating two lines,
+// which is an empty line if blankLine is true
+//
+func lineSeparatorDoc(blankLine bool) prettier.Doc {
+	if blankLine {
+		return blankLineDoc
+	}
+	return prettier.HardLine{}
+}
+
+// commentLinesDoc returns the document for the given comments,
+// each on its own line
+//
+func commentLinesDoc(comments []*Comment) prettier.Doc {
+	var doc prettier.Concat
+	for i, comment := range comments {
+		if i > 0 {
+			doc = append(doc, lineSeparatorDoc(comment.BlankLineBefore))
+		}
+		doc = append(doc, comment.Doc())
+	}
+	return doc
+}
+
+// trailingCommentsDoc returns the document for the given comments,
+// which follow an element.
+//
+// Once a comment is printed on its own line, or a line comment is printed,
+// all following comments are printed on their own line
+//
+func trailingCommentsDoc(comments []*Comment) prettier.Doc {
+	var doc prettier.Concat
+	ownLine := false
+	for i, comment := range comments {
+		if comment.OwnLine ||
+			(i > 0 && comments[i-1].isLineComment()) {
+
+			ownLine = true
+		}
+		if ownLine {
+			doc = append(doc, lineSeparatorDoc(comment.BlankLineBefore))
+		} else {
+			doc = append(doc, prettier.Space)
+		}
+		doc = append(doc, comment.Doc())
+	}
+	return doc
+}
+
+// commentedDoc returns the document for an element with the given comments,
+// i.e. the given document of the element, preceded by the leading comments,
+// and followed by the trailing comments.
+//
+// The inner comments, e.g. the comments inside of an expression,
+// are also printed after the element, unless the element prints them itself
+//
+func commentedDoc(comments *Comments, doc prettier.Doc, printsInnerComments bool) prettier.Doc {
+	if comments == nil {
+		return doc
+	}
+
+	var result prettier.Concat
+
+	if len(comments.Leading) > 0 {
+		result = append(
+			result,
+			commentLinesDoc(comments.Leading),
+			lineSeparatorDoc(comments.BlankLineBefore),
+		)
+	}
+
+	result = append(result, doc)
+
+	trailingComments := comments.Trailing
+
+	if !printsInnerComments && len(comments.Inner) > 0 {
+		trailingComments = make([]*Comment, 0, len(comments.Inner)+len(comments.Trailing))
+		trailingComments = append(trailingComments, comments.Inner...)
+		trailingComments = append(trailingComments, comments.Trailing...)
+	}
+
+	return append(result, trailingCommentsDoc(trailingComments))
+}
+
+// innerCommentsDoc returns the document for the inner comments
+// of an element which prints them at the start of its body,
+// including the line separating them from the rest of the body
+//
+func innerCommentsDoc(comments *Comments) prettier.Doc {
+	if comments == nil || len(comments.Inner) == 0 {
+		return nil
+	}
+	return prettier.Concat{
+		prettier.HardLine{},
+		commentLinesDoc(comments.Inner),
+	}
+}
+
+// IsEmpty returns true if no comments are attached
+//
+func (c *Comments) IsEmpty() bool {
+	return c == nil ||
+		(len(c.Leading) == 0 &&
+			len(c.Trailing) == 0 &&
+			len(c.Inner) == 0)
+}
+
+// elementDoc returns the document for the given declaration or statement,
+// including its comments
+//
+func elementDoc(element interface {
+	CommentedElement
+	Doc() prettier.Doc
+}) prettier.Doc {
+	return commentedDoc(
+		element.ElementComments(),
+		element.Doc(),
+		printsInnerComments(element),
+	)
+}
+
+// printsInnerComments returns true if the given element prints
+// its inner comments in its body
+//
+func printsInnerComments(element CommentedElement) bool {
+	switch element := element.(type) {
+	case *Block,
+		*InterfaceDeclaration,
+		*TransactionDeclaration:
+		return true
+	case *CompositeDeclaration:
+		return element.CompositeKind != common.CompositeKindEvent
+	}
+	return false
+}
+
+// elementSeparatorDoc returns the document separating the given element
+// from the preceding element in a list of elements, e.g. statements.
+//
+// If comments are attached to the element, the empty lines of the source are preserved.
+// Otherwise, the given default separator is used
+//
+func elementSeparatorDoc(element Commented, defaultDoc prettier.Doc) prettier.Doc {
+	comments := element.ElementComments()
+	if comments == nil {
+		return defaultDoc
+	}
+	return lineSeparatorDoc(comments.hasBlankLineBefore())
+}
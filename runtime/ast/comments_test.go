@@ -0,0 +1,137 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/turbolent/prettier"
+)
+
+func TestBlock_Doc_Comments(t *testing.T) {
+
+	t.Parallel()
+
+	newStatement := func(name string, comments *Comments) Statement {
+		return &ExpressionStatement{
+			Expression: &IdentifierExpression{
+				Identifier: Identifier{
+					Identifier: name,
+				},
+			},
+			Comments: comments,
+		}
+	}
+
+	t.Run("statements", func(t *testing.T) {
+
+		t.Parallel()
+
+		block := &Block{
+			Statements: []Statement{
+				newStatement("a", &Comments{
+					Leading: []*Comment{
+						{Text: "// leading", OwnLine: true},
+					},
+					Trailing: []*Comment{
+						{Text: "/* inline */"},
+						{Text: "// trailing"},
+					},
+				}),
+				newStatement("b", &Comments{
+					BlankLineBefore: true,
+					Trailing: []*Comment{
+						{Text: "// own line", OwnLine: true},
+					},
+				}),
+				newStatement("c", &Comments{
+					Leading: []*Comment{
+						{Text: "// separated", OwnLine: true, BlankLineBefore: true},
+					},
+				}),
+			},
+			Comments: &Comments{},
+		}
+
+		var b strings.Builder
+		prettier.Prettier(&b, block.Doc(), 80, "    ")
+
+		assert.Equal(t,
+			`{
+    // leading
+    a /* inline */ // trailing
+
+    b
+    // own line
+
+    // separated
+    c
+}`,
+			b.String(),
+		)
+	})
+
+	t.Run("empty, inner", func(t *testing.T) {
+
+		t.Parallel()
+
+		block := &Block{
+			Comments: &Comments{
+				Inner: []*Comment{
+					{Text: "// TODO", OwnLine: true},
+				},
+			},
+		}
+
+		var b strings.Builder
+		prettier.Prettier(&b, block.Doc(), 80, "    ")
+
+		assert.Equal(t,
+			`{
+    // TODO
+}`,
+			b.String(),
+		)
+	})
+
+	t.Run("no comments", func(t *testing.T) {
+
+		t.Parallel()
+
+		block := &Block{
+			Statements: []Statement{
+				newStatement("a", nil),
+				newStatement("b", nil),
+			},
+		}
+
+		var b strings.Builder
+		prettier.Prettier(&b, block.Doc(), 80, "    ")
+
+		assert.Equal(t,
+			`{
+    a
+    b
+}`,
+			b.String(),
+		)
+	})
+}
@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -32,9 +34,12 @@ type CompositeDeclaration struct {
 	Access        Access
 	CompositeKind common.CompositeKind
 	Identifier    Identifier
-	Conformances  []*NominalType
-	Members       *Members
-	DocString     string
+	// TypeParameterList is the type parameter list of a generic composite, if any
+	TypeParameterList *TypeParameterList `json:",omitempty"`
+	Conformances      []*NominalType
+	Members           *Members
+	DocString         string
+	Comments          *Comments `json:",omitempty"`
 	Range
 }
 
@@ -46,6 +51,14 @@ func (d *CompositeDeclaration) Walk(walkChild func(Element)) {
 	walkDeclarations(walkChild, d.Members.declarations)
 }
 
+func (d *CompositeDeclaration) ElementComments() *Comments {
+	return d.Comments
+}
+
+func (d *CompositeDeclaration) SetElementComments(comments *Comments) {
+	d.Comments = comments
+}
+
 func (*CompositeDeclaration) isDeclaration() {}
 
 // NOTE: statement, so it can be represented in the AST,
@@ -73,6 +86,85 @@ func (d *CompositeDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+var compositeDeclarationEventKeywordSpaceDoc prettier.Doc = prettier.Text("event ")
+var compositeDeclarationConformanceSeparatorDoc prettier.Doc = prettier.Text(":")
+var compositeDeclarationConformancesSeparatorDoc prettier.Doc = prettier.Concat{
+	prettier.Text(","),
+	prettier.Line{},
+}
+
+func (d *CompositeDeclaration) Doc() prettier.Doc {
+	if d.CompositeKind == common.CompositeKindEvent {
+		return declarationDoc(
+			d.Comments,
+			d.DocString,
+			d.Access,
+			d.eventDoc(),
+		)
+	}
+
+	doc := prettier.Concat{
+		prettier.Text(d.CompositeKind.Keyword()),
+		prettier.Space,
+		prettier.Text(d.Identifier.Identifier),
+		d.TypeParameterList.Doc(),
+	}
+
+	if len(d.Conformances) > 0 {
+		conformancesDoc := make([]prettier.Doc, 0, len(d.Conformances))
+		for _, conformance := range d.Conformances {
+			conformancesDoc = append(conformancesDoc, conformance.Doc())
+		}
+
+		doc = append(
+			doc,
+			compositeDeclarationConformanceSeparatorDoc,
+			prettier.Group{
+				Doc: prettier.Indent{
+					Doc: prettier.Concat{
+						prettier.Line{},
+						prettier.Join(
+							compositeDeclarationConformancesSeparatorDoc,
+							conformancesDoc...,
+						),
+					},
+				},
+			},
+		)
+	}
+
+	return declarationDoc(
+		d.Comments,
+		d.DocString,
+		d.Access,
+		prettier.Concat{
+			doc,
+			prettier.Space,
+			d.Members.doc(d.Comments),
+		},
+	)
+}
+
+// eventDoc returns the document for an event declaration,
+// which only has the parameter list of its initializer
+//
+func (d *CompositeDeclaration) eventDoc() prettier.Doc {
+	var parameterList *ParameterList
+
+	initializers := d.Members.Initializers()
+	if len(initializers) > 0 {
+		parameterList = initializers[0].FunctionDeclaration.ParameterList
+	}
+
+	return prettier.Concat{
+		compositeDeclarationEventKeywordSpaceDoc,
+		prettier.Text(d.Identifier.Identifier),
+		prettier.Group{
+			Doc: parameterList.Doc(),
+		},
+	}
+}
+
 func (d *CompositeDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias CompositeDeclaration
 	return json.Marshal(&struct {
@@ -92,6 +184,7 @@ type FieldDeclaration struct {
 	Identifier     Identifier
 	TypeAnnotation *TypeAnnotation
 	DocString      string
+	Comments       *Comments `json:",omitempty"`
 	Range
 }
 
@@ -104,6 +197,14 @@ func (d *FieldDeclaration) Walk(_ func(Element)) {
 	// TODO: walk type
 }
 
+func (d *FieldDeclaration) ElementComments() *Comments {
+	return d.Comments
+}
+
+func (d *FieldDeclaration) SetElementComments(comments *Comments) {
+	d.Comments = comments
+}
+
 func (*FieldDeclaration) isDeclaration() {}
 
 func (d *FieldDeclaration) DeclarationIdentifier() *Identifier {
@@ -126,6 +227,30 @@ func (d *FieldDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+func (d *FieldDeclaration) Doc() prettier.Doc {
+	var doc prettier.Concat
+
+	if d.VariableKind != VariableKindNotSpecified {
+		doc = append(
+			doc,
+			prettier.Text(d.VariableKind.Keyword()),
+			prettier.Space,
+		)
+	}
+
+	return declarationDoc(
+		d.Comments,
+		d.DocString,
+		d.Access,
+		append(
+			doc,
+			prettier.Text(d.Identifier.Identifier),
+			typeSeparatorDoc,
+			d.TypeAnnotation.Doc(),
+		),
+	)
+}
+
 func (d *FieldDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias FieldDeclaration
 	return json.Marshal(&struct {
@@ -143,7 +268,8 @@ type EnumCaseDeclaration struct {
 	Access     Access
 	Identifier Identifier
 	DocString  string
-	StartPos   Position `json:"-"`
+	Comments   *Comments `json:",omitempty"`
+	StartPos   Position  `json:"-"`
 }
 
 func (d *EnumCaseDeclaration) Accept(visitor Visitor) Repr {
@@ -154,6 +280,14 @@ func (*EnumCaseDeclaration) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (d *EnumCaseDeclaration) ElementComments() *Comments {
+	return d.Comments
+}
+
+func (d *EnumCaseDeclaration) SetElementComments(comments *Comments) {
+	d.Comments = comments
+}
+
 func (*EnumCaseDeclaration) isDeclaration() {}
 
 func (d *EnumCaseDeclaration) DeclarationIdentifier() *Identifier {
@@ -184,6 +318,20 @@ func (d *EnumCaseDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+const enumCaseDeclarationCaseKeywordSpaceDoc = prettier.Text("case ")
+
+func (d *EnumCaseDeclaration) Doc() prettier.Doc {
+	return declarationDoc(
+		d.Comments,
+		d.DocString,
+		d.Access,
+		prettier.Concat{
+			enumCaseDeclarationCaseKeywordSpaceDoc,
+			prettier.Text(d.Identifier.Identifier),
+		},
+	)
+}
+
 func (d *EnumCaseDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias EnumCaseDeclaration
 	return json.Marshal(&struct {
@@ -20,10 +20,12 @@ package ast
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/turbolent/prettier"
 
 	"github.com/onflow/cadence/runtime/common"
 )
@@ -158,3 +160,183 @@ func TestCompositeDeclaration_MarshalJSON(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestCompositeDeclaration_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		decl := &CompositeDeclaration{
+			Access:        AccessPublic,
+			CompositeKind: common.CompositeKindStructure,
+			Identifier: Identifier{
+				Identifier: "AB",
+			},
+			Conformances: []*NominalType{
+				{
+					Identifier: Identifier{
+						Identifier: "CD",
+					},
+				},
+				{
+					Identifier: Identifier{
+						Identifier: "EF",
+					},
+				},
+			},
+			Members: NewMembers(
+				[]Declaration{
+					&FieldDeclaration{
+						Access:       AccessPublic,
+						VariableKind: VariableKindConstant,
+						Identifier: Identifier{
+							Identifier: "x",
+						},
+						TypeAnnotation: &TypeAnnotation{
+							Type: &NominalType{
+								Identifier: Identifier{
+									Identifier: "Int",
+								},
+							},
+						},
+					},
+					&FieldDeclaration{
+						Access:       AccessPrivate,
+						VariableKind: VariableKindVariable,
+						Identifier: Identifier{
+							Identifier: "y",
+						},
+						TypeAnnotation: &TypeAnnotation{
+							Type: &NominalType{
+								Identifier: Identifier{
+									Identifier: "Bool",
+								},
+							},
+						},
+					},
+					&SpecialFunctionDeclaration{
+						Kind: common.DeclarationKindInitializer,
+						FunctionDeclaration: &FunctionDeclaration{
+							Identifier: Identifier{
+								Identifier: "init",
+							},
+							ParameterList: &ParameterList{},
+							FunctionBlock: &FunctionBlock{
+								Block: &Block{},
+							},
+						},
+					},
+				},
+			),
+		}
+
+		var b strings.Builder
+		prettier.Prettier(&b, decl.Doc(), 80, "    ")
+
+		assert.Equal(t,
+			`pub struct AB: CD, EF {
+    pub let x: Int
+    priv var y: Bool
+
+    init() {}
+}`,
+			b.String(),
+		)
+	})
+
+	t.Run("enum", func(t *testing.T) {
+
+		t.Parallel()
+
+		decl := &CompositeDeclaration{
+			Access:        AccessPublic,
+			CompositeKind: common.CompositeKindEnum,
+			Identifier: Identifier{
+				Identifier: "AB",
+			},
+			Conformances: []*NominalType{
+				{
+					Identifier: Identifier{
+						Identifier: "UInt8",
+					},
+				},
+			},
+			Members: NewMembers(
+				[]Declaration{
+					&EnumCaseDeclaration{
+						Identifier: Identifier{
+							Identifier: "c",
+						},
+					},
+					&EnumCaseDeclaration{
+						Identifier: Identifier{
+							Identifier: "d",
+						},
+					},
+				},
+			),
+		}
+
+		var b strings.Builder
+		prettier.Prettier(&b, decl.Doc(), 80, "    ")
+
+		assert.Equal(t,
+			`pub enum AB: UInt8 {
+    case c
+    case d
+}`,
+			b.String(),
+		)
+	})
+
+	t.Run("event", func(t *testing.T) {
+
+		t.Parallel()
+
+		decl := &CompositeDeclaration{
+			Access:        AccessPublic,
+			CompositeKind: common.CompositeKindEvent,
+			Identifier: Identifier{
+				Identifier: "AB",
+			},
+			Members: NewMembers(
+				[]Declaration{
+					&SpecialFunctionDeclaration{
+						Kind: common.DeclarationKindInitializer,
+						FunctionDeclaration: &FunctionDeclaration{
+							ParameterList: &ParameterList{
+								Parameters: []*Parameter{
+									{
+										Identifier: Identifier{
+											Identifier: "x",
+										},
+										TypeAnnotation: &TypeAnnotation{
+											Type: &NominalType{
+												Identifier: Identifier{
+													Identifier: "Int",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			),
+			DocString: " Test",
+		}
+
+		var b strings.Builder
+		prettier.Prettier(&b, decl.Doc(), 80, "    ")
+
+		assert.Equal(t,
+			`/// Test
+pub event AB(x: Int)`,
+			b.String(),
+		)
+	})
+}
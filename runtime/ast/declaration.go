@@ -18,14 +18,68 @@
 
 package ast
 
-import "github.com/onflow/cadence/runtime/common"
+import (
+	"strings"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/common"
+)
 
 type Declaration interface {
-	Element
+	CommentedElement
 	isDeclaration()
 	DeclarationIdentifier() *Identifier
 	DeclarationKind() common.DeclarationKind
 	DeclarationAccess() Access
 	DeclarationMembers() *Members
 	DeclarationDocString() string
+	Doc() prettier.Doc
+}
+
+const docStringLinePrefix = "///"
+
+// blankLineDoc separates two documents with an empty line.
+//
+// NOTE: a hard line is indented, so an empty line is emitted as text,
+// to avoid trailing whitespace
+//
+var blankLineDoc prettier.Doc = prettier.Concat{
+	prettier.Text("\n"),
+	prettier.HardLine{},
+}
+
+// declarationDoc returns the document for a declaration,
+// preceded by its doc string, if any, written as line doc comments (`///`),
+// and its access modifier, if any.
+//
+// The doc string is not printed if comments are attached to the declaration,
+// as it is already part of the leading comments
+//
+func declarationDoc(comments *Comments, docString string, access Access, doc prettier.Doc) prettier.Doc {
+	var result prettier.Concat
+
+	if docString != "" && comments == nil {
+		for _, line := range strings.Split(docString, "\n") {
+			result = append(
+				result,
+				prettier.Text(docStringLinePrefix+line),
+				prettier.HardLine{},
+			)
+		}
+	}
+
+	if access != AccessNotSpecified {
+		result = append(
+			result,
+			prettier.Text(access.Keyword()),
+			prettier.Space,
+		)
+	}
+
+	if len(result) == 0 {
+		return doc
+	}
+
+	return append(result, doc)
 }
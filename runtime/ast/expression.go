@@ -36,6 +36,7 @@ type Expression interface {
 	isExpression()
 	AcceptExp(ExpressionVisitor) Repr
 	Doc() prettier.Doc
+	precedence() precedence
 }
 
 // BoolExpression
@@ -51,6 +52,10 @@ func (*BoolExpression) isExpression() {}
 
 func (*BoolExpression) isIfStatementTest() {}
 
+func (*BoolExpression) precedence() precedence {
+	return precedenceLiteral
+}
+
 func (e *BoolExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -104,6 +109,10 @@ func (*NilExpression) isExpression() {}
 
 func (*NilExpression) isIfStatementTest() {}
 
+func (*NilExpression) precedence() precedence {
+	return precedenceLiteral
+}
+
 func (e *NilExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -160,6 +169,10 @@ func (*StringExpression) isExpression() {}
 
 func (*StringExpression) isIfStatementTest() {}
 
+func (*StringExpression) precedence() precedence {
+	return precedenceLiteral
+}
+
 func (e *StringExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -206,6 +219,14 @@ func (*IntegerExpression) isExpression() {}
 
 func (*IntegerExpression) isIfStatementTest() {}
 
+func (e *IntegerExpression) precedence() precedence {
+	// Negative literals are parsed as a unary minus applied to a literal
+	if e.Value.Sign() < 0 {
+		return precedenceUnaryPrefix
+	}
+	return precedenceLiteral
+}
+
 func (e *IntegerExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -264,6 +285,14 @@ func (*FixedPointExpression) isExpression() {}
 
 func (*FixedPointExpression) isIfStatementTest() {}
 
+func (e *FixedPointExpression) precedence() precedence {
+	// Negative literals are parsed as a unary minus applied to a literal
+	if e.Negative {
+		return precedenceUnaryPrefix
+	}
+	return precedenceLiteral
+}
+
 func (e *FixedPointExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -335,6 +364,10 @@ func (*ArrayExpression) isExpression() {}
 
 func (*ArrayExpression) isIfStatementTest() {}
 
+func (*ArrayExpression) precedence() precedence {
+	return precedenceLiteral
+}
+
 func (e *ArrayExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -366,6 +399,14 @@ var arrayExpressionSeparatorDoc prettier.Doc = prettier.Concat{
 }
 
 func (e *ArrayExpression) Doc() prettier.Doc {
+	return e.doc(prettier.SoftLine{})
+}
+
+// doc returns the document for the array expression,
+// with the given line document after the opening bracket
+// and before the closing bracket
+//
+func (e *ArrayExpression) doc(line prettier.Doc) prettier.Doc {
 	if len(e.Values) == 0 {
 		return prettier.Text("[]")
 	}
@@ -376,7 +417,7 @@ func (e *ArrayExpression) Doc() prettier.Doc {
 	}
 	return prettier.WrapBrackets(
 		prettier.Join(arrayExpressionSeparatorDoc, elementDocs...),
-		prettier.SoftLine{},
+		line,
 	)
 }
 
@@ -404,6 +445,10 @@ func (*DictionaryExpression) isExpression() {}
 
 func (*DictionaryExpression) isIfStatementTest() {}
 
+func (*DictionaryExpression) precedence() precedence {
+	return precedenceLiteral
+}
+
 func (e *DictionaryExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -440,6 +485,14 @@ var dictionaryExpressionSeparatorDoc prettier.Doc = prettier.Concat{
 }
 
 func (e *DictionaryExpression) Doc() prettier.Doc {
+	return e.doc(prettier.SoftLine{})
+}
+
+// doc returns the document for the dictionary expression,
+// with the given line document after the opening brace
+// and before the closing brace
+//
+func (e *DictionaryExpression) doc(line prettier.Doc) prettier.Doc {
 	if len(e.Entries) == 0 {
 		return prettier.Text("{}")
 	}
@@ -451,7 +504,7 @@ func (e *DictionaryExpression) Doc() prettier.Doc {
 
 	return prettier.WrapBraces(
 		prettier.Join(dictionaryExpressionSeparatorDoc, entryDocs...),
-		prettier.SoftLine{},
+		line,
 	)
 }
 
@@ -512,6 +565,10 @@ func (*IdentifierExpression) isExpression() {}
 
 func (*IdentifierExpression) isIfStatementTest() {}
 
+func (*IdentifierExpression) precedence() precedence {
+	return precedenceLiteral
+}
+
 func (e *IdentifierExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -586,6 +643,10 @@ func (*InvocationExpression) isExpression() {}
 
 func (*InvocationExpression) isIfStatementTest() {}
 
+func (*InvocationExpression) precedence() precedence {
+	return precedenceAccess
+}
+
 func (e *InvocationExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -619,10 +680,23 @@ func (e *InvocationExpression) String() string {
 }
 
 func (e *InvocationExpression) Doc() prettier.Doc {
+	return e.doc(postfixOperandDoc(e.InvokedExpression, precedenceAccess))
+}
+
+// nominalTypeDoc returns the document for the invocation of a nominal type,
+// as it occurs in create expressions and emit statements.
+//
+// The invoked expression is a nominal type, which may not be broken
+// across lines
+//
+func (e *InvocationExpression) nominalTypeDoc() prettier.Doc {
+	return e.doc(prettier.Text(e.InvokedExpression.String()))
+}
+
+func (e *InvocationExpression) doc(invokedExpressionDoc prettier.Doc) prettier.Doc {
 
 	result := prettier.Concat{
-		// TODO: potentially parenthesize
-		e.InvokedExpression.Doc(),
+		invokedExpressionDoc,
 	}
 
 	if len(e.TypeArguments) > 0 {
@@ -713,6 +787,10 @@ func (*MemberExpression) isExpression() {}
 
 func (*MemberExpression) isIfStatementTest() {}
 
+func (*MemberExpression) precedence() precedence {
+	return precedenceAccess
+}
+
 func (*MemberExpression) isAccessExpression() {}
 
 func (e *MemberExpression) AccessedExpression() Expression {
@@ -752,9 +830,16 @@ func (e *MemberExpression) Doc() prettier.Doc {
 	} else {
 		separatorDoc = memberExpressionSeparatorDoc
 	}
+	var expressionDoc prettier.Doc
+	if _, ok := e.Expression.(*IntegerExpression); ok {
+		// The dot would be parsed as part of a fixed-point literal
+		expressionDoc = prettier.WrapParentheses(e.Expression.Doc(), prettier.SoftLine{})
+	} else {
+		expressionDoc = parenthesizedExpressionDoc(e.Expression, precedenceAccess)
+	}
+
 	return prettier.Concat{
-		// TODO: potentially parenthesize
-		e.Expression.Doc(),
+		expressionDoc,
 		prettier.Group{
 			Doc: prettier.Indent{
 				Doc: prettier.Concat{
@@ -806,6 +891,10 @@ func (*IndexExpression) isExpression() {}
 
 func (*IndexExpression) isIfStatementTest() {}
 
+func (*IndexExpression) precedence() precedence {
+	return precedenceAccess
+}
+
 func (*IndexExpression) isAccessExpression() {}
 
 func (e *IndexExpression) AccessedExpression() Expression {
@@ -834,8 +923,7 @@ func (e *IndexExpression) String() string {
 
 func (e *IndexExpression) Doc() prettier.Doc {
 	return prettier.Concat{
-		// TODO: potentially parenthesize
-		e.TargetExpression.Doc(),
+		postfixOperandDoc(e.TargetExpression, precedenceAccess),
 		prettier.WrapBrackets(
 			e.IndexingExpression.Doc(),
 			prettier.SoftLine{},
@@ -868,6 +956,10 @@ func (*ConditionalExpression) isExpression() {}
 
 func (*ConditionalExpression) isIfStatementTest() {}
 
+func (*ConditionalExpression) precedence() precedence {
+	return precedenceTernary
+}
+
 func (e *ConditionalExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -900,13 +992,19 @@ var conditionalExpressionBranchSeparatorDoc prettier.Doc = prettier.Concat{
 }
 
 func (e *ConditionalExpression) Doc() prettier.Doc {
-	// TODO: potentially parenthesize
-	testDoc := e.Test.Doc()
+	// The test must be parenthesized if it is a conditional expression itself,
+	// or if it ends with a type, as the question mark would extend the type
 
-	// TODO: potentially parenthesize
-	thenDoc := e.Then.Doc()
+	var testDoc prettier.Doc
+	if endsWithType(e.Test) {
+		testDoc = prettier.WrapParentheses(e.Test.Doc(), prettier.SoftLine{})
+	} else {
+		testDoc = parenthesizedExpressionDoc(e.Test, precedenceTernary+1)
+	}
 
-	// TODO: potentially parenthesize
+	// The branches extend as far as possible, so they never need parentheses
+
+	thenDoc := e.Then.Doc()
 	elseDoc := e.Else.Doc()
 
 	return prettier.Group{
@@ -963,6 +1061,10 @@ func (*UnaryExpression) isExpression() {}
 
 func (*UnaryExpression) isIfStatementTest() {}
 
+func (*UnaryExpression) precedence() precedence {
+	return precedenceUnaryPrefix
+}
+
 func (e *UnaryExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -986,8 +1088,7 @@ func (e *UnaryExpression) String() string {
 func (e *UnaryExpression) Doc() prettier.Doc {
 	return prettier.Concat{
 		prettier.Text(e.Operation.Symbol()),
-		// TODO: potentially parenthesize
-		e.Expression.Doc(),
+		parenthesizedExpressionDoc(e.Expression, precedenceUnaryPrefix),
 	}
 }
 
@@ -1026,6 +1127,10 @@ func (*BinaryExpression) isExpression() {}
 
 func (*BinaryExpression) isIfStatementTest() {}
 
+func (e *BinaryExpression) precedence() precedence {
+	return e.Operation.precedence()
+}
+
 func (e *BinaryExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -1047,11 +1152,14 @@ func (e *BinaryExpression) String() string {
 }
 
 func (e *BinaryExpression) Doc() prettier.Doc {
-	// TODO: potentially parenthesize
-	leftDoc := e.Left.Doc()
+	var leftDoc prettier.Doc
+	if e.leftNeedsParentheses() {
+		leftDoc = prettier.WrapParentheses(e.Left.Doc(), prettier.SoftLine{})
+	} else {
+		leftDoc = e.Left.Doc()
+	}
 
-	// TODO: potentially parenthesize
-	rightDoc := e.Right.Doc()
+	rightDoc := parenthesizedExpressionDoc(e.Right, e.minimumRightPrecedence())
 
 	return prettier.Group{
 		Doc: prettier.Concat{
@@ -1068,6 +1176,54 @@ func (e *BinaryExpression) Doc() prettier.Doc {
 	}
 }
 
+// minimumLeftPrecedence returns the minimum precedence
+// the left sub-expression must have to not be parenthesized.
+//
+// Comparisons are not chained, e.g. `a < b > c` is ambiguous with an invocation
+//
+func (e *BinaryExpression) minimumLeftPrecedence() precedence {
+	operationPrecedence := e.Operation.precedence()
+	if e.Operation.isRightAssociative() ||
+		operationPrecedence == precedenceComparison {
+
+		return operationPrecedence + 1
+	}
+	return operationPrecedence
+}
+
+// minimumRightPrecedence returns the minimum precedence
+// the right sub-expression must have to not be parenthesized
+//
+func (e *BinaryExpression) minimumRightPrecedence() precedence {
+	operationPrecedence := e.Operation.precedence()
+	if e.Operation.isRightAssociative() {
+		return operationPrecedence
+	}
+	return operationPrecedence + 1
+}
+
+// leftNeedsParentheses returns true if the left sub-expression must be parenthesized.
+//
+// In addition to the precedence, a left sub-expression which ends with a type
+// must be parenthesized when the operator could be parsed as part of the type,
+// e.g. `(x as T?) ?? y` or `(x as T) < y`
+//
+func (e *BinaryExpression) leftNeedsParentheses() bool {
+	if e.Left.precedence() < e.minimumLeftPrecedence() {
+		return true
+	}
+
+	switch e.Operation {
+	case OperationNilCoalesce,
+		OperationLess,
+		OperationBitwiseLeftShift:
+
+		return endsWithType(e.Left)
+	}
+
+	return false
+}
+
 func (e *BinaryExpression) StartPosition() Position {
 	return e.Left.StartPosition()
 }
@@ -1104,6 +1260,10 @@ func (*FunctionExpression) isExpression() {}
 
 func (*FunctionExpression) isIfStatementTest() {}
 
+func (*FunctionExpression) precedence() precedence {
+	return precedenceLiteral
+}
+
 func (e *FunctionExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -1124,27 +1284,13 @@ func (e *FunctionExpression) String() string {
 }
 
 var functionExpressionFunKeywordDoc prettier.Doc = prettier.Text("fun ")
-var functionExpressionParameterSeparatorDoc prettier.Doc = prettier.Concat{
-	prettier.Text(","),
-	prettier.Line{},
-}
 
 var typeSeparatorDoc prettier.Doc = prettier.Text(": ")
 var functionExpressionEmptyBlockDoc prettier.Doc = prettier.Text(" {}")
 
 func (e *FunctionExpression) Doc() prettier.Doc {
 
-	signatureDoc := e.parametersDoc()
-
-	if e.ReturnTypeAnnotation != nil &&
-		!IsEmptyType(e.ReturnTypeAnnotation.Type) {
-
-		signatureDoc = prettier.Concat{
-			signatureDoc,
-			typeSeparatorDoc,
-			e.ReturnTypeAnnotation.Doc(),
-		}
-	}
+	signatureDoc := functionSignatureDoc(e.ParameterList, e.ReturnTypeAnnotation)
 
 	doc := prettier.Concat{
 		functionExpressionFunKeywordDoc,
@@ -1153,59 +1299,34 @@ func (e *FunctionExpression) Doc() prettier.Doc {
 		},
 	}
 
-	if e.FunctionBlock.IsEmpty() {
+	if e.FunctionBlock.IsEmpty() && !e.FunctionBlock.Block.hasInnerComments() {
 		return append(doc, functionExpressionEmptyBlockDoc)
 	} else {
-		// TODO: pre-conditions
-		// TODO: post-conditions
-
-		blockDoc := e.FunctionBlock.Block.Doc()
-
 		return append(
 			doc,
 			prettier.Space,
-			blockDoc,
+			e.FunctionBlock.Doc(),
 		)
 	}
 }
 
-func (e *FunctionExpression) parametersDoc() prettier.Doc {
+// functionSignatureDoc returns the document for the parameter list
+// and the return type annotation, if any, of a function
+//
+func functionSignatureDoc(parameterList *ParameterList, returnTypeAnnotation *TypeAnnotation) prettier.Doc {
+	signatureDoc := parameterList.Doc()
 
-	if e.ParameterList == nil ||
-		len(e.ParameterList.Parameters) == 0 {
+	if returnTypeAnnotation != nil &&
+		!IsEmptyType(returnTypeAnnotation.Type) {
 
-		return prettier.Text("()")
-	}
-
-	parameterDocs := make([]prettier.Doc, 0, len(e.ParameterList.Parameters))
-
-	for _, parameter := range e.ParameterList.Parameters {
-		var parameterDoc prettier.Concat
-
-		if parameter.Label != "" {
-			parameterDoc = append(parameterDoc,
-				prettier.Text(parameter.Label),
-				prettier.Space,
-			)
-		}
-
-		parameterDoc = append(
-			parameterDoc,
-			prettier.Text(parameter.Identifier.Identifier),
+		signatureDoc = prettier.Concat{
+			signatureDoc,
 			typeSeparatorDoc,
-			parameter.TypeAnnotation.Doc(),
-		)
-
-		parameterDocs = append(parameterDocs, parameterDoc)
+			returnTypeAnnotation.Doc(),
+		}
 	}
 
-	return prettier.WrapParentheses(
-		prettier.Join(
-			functionExpressionParameterSeparatorDoc,
-			parameterDocs...,
-		),
-		prettier.SoftLine{},
-	)
+	return signatureDoc
 }
 
 func (e *FunctionExpression) StartPosition() Position {
@@ -1244,6 +1365,10 @@ func (*CastingExpression) isExpression() {}
 
 func (*CastingExpression) isIfStatementTest() {}
 
+func (*CastingExpression) precedence() precedence {
+	return precedenceCasting
+}
+
 func (e *CastingExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -1264,8 +1389,7 @@ func (e *CastingExpression) String() string {
 }
 
 func (e *CastingExpression) Doc() prettier.Doc {
-	// TODO: potentially parenthesize
-	doc := e.Expression.Doc()
+	doc := parenthesizedExpressionDoc(e.Expression, precedenceCasting)
 
 	return prettier.Group{
 		Doc: prettier.Concat{
@@ -1314,6 +1438,10 @@ func (*CreateExpression) isExpression() {}
 
 func (*CreateExpression) isIfStatementTest() {}
 
+func (*CreateExpression) precedence() precedence {
+	return precedenceLiteral
+}
+
 func (e *CreateExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -1336,8 +1464,7 @@ func (e *CreateExpression) String() string {
 func (e *CreateExpression) Doc() prettier.Doc {
 	return prettier.Concat{
 		prettier.Text("create "),
-		// TODO: potentially parenthesize
-		e.InvocationExpression.Doc(),
+		e.InvocationExpression.nominalTypeDoc(),
 	}
 }
 
@@ -1375,6 +1502,10 @@ func (*DestroyExpression) isExpression() {}
 
 func (*DestroyExpression) isIfStatementTest() {}
 
+func (*DestroyExpression) precedence() precedence {
+	return precedenceTernary
+}
+
 func (e *DestroyExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -1399,7 +1530,6 @@ const destroyExpressionKeywordDoc = prettier.Text("destroy ")
 func (e *DestroyExpression) Doc() prettier.Doc {
 	return prettier.Concat{
 		destroyExpressionKeywordDoc,
-		// TODO: potentially parenthesize
 		e.Expression.Doc(),
 	}
 }
@@ -1439,6 +1569,10 @@ func (*ReferenceExpression) isExpression() {}
 
 func (*ReferenceExpression) isIfStatementTest() {}
 
+func (*ReferenceExpression) precedence() precedence {
+	return precedenceTernary
+}
+
 func (e *ReferenceExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -1464,8 +1598,7 @@ var referenceExpressionRefOperatorDoc prettier.Doc = prettier.Text("&")
 var referenceExpressionAsOperatorDoc prettier.Doc = prettier.Text("as")
 
 func (e *ReferenceExpression) Doc() prettier.Doc {
-	// TODO: potentially parenthesize
-	doc := e.Expression.Doc()
+	doc := parenthesizedExpressionDoc(e.Expression, precedenceCasting)
 
 	return prettier.Group{
 		Doc: prettier.Concat{
@@ -1515,6 +1648,10 @@ func (*ForceExpression) isExpression() {}
 
 func (*ForceExpression) isIfStatementTest() {}
 
+func (*ForceExpression) precedence() precedence {
+	return precedenceAccess
+}
+
 func (e *ForceExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
@@ -1535,8 +1672,7 @@ const forceExpressionOperatorDoc = prettier.Text("!")
 
 func (e *ForceExpression) Doc() prettier.Doc {
 	return prettier.Concat{
-		// TODO: potentially parenthesize
-		e.Expression.Doc(),
+		postfixOperandDoc(e.Expression, precedenceAccess),
 		forceExpressionOperatorDoc,
 	}
 }
@@ -1576,6 +1712,10 @@ func (*PathExpression) isExpression() {}
 
 func (*PathExpression) isIfStatementTest() {}
 
+func (*PathExpression) precedence() precedence {
+	return precedenceLiteral
+}
+
 func (e *PathExpression) Accept(visitor Visitor) Repr {
 	return e.AcceptExp(visitor)
 }
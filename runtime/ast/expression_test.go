@@ -21,6 +21,7 @@ package ast
 import (
 	"encoding/json"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -2080,3 +2081,139 @@ func TestFunctionExpression_Doc(t *testing.T) {
 		assert.Equal(t, expected, expr.Doc())
 	})
 }
+
+func TestExpression_Doc_Parentheses(t *testing.T) {
+
+	t.Parallel()
+
+	identifier := func(name string) *IdentifierExpression {
+		return &IdentifierExpression{
+			Identifier: Identifier{
+				Identifier: name,
+			},
+		}
+	}
+
+	binary := func(operation Operation, left, right Expression) *BinaryExpression {
+		return &BinaryExpression{
+			Operation: operation,
+			Left:      left,
+			Right:     right,
+		}
+	}
+
+	type testCase struct {
+		name     string
+		expr     Expression
+		expected string
+	}
+
+	testCases := []testCase{
+		{
+			name: "lower precedence left",
+			expr: binary(
+				OperationMul,
+				binary(OperationPlus, identifier("a"), identifier("b")),
+				identifier("c"),
+			),
+			expected: "(a + b) * c",
+		},
+		{
+			name: "higher precedence left",
+			expr: binary(
+				OperationPlus,
+				binary(OperationMul, identifier("a"), identifier("b")),
+				identifier("c"),
+			),
+			expected: "a * b + c",
+		},
+		{
+			name: "left associative",
+			expr: binary(
+				OperationMinus,
+				identifier("a"),
+				binary(OperationMinus, identifier("b"), identifier("c")),
+			),
+			expected: "a - (b - c)",
+		},
+		{
+			name: "right associative",
+			expr: binary(
+				OperationNilCoalesce,
+				binary(OperationNilCoalesce, identifier("a"), identifier("b")),
+				identifier("c"),
+			),
+			expected: "(a ?? b) ?? c",
+		},
+		{
+			name: "unary",
+			expr: &UnaryExpression{
+				Operation:  OperationMinus,
+				Expression: binary(OperationPlus, identifier("a"), identifier("b")),
+			},
+			expected: "-(a + b)",
+		},
+		{
+			name: "member",
+			expr: &MemberExpression{
+				Expression: &ForceExpression{
+					Expression: identifier("a"),
+				},
+				Identifier: Identifier{
+					Identifier: "b",
+				},
+			},
+			expected: "a!.b",
+		},
+		{
+			name: "casting, nil-coalescing",
+			expr: binary(
+				OperationNilCoalesce,
+				&CastingExpression{
+					Operation:  OperationFailableCast,
+					Expression: identifier("a"),
+					TypeAnnotation: &TypeAnnotation{
+						Type: &NominalType{
+							Identifier: Identifier{
+								Identifier: "T",
+							},
+						},
+					},
+				},
+				identifier("b"),
+			),
+			expected: "(a as? T) ?? b",
+		},
+		{
+			name: "conditional",
+			expr: &ConditionalExpression{
+				Test: &ConditionalExpression{
+					Test: identifier("a"),
+					Then: identifier("b"),
+					Else: identifier("c"),
+				},
+				Then: identifier("d"),
+				Else: &ConditionalExpression{
+					Test: identifier("e"),
+					Then: identifier("f"),
+					Else: identifier("g"),
+				},
+			},
+			expected: "(a ? b : c) ? d : e ? f : g",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			var b strings.Builder
+			prettier.Prettier(&b, testCase.expr.Doc(), 80, "    ")
+
+			assert.Equal(t, testCase.expected, b.String())
+		})
+	}
+}
@@ -21,17 +21,21 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
 type FunctionDeclaration struct {
 	Access               Access
 	Identifier           Identifier
+	TypeParameterList    *TypeParameterList `json:",omitempty"`
 	ParameterList        *ParameterList
 	ReturnTypeAnnotation *TypeAnnotation
 	FunctionBlock        *FunctionBlock
 	DocString            string
-	StartPos             Position `json:"-"`
+	Comments             *Comments `json:",omitempty"`
+	StartPos             Position  `json:"-"`
 }
 
 func (d *FunctionDeclaration) StartPosition() Position {
@@ -42,7 +46,11 @@ func (d *FunctionDeclaration) EndPosition() Position {
 	if d.FunctionBlock != nil {
 		return d.FunctionBlock.EndPosition()
 	}
-	if d.ReturnTypeAnnotation != nil {
+	// The missing return type of a function is an empty type,
+	// which is positioned before the end of the parameter list
+	if d.ReturnTypeAnnotation != nil &&
+		!IsEmptyType(d.ReturnTypeAnnotation.Type) {
+
 		return d.ReturnTypeAnnotation.EndPosition()
 	}
 	return d.ParameterList.EndPosition()
@@ -60,6 +68,14 @@ func (d *FunctionDeclaration) Walk(walkChild func(Element)) {
 	}
 }
 
+func (d *FunctionDeclaration) ElementComments() *Comments {
+	return d.Comments
+}
+
+func (d *FunctionDeclaration) SetElementComments(comments *Comments) {
+	d.Comments = comments
+}
+
 func (*FunctionDeclaration) isDeclaration() {}
 func (*FunctionDeclaration) isStatement()   {}
 
@@ -92,6 +108,43 @@ func (d *FunctionDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+var functionDeclarationFunKeywordSpaceDoc prettier.Doc = prettier.Text("fun ")
+
+func (d *FunctionDeclaration) Doc() prettier.Doc {
+	return declarationDoc(
+		d.Comments,
+		d.DocString,
+		d.Access,
+		prettier.Concat{
+			functionDeclarationFunKeywordSpaceDoc,
+			d.signatureAndBodyDoc(),
+		},
+	)
+}
+
+// signatureAndBodyDoc returns the document for the identifier,
+// the signature, and the function block, if any, of the function
+//
+func (d *FunctionDeclaration) signatureAndBodyDoc() prettier.Doc {
+	doc := prettier.Concat{
+		prettier.Text(d.Identifier.Identifier),
+		d.TypeParameterList.Doc(),
+		prettier.Group{
+			Doc: functionSignatureDoc(d.ParameterList, d.ReturnTypeAnnotation),
+		},
+	}
+
+	if d.FunctionBlock != nil {
+		doc = append(
+			doc,
+			prettier.Space,
+			d.FunctionBlock.Doc(),
+		)
+	}
+
+	return doc
+}
+
 func (d *FunctionDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias FunctionDeclaration
 	return json.Marshal(&struct {
@@ -128,6 +181,14 @@ func (d *SpecialFunctionDeclaration) Walk(walkChild func(Element)) {
 	d.FunctionDeclaration.Walk(walkChild)
 }
 
+func (d *SpecialFunctionDeclaration) ElementComments() *Comments {
+	return d.FunctionDeclaration.ElementComments()
+}
+
+func (d *SpecialFunctionDeclaration) SetElementComments(comments *Comments) {
+	d.FunctionDeclaration.SetElementComments(comments)
+}
+
 func (*SpecialFunctionDeclaration) isDeclaration() {}
 func (*SpecialFunctionDeclaration) isStatement()   {}
 
@@ -151,6 +212,32 @@ func (d *SpecialFunctionDeclaration) DeclarationDocString() string {
 	return d.FunctionDeclaration.DeclarationDocString()
 }
 
+func (d *SpecialFunctionDeclaration) Doc() prettier.Doc {
+	functionDeclaration := d.FunctionDeclaration
+
+	var doc prettier.Doc
+
+	switch d.Kind {
+	case common.DeclarationKindExecute:
+		// The execute block of a transaction has no parameters
+		doc = prettier.Concat{
+			prettier.Text(functionDeclaration.Identifier.Identifier),
+			prettier.Space,
+			functionDeclaration.FunctionBlock.Doc(),
+		}
+
+	default:
+		doc = functionDeclaration.signatureAndBodyDoc()
+	}
+
+	return declarationDoc(
+		functionDeclaration.Comments,
+		functionDeclaration.DocString,
+		functionDeclaration.Access,
+		doc,
+	)
+}
+
 func (d *SpecialFunctionDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias SpecialFunctionDeclaration
 	return json.Marshal(&struct {
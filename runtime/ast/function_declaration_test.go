@@ -20,10 +20,12 @@ package ast
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/turbolent/prettier"
 
 	"github.com/onflow/cadence/runtime/common"
 )
@@ -316,3 +318,100 @@ func TestSpecialFunctionDeclaration_MarshalJSON(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestFunctionDeclaration_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	decl := &FunctionDeclaration{
+		Access: AccessPublic,
+		Identifier: Identifier{
+			Identifier: "xyz",
+		},
+		ParameterList: &ParameterList{
+			Parameters: []*Parameter{
+				{
+					Label: "ok",
+					Identifier: Identifier{
+						Identifier: "foobar",
+					},
+					TypeAnnotation: &TypeAnnotation{
+						Type: &NominalType{
+							Identifier: Identifier{
+								Identifier: "AB",
+							},
+						},
+					},
+				},
+			},
+		},
+		ReturnTypeAnnotation: &TypeAnnotation{
+			IsResource: true,
+			Type: &NominalType{
+				Identifier: Identifier{
+					Identifier: "CD",
+				},
+			},
+		},
+		FunctionBlock: &FunctionBlock{
+			Block: &Block{
+				Statements: []Statement{
+					&ReturnStatement{
+						Expression: &IdentifierExpression{
+							Identifier: Identifier{
+								Identifier: "foobar",
+							},
+						},
+					},
+				},
+			},
+			PreConditions: &Conditions{
+				{
+					Kind: ConditionKindPre,
+					Test: &BoolExpression{
+						Value: true,
+					},
+					Message: &StringExpression{
+						Value: "test",
+					},
+				},
+			},
+		},
+		DocString: " Test\n doc",
+	}
+
+	var b strings.Builder
+	prettier.Prettier(&b, decl.Doc(), 80, "    ")
+
+	assert.Equal(t,
+		`/// Test
+/// doc
+pub fun xyz(ok foobar: AB): @CD {
+    pre {
+        true: "test"
+    }
+    return foobar
+}`,
+		b.String(),
+	)
+}
+
+func TestSpecialFunctionDeclaration_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	decl := &SpecialFunctionDeclaration{
+		Kind: common.DeclarationKindInitializer,
+		FunctionDeclaration: &FunctionDeclaration{
+			Identifier: Identifier{
+				Identifier: "init",
+			},
+			ParameterList: &ParameterList{},
+		},
+	}
+
+	var b strings.Builder
+	prettier.Prettier(&b, decl.Doc(), 80, "    ")
+
+	assert.Equal(t, "init()", b.String())
+}
@@ -21,7 +21,10 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
 )
 
 // ImportDeclaration
@@ -30,6 +33,7 @@ type ImportDeclaration struct {
 	Identifiers []Identifier
 	Location    common.Location
 	LocationPos Position
+	Comments    *Comments `json:",omitempty"`
 	Range
 }
 
@@ -45,6 +49,14 @@ func (*ImportDeclaration) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (d *ImportDeclaration) ElementComments() *Comments {
+	return d.Comments
+}
+
+func (d *ImportDeclaration) SetElementComments(comments *Comments) {
+	d.Comments = comments
+}
+
 func (d *ImportDeclaration) DeclarationIdentifier() *Identifier {
 	return nil
 }
@@ -65,6 +77,59 @@ func (d *ImportDeclaration) DeclarationDocString() string {
 	return ""
 }
 
+const importDeclarationImportKeywordSpaceDoc = prettier.Text("import ")
+const importDeclarationSpaceFromKeywordSpaceDoc = prettier.Text(" from ")
+
+var importDeclarationIdentifierSeparatorDoc prettier.Doc = prettier.Concat{
+	prettier.Text(","),
+	prettier.Line{},
+}
+
+func (d *ImportDeclaration) Doc() prettier.Doc {
+	doc := prettier.Concat{
+		importDeclarationImportKeywordSpaceDoc,
+	}
+
+	if len(d.Identifiers) > 0 {
+		identifierDocs := make([]prettier.Doc, 0, len(d.Identifiers))
+		for _, identifier := range d.Identifiers {
+			identifierDocs = append(identifierDocs, prettier.Text(identifier.Identifier))
+		}
+
+		doc = append(
+			doc,
+			prettier.Group{
+				Doc: prettier.Join(
+					importDeclarationIdentifierSeparatorDoc,
+					identifierDocs...,
+				),
+			},
+			importDeclarationSpaceFromKeywordSpaceDoc,
+		)
+	}
+
+	return append(doc, importLocationDoc(d.Location))
+}
+
+func importLocationDoc(location common.Location) prettier.Doc {
+	switch location := location.(type) {
+	case common.AddressLocation:
+		address := location.Address
+		if address == (common.Address{}) {
+			return prettier.Text(address.HexWithPrefix())
+		}
+		return prettier.Text(address.ShortHexWithPrefix())
+
+	case common.StringLocation:
+		return prettier.Text(QuoteString(string(location)))
+
+	case common.IdentifierLocation:
+		return prettier.Text(string(location))
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
 func (d *ImportDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias ImportDeclaration
 	return json.Marshal(&struct {
@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -32,6 +34,7 @@ type InterfaceDeclaration struct {
 	Identifier    Identifier
 	Members       *Members
 	DocString     string
+	Comments      *Comments `json:",omitempty"`
 	Range
 }
 
@@ -43,6 +46,14 @@ func (d *InterfaceDeclaration) Walk(walkChild func(Element)) {
 	walkDeclarations(walkChild, d.Members.declarations)
 }
 
+func (d *InterfaceDeclaration) ElementComments() *Comments {
+	return d.Comments
+}
+
+func (d *InterfaceDeclaration) SetElementComments(comments *Comments) {
+	d.Comments = comments
+}
+
 func (*InterfaceDeclaration) isDeclaration() {}
 
 // NOTE: statement, so it can be represented in the AST,
@@ -70,6 +81,25 @@ func (d *InterfaceDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+const interfaceDeclarationInterfaceKeywordDoc = prettier.Text("interface")
+
+func (d *InterfaceDeclaration) Doc() prettier.Doc {
+	return declarationDoc(
+		d.Comments,
+		d.DocString,
+		d.Access,
+		prettier.Concat{
+			prettier.Text(d.CompositeKind.Keyword()),
+			prettier.Space,
+			interfaceDeclarationInterfaceKeywordDoc,
+			prettier.Space,
+			prettier.Text(d.Identifier.Identifier),
+			prettier.Space,
+			d.Members.doc(d.Comments),
+		},
+	)
+}
+
 func (d *InterfaceDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias InterfaceDeclaration
 	return json.Marshal(&struct {
@@ -20,10 +20,12 @@ package ast
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/turbolent/prettier"
 
 	"github.com/onflow/cadence/runtime/common"
 )
@@ -72,3 +74,37 @@ func TestInterfaceDeclaration_MarshalJSON(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestInterfaceDeclaration_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	decl := &InterfaceDeclaration{
+		Access:        AccessPublic,
+		CompositeKind: common.CompositeKindResource,
+		Identifier: Identifier{
+			Identifier: "AB",
+		},
+		Members: NewMembers(
+			[]Declaration{
+				&FunctionDeclaration{
+					Access: AccessPublic,
+					Identifier: Identifier{
+						Identifier: "test",
+					},
+					ParameterList: &ParameterList{},
+				},
+			},
+		),
+	}
+
+	var b strings.Builder
+	prettier.Prettier(&b, decl.Doc(), 80, "    ")
+
+	assert.Equal(t,
+		`pub resource interface AB {
+    pub fun test()
+}`,
+		b.String(),
+	)
+}
@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -41,6 +43,71 @@ func (m *Members) Declarations() []Declaration {
 	return m.declarations
 }
 
+// Doc returns the document for the members, enclosed in braces.
+//
+// Members are separated by an empty line,
+// except for consecutive fields and consecutive enum cases
+//
+func (m *Members) Doc() prettier.Doc {
+	return m.doc(nil)
+}
+
+// doc returns the document for the members,
+// preceded by the given inner comments of the declaration
+// which contains the members
+//
+func (m *Members) doc(comments *Comments) prettier.Doc {
+	innerCommentsDoc := innerCommentsDoc(comments)
+
+	if (m == nil || len(m.declarations) == 0) && innerCommentsDoc == nil {
+		return blockEmptyDoc
+	}
+
+	var membersDoc prettier.Concat
+
+	if innerCommentsDoc != nil {
+		membersDoc = append(membersDoc, innerCommentsDoc)
+	}
+
+	if m != nil {
+		for i, declaration := range m.declarations {
+			var separatorDoc prettier.Doc = prettier.HardLine{}
+			if i > 0 {
+				if !areGroupedMembers(m.declarations[i-1], declaration) {
+					separatorDoc = blankLineDoc
+				}
+				separatorDoc = elementSeparatorDoc(declaration, separatorDoc)
+			}
+			membersDoc = append(
+				membersDoc,
+				separatorDoc,
+				elementDoc(declaration),
+			)
+		}
+	}
+
+	return prettier.Concat{
+		blockStartDoc,
+		prettier.Indent{
+			Doc: membersDoc,
+		},
+		prettier.HardLine{},
+		blockEndDoc,
+	}
+}
+
+func areGroupedMembers(previous, next Declaration) bool {
+	switch previous.(type) {
+	case *FieldDeclaration:
+		_, ok := next.(*FieldDeclaration)
+		return ok
+	case *EnumCaseDeclaration:
+		_, ok := next.(*EnumCaseDeclaration)
+		return ok
+	}
+	return false
+}
+
 func (m *Members) Fields() []*FieldDeclaration {
 	return m.indices.Fields(m.declarations)
 }
@@ -65,6 +132,10 @@ func (m *Members) EnumCases() []*EnumCaseDeclaration {
 	return m.indices.EnumCases(m.declarations)
 }
 
+func (m *Members) TypeAliases() []*TypeAliasDeclaration {
+	return m.indices.TypeAliases(m.declarations)
+}
+
 func (m *Members) FieldsByIdentifier() map[string]*FieldDeclaration {
 	return m.indices.FieldsByIdentifier(m.declarations)
 }
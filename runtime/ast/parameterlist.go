@@ -18,7 +18,11 @@
 
 package ast
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/turbolent/prettier"
+)
 
 type ParameterList struct {
 	once                    sync.Once
@@ -54,3 +58,30 @@ func (l *ParameterList) initialize() {
 	}
 	l._parametersByIdentifier = parametersByIdentifier
 }
+
+var parameterListEmptyDoc prettier.Doc = prettier.Text("()")
+var parameterListSeparatorDoc prettier.Doc = prettier.Concat{
+	prettier.Text(","),
+	prettier.Line{},
+}
+
+func (l *ParameterList) Doc() prettier.Doc {
+
+	if l == nil || len(l.Parameters) == 0 {
+		return parameterListEmptyDoc
+	}
+
+	parameterDocs := make([]prettier.Doc, 0, len(l.Parameters))
+
+	for _, parameter := range l.Parameters {
+		parameterDocs = append(parameterDocs, parameter.Doc())
+	}
+
+	return prettier.WrapParentheses(
+		prettier.Join(
+			parameterListSeparatorDoc,
+			parameterDocs...,
+		),
+		prettier.SoftLine{},
+	)
+}
@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -28,6 +30,7 @@ import (
 
 type PragmaDeclaration struct {
 	Expression Expression
+	Comments   *Comments `json:",omitempty"`
 	Range
 }
 
@@ -43,6 +46,14 @@ func (d *PragmaDeclaration) Walk(walkChild func(Element)) {
 	walkChild(d.Expression)
 }
 
+func (d *PragmaDeclaration) ElementComments() *Comments {
+	return d.Comments
+}
+
+func (d *PragmaDeclaration) SetElementComments(comments *Comments) {
+	d.Comments = comments
+}
+
 func (d *PragmaDeclaration) DeclarationIdentifier() *Identifier {
 	return nil
 }
@@ -63,6 +74,15 @@ func (d *PragmaDeclaration) DeclarationDocString() string {
 	return ""
 }
 
+const pragmaDeclarationSymbolDoc = prettier.Text("#")
+
+func (d *PragmaDeclaration) Doc() prettier.Doc {
+	return prettier.Concat{
+		pragmaDeclarationSymbolDoc,
+		d.Expression.Doc(),
+	}
+}
+
 func (d *PragmaDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias PragmaDeclaration
 	return json.Marshal(&struct {
@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/turbolent/prettier"
 )
 
 func TestPragmaDeclaration_MarshalJSON(t *testing.T) {
@@ -64,3 +65,24 @@ func TestPragmaDeclaration_MarshalJSON(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestPragmaDeclaration_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	decl := &PragmaDeclaration{
+		Expression: &IdentifierExpression{
+			Identifier: Identifier{
+				Identifier: "test",
+			},
+		},
+	}
+
+	assert.Equal(t,
+		prettier.Concat{
+			prettier.Text("#"),
+			prettier.Text("test"),
+		},
+		decl.Doc(),
+	)
+}
@@ -0,0 +1,245 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// precedence is the order of importance of expressions / operators,
+// from lowest to highest.
+//
+// NOTE: must be kept in sync with the binding powers of the parser
+//
+type precedence uint
+
+const (
+	precedenceUnknown precedence = iota
+	// precedenceTernary is the precedence of
+	// - ConditionalExpression. right associative!
+	// - DestroyExpression and ReferenceExpression,
+	//   as their sub-expressions extend as far to the right as possible
+	precedenceTernary
+	// precedenceLogicalOr is the precedence of
+	// - BinaryExpression, with OperationOr. right associative!
+	precedenceLogicalOr
+	// precedenceLogicalAnd is the precedence of
+	// - BinaryExpression, with OperationAnd. right associative!
+	precedenceLogicalAnd
+	// precedenceComparison is the precedence of
+	// - BinaryExpression, with OperationEqual, OperationNotEqual,
+	//   OperationLessEqual, OperationLess, OperationGreater, or OperationGreaterEqual.
+	precedenceComparison
+	// precedenceNilCoalescing is the precedence of
+	// - BinaryExpression, with OperationNilCoalesce. right associative!
+	precedenceNilCoalescing
+	// precedenceBitwiseOr is the precedence of
+	// - BinaryExpression, with OperationBitwiseOr.
+	precedenceBitwiseOr
+	// precedenceBitwiseXor is the precedence of
+	// - BinaryExpression, with OperationBitwiseXor.
+	precedenceBitwiseXor
+	// precedenceBitwiseAnd is the precedence of
+	// - BinaryExpression, with OperationBitwiseAnd.
+	precedenceBitwiseAnd
+	// precedenceBitwiseShift is the precedence of
+	// - BinaryExpression, with OperationBitwiseLeftShift or OperationBitwiseRightShift.
+	precedenceBitwiseShift
+	// precedenceAddition is the precedence of
+	// - BinaryExpression, with OperationPlus or OperationMinus.
+	precedenceAddition
+	// precedenceMultiplication is the precedence of
+	// - BinaryExpression, with OperationMul, OperationMod, or OperationDiv.
+	precedenceMultiplication
+	// precedenceCasting is the precedence of
+	// - CastingExpression.
+	precedenceCasting
+	// precedenceUnaryPrefix is the precedence of
+	// - UnaryExpression
+	// - negative IntegerExpression and FixedPointExpression
+	precedenceUnaryPrefix
+	// precedenceAccess is the precedence of
+	// - InvocationExpression
+	// - IndexExpression
+	// - MemberExpression
+	// - ForceExpression. the parser has a lower binding power for the force operator,
+	//   but like all postfix operators, it is applied from left to right
+	precedenceAccess
+	// precedenceLiteral is the precedence of
+	// - BoolExpression
+	// - NilExpression
+	// - StringExpression
+	// - IntegerExpression
+	// - FixedPointExpression
+	// - ArrayExpression
+	// - DictionaryExpression
+	// - IdentifierExpression
+	// - FunctionExpression
+	// - PathExpression
+	// - CreateExpression
+	precedenceLiteral
+)
+
+func (s Operation) precedence() precedence {
+	switch s {
+	case OperationOr:
+		return precedenceLogicalOr
+	case OperationAnd:
+		return precedenceLogicalAnd
+	case OperationEqual,
+		OperationNotEqual,
+		OperationLess,
+		OperationGreater,
+		OperationLessEqual,
+		OperationGreaterEqual:
+		return precedenceComparison
+	case OperationNilCoalesce:
+		return precedenceNilCoalescing
+	case OperationBitwiseOr:
+		return precedenceBitwiseOr
+	case OperationBitwiseXor:
+		return precedenceBitwiseXor
+	case OperationBitwiseAnd:
+		return precedenceBitwiseAnd
+	case OperationBitwiseLeftShift,
+		OperationBitwiseRightShift:
+		return precedenceBitwiseShift
+	case OperationPlus,
+		OperationMinus:
+		return precedenceAddition
+	case OperationMul,
+		OperationDiv,
+		OperationMod:
+		return precedenceMultiplication
+	case OperationCast,
+		OperationFailableCast,
+		OperationForceCast:
+		return precedenceCasting
+	case OperationNegate,
+		OperationMove:
+		return precedenceUnaryPrefix
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+// isRightAssociative returns true if the binary operation groups to the right,
+// i.e. `a op b op c` is parsed as `a op (b op c)`
+//
+func (s Operation) isRightAssociative() bool {
+	switch s {
+	case OperationOr,
+		OperationAnd,
+		OperationNilCoalesce:
+		return true
+	}
+	return false
+}
+
+// parenthesizedExpressionDoc returns the document for the given sub-expression,
+// wrapped in parentheses if the precedence of the sub-expression
+// is lower than the given minimum precedence
+//
+func parenthesizedExpressionDoc(e Expression, minimumPrecedence precedence) prettier.Doc {
+	doc := e.Doc()
+	if e.precedence() >= minimumPrecedence {
+		return doc
+	}
+	return prettier.WrapParentheses(doc, prettier.SoftLine{})
+}
+
+// endsWithType returns true if the document of the given expression ends with a type,
+// e.g. the casting expression `x as T`, or the binary expression `a + b as T`.
+//
+// Such an expression must be parenthesized when it is followed by a token
+// that could be parsed as part of the type, e.g. `?`, `??`, or `<`
+//
+func endsWithType(e Expression) bool {
+	switch e := e.(type) {
+	case *CastingExpression, *ReferenceExpression:
+		return true
+
+	case *BinaryExpression:
+		if e.Right.precedence() < e.minimumRightPrecedence() {
+			return false
+		}
+		return endsWithType(e.Right)
+	}
+
+	return false
+}
+
+// startsWithFunctionExpression returns true if the document of the given expression
+// might start with a function expression, e.g. the invocation `(fun () {})()`
+//
+func startsWithFunctionExpression(e Expression) bool {
+	switch e := e.(type) {
+	case *FunctionExpression:
+		return true
+	case *InvocationExpression:
+		return startsWithFunctionExpression(e.InvokedExpression)
+	case *MemberExpression:
+		return startsWithFunctionExpression(e.Expression)
+	case *IndexExpression:
+		return startsWithFunctionExpression(e.TargetExpression)
+	case *ForceExpression:
+		return startsWithFunctionExpression(e.Expression)
+	case *CastingExpression:
+		return startsWithFunctionExpression(e.Expression)
+	case *BinaryExpression:
+		return startsWithFunctionExpression(e.Left)
+	case *ConditionalExpression:
+		return startsWithFunctionExpression(e.Test)
+	}
+	return false
+}
+
+// postfixOperandDoc returns the document for the operand of an invocation,
+// an index expression, or a force expression, parenthesized if necessary.
+//
+// The parser does not allow a line break before these postfix operators
+// if the operand started with a line break after its first token,
+// so array literals, dictionary literals, and parenthesized expressions
+// are not broken after their opening token
+//
+func postfixOperandDoc(e Expression, minimumPrecedence precedence) prettier.Doc {
+	if e.precedence() < minimumPrecedence {
+		return prettier.Concat{
+			prettier.Text("("),
+			e.Doc(),
+			prettier.Text(")"),
+		}
+	}
+
+	switch e := e.(type) {
+	case *ArrayExpression:
+		return e.doc(noLineDoc)
+	case *DictionaryExpression:
+		return e.doc(noLineDoc)
+	}
+
+	return e.Doc()
+}
+
+// noLineDoc is an empty document which can be used in place of a line,
+// to prevent a line break
+//
+const noLineDoc = prettier.Text("")
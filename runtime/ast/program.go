@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -28,6 +30,10 @@ type Program struct {
 	// all declarations, in the order they are defined
 	declarations []Declaration
 	indices      programIndices
+	// comments are the comments of the program
+	// which could not be attached to a declaration,
+	// i.e. the comments of a program without declarations
+	comments *Comments
 }
 
 func NewProgram(declarations []Declaration) *Program {
@@ -57,6 +63,17 @@ func (p *Program) EndPosition() Position {
 	return lastDeclaration.EndPosition()
 }
 
+// Comments returns the comments of the program
+// which could not be attached to a declaration
+//
+func (p *Program) Comments() *Comments {
+	return p.comments
+}
+
+func (p *Program) SetComments(comments *Comments) {
+	p.comments = comments
+}
+
 func (p *Program) Accept(visitor Visitor) Repr {
 	return visitor.VisitProgram(p)
 }
@@ -65,6 +82,48 @@ func (d *Program) Walk(walkChild func(Element)) {
 	walkDeclarations(walkChild, d.declarations)
 }
 
+// Doc returns the document for the program.
+//
+// Declarations are separated by an empty line,
+// except for consecutive imports and consecutive pragmas.
+// If comments are attached, the empty lines of the source are preserved instead
+//
+func (p *Program) Doc() prettier.Doc {
+	var doc prettier.Concat
+
+	for i, declaration := range p.declarations {
+		if i > 0 {
+			var separatorDoc prettier.Doc = blankLineDoc
+			if areGroupedDeclarations(p.declarations[i-1], declaration) {
+				separatorDoc = prettier.HardLine{}
+			}
+			doc = append(doc, elementSeparatorDoc(declaration, separatorDoc))
+		}
+		doc = append(doc, elementDoc(declaration))
+	}
+
+	if p.comments != nil && len(p.comments.Inner) > 0 {
+		if len(doc) > 0 {
+			doc = append(doc, prettier.HardLine{})
+		}
+		doc = append(doc, commentLinesDoc(p.comments.Inner))
+	}
+
+	return doc
+}
+
+func areGroupedDeclarations(previous, next Declaration) bool {
+	switch previous.(type) {
+	case *ImportDeclaration:
+		_, ok := next.(*ImportDeclaration)
+		return ok
+	case *PragmaDeclaration:
+		_, ok := next.(*PragmaDeclaration)
+		return ok
+	}
+	return false
+}
+
 func (p *Program) PragmaDeclarations() []*PragmaDeclaration {
 	return p.indices.pragmaDeclarations(p.declarations)
 }
@@ -93,6 +152,10 @@ func (p *Program) VariableDeclarations() []*VariableDeclaration {
 	return p.indices.variableDeclarations(p.declarations)
 }
 
+func (p *Program) TypeAliasDeclarations() []*TypeAliasDeclaration {
+	return p.indices.typeAliasDeclarations(p.declarations)
+}
+
 // SoleContractDeclaration returns the sole contract declaration, if any,
 // and if there are no other actionable declarations.
 //
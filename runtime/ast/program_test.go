@@ -20,10 +20,15 @@ package ast
 
 import (
 	"encoding/json"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/common"
 )
 
 func TestProgram_MarshalJSON(t *testing.T) {
@@ -45,3 +50,68 @@ func TestProgram_MarshalJSON(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestProgram_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	program := NewProgram(
+		[]Declaration{
+			&PragmaDeclaration{
+				Expression: &IdentifierExpression{
+					Identifier: Identifier{
+						Identifier: "test",
+					},
+				},
+			},
+			&ImportDeclaration{
+				Location: common.StringLocation("A"),
+			},
+			&ImportDeclaration{
+				Identifiers: []Identifier{
+					{Identifier: "B"},
+				},
+				Location: common.IdentifierLocation("C"),
+			},
+			&VariableDeclaration{
+				Access:     AccessPublic,
+				IsConstant: true,
+				Identifier: Identifier{
+					Identifier: "x",
+				},
+				Transfer: &Transfer{
+					Operation: TransferOperationCopy,
+				},
+				Value: &IntegerExpression{
+					PositiveLiteral: "1",
+					Value:           big.NewInt(1),
+					Base:            10,
+				},
+			},
+			&FunctionDeclaration{
+				Identifier: Identifier{
+					Identifier: "test",
+				},
+				ParameterList: &ParameterList{},
+				FunctionBlock: &FunctionBlock{
+					Block: &Block{},
+				},
+			},
+		},
+	)
+
+	var b strings.Builder
+	prettier.Prettier(&b, program.Doc(), 80, "    ")
+
+	assert.Equal(t,
+		`#test
+
+import "A"
+import B from C
+
+pub let x = 1
+
+fun test() {}`,
+		b.String(),
+	)
+}
@@ -25,14 +25,16 @@ import (
 )
 
 type Statement interface {
-	Element
+	CommentedElement
 	isStatement()
+	Doc() prettier.Doc
 }
 
 // ReturnStatement
 
 type ReturnStatement struct {
 	Expression Expression
+	Comments   *Comments `json:",omitempty"`
 	Range
 }
 
@@ -50,6 +52,14 @@ func (s *ReturnStatement) Walk(walkChild func(Element)) {
 	}
 }
 
+func (s *ReturnStatement) ElementComments() *Comments {
+	return s.Comments
+}
+
+func (s *ReturnStatement) SetElementComments(comments *Comments) {
+	s.Comments = comments
+}
+
 const returnStatementKeywordDoc = prettier.Text("return")
 const returnStatementKeywordSpaceDoc = prettier.Text("return ")
 
@@ -60,7 +70,6 @@ func (s *ReturnStatement) Doc() prettier.Doc {
 
 	return prettier.Concat{
 		returnStatementKeywordSpaceDoc,
-		// TODO: potentially parenthesize
 		s.Expression.Doc(),
 	}
 }
@@ -79,6 +88,7 @@ func (s *ReturnStatement) MarshalJSON() ([]byte, error) {
 // BreakStatement
 
 type BreakStatement struct {
+	Comments *Comments `json:",omitempty"`
 	Range
 }
 
@@ -94,6 +104,14 @@ func (*BreakStatement) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (s *BreakStatement) ElementComments() *Comments {
+	return s.Comments
+}
+
+func (s *BreakStatement) SetElementComments(comments *Comments) {
+	s.Comments = comments
+}
+
 const breakStatementKeywordDoc = prettier.Text("break")
 
 func (*BreakStatement) Doc() prettier.Doc {
@@ -114,6 +132,7 @@ func (s *BreakStatement) MarshalJSON() ([]byte, error) {
 // ContinueStatement
 
 type ContinueStatement struct {
+	Comments *Comments `json:",omitempty"`
 	Range
 }
 
@@ -129,6 +148,14 @@ func (*ContinueStatement) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (s *ContinueStatement) ElementComments() *Comments {
+	return s.Comments
+}
+
+func (s *ContinueStatement) SetElementComments(comments *Comments) {
+	s.Comments = comments
+}
+
 const continueStatementKeywordDoc = prettier.Text("continue")
 
 func (*ContinueStatement) Doc() prettier.Doc {
@@ -151,6 +178,7 @@ func (s *ContinueStatement) MarshalJSON() ([]byte, error) {
 type IfStatementTest interface {
 	Element
 	isIfStatementTest()
+	Doc() prettier.Doc
 }
 
 // IfStatement
@@ -159,7 +187,8 @@ type IfStatement struct {
 	Test     IfStatementTest
 	Then     *Block
 	Else     *Block
-	StartPos Position `json:"-"`
+	StartPos Position  `json:"-"`
+	Comments *Comments `json:",omitempty"`
 }
 
 var _ Statement = &IfStatement{}
@@ -189,28 +218,33 @@ func (s *IfStatement) Walk(walkChild func(Element)) {
 	}
 }
 
+func (s *IfStatement) ElementComments() *Comments {
+	return s.Comments
+}
+
+func (s *IfStatement) SetElementComments(comments *Comments) {
+	s.Comments = comments
+}
+
 const ifStatementIfKeywordSpaceDoc = prettier.Text("if ")
 const ifStatementSpaceElseKeywordSpaceDoc = prettier.Text(" else ")
 
 func (s *IfStatement) Doc() prettier.Doc {
-	var testDoc prettier.Doc
-	// TODO: replace once IfStatementTest implements Doc
-	testWithDoc, ok := s.Test.(interface{ Doc() prettier.Doc })
-	if ok {
-		testDoc = testWithDoc.Doc()
-	}
-
 	doc := prettier.Concat{
 		ifStatementIfKeywordSpaceDoc,
-		testDoc,
+		s.Test.Doc(),
 		prettier.Space,
 		s.Then.Doc(),
 	}
 
 	if s.Else != nil {
 		var elseDoc prettier.Doc
-		if len(s.Else.Statements) == 1 {
-			if elseIfStatement, ok := s.Else.Statements[0].(*IfStatement); ok {
+		// An else block which only contains an if statement is printed as `else if`,
+		// unless comments are attached, which would not have a place
+		if len(s.Else.Statements) == 1 && s.Else.Comments.IsEmpty() {
+			if elseIfStatement, ok := s.Else.Statements[0].(*IfStatement); ok &&
+				elseIfStatement.Comments.IsEmpty() {
+
 				elseDoc = elseIfStatement.Doc()
 			}
 		}
@@ -250,7 +284,8 @@ func (s *IfStatement) MarshalJSON() ([]byte, error) {
 type WhileStatement struct {
 	Test     Expression
 	Block    *Block
-	StartPos Position `json:"-"`
+	StartPos Position  `json:"-"`
+	Comments *Comments `json:",omitempty"`
 }
 
 var _ Statement = &WhileStatement{}
@@ -266,6 +301,14 @@ func (s *WhileStatement) Walk(walkChild func(Element)) {
 	walkChild(s.Block)
 }
 
+func (s *WhileStatement) ElementComments() *Comments {
+	return s.Comments
+}
+
+func (s *WhileStatement) SetElementComments(comments *Comments) {
+	s.Comments = comments
+}
+
 func (s *WhileStatement) StartPosition() Position {
 	return s.StartPos
 }
@@ -307,7 +350,8 @@ type ForStatement struct {
 	Index      *Identifier
 	Value      Expression
 	Block      *Block
-	StartPos   Position `json:"-"`
+	StartPos   Position  `json:"-"`
+	Comments   *Comments `json:",omitempty"`
 }
 
 var _ Statement = &ForStatement{}
@@ -323,6 +367,14 @@ func (s *ForStatement) Walk(walkChild func(Element)) {
 	walkChild(s.Block)
 }
 
+func (s *ForStatement) ElementComments() *Comments {
+	return s.Comments
+}
+
+func (s *ForStatement) SetElementComments(comments *Comments) {
+	s.Comments = comments
+}
+
 func (s *ForStatement) StartPosition() Position {
 	return s.StartPos
 }
@@ -378,7 +430,8 @@ func (s *ForStatement) MarshalJSON() ([]byte, error) {
 
 type EmitStatement struct {
 	InvocationExpression *InvocationExpression
-	StartPos             Position `json:"-"`
+	StartPos             Position  `json:"-"`
+	Comments             *Comments `json:",omitempty"`
 }
 
 var _ Statement = &EmitStatement{}
@@ -401,13 +454,20 @@ func (s *EmitStatement) Walk(walkChild func(Element)) {
 	walkChild(s.InvocationExpression)
 }
 
+func (s *EmitStatement) ElementComments() *Comments {
+	return s.Comments
+}
+
+func (s *EmitStatement) SetElementComments(comments *Comments) {
+	s.Comments = comments
+}
+
 const emitStatementKeywordSpaceDoc = prettier.Text("emit ")
 
 func (s *EmitStatement) Doc() prettier.Doc {
 	return prettier.Concat{
 		emitStatementKeywordSpaceDoc,
-		// TODO: potentially parenthesize
-		s.InvocationExpression.Doc(),
+		s.InvocationExpression.nominalTypeDoc(),
 	}
 }
 
@@ -430,6 +490,7 @@ type AssignmentStatement struct {
 	Target   Expression
 	Transfer *Transfer
 	Value    Expression
+	Comments *Comments `json:",omitempty"`
 }
 
 var _ Statement = &AssignmentStatement{}
@@ -453,6 +514,14 @@ func (s *AssignmentStatement) Walk(walkChild func(Element)) {
 	walkChild(s.Value)
 }
 
+func (s *AssignmentStatement) ElementComments() *Comments {
+	return s.Comments
+}
+
+func (s *AssignmentStatement) SetElementComments(comments *Comments) {
+	s.Comments = comments
+}
+
 func (s *AssignmentStatement) Doc() prettier.Doc {
 	return prettier.Group{
 		Doc: prettier.Concat{
@@ -485,8 +554,9 @@ func (s *AssignmentStatement) MarshalJSON() ([]byte, error) {
 // SwapStatement
 
 type SwapStatement struct {
-	Left  Expression
-	Right Expression
+	Left     Expression
+	Right    Expression
+	Comments *Comments `json:",omitempty"`
 }
 
 var _ Statement = &SwapStatement{}
@@ -510,6 +580,14 @@ func (s *SwapStatement) Walk(walkChild func(Element)) {
 	walkChild(s.Right)
 }
 
+func (s *SwapStatement) ElementComments() *Comments {
+	return s.Comments
+}
+
+func (s *SwapStatement) SetElementComments(comments *Comments) {
+	s.Comments = comments
+}
+
 const swapStatementSpaceSymbolSpaceDoc = prettier.Text(" <-> ")
 
 func (s *SwapStatement) Doc() prettier.Doc {
@@ -539,6 +617,7 @@ func (s *SwapStatement) MarshalJSON() ([]byte, error) {
 
 type ExpressionStatement struct {
 	Expression Expression
+	Comments   *Comments `json:",omitempty"`
 }
 
 var _ Statement = &ExpressionStatement{}
@@ -561,8 +640,21 @@ func (s *ExpressionStatement) Walk(walkChild func(Element)) {
 	walkChild(s.Expression)
 }
 
+func (s *ExpressionStatement) ElementComments() *Comments {
+	return s.Comments
+}
+
+func (s *ExpressionStatement) SetElementComments(comments *Comments) {
+	s.Comments = comments
+}
+
 func (s *ExpressionStatement) Doc() prettier.Doc {
-	return s.Expression.Doc()
+	doc := s.Expression.Doc()
+	if startsWithFunctionExpression(s.Expression) {
+		// The statement would be parsed as a function declaration
+		return prettier.WrapParentheses(doc, prettier.SoftLine{})
+	}
+	return doc
 }
 
 func (s *ExpressionStatement) MarshalJSON() ([]byte, error) {
@@ -583,6 +675,7 @@ func (s *ExpressionStatement) MarshalJSON() ([]byte, error) {
 type SwitchStatement struct {
 	Expression Expression
 	Cases      []*SwitchCase
+	Comments   *Comments `json:",omitempty"`
 	Range
 }
 
@@ -605,17 +698,29 @@ func (s *SwitchStatement) Walk(walkChild func(Element)) {
 	}
 }
 
+func (s *SwitchStatement) ElementComments() *Comments {
+	return s.Comments
+}
+
+func (s *SwitchStatement) SetElementComments(comments *Comments) {
+	s.Comments = comments
+}
+
 const switchStatementKeywordSpaceDoc = prettier.Text("switch ")
 
 func (s *SwitchStatement) Doc() prettier.Doc {
 
 	bodyDoc := make(prettier.Concat, 0, len(s.Cases))
 
-	for _, switchCase := range s.Cases {
+	for i, switchCase := range s.Cases {
+		var separatorDoc prettier.Doc = prettier.HardLine{}
+		if i > 0 {
+			separatorDoc = elementSeparatorDoc(switchCase, separatorDoc)
+		}
 		bodyDoc = append(
 			bodyDoc,
-			prettier.HardLine{},
-			switchCase.Doc(),
+			separatorDoc,
+			commentedDoc(switchCase.Comments, switchCase.Doc(), false),
 		)
 	}
 
@@ -657,9 +762,18 @@ func (s *SwitchStatement) MarshalJSON() ([]byte, error) {
 type SwitchCase struct {
 	Expression Expression
 	Statements []Statement
+	Comments   *Comments `json:",omitempty"`
 	Range
 }
 
+func (s *SwitchCase) ElementComments() *Comments {
+	return s.Comments
+}
+
+func (s *SwitchCase) SetElementComments(comments *Comments) {
+	s.Comments = comments
+}
+
 func (s *SwitchCase) MarshalJSON() ([]byte, error) {
 	type Alias SwitchCase
 	return json.Marshal(&struct {
@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -32,6 +34,7 @@ type TransactionDeclaration struct {
 	Execute        *SpecialFunctionDeclaration
 	PostConditions *Conditions
 	DocString      string
+	Comments       *Comments `json:",omitempty"`
 	Range
 }
 
@@ -53,6 +56,14 @@ func (d *TransactionDeclaration) Walk(walkChild func(Element)) {
 	// TODO: walk pre and post-conditions
 }
 
+func (d *TransactionDeclaration) ElementComments() *Comments {
+	return d.Comments
+}
+
+func (d *TransactionDeclaration) SetElementComments(comments *Comments) {
+	d.Comments = comments
+}
+
 func (*TransactionDeclaration) isDeclaration() {}
 func (*TransactionDeclaration) isStatement()   {}
 
@@ -76,6 +87,98 @@ func (d *TransactionDeclaration) DeclarationDocString() string {
 	return ""
 }
 
+const transactionDeclarationTransactionKeywordDoc = prettier.Text("transaction")
+
+func (d *TransactionDeclaration) Doc() prettier.Doc {
+	doc := prettier.Concat{
+		transactionDeclarationTransactionKeywordDoc,
+	}
+
+	if d.ParameterList != nil {
+		doc = append(
+			doc,
+			prettier.Group{
+				Doc: d.ParameterList.Doc(),
+			},
+		)
+	}
+
+	// The fields, the prepare block, the pre-conditions, the execute block,
+	// and the post-conditions are separated by an empty line.
+	// If comments are attached, the empty lines of the source are preserved instead
+
+	var bodyDoc prettier.Concat
+
+	appendSection := func(element CommentedElement, separatorDoc prettier.Doc, doc prettier.Doc) {
+		if len(bodyDoc) > 0 {
+			if element != nil {
+				separatorDoc = elementSeparatorDoc(element, separatorDoc)
+			}
+			bodyDoc = append(bodyDoc, separatorDoc)
+		}
+		bodyDoc = append(bodyDoc, doc)
+	}
+
+	for i, field := range d.Fields {
+		var separatorDoc prettier.Doc = blankLineDoc
+		if i > 0 {
+			separatorDoc = prettier.HardLine{}
+		}
+		appendSection(field, separatorDoc, elementDoc(field))
+	}
+
+	if d.Prepare != nil {
+		appendSection(d.Prepare, blankLineDoc, elementDoc(d.Prepare))
+	}
+
+	if d.PreConditions != nil {
+		appendSection(nil, blankLineDoc, d.PreConditions.Doc(ConditionKindPre))
+	}
+
+	if d.Execute != nil {
+		appendSection(d.Execute, blankLineDoc, elementDoc(d.Execute))
+	}
+
+	if d.PostConditions != nil {
+		appendSection(nil, blankLineDoc, d.PostConditions.Doc(ConditionKindPost))
+	}
+
+	innerCommentsDoc := innerCommentsDoc(d.Comments)
+
+	if len(bodyDoc) == 0 && innerCommentsDoc == nil {
+		doc = append(
+			doc,
+			prettier.Space,
+			blockEmptyDoc,
+		)
+	} else {
+		var indentedDoc prettier.Concat
+		if innerCommentsDoc != nil {
+			indentedDoc = append(indentedDoc, innerCommentsDoc)
+		}
+		if len(bodyDoc) > 0 {
+			indentedDoc = append(
+				indentedDoc,
+				prettier.HardLine{},
+				bodyDoc,
+			)
+		}
+
+		doc = append(
+			doc,
+			prettier.Space,
+			blockStartDoc,
+			prettier.Indent{
+				Doc: indentedDoc,
+			},
+			prettier.HardLine{},
+			blockEndDoc,
+		)
+	}
+
+	return declarationDoc(d.Comments, d.DocString, AccessNotSpecified, doc)
+}
+
 func (d *TransactionDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias TransactionDeclaration
 	return json.Marshal(&struct {
@@ -20,10 +20,14 @@ package ast
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/common"
 )
 
 func TestTransactionDeclaration_MarshalJSON(t *testing.T) {
@@ -75,3 +79,132 @@ func TestTransactionDeclaration_MarshalJSON(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestTransactionDeclaration_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	decl := &TransactionDeclaration{
+		ParameterList: &ParameterList{
+			Parameters: []*Parameter{
+				{
+					Identifier: Identifier{
+						Identifier: "x",
+					},
+					TypeAnnotation: &TypeAnnotation{
+						Type: &NominalType{
+							Identifier: Identifier{
+								Identifier: "Int",
+							},
+						},
+					},
+				},
+			},
+		},
+		Fields: []*FieldDeclaration{
+			{
+				Access:       AccessNotSpecified,
+				VariableKind: VariableKindConstant,
+				Identifier: Identifier{
+					Identifier: "y",
+				},
+				TypeAnnotation: &TypeAnnotation{
+					Type: &NominalType{
+						Identifier: Identifier{
+							Identifier: "Int",
+						},
+					},
+				},
+			},
+		},
+		Prepare: &SpecialFunctionDeclaration{
+			Kind: common.DeclarationKindPrepare,
+			FunctionDeclaration: &FunctionDeclaration{
+				Identifier: Identifier{
+					Identifier: "prepare",
+				},
+				ParameterList: &ParameterList{
+					Parameters: []*Parameter{
+						{
+							Identifier: Identifier{
+								Identifier: "signer",
+							},
+							TypeAnnotation: &TypeAnnotation{
+								Type: &NominalType{
+									Identifier: Identifier{
+										Identifier: "AuthAccount",
+									},
+								},
+							},
+						},
+					},
+				},
+				FunctionBlock: &FunctionBlock{
+					Block: &Block{
+						Statements: []Statement{
+							&AssignmentStatement{
+								Target: &MemberExpression{
+									Expression: &IdentifierExpression{
+										Identifier: Identifier{
+											Identifier: "self",
+										},
+									},
+									Identifier: Identifier{
+										Identifier: "y",
+									},
+								},
+								Transfer: &Transfer{
+									Operation: TransferOperationCopy,
+								},
+								Value: &IdentifierExpression{
+									Identifier: Identifier{
+										Identifier: "x",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		PreConditions: &Conditions{
+			{
+				Kind: ConditionKindPre,
+				Test: &BoolExpression{
+					Value: true,
+				},
+			},
+		},
+		Execute: &SpecialFunctionDeclaration{
+			Kind: common.DeclarationKindExecute,
+			FunctionDeclaration: &FunctionDeclaration{
+				Identifier: Identifier{
+					Identifier: "execute",
+				},
+				FunctionBlock: &FunctionBlock{
+					Block: &Block{},
+				},
+			},
+		},
+	}
+
+	var b strings.Builder
+	prettier.Prettier(&b, decl.Doc(), 80, "    ")
+
+	assert.Equal(t,
+		`transaction(x: Int) {
+    let y: Int
+
+    prepare(signer: AuthAccount) {
+        self.y = x
+    }
+
+    pre {
+        true
+    }
+
+    execute {}
+}`,
+		b.String(),
+	)
+}
@@ -520,9 +520,10 @@ const restrictedTypeEndDoc = prettier.Text("}")
 const restrictedTypeSeparatorDoc = prettier.Text(",")
 
 func (t *RestrictedType) Doc() prettier.Doc {
-	restrictionsDoc := prettier.Concat{
-		prettier.SoftLine{},
-	}
+	// NOTE: the parser only accepts a restricted type
+	// if there is no whitespace after the opening brace,
+	// so there is no line break before the first restriction
+	var restrictionsDoc prettier.Concat
 
 	for i, restriction := range t.Restrictions {
 		if i > 0 {
@@ -543,6 +544,13 @@ func (t *RestrictedType) Doc() prettier.Doc {
 		doc = append(doc, t.Type.Doc())
 	}
 
+	if len(t.Restrictions) == 0 {
+		return append(doc,
+			restrictedTypeStartDoc,
+			restrictedTypeEndDoc,
+		)
+	}
+
 	return append(doc,
 		prettier.Group{
 			Doc: prettier.Concat{
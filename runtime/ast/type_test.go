@@ -735,7 +735,6 @@ func TestRestrictedType_Doc(t *testing.T) {
 					prettier.Text("{"),
 					prettier.Indent{
 						Doc: prettier.Concat{
-							prettier.SoftLine{},
 							prettier.Text("CD"),
 							prettier.Text(","),
 							prettier.Line{},
@@ -0,0 +1,52 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package ast
+
+import "github.com/turbolent/prettier"
+
+// TypeParameter is a type parameter of a generic function or composite declaration,
+// e.g. `T` or `T: AnyResource`
+//
+type TypeParameter struct {
+	Identifier Identifier
+	// TypeBound is the optional upper bound of the type parameter
+	TypeBound *TypeAnnotation `json:",omitempty"`
+}
+
+func (p *TypeParameter) StartPosition() Position {
+	return p.Identifier.StartPosition()
+}
+
+func (p *TypeParameter) EndPosition() Position {
+	if p.TypeBound != nil {
+		return p.TypeBound.EndPosition()
+	}
+	return p.Identifier.EndPosition()
+}
+
+func (p *TypeParameter) Doc() prettier.Doc {
+	if p.TypeBound == nil {
+		return prettier.Text(p.Identifier.Identifier)
+	}
+
+	return prettier.Concat{
+		prettier.Text(p.Identifier.Identifier),
+		typeSeparatorDoc,
+		p.TypeBound.Doc(),
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package ast
+
+import "github.com/turbolent/prettier"
+
+type TypeParameterList struct {
+	TypeParameters []*TypeParameter
+	Range
+}
+
+// IsEmpty returns true if the given type parameter list is nil
+// or has no type parameters
+//
+func (l *TypeParameterList) IsEmpty() bool {
+	return l == nil || len(l.TypeParameters) == 0
+}
+
+func (l *TypeParameterList) Doc() prettier.Doc {
+
+	if l.IsEmpty() {
+		return prettier.Concat{}
+	}
+
+	typeParameterDocs := make([]prettier.Doc, 0, len(l.TypeParameters))
+
+	for _, typeParameter := range l.TypeParameters {
+		typeParameterDocs = append(typeParameterDocs, typeParameter.Doc())
+	}
+
+	return prettier.Group{
+		Doc: prettier.Concat{
+			instantiationTypeStartDoc,
+			prettier.Indent{
+				Doc: prettier.Concat{
+					prettier.SoftLine{},
+					prettier.Join(
+						parameterListSeparatorDoc,
+						typeParameterDocs...,
+					),
+				},
+			},
+			prettier.SoftLine{},
+			instantiationTypeEndDoc,
+		},
+	}
+}
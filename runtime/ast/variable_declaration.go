@@ -38,6 +38,7 @@ type VariableDeclaration struct {
 	SecondValue       Expression
 	ParentIfStatement *IfStatement `json:"-"`
 	DocString         string
+	Comments          *Comments `json:",omitempty"`
 }
 
 func (d *VariableDeclaration) StartPosition() Position {
@@ -69,6 +70,14 @@ func (d *VariableDeclaration) Walk(walkChild func(Element)) {
 	}
 }
 
+func (d *VariableDeclaration) ElementComments() *Comments {
+	return d.Comments
+}
+
+func (d *VariableDeclaration) SetElementComments(comments *Comments) {
+	d.Comments = comments
+}
+
 func (d *VariableDeclaration) DeclarationIdentifier() *Identifier {
 	return &d.Identifier
 }
@@ -101,31 +110,58 @@ func (d *VariableDeclaration) Doc() prettier.Doc {
 		keywordDoc = letKeywordDoc
 	}
 
-	// TODO: second transfer and value (if any)
+	identifierTypeDoc := prettier.Concat{
+		prettier.Text(d.Identifier.Identifier),
+	}
+
+	if d.TypeAnnotation != nil {
+		identifierTypeDoc = append(
+			identifierTypeDoc,
+			typeSeparatorDoc,
+			d.TypeAnnotation.Doc(),
+		)
+	}
 
-	// TODO: potentially parenthesize
-	valueDoc := d.Value.Doc()
+	valueDoc := prettier.Concat{
+		identifierTypeDoc,
+		prettier.Space,
+		d.Transfer.Doc(),
+		prettier.Space,
+		prettier.Group{
+			Doc: prettier.Indent{
+				Doc: d.Value.Doc(),
+			},
+		},
+	}
 
-	return prettier.Group{
-		Doc: prettier.Concat{
-			keywordDoc,
+	if d.SecondTransfer != nil && d.SecondValue != nil {
+		valueDoc = append(
+			valueDoc,
+			prettier.Space,
+			d.SecondTransfer.Doc(),
 			prettier.Space,
 			prettier.Group{
-				Doc: prettier.Concat{
-					prettier.Text(d.Identifier.Identifier),
-					prettier.Space,
-					// TODO: type annotation, if any
-					d.Transfer.Doc(),
-					prettier.Space,
-					prettier.Group{
-						Doc: prettier.Indent{
-							Doc: valueDoc,
-						},
-					},
+				Doc: prettier.Indent{
+					Doc: d.SecondValue.Doc(),
 				},
 			},
-		},
+		)
 	}
+
+	return declarationDoc(
+		d.Comments,
+		d.DocString,
+		d.Access,
+		prettier.Group{
+			Doc: prettier.Concat{
+				keywordDoc,
+				prettier.Space,
+				prettier.Group{
+					Doc: valueDoc,
+				},
+			},
+		},
+	)
 }
 
 func (d *VariableDeclaration) MarshalJSON() ([]byte, error) {
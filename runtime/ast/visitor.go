@@ -98,4 +98,5 @@ type Visitor interface {
 	VisitPragmaDeclaration(*PragmaDeclaration) Repr
 	VisitImportDeclaration(*ImportDeclaration) Repr
 	VisitTransactionDeclaration(*TransactionDeclaration) Repr
+	VisitTypeAliasDeclaration(*TypeAliasDeclaration) Repr
 }
@@ -21,12 +21,16 @@ package execute
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/c-bata/go-prompt"
 
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
 )
 
 const commandShortHelp = "h"
@@ -35,15 +39,35 @@ const commandShortContinue = "c"
 const commandLongContinue = "continue"
 const commandShortNext = "n"
 const commandLongNext = "next"
+const commandShortStep = "si"
+const commandLongStep = "step"
+const commandShortStepOut = "so"
+const commandLongStepOut = "stepout"
+const commandShortBreak = "b"
+const commandLongBreak = "break"
+const commandShortDelete = "d"
+const commandLongDelete = "delete"
+const commandShortBacktrace = "bt"
+const commandLongBacktrace = "backtrace"
+const commandShortFrame = "f"
+const commandLongFrame = "frame"
 const commandLongExit = "exit"
 const commandShortShow = "s"
 const commandLongShow = "show"
 const commandShortWhere = "w"
 const commandLongWhere = "where"
 
+const breakpointConditionSeparator = " if "
+
 var debuggerCommandSuggestions = []prompt.Suggest{
 	{Text: commandLongContinue, Description: "Continue"},
-	{Text: commandLongNext, Description: "Next / step"},
+	{Text: commandLongNext, Description: "Next / step over"},
+	{Text: commandLongStep, Description: "Step into"},
+	{Text: commandLongStepOut, Description: "Step out"},
+	{Text: commandLongBreak, Description: "Add breakpoint: break [file:]line [if condition]. List breakpoints if no line is given"},
+	{Text: commandLongDelete, Description: "Delete breakpoint: delete id"},
+	{Text: commandLongBacktrace, Description: "Call stack"},
+	{Text: commandLongFrame, Description: "Select frame: frame index"},
 	{Text: commandLongWhere, Description: "Location info"},
 	{Text: commandLongShow, Description: "Show variable(s)"},
 	{Text: commandLongExit, Description: "Exit"},
@@ -53,13 +77,27 @@ var debuggerCommandSuggestions = []prompt.Suggest{
 type InteractiveDebugger struct {
 	debugger *interpreter.Debugger
 	stop     interpreter.Stop
+	frames   []interpreter.StackFrame
+	// frameIndex is the index of the selected frame in frames
+	frameIndex int
 }
 
 func NewInteractiveDebugger(debugger *interpreter.Debugger, stop interpreter.Stop) *InteractiveDebugger {
-	return &InteractiveDebugger{
+	d := &InteractiveDebugger{
 		debugger: debugger,
-		stop:     stop,
 	}
+	d.setStop(stop)
+	return d
+}
+
+func (d *InteractiveDebugger) setStop(stop interpreter.Stop) {
+	d.stop = stop
+	d.frames = d.debugger.StackFrames(stop)
+	d.frameIndex = 0
+}
+
+func (d *InteractiveDebugger) frame() interpreter.StackFrame {
+	return d.frames[d.frameIndex]
 }
 
 func (d *InteractiveDebugger) Continue() {
@@ -67,14 +105,25 @@ func (d *InteractiveDebugger) Continue() {
 }
 
 func (d *InteractiveDebugger) Next() {
-	d.stop = d.debugger.Next()
+	d.setStop(d.debugger.StepOver())
+	d.Where()
+}
+
+func (d *InteractiveDebugger) Step() {
+	d.setStop(d.debugger.StepIn())
+	d.Where()
 }
 
-// Show shows the values for the variables with the given names.
+func (d *InteractiveDebugger) StepOut() {
+	d.setStop(d.debugger.StepOut())
+	d.Where()
+}
+
+// Show shows the values for the variables with the given names, in the selected frame.
 // If no names are given, lists all non-base variables
 //
 func (d *InteractiveDebugger) Show(names []string) {
-	current := d.debugger.CurrentActivation(d.stop.Interpreter)
+	current := d.frame().Activation
 	switch len(names) {
 	case 0:
 		for name := range current.FunctionValues() { //nolint:maprangecheck
@@ -107,8 +156,103 @@ func (d *InteractiveDebugger) Show(names []string) {
 	}
 }
 
+// Break adds a breakpoint, given an argument of the form `[file:]line [if condition]`.
+// If no file is given, the breakpoint is added to the program of the selected frame.
+// If no argument is given, lists all breakpoints
+//
+func (d *InteractiveDebugger) Break(argument string) {
+	if argument == "" {
+		for _, breakpoint := range d.debugger.Breakpoints() {
+			fmt.Println(formatBreakpoint(breakpoint))
+		}
+		return
+	}
+
+	var condition ast.Expression
+	if index := strings.Index(argument, breakpointConditionSeparator); index >= 0 {
+		code := strings.TrimSpace(argument[index+len(breakpointConditionSeparator):])
+		argument = strings.TrimSpace(argument[:index])
+
+		var errs []error
+		condition, errs = parser2.ParseExpression(code)
+		if len(errs) > 0 {
+			err := parser2.Error{
+				Code:   code,
+				Errors: errs,
+			}
+			fmt.Println(colorizeError(fmt.Sprintf("error: invalid condition: %s", err)))
+			return
+		}
+	}
+
+	location := d.frame().Interpreter.Location
+	lineArgument := argument
+	if index := strings.LastIndex(argument, ":"); index >= 0 {
+		location = common.StringLocation(argument[:index])
+		lineArgument = argument[index+1:]
+	}
+
+	line, err := strconv.Atoi(lineArgument)
+	if err != nil || line < 1 {
+		fmt.Println(colorizeError(fmt.Sprintf("error: invalid line '%s'", lineArgument)))
+		return
+	}
+
+	breakpoint := d.debugger.AddBreakpoint(location, line, condition)
+	fmt.Println(formatBreakpoint(breakpoint))
+}
+
+// Delete deletes the breakpoint with the given ID
+//
+func (d *InteractiveDebugger) Delete(arguments []string) {
+	if len(arguments) != 1 {
+		fmt.Println(colorizeError("error: expected breakpoint ID"))
+		return
+	}
+
+	id, err := strconv.Atoi(arguments[0])
+	if err != nil || !d.debugger.RemoveBreakpoint(id) {
+		fmt.Println(colorizeError(fmt.Sprintf("error: unknown breakpoint '%s'", arguments[0])))
+	}
+}
+
+// Backtrace lists the frames of the call stack, starting with the innermost frame
+//
+func (d *InteractiveDebugger) Backtrace() {
+	for i, frame := range d.frames {
+		marker := " "
+		if i == d.frameIndex {
+			marker = "*"
+		}
+		fmt.Printf("%s #%d %s\n", marker, i, formatFrame(frame))
+	}
+}
+
+// Frame selects the frame with the given index.
+// If no index is given, shows the selected frame
+//
+func (d *InteractiveDebugger) Frame(arguments []string) {
+	if len(arguments) > 0 {
+		index, err := strconv.Atoi(arguments[0])
+		if err != nil || index < 0 || index >= len(d.frames) {
+			fmt.Println(colorizeError(fmt.Sprintf("error: invalid frame '%s'", arguments[0])))
+			return
+		}
+		d.frameIndex = index
+	}
+
+	fmt.Printf("#%d %s\n", d.frameIndex, formatFrame(d.frame()))
+}
+
 func (d *InteractiveDebugger) Run() {
 
+	if d.stop.Breakpoint != nil {
+		fmt.Printf("Stopped at %s\n", formatBreakpoint(d.stop.Breakpoint))
+		if d.stop.ConditionError != nil {
+			fmt.Println(colorizeError(fmt.Sprintf("error: failed to evaluate condition: %s", d.stop.ConditionError)))
+		}
+	}
+
 	executor := func(in string) {
 		in = strings.TrimSpace(in)
 
@@ -123,6 +267,18 @@ func (d *InteractiveDebugger) Run() {
 			d.Continue()
 		case commandShortNext, commandLongNext:
 			d.Next()
+		case commandShortStep, commandLongStep:
+			d.Step()
+		case commandShortStepOut, commandLongStepOut:
+			d.StepOut()
+		case commandShortBreak, commandLongBreak:
+			d.Break(strings.TrimSpace(strings.TrimPrefix(in, command)))
+		case commandShortDelete, commandLongDelete:
+			d.Delete(arguments)
+		case commandShortBacktrace, commandLongBacktrace:
+			d.Backtrace()
+		case commandShortFrame, commandLongFrame:
+			d.Frame(arguments)
 		case commandShortShow, commandLongShow:
 			d.Show(arguments)
 		case commandShortWhere, commandLongWhere:
@@ -176,10 +332,42 @@ func (d *InteractiveDebugger) Help() {
 	_ = w.Flush()
 }
 
+// Where shows the location of the selected frame
+//
 func (d *InteractiveDebugger) Where() {
+	frame := d.frame()
 	fmt.Printf(
 		"%s @ %d\n",
-		d.stop.Interpreter.Location,
-		d.stop.Statement.StartPosition().Line,
+		frame.Interpreter.Location,
+		frameLine(frame),
+	)
+}
+
+func frameLine(frame interpreter.StackFrame) int {
+	if frame.Statement == nil {
+		return 0
+	}
+	return frame.Statement.StartPosition().Line
+}
+
+func formatFrame(frame interpreter.StackFrame) string {
+	name := frame.FunctionName
+	if name == "" {
+		name = "<anonymous>"
+	}
+
+	return fmt.Sprintf("%s at %s:%d", name, frame.Interpreter.Location, frameLine(frame))
+}
+
+func formatBreakpoint(breakpoint *interpreter.Breakpoint) string {
+	result := fmt.Sprintf(
+		"breakpoint %d at %s:%d",
+		breakpoint.ID,
+		breakpoint.Location,
+		breakpoint.Line,
 	)
+	if breakpoint.Condition != nil {
+		result += breakpointConditionSeparator + breakpoint.Condition.String()
+	}
+	return result
 }
@@ -0,0 +1,198 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+// errNotEquivalent is returned when the formatted code
+// does not parse to a program which is equivalent to the original program,
+// e.g. because a comment would be attached to a different element
+//
+var errNotEquivalent = errors.New("formatted code is not equivalent to the original code")
+
+// errNotStable is returned when formatting the formatted code again
+// would result in different code
+//
+var errNotStable = errors.New("formatted code is not stable")
+
+type formatOptions struct {
+	// width is the maximum line width
+	width int
+	// indent is the string used for one level of indentation
+	indent string
+}
+
+// format formats the given code, including its comments.
+//
+// The formatted code is verified: it must parse to a program which is equivalent
+// to the program of the given code, and formatting it again must not change it.
+// Otherwise, an error is returned, so the formatted code never changes the meaning of the code
+//
+func format(code string, options formatOptions) (string, error) {
+	program, err := parser2.ParseProgramWithComments(code)
+	if err != nil {
+		return "", err
+	}
+
+	formatted := formatProgram(program, options)
+
+	formattedProgram, err := parser2.ParseProgramWithComments(formatted)
+	if err != nil {
+		return "", errNotEquivalent
+	}
+
+	equivalent, err := programsEquivalent(program, formattedProgram)
+	if err != nil {
+		return "", err
+	}
+	if !equivalent {
+		return "", errNotEquivalent
+	}
+
+	if formatProgram(formattedProgram, options) != formatted {
+		return "", errNotStable
+	}
+
+	return formatted, nil
+}
+
+func formatProgram(program *ast.Program, options formatOptions) string {
+	var builder strings.Builder
+	prettier.Prettier(&builder, program.Doc(), options.width, options.indent)
+	builder.WriteByte('\n')
+	return builder.String()
+}
+
+// programsEquivalent returns true if the given programs are equal,
+// ignoring positions and blank lines.
+//
+// Comments are compared by their text and the element they are attached to,
+// so a formatting which moves a comment, e.g. out of an expression, is not equivalent
+//
+func programsEquivalent(a, b *ast.Program) (bool, error) {
+	normalizedA, err := normalizeProgram(a)
+	if err != nil {
+		return false, err
+	}
+
+	normalizedB, err := normalizeProgram(b)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(normalizedA, normalizedB), nil
+}
+
+// normalizeProgram returns the JSON representation of the given program,
+// without positions and blank lines
+//
+func normalizeProgram(program *ast.Program) (interface{}, error) {
+	encoded, err := json.Marshal(program)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	err = json.Unmarshal(encoded, &decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return removePositions(decoded), nil
+}
+
+func removePositions(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			// Positions are e.g. `StartPos`, `EndPos`, and `AccessPos`
+			if strings.HasSuffix(key, "Pos") {
+				delete(value, key)
+				continue
+			}
+
+			if key == "Comments" {
+				comments := normalizeComments(nested)
+				if comments == nil {
+					delete(value, key)
+				} else {
+					value[key] = comments
+				}
+				continue
+			}
+
+			value[key] = removePositions(nested)
+		}
+
+	case []interface{}:
+		for i, nested := range value {
+			value[i] = removePositions(nested)
+		}
+	}
+
+	return value
+}
+
+// normalizeComments returns the texts of the leading, trailing, and inner comments
+// in the given JSON representation of comments, or nil if there are no comments.
+//
+// The blank lines and the lines of the comments may be changed by formatting
+//
+func normalizeComments(value interface{}) interface{} {
+	comments, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := map[string]interface{}{}
+
+	for _, key := range []string{"Leading", "Trailing", "Inner"} {
+		nested, ok := comments[key].([]interface{})
+		if !ok || len(nested) == 0 {
+			continue
+		}
+
+		texts := make([]interface{}, 0, len(nested))
+		for _, comment := range nested {
+			comment, ok := comment.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			texts = append(texts, comment["Text"])
+		}
+
+		result[key] = texts
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
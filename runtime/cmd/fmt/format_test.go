@@ -0,0 +1,235 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+const unformattedCode = `
+// Test
+pub  contract Test {
+
+    /// The number
+    pub let x:Int // x
+
+
+    init( ) { self.x=1 }
+}
+`
+
+func TestFormat(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("spaces", func(t *testing.T) {
+
+		t.Parallel()
+
+		formatted, err := format(unformattedCode, formatOptions{width: 80, indent: "    "})
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			`// Test
+pub contract Test {
+    /// The number
+    pub let x: Int // x
+
+    init() {
+        self.x = 1
+    }
+}
+`,
+			formatted,
+		)
+	})
+
+	t.Run("tabs", func(t *testing.T) {
+
+		t.Parallel()
+
+		formatted, err := format(unformattedCode, formatOptions{width: 80, indent: "\t"})
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			"// Test\n"+
+				"pub contract Test {\n"+
+				"\t/// The number\n"+
+				"\tpub let x: Int // x\n"+
+				"\n"+
+				"\tinit() {\n"+
+				"\t\tself.x = 1\n"+
+				"\t}\n"+
+				"}\n",
+			formatted,
+		)
+	})
+
+	t.Run("width", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = "pub fun test(first: Int, second: Int) {}\n"
+
+		formatted, err := format(code, formatOptions{width: 80, indent: "  "})
+		require.NoError(t, err)
+		assert.Equal(t, code, formatted)
+
+		formatted, err = format(code, formatOptions{width: 20, indent: "  "})
+		require.NoError(t, err)
+		assert.Equal(t,
+			"pub fun test(\n"+
+				"  first: Int,\n"+
+				"  second: Int\n"+
+				") {}\n",
+			formatted,
+		)
+	})
+
+	t.Run("idempotent", func(t *testing.T) {
+
+		t.Parallel()
+
+		options := formatOptions{width: 80, indent: "    "}
+
+		formatted, err := format(unformattedCode, options)
+		require.NoError(t, err)
+
+		formattedAgain, err := format(formatted, options)
+		require.NoError(t, err)
+
+		assert.Equal(t, formatted, formattedAgain)
+	})
+
+	t.Run("comments in expressions", func(t *testing.T) {
+
+		t.Parallel()
+
+		options := formatOptions{width: 80, indent: "    "}
+
+		// The comments would be moved after the expressions
+
+		_, err := format(
+			"fun test() {\n"+
+				"    foo(\n"+
+				"        1, // first\n"+
+				"        2 // second\n"+
+				"    )\n"+
+				"}\n",
+			options,
+		)
+		require.ErrorIs(t, err, errNotEquivalent)
+
+		_, err = format(
+			"fun test() {\n"+
+				"    let x = [1, /* inner */ 2]\n"+
+				"}\n",
+			options,
+		)
+		require.ErrorIs(t, err, errNotEquivalent)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := format("let x = ", formatOptions{width: 80, indent: "    "})
+		require.Error(t, err)
+
+		assert.IsType(t, parser2.Error{}, err)
+	})
+}
+
+func TestProgramsEquivalent(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, a, b string, expected bool) {
+		programA, err := parser2.ParseProgramWithComments(a)
+		require.NoError(t, err)
+
+		programB, err := parser2.ParseProgramWithComments(b)
+		require.NoError(t, err)
+
+		equivalent, err := programsEquivalent(programA, programB)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, equivalent)
+	}
+
+	t.Run("positions and blank lines", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			"// test\nfun test() { let x = 1 // x\n}",
+			"// test\n\nfun test() {\n    let x = 1 // x\n}",
+			true,
+		)
+	})
+
+	t.Run("different comments", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			"fun test() { let x = 1 }",
+			"fun test() {\n    let x = 1 // x\n}",
+			false,
+		)
+	})
+
+	t.Run("moved comments", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			"fun test() {\n    let x = [1, /* x */ 2]\n}",
+			"fun test() {\n    let x = [1, 2] /* x */\n}",
+			false,
+		)
+	})
+
+	t.Run("different", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			"fun test() { let x = 1 }",
+			"fun test() { let x = 2 }",
+			false,
+		)
+	})
+
+	t.Run("different doc strings", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			"/// a\nfun test() {}",
+			"/// b\nfun test() {}",
+			false,
+		)
+	})
+}
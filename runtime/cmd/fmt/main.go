@@ -0,0 +1,181 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/cmd"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/pretty"
+)
+
+var checkFlag = flag.Bool("check", false, "only check if the files are formatted, and exit with a non-zero status if not")
+var widthFlag = flag.Int("width", 80, "the maximum line width")
+var indentFlag = flag.Int("indent", 4, "the number of spaces used for one level of indentation")
+var tabsFlag = flag.Bool("tabs", false, "indent with tabs instead of spaces")
+
+// A formatter for Cadence code.
+//
+// The given files are formatted in place.
+// If no files are given, the code is read from standard input, and the formatted code is written to standard output.
+//
+// Usage: go run ./runtime/cmd/fmt [-check] [-width 80] [-indent 4] [-tabs] [files...]
+//
+func main() {
+	flag.Parse()
+
+	if *widthFlag <= 0 {
+		cmd.ExitWithError("invalid width: must be positive")
+	}
+
+	if *indentFlag < 0 {
+		cmd.ExitWithError("invalid indent: must not be negative")
+	}
+
+	options := formatOptions{
+		width:  *widthFlag,
+		indent: strings.Repeat(" ", *indentFlag),
+	}
+	if *tabsFlag {
+		options.indent = "\t"
+	}
+
+	paths := flag.Args()
+
+	if len(paths) == 0 {
+		if !run("", options, *checkFlag) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	allSucceeded := true
+
+	for _, path := range paths {
+		if !run(path, options, *checkFlag) {
+			allSucceeded = false
+		}
+	}
+
+	if !allSucceeded {
+		os.Exit(1)
+	}
+}
+
+// run formats the code in the file at the given path, or standard input, if the path is empty.
+//
+// In check mode, the path of a file which is not formatted is reported.
+// Otherwise, the formatted code is written to the file, or standard output.
+//
+// Returns false if the code could not be formatted, or is not formatted in check mode
+//
+func run(path string, options formatOptions, check bool) bool {
+	code, err := read(path)
+	if err != nil {
+		printError(err, path, "")
+		return false
+	}
+
+	formatted, err := format(code, options)
+	if err != nil {
+		printError(err, path, code)
+		return false
+	}
+
+	if check {
+		if formatted == code {
+			return true
+		}
+
+		name := path
+		if name == "" {
+			name = "<standard input>"
+		}
+		fmt.Println(name)
+		return false
+	}
+
+	if path == "" {
+		fmt.Print(formatted)
+		return true
+	}
+
+	if formatted == code {
+		return true
+	}
+
+	err = write(path, formatted)
+	if err != nil {
+		printError(err, path, code)
+		return false
+	}
+
+	return true
+}
+
+func read(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// write replaces the contents of the file at the given path,
+// keeping its permissions
+//
+func write(path string, contents string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, []byte(contents), info.Mode().Perm())
+}
+
+func printError(err error, path string, code string) {
+	if parseErr, ok := err.(parser2.Error); ok {
+		location := common.StringLocation(path)
+		printErr := pretty.NewErrorPrettyPrinter(os.Stderr, true).
+			PrettyPrintError(parseErr, location, map[common.LocationID]string{location.ID(): code})
+		if printErr != nil {
+			panic(printErr)
+		}
+		return
+	}
+
+	if path == "" {
+		_, _ = fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		return
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "error: %s: %s\n", path, err)
+}
@@ -0,0 +1,245 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/onflow/cadence/runtime/analysis"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/cmd"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/lint"
+	"github.com/onflow/cadence/runtime/pretty"
+)
+
+var enableFlag = flag.String("enable", "", "comma-separated names of the analyzers to run. all analyzers are run by default")
+var disableFlag = flag.String("disable", "", "comma-separated names of the analyzers not to run")
+var listFlag = flag.Bool("list", false, "list the available analyzers")
+var fixFlag = flag.Bool("fix", false, "apply the suggested fixes")
+
+// A linter for Cadence programs.
+//
+// The programs at the given paths are checked, and analyzed by the selected analyzers.
+//
+// Usage: go run ./runtime/cmd/lint [-enable a,b] [-disable c] [-fix] files...
+//
+func main() {
+	flag.Parse()
+
+	analyzers, err := lint.SelectAnalyzers(
+		splitNames(*enableFlag),
+		splitNames(*disableFlag),
+	)
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+
+	if *listFlag {
+		printAnalyzers(analyzers)
+		return
+	}
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		cmd.ExitWithError("no files given")
+	}
+
+	locations := make([]common.Location, 0, len(paths))
+	for _, path := range paths {
+		locations = append(locations, common.StringLocation(path))
+	}
+
+	programs, err := analysis.Load(
+		&analysis.Config{
+			ResolveCode: func(
+				location common.Location,
+				_ common.Location,
+				_ ast.Range,
+			) (
+				string,
+				error,
+			) {
+				stringLocation, ok := location.(common.StringLocation)
+				if !ok {
+					return "", fmt.Errorf("cannot import `%s`. only files are supported", location)
+				}
+
+				code, err := ioutil.ReadFile(string(stringLocation))
+				if err != nil {
+					return "", err
+				}
+				return string(code), nil
+			},
+		},
+		locations...,
+	)
+	if err != nil {
+		printErr := pretty.NewErrorPrettyPrinter(os.Stdout, true).
+			PrettyPrintError(err, nil, programs.Codes())
+		if printErr != nil {
+			panic(printErr)
+		}
+		os.Exit(1)
+	}
+
+	codes := programs.Codes()
+
+	reported := false
+
+	for _, location := range locations {
+		program := programs[location.ID()]
+
+		var diagnostics []analysis.Diagnostic
+
+		program.Run(analyzers, func(diagnostic analysis.Diagnostic) {
+			diagnostics = append(diagnostics, diagnostic)
+		})
+
+		sort.SliceStable(diagnostics, func(i, j int) bool {
+			return diagnostics[i].StartPos.Offset < diagnostics[j].StartPos.Offset
+		})
+
+		if *fixFlag {
+			diagnostics, err = fix(program, diagnostics)
+			if err != nil {
+				cmd.ExitWithError(err.Error())
+			}
+		}
+
+		for _, diagnostic := range diagnostics {
+			reported = true
+
+			printErr := pretty.NewErrorPrettyPrinter(os.Stdout, true).
+				PrettyPrintError(diagnosticError{diagnostic}, diagnostic.Location, codes)
+			if printErr != nil {
+				panic(printErr)
+			}
+			fmt.Println()
+		}
+	}
+
+	if reported {
+		os.Exit(1)
+	}
+}
+
+func splitNames(names string) []string {
+	if names == "" {
+		return nil
+	}
+
+	result := strings.Split(names, ",")
+	for i, name := range result {
+		result[i] = strings.TrimSpace(name)
+	}
+	return result
+}
+
+func printAnalyzers(analyzers []*analysis.Analyzer) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	for _, analyzer := range analyzers {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\n", analyzer.Name, analyzer.Description)
+	}
+	_ = writer.Flush()
+}
+
+// fix applies the first suggested fix of each of the given diagnostics to the program,
+// and writes the fixed code to the file of the program.
+//
+// Fixes which overlap with a previously applied fix are not applied.
+// Returns the diagnostics which were not fixed
+//
+func fix(program *analysis.Program, diagnostics []analysis.Diagnostic) ([]analysis.Diagnostic, error) {
+	var edits []analysis.TextEdit
+	var unfixed []analysis.Diagnostic
+
+	overlaps := func(fixEdits []analysis.TextEdit) bool {
+		for _, fixEdit := range fixEdits {
+			for _, edit := range edits {
+				if fixEdit.StartPos.Offset <= edit.EndPos.Offset &&
+					edit.StartPos.Offset <= fixEdit.EndPos.Offset {
+
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for _, diagnostic := range diagnostics {
+		if len(diagnostic.SuggestedFixes) == 0 {
+			unfixed = append(unfixed, diagnostic)
+			continue
+		}
+
+		fixEdits := diagnostic.SuggestedFixes[0].TextEdits
+		if overlaps(fixEdits) {
+			unfixed = append(unfixed, diagnostic)
+			continue
+		}
+
+		edits = append(edits, fixEdits...)
+	}
+
+	if len(edits) == 0 {
+		return unfixed, nil
+	}
+
+	path := string(program.Location.(common.StringLocation))
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fixedCode := analysis.ApplyTextEdits(program.Code, edits)
+
+	err = ioutil.WriteFile(path, []byte(fixedCode), info.Mode().Perm())
+	if err != nil {
+		return nil, err
+	}
+
+	return unfixed, nil
+}
+
+// diagnosticError is a diagnostic which can be pretty-printed as an error,
+// prefixed with the severity of the diagnostic
+//
+type diagnosticError struct {
+	analysis.Diagnostic
+}
+
+func (e diagnosticError) Error() string {
+	return fmt.Sprintf("%s (%s)", e.Message, e.Category)
+}
+
+func (e diagnosticError) SecondaryError() string {
+	return e.SecondaryMessage
+}
+
+func (e diagnosticError) Prefix() string {
+	return e.Severity.String()
+}
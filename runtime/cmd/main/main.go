@@ -19,6 +19,7 @@
 package main
 
 import (
+	"flag"
 	"os"
 	"os/signal"
 
@@ -26,8 +27,14 @@ import (
 	"github.com/onflow/cadence/runtime/interpreter"
 )
 
+var debugFlag = flag.Bool("debug", false, "stop before the first statement is executed, e.g. to add breakpoints")
+
 func main() {
-	if len(os.Args) > 1 {
+	flag.Parse()
+
+	args := flag.Args()
+
+	if len(args) > 0 {
 		// TODO: also make the REPL support the interactive debugger
 
 		signals := make(chan os.Signal, 1)
@@ -36,15 +43,26 @@ func main() {
 
 		debugger := interpreter.NewDebugger()
 
+		if *debugFlag {
+			debugger.RequestPause()
+		}
+
 		go func() {
 			for range signals {
-				stop := debugger.Pause()
+				debugger.RequestPause()
+			}
+		}()
+
+		// The program is stopped when a pause was requested, or when a breakpoint is hit
+
+		go func() {
+			for stop := range debugger.Stops() {
 				execute.NewInteractiveDebugger(debugger, stop).Run()
 				debugger.Continue()
 			}
 		}()
 
-		execute.Execute(os.Args[1:], debugger)
+		execute.Execute(args, debugger)
 	} else {
 		execute.RunREPL()
 	}
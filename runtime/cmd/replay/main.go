@@ -0,0 +1,150 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/cmd"
+	"github.com/onflow/cadence/runtime/cmd/execute"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+var debugFlag = flag.Bool("debug", false, "stop before the first statement is executed, e.g. to add breakpoints")
+var scriptFlag = flag.Bool("script", false, "execute the code as a script instead of as a transaction")
+var idFlag = flag.String("id", "", "hex-encoded ID of the recorded transaction or script")
+
+// Replays a recorded execution of a transaction or script offline.
+//
+// The calls of the runtime interface, recorded with runtime.RecordingInterface,
+// are read from the recording file, and the given transaction or script is executed
+// with the recorded results of the calls.
+//
+// Usage: go run ./runtime/cmd/replay [-debug] [-script] [-id hex] recording.json code.cdc
+//
+func main() {
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 2 {
+		cmd.ExitWithError("usage: replay [-debug] [-script] [-id hex] recording.json code.cdc")
+	}
+
+	recording, err := runtime.ReadInterfaceRecordingFile(args[0])
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+
+	code, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+
+	arguments, err := recording.Arguments()
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+
+	id, err := hex.DecodeString(*idFlag)
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+
+	runtimeInterface := runtime.NewReplayingInterface(recording)
+
+	options := []runtime.Option{
+		runtime.WithAtreeValidationEnabled(true),
+	}
+
+	if *debugFlag {
+		options = append(options, runtime.WithDebugger(newDebugger()))
+	}
+
+	rt := runtime.NewInterpreterRuntime(options...)
+
+	script := runtime.Script{
+		Source:    code,
+		Arguments: arguments,
+	}
+
+	if *scriptFlag {
+		var result cadence.Value
+		result, err = rt.ExecuteScript(
+			script,
+			runtime.Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation(id),
+			},
+		)
+		if err == nil {
+			fmt.Println(result)
+		}
+	} else {
+		err = rt.ExecuteTransaction(
+			script,
+			runtime.Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation(id),
+			},
+		)
+	}
+
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+
+	if remaining := runtimeInterface.Remaining(); remaining > 0 {
+		cmd.ExitWithError(fmt.Sprintf("%d recorded calls were not replayed", remaining))
+	}
+}
+
+// newDebugger returns a new debugger which is paused before the first statement,
+// and which can be paused by interrupting the process.
+// The interactive debugger is started when the program is stopped
+//
+func newDebugger() *interpreter.Debugger {
+	debugger := interpreter.NewDebugger()
+	debugger.RequestPause()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+
+	go func() {
+		for range signals {
+			debugger.RequestPause()
+		}
+	}()
+
+	go func() {
+		for stop := range debugger.Stops() {
+			execute.NewInteractiveDebugger(debugger, stop).Run()
+			debugger.Continue()
+		}
+	}()
+
+	return debugger
+}
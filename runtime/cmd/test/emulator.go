@@ -0,0 +1,270 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+// emulatorBackend is the test framework which provides the blockchain functionality
+// of the `Test` contract, by executing scripts and transactions
+// using a runtime backed by an in-memory runtime interface.
+//
+type emulatorBackend struct {
+	runtime                 runtime.Runtime
+	runtimeInterface        *emulatorInterface
+	pendingTransactions     []*emulatorTransaction
+	nextScriptLocation      uint64
+	nextTransactionLocation uint64
+}
+
+type emulatorTransaction struct {
+	code        []byte
+	authorizers []common.Address
+	arguments   [][]byte
+}
+
+var _ stdlib.TestFramework = &emulatorBackend{}
+
+func newEmulatorBackend() *emulatorBackend {
+	return &emulatorBackend{
+		runtime:          runtime.NewInterpreterRuntime(),
+		runtimeInterface: newEmulatorInterface(),
+	}
+}
+
+func (e *emulatorBackend) newScriptLocation() common.ScriptLocation {
+	e.nextScriptLocation++
+	return common.ScriptLocation(fmt.Sprintf("%x", e.nextScriptLocation))
+}
+
+func (e *emulatorBackend) newTransactionLocation() common.TransactionLocation {
+	e.nextTransactionLocation++
+	return common.TransactionLocation(fmt.Sprintf("%x", e.nextTransactionLocation))
+}
+
+func (e *emulatorBackend) RunScript(
+	inter *interpreter.Interpreter,
+	code string,
+	arguments []interpreter.Value,
+) *stdlib.ScriptResult {
+
+	encodedArguments, err := encodeArguments(inter, arguments)
+	if err != nil {
+		return &stdlib.ScriptResult{
+			Error: err,
+		}
+	}
+
+	result, err := e.runtime.ExecuteScript(
+		runtime.Script{
+			Source:    []byte(code),
+			Arguments: encodedArguments,
+		},
+		runtime.Context{
+			Interface: e.runtimeInterface,
+			Location:  e.newScriptLocation(),
+		},
+	)
+	if err != nil {
+		return &stdlib.ScriptResult{
+			Error: err,
+		}
+	}
+
+	value, err := runtime.ImportValue(inter, result, nil)
+	if err != nil {
+		return &stdlib.ScriptResult{
+			Error: err,
+		}
+	}
+
+	return &stdlib.ScriptResult{
+		Value: value,
+	}
+}
+
+func (e *emulatorBackend) CreateAccount() (*stdlib.Account, error) {
+	address, err := e.runtimeInterface.CreateAccount(common.Address{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &stdlib.Account{
+		Address: address,
+	}, nil
+}
+
+func (e *emulatorBackend) AddTransaction(
+	inter *interpreter.Interpreter,
+	code string,
+	authorizers []common.Address,
+	arguments []interpreter.Value,
+) error {
+
+	encodedArguments, err := encodeArguments(inter, arguments)
+	if err != nil {
+		return err
+	}
+
+	e.pendingTransactions = append(
+		e.pendingTransactions,
+		&emulatorTransaction{
+			code:        []byte(code),
+			authorizers: authorizers,
+			arguments:   encodedArguments,
+		},
+	)
+
+	return nil
+}
+
+func (e *emulatorBackend) ExecuteNextTransaction() *stdlib.TransactionResult {
+	if len(e.pendingTransactions) == 0 {
+		return nil
+	}
+
+	transaction := e.pendingTransactions[0]
+	e.pendingTransactions = e.pendingTransactions[1:]
+
+	return &stdlib.TransactionResult{
+		Error: e.executeTransaction(transaction),
+	}
+}
+
+func (e *emulatorBackend) executeTransaction(transaction *emulatorTransaction) error {
+	e.runtimeInterface.signingAccounts = transaction.authorizers
+	defer func() {
+		e.runtimeInterface.signingAccounts = nil
+	}()
+
+	return e.runtime.ExecuteTransaction(
+		runtime.Script{
+			Source:    transaction.code,
+			Arguments: transaction.arguments,
+		},
+		runtime.Context{
+			Interface: e.runtimeInterface,
+			Location:  e.newTransactionLocation(),
+		},
+	)
+}
+
+func (e *emulatorBackend) CommitBlock() error {
+	pendingCount := len(e.pendingTransactions)
+	if pendingCount > 0 {
+		return fmt.Errorf(
+			"cannot commit block: %d transaction(s) not executed",
+			pendingCount,
+		)
+	}
+
+	e.runtimeInterface.blockHeight++
+
+	return nil
+}
+
+func (e *emulatorBackend) DeployContract(
+	inter *interpreter.Interpreter,
+	name string,
+	code string,
+	account *stdlib.Account,
+	arguments []interpreter.Value,
+) error {
+
+	// Deploy the contract using a transaction,
+	// which passes the code and the initializer arguments as transaction arguments
+
+	encodedCode, err := json.Encode(cadence.String(hex.EncodeToString([]byte(code))))
+	if err != nil {
+		return err
+	}
+
+	encodedInitializerArguments, err := encodeArguments(inter, arguments)
+	if err != nil {
+		return err
+	}
+
+	encodedArguments := append([][]byte{encodedCode}, encodedInitializerArguments...)
+
+	// The types of the transaction parameters are the types of the given arguments
+
+	var parameters strings.Builder
+	var initializerArguments strings.Builder
+
+	for i, argument := range arguments {
+		parameterType, err := inter.ConvertStaticToSemaType(argument.StaticType())
+		if err != nil {
+			return err
+		}
+
+		parameterName := fmt.Sprintf("arg%d", i)
+
+		fmt.Fprintf(&parameters, ", %s: %s", parameterName, parameterType.QualifiedString())
+		fmt.Fprintf(&initializerArguments, ", %s", parameterName)
+	}
+
+	script := fmt.Sprintf(
+		`
+          transaction(code: String%s) {
+              prepare(signer: AuthAccount) {
+                  signer.contracts.add(name: "%s", code: code.decodeHex()%s)
+              }
+          }
+        `,
+		parameters.String(),
+		name,
+		initializerArguments.String(),
+	)
+
+	return e.executeTransaction(
+		&emulatorTransaction{
+			code:        []byte(script),
+			authorizers: []common.Address{account.Address},
+			arguments:   encodedArguments,
+		},
+	)
+}
+
+func encodeArguments(inter *interpreter.Interpreter, arguments []interpreter.Value) ([][]byte, error) {
+	encodedArguments := make([][]byte, len(arguments))
+
+	for i, argument := range arguments {
+		exportedArgument, err := runtime.ExportValue(argument, inter)
+		if err != nil {
+			return nil, err
+		}
+
+		encodedArguments[i], err = json.Encode(exportedArgument)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return encodedArguments, nil
+}
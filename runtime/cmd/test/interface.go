@@ -0,0 +1,427 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/onflow/atree"
+	"github.com/opentracing/opentracing-go"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// emulatorInterface is an in-memory implementation of the runtime interface.
+// All account state, i.e. the ledger, the contract code, and the account keys,
+// is kept in memory and lost when the emulator is discarded.
+//
+type emulatorInterface struct {
+	ledger          map[string][]byte
+	storageIndices  map[string]uint64
+	contracts       map[common.Address]map[string][]byte
+	accountKeys     map[common.Address][]*runtime.AccountKey
+	programs        map[common.LocationID]*interpreter.Program
+	nextAddress     uint64
+	nextUUID        uint64
+	blockHeight     uint64
+	signingAccounts []runtime.Address
+}
+
+var _ runtime.Interface = &emulatorInterface{}
+
+func newEmulatorInterface() *emulatorInterface {
+	return &emulatorInterface{
+		ledger:         map[string][]byte{},
+		storageIndices: map[string]uint64{},
+		contracts:      map[common.Address]map[string][]byte{},
+		accountKeys:    map[common.Address][]*runtime.AccountKey{},
+		programs:       map[common.LocationID]*interpreter.Program{},
+		nextAddress:    1,
+	}
+}
+
+func ledgerKey(owner, key []byte) string {
+	return string(owner) + "|" + string(key)
+}
+
+func (i *emulatorInterface) ResolveLocation(
+	identifiers []runtime.Identifier,
+	location runtime.Location,
+) ([]runtime.ResolvedLocation, error) {
+
+	addressLocation, isAddress := location.(common.AddressLocation)
+
+	// If the location is not an address location, e.g. an identifier or a string location,
+	// or it is an address location but identifiers are given, use it as-is
+
+	if !isAddress || addressLocation.Name != "" {
+		return []runtime.ResolvedLocation{
+			{
+				Location:    location,
+				Identifiers: identifiers,
+			},
+		}, nil
+	}
+
+	// If no identifiers are given, import all contracts deployed to the account
+
+	if len(identifiers) == 0 {
+		contractNames, err := i.GetAccountContractNames(addressLocation.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		identifiers = make([]runtime.Identifier, len(contractNames))
+		for index, name := range contractNames {
+			identifiers[index] = runtime.Identifier{
+				Identifier: name,
+			}
+		}
+	}
+
+	// Each identifier refers to a contract in the account
+
+	resolvedLocations := make([]runtime.ResolvedLocation, len(identifiers))
+	for index, identifier := range identifiers {
+		resolvedLocations[index] = runtime.ResolvedLocation{
+			Location: common.AddressLocation{
+				Address: addressLocation.Address,
+				Name:    identifier.Identifier,
+			},
+			Identifiers: []runtime.Identifier{identifier},
+		}
+	}
+
+	return resolvedLocations, nil
+}
+
+func (i *emulatorInterface) GetCode(location runtime.Location) ([]byte, error) {
+	return nil, fmt.Errorf("cannot get code: unsupported location: %s", location)
+}
+
+func (i *emulatorInterface) GetProgram(location runtime.Location) (*interpreter.Program, error) {
+	return i.programs[location.ID()], nil
+}
+
+func (i *emulatorInterface) SetProgram(location runtime.Location, program *interpreter.Program) error {
+	i.programs[location.ID()] = program
+	return nil
+}
+
+func (i *emulatorInterface) GetValue(owner, key []byte) ([]byte, error) {
+	return i.ledger[ledgerKey(owner, key)], nil
+}
+
+func (i *emulatorInterface) SetValue(owner, key, value []byte) error {
+	if len(value) == 0 {
+		delete(i.ledger, ledgerKey(owner, key))
+	} else {
+		i.ledger[ledgerKey(owner, key)] = value
+	}
+	return nil
+}
+
+func (i *emulatorInterface) ValueExists(owner, key []byte) (bool, error) {
+	_, ok := i.ledger[ledgerKey(owner, key)]
+	return ok, nil
+}
+
+func (i *emulatorInterface) AllocateStorageIndex(owner []byte) (atree.StorageIndex, error) {
+	index := i.storageIndices[string(owner)] + 1
+	i.storageIndices[string(owner)] = index
+
+	var result atree.StorageIndex
+	binary.BigEndian.PutUint64(result[:], index)
+	return result, nil
+}
+
+func (i *emulatorInterface) CreateAccount(_ runtime.Address) (runtime.Address, error) {
+	var address runtime.Address
+	binary.BigEndian.PutUint64(address[:], i.nextAddress)
+	i.nextAddress++
+
+	i.contracts[address] = map[string][]byte{}
+
+	return address, nil
+}
+
+func (i *emulatorInterface) AddEncodedAccountKey(_ runtime.Address, _ []byte) error {
+	return fmt.Errorf("cannot add encoded account key: not supported")
+}
+
+func (i *emulatorInterface) RevokeEncodedAccountKey(_ runtime.Address, _ int) ([]byte, error) {
+	return nil, fmt.Errorf("cannot revoke encoded account key: not supported")
+}
+
+func (i *emulatorInterface) AddAccountKey(
+	address runtime.Address,
+	publicKey *runtime.PublicKey,
+	hashAlgo runtime.HashAlgorithm,
+	weight int,
+) (*runtime.AccountKey, error) {
+
+	keys := i.accountKeys[address]
+
+	accountKey := &runtime.AccountKey{
+		KeyIndex:  len(keys),
+		PublicKey: publicKey,
+		HashAlgo:  hashAlgo,
+		Weight:    weight,
+	}
+
+	i.accountKeys[address] = append(keys, accountKey)
+
+	return accountKey, nil
+}
+
+func (i *emulatorInterface) GetAccountKey(address runtime.Address, index int) (*runtime.AccountKey, error) {
+	keys := i.accountKeys[address]
+	if index < 0 || index >= len(keys) {
+		return nil, nil
+	}
+	return keys[index], nil
+}
+
+func (i *emulatorInterface) RevokeAccountKey(address runtime.Address, index int) (*runtime.AccountKey, error) {
+	keys := i.accountKeys[address]
+	if index < 0 || index >= len(keys) {
+		return nil, nil
+	}
+
+	accountKey := keys[index]
+	accountKey.IsRevoked = true
+
+	return accountKey, nil
+}
+
+func (i *emulatorInterface) UpdateAccountContractCode(address runtime.Address, name string, code []byte) error {
+	contracts, ok := i.contracts[address]
+	if !ok {
+		contracts = map[string][]byte{}
+		i.contracts[address] = contracts
+	}
+	contracts[name] = code
+	i.invalidateProgram(address, name)
+	return nil
+}
+
+func (i *emulatorInterface) GetAccountContractCode(address runtime.Address, name string) ([]byte, error) {
+	return i.contracts[address][name], nil
+}
+
+func (i *emulatorInterface) RemoveAccountContractCode(address runtime.Address, name string) error {
+	delete(i.contracts[address], name)
+	i.invalidateProgram(address, name)
+	return nil
+}
+
+// invalidateProgram removes the program of the given contract,
+// so the updated code gets parsed and checked on next use
+//
+func (i *emulatorInterface) invalidateProgram(address runtime.Address, name string) {
+	location := common.AddressLocation{
+		Address: address,
+		Name:    name,
+	}
+	delete(i.programs, location.ID())
+}
+
+func (i *emulatorInterface) GetSigningAccounts() ([]runtime.Address, error) {
+	return i.signingAccounts, nil
+}
+
+func (i *emulatorInterface) ProgramLog(message string) error {
+	fmt.Println(message)
+	return nil
+}
+
+func (i *emulatorInterface) EmitEvent(_ cadence.Event) error {
+	return nil
+}
+
+func (i *emulatorInterface) GenerateUUID() (uint64, error) {
+	uuid := i.nextUUID
+	i.nextUUID++
+	return uuid, nil
+}
+
+func (i *emulatorInterface) MeterComputation(_ common.ComputationKind, _ uint) error {
+	return nil
+}
+
+func (i *emulatorInterface) MeterMemory(_ common.MemoryKind, _ uint) error {
+	return nil
+}
+
+func (i *emulatorInterface) DecodeArgument(argument []byte, _ cadence.Type) (cadence.Value, error) {
+	return json.Decode(argument)
+}
+
+func (i *emulatorInterface) GetCurrentBlockHeight() (uint64, error) {
+	return i.blockHeight, nil
+}
+
+func (i *emulatorInterface) GetBlockAtHeight(height uint64) (runtime.Block, bool, error) {
+	if height > i.blockHeight {
+		return runtime.Block{}, false, nil
+	}
+
+	var hash runtime.BlockHash
+	binary.BigEndian.PutUint64(hash[:], height)
+
+	return runtime.Block{
+		Height:    height,
+		View:      height,
+		Hash:      hash,
+		Timestamp: time.Unix(int64(height), 0).UnixNano(),
+	}, true, nil
+}
+
+func (i *emulatorInterface) UnsafeRandom() (uint64, error) {
+	// Deterministic, so test runs are reproducible
+	return i.blockHeight, nil
+}
+
+func (i *emulatorInterface) VerifySignature(
+	_ []byte,
+	_ string,
+	_ []byte,
+	_ []byte,
+	_ runtime.SignatureAlgorithm,
+	_ runtime.HashAlgorithm,
+) (bool, error) {
+	return false, fmt.Errorf("cannot verify signature: not supported")
+}
+
+func (i *emulatorInterface) Hash(data []byte, tag string, hashAlgorithm runtime.HashAlgorithm) ([]byte, error) {
+	if tag != "" {
+		return nil, fmt.Errorf("cannot hash with tag: not supported")
+	}
+
+	switch hashAlgorithm {
+	case runtime.HashAlgorithmSHA2_256:
+		result := sha256.Sum256(data)
+		return result[:], nil
+
+	case runtime.HashAlgorithmSHA2_384:
+		result := sha512.Sum384(data)
+		return result[:], nil
+
+	case runtime.HashAlgorithmSHA3_256:
+		result := sha3.Sum256(data)
+		return result[:], nil
+
+	case runtime.HashAlgorithmSHA3_384:
+		result := sha3.Sum384(data)
+		return result[:], nil
+
+	case runtime.HashAlgorithmKECCAK_256:
+		hasher := sha3.NewLegacyKeccak256()
+		hasher.Write(data)
+		return hasher.Sum(nil), nil
+
+	default:
+		return nil, fmt.Errorf("cannot hash: unsupported hash algorithm: %s", hashAlgorithm)
+	}
+}
+
+func (i *emulatorInterface) GetAccountBalance(_ common.Address) (uint64, error) {
+	return 0, nil
+}
+
+func (i *emulatorInterface) GetAccountAvailableBalance(_ common.Address) (uint64, error) {
+	return 0, nil
+}
+
+func (i *emulatorInterface) GetStorageUsed(address runtime.Address) (uint64, error) {
+	var used uint64
+	prefix := string(address[:]) + "|"
+	for key, value := range i.ledger { //nolint:maprangecheck
+		if len(key) >= len(prefix) && key[:len(prefix)] == prefix {
+			used += uint64(len(key) + len(value))
+		}
+	}
+	return used, nil
+}
+
+func (i *emulatorInterface) GetStorageCapacity(_ runtime.Address) (uint64, error) {
+	// The emulator does not limit storage
+	return ^uint64(0), nil
+}
+
+func (i *emulatorInterface) ImplementationDebugLog(_ string) error {
+	return nil
+}
+
+func (i *emulatorInterface) ValidatePublicKey(_ *runtime.PublicKey) error {
+	return nil
+}
+
+func (i *emulatorInterface) GetAccountContractNames(address runtime.Address) ([]string, error) {
+	contracts := i.contracts[address]
+
+	names := make([]string, 0, len(contracts))
+	for name := range contracts { //nolint:maprangecheck
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (i *emulatorInterface) RecordTrace(
+	_ string,
+	_ common.Location,
+	_ time.Duration,
+	_ []opentracing.LogRecord,
+) {
+	// NO-OP
+}
+
+func (i *emulatorInterface) BLSVerifyPOP(_ *runtime.PublicKey, _ []byte) (bool, error) {
+	return false, fmt.Errorf("cannot verify proof of possession: not supported")
+}
+
+func (i *emulatorInterface) BLSAggregateSignatures(_ [][]byte) ([]byte, error) {
+	return nil, fmt.Errorf("cannot aggregate signatures: not supported")
+}
+
+func (i *emulatorInterface) BLSAggregatePublicKeys(_ []*runtime.PublicKey) (*runtime.PublicKey, error) {
+	return nil, fmt.Errorf("cannot aggregate public keys: not supported")
+}
+
+func (i *emulatorInterface) ResourceOwnerChanged(
+	_ *interpreter.Interpreter,
+	_ *interpreter.CompositeValue,
+	_ common.Address,
+	_ common.Address,
+) {
+	// NO-OP
+}
@@ -0,0 +1,79 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/onflow/cadence/runtime/cmd"
+	"github.com/onflow/cadence/runtime/pretty"
+)
+
+var runFlag = flag.String("run", "", "run only the test functions matching the regular expression")
+
+func main() {
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		cmd.ExitWithError("no test files given")
+	}
+
+	var filter *regexp.Regexp
+	if *runFlag != "" {
+		var err error
+		filter, err = regexp.Compile(*runFlag)
+		if err != nil {
+			cmd.ExitWithError(fmt.Sprintf("invalid run pattern: %s", err))
+		}
+	}
+
+	passed := 0
+	failed := 0
+
+	for _, path := range paths {
+		runner := newTestRunner(path, filter)
+
+		for _, result := range runner.run() {
+			if result.err == nil {
+				passed++
+				fmt.Printf("%s %s\n", colorizeResult("PASS", true), result.name)
+				continue
+			}
+
+			failed++
+			fmt.Printf("%s %s\n", colorizeResult("FAIL", false), result.name)
+
+			printErr := pretty.NewErrorPrettyPrinter(os.Stdout, true).
+				PrettyPrintError(result.err, result.location, runner.codes)
+			if printErr != nil {
+				panic(printErr)
+			}
+		}
+	}
+
+	fmt.Printf("\n%d passed, %d failed\n", passed, failed)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
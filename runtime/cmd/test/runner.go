@@ -0,0 +1,329 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+const testFunctionPrefix = "test"
+const setupFunctionName = "setup"
+
+var valueDeclarations = append(
+	stdlib.FlowBuiltInFunctions(stdlib.DefaultFlowBuiltinImpls()),
+	stdlib.BuiltinFunctions...,
+)
+
+var typeDeclarations = append(
+	stdlib.FlowBuiltInTypes,
+	stdlib.BuiltinTypes...,
+).ToTypeDeclarations()
+
+func colorizeResult(result string, succeeded bool) string {
+	if succeeded {
+		return aurora.Green(result).String()
+	}
+	return aurora.Red(result).String()
+}
+
+type testResult struct {
+	name     string
+	location common.Location
+	err      error
+}
+
+// testRunner runs the test functions declared in a file.
+//
+// Test functions are public functions which have no parameters,
+// and which have a name that starts with `test`.
+// Each test function is run in isolation, i.e. in a new interpreter,
+// with empty storage, and a new emulator blockchain.
+//
+// If the file declares a public function named `setup`,
+// it is run before each test function.
+//
+type testRunner struct {
+	path     string
+	filter   *regexp.Regexp
+	codes    map[common.LocationID]string
+	checkers map[common.LocationID]*sema.Checker
+}
+
+func newTestRunner(path string, filter *regexp.Regexp) *testRunner {
+	return &testRunner{
+		path:     path,
+		filter:   filter,
+		codes:    map[common.LocationID]string{},
+		checkers: map[common.LocationID]*sema.Checker{},
+	}
+}
+
+func (r *testRunner) run() []testResult {
+	location := common.StringLocation(r.path)
+
+	checker, err := r.check(location)
+	if err != nil {
+		return []testResult{
+			{
+				name:     r.path,
+				location: location,
+				err:      err,
+			},
+		}
+	}
+
+	var testFunctionNames []string
+	hasSetup := false
+
+	for _, declaration := range checker.Program.FunctionDeclarations() {
+		if declaration.Access != ast.AccessPublic ||
+			declaration.ParameterList != nil && len(declaration.ParameterList.Parameters) > 0 {
+
+			continue
+		}
+
+		name := declaration.Identifier.Identifier
+
+		if name == setupFunctionName {
+			hasSetup = true
+			continue
+		}
+
+		if !strings.HasPrefix(name, testFunctionPrefix) {
+			continue
+		}
+
+		if r.filter != nil && !r.filter.MatchString(name) {
+			continue
+		}
+
+		testFunctionNames = append(testFunctionNames, name)
+	}
+
+	results := make([]testResult, 0, len(testFunctionNames))
+
+	for _, name := range testFunctionNames {
+		results = append(
+			results,
+			testResult{
+				name:     fmt.Sprintf("%s:%s", r.path, name),
+				location: location,
+				err:      r.runTest(checker, name, hasSetup),
+			},
+		)
+	}
+
+	return results
+}
+
+func (r *testRunner) runTest(checker *sema.Checker, name string, hasSetup bool) error {
+	inter, err := r.newInterpreter(checker, newEmulatorBackend())
+	if err != nil {
+		return err
+	}
+
+	err = inter.Interpret()
+	if err != nil {
+		return err
+	}
+
+	if hasSetup {
+		_, err = inter.Invoke(setupFunctionName)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = inter.Invoke(name)
+	return err
+}
+
+// check parses and checks the program in the given file,
+// and all files it imports
+//
+func (r *testRunner) check(location common.StringLocation) (*sema.Checker, error) {
+
+	checker, ok := r.checkers[location.ID()]
+	if ok {
+		return checker, nil
+	}
+
+	code, err := ioutil.ReadFile(string(location))
+	if err != nil {
+		return nil, err
+	}
+
+	r.codes[location.ID()] = string(code)
+
+	program, err := parser2.ParseProgram(string(code))
+	if err != nil {
+		return nil, err
+	}
+
+	checker, err = sema.NewChecker(
+		program,
+		location,
+		sema.WithPredeclaredValues(valueDeclarations.ToSemaValueDeclarations()),
+		sema.WithPredeclaredTypes(typeDeclarations),
+		sema.WithImportHandler(
+			func(_ *sema.Checker, importedLocation common.Location, _ ast.Range) (sema.Import, error) {
+				var elaboration *sema.Elaboration
+
+				switch importedLocation := importedLocation.(type) {
+				case common.IdentifierLocation:
+					if importedLocation != stdlib.TestContractLocation {
+						return nil, fmt.Errorf("cannot import `%s`: unknown identifier", importedLocation)
+					}
+					elaboration = stdlib.TestContractChecker.Elaboration
+
+				case common.StringLocation:
+					importedChecker, err := r.check(importedLocation)
+					if err != nil {
+						return nil, err
+					}
+					elaboration = importedChecker.Elaboration
+
+				default:
+					return nil, fmt.Errorf("cannot import `%s`: only files and `Test` are supported", importedLocation)
+				}
+
+				return sema.ElaborationImport{
+					Elaboration: elaboration,
+				}, nil
+			},
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checker.Check()
+	if err != nil {
+		return nil, err
+	}
+
+	r.checkers[location.ID()] = checker
+
+	return checker, nil
+}
+
+func (r *testRunner) newInterpreter(
+	checker *sema.Checker,
+	testFramework stdlib.TestFramework,
+) (*interpreter.Interpreter, error) {
+
+	var uuid uint64
+
+	return interpreter.NewInterpreter(
+		interpreter.ProgramFromChecker(checker),
+		checker.Location,
+		interpreter.WithStorage(interpreter.NewInMemoryStorage()),
+		interpreter.WithPredeclaredValues(valueDeclarations.ToInterpreterValueDeclarations()),
+		interpreter.WithUUIDHandler(func() (uint64, error) {
+			defer func() { uuid++ }()
+			return uuid, nil
+		}),
+		interpreter.WithImportLocationHandler(
+			func(inter *interpreter.Interpreter, location common.Location) interpreter.Import {
+				var importedChecker *sema.Checker
+
+				switch location {
+				case stdlib.TestContractLocation:
+					importedChecker = stdlib.TestContractChecker
+
+				default:
+					importedChecker = r.checkers[location.ID()]
+					if importedChecker == nil {
+						panic(fmt.Errorf("cannot import `%s`: not checked", location))
+					}
+				}
+
+				subInterpreter, err := inter.NewSubInterpreter(
+					interpreter.ProgramFromChecker(importedChecker),
+					location,
+				)
+				if err != nil {
+					panic(err)
+				}
+
+				return interpreter.InterpreterImport{
+					Interpreter: subInterpreter,
+				}
+			},
+		),
+		interpreter.WithContractValueHandler(
+			func(
+				inter *interpreter.Interpreter,
+				compositeType *sema.CompositeType,
+				constructorGenerator func(common.Address) *interpreter.HostFunctionValue,
+				invocationRange ast.Range,
+			) *interpreter.CompositeValue {
+
+				constructor := constructorGenerator(common.Address{})
+
+				var contract *interpreter.CompositeValue
+				var err error
+
+				switch compositeType.Location {
+				case stdlib.TestContractLocation:
+					contract, err = stdlib.NewTestContract(
+						inter,
+						constructor,
+						invocationRange,
+						testFramework,
+					)
+
+				default:
+					// Contracts declared in test files and imported files
+					// are instantiated using the initializer, which may not have parameters
+
+					var value interpreter.Value
+					value, err = inter.InvokeFunctionValue(
+						constructor,
+						nil,
+						nil,
+						nil,
+						invocationRange,
+					)
+					if err == nil {
+						contract = value.(*interpreter.CompositeValue)
+					}
+				}
+
+				if err != nil {
+					panic(err)
+				}
+
+				return contract
+			},
+		),
+	)
+}
@@ -32,7 +32,7 @@ const (
 	ComputationKindStatement ComputationKind = ComputationKindRangeStart + iota
 	ComputationKindLoop
 	ComputationKindFunctionInvocation
-	_
+	ComputationKindStorageIteration
 	_
 	_
 	_
@@ -84,8 +84,8 @@ const (
 	_
 	_
 	_
-	_
-	_
+	ComputationKindSearchStringValue
+	ComputationKindSplitStringValue
 	_
 	_
 	_
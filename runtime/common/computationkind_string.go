@@ -12,6 +12,7 @@ func _() {
 	_ = x[ComputationKindStatement-1001]
 	_ = x[ComputationKindLoop-1002]
 	_ = x[ComputationKindFunctionInvocation-1003]
+	_ = x[ComputationKindStorageIteration-1004]
 	_ = x[ComputationKindCreateCompositeValue-1010]
 	_ = x[ComputationKindTransferCompositeValue-1011]
 	_ = x[ComputationKindDestroyCompositeValue-1012]
@@ -21,6 +22,8 @@ func _() {
 	_ = x[ComputationKindCreateDictionaryValue-1040]
 	_ = x[ComputationKindTransferDictionaryValue-1041]
 	_ = x[ComputationKindDestroyDictionaryValue-1042]
+	_ = x[ComputationKindSearchStringValue-1055]
+	_ = x[ComputationKindSplitStringValue-1056]
 	_ = x[ComputationKindSTDLIBPanic-1100]
 	_ = x[ComputationKindSTDLIBAssert-1101]
 	_ = x[ComputationKindSTDLIBUnsafeRandom-1102]
@@ -30,28 +33,30 @@ func _() {
 
 const (
 	_ComputationKind_name_0 = "Unknown"
-	_ComputationKind_name_1 = "StatementLoopFunctionInvocation"
+	_ComputationKind_name_1 = "StatementLoopFunctionInvocationStorageIteration"
 	_ComputationKind_name_2 = "CreateCompositeValueTransferCompositeValueDestroyCompositeValue"
 	_ComputationKind_name_3 = "CreateArrayValueTransferArrayValueDestroyArrayValue"
 	_ComputationKind_name_4 = "CreateDictionaryValueTransferDictionaryValueDestroyDictionaryValue"
-	_ComputationKind_name_5 = "STDLIBPanicSTDLIBAssertSTDLIBUnsafeRandom"
-	_ComputationKind_name_6 = "STDLIBRLPDecodeStringSTDLIBRLPDecodeList"
+	_ComputationKind_name_5 = "SearchStringValueSplitStringValue"
+	_ComputationKind_name_6 = "STDLIBPanicSTDLIBAssertSTDLIBUnsafeRandom"
+	_ComputationKind_name_7 = "STDLIBRLPDecodeStringSTDLIBRLPDecodeList"
 )
 
 var (
-	_ComputationKind_index_1 = [...]uint8{0, 9, 13, 31}
+	_ComputationKind_index_1 = [...]uint8{0, 9, 13, 31, 47}
 	_ComputationKind_index_2 = [...]uint8{0, 20, 42, 63}
 	_ComputationKind_index_3 = [...]uint8{0, 16, 34, 51}
 	_ComputationKind_index_4 = [...]uint8{0, 21, 44, 66}
-	_ComputationKind_index_5 = [...]uint8{0, 11, 23, 41}
-	_ComputationKind_index_6 = [...]uint8{0, 21, 40}
+	_ComputationKind_index_5 = [...]uint8{0, 17, 33}
+	_ComputationKind_index_6 = [...]uint8{0, 11, 23, 41}
+	_ComputationKind_index_7 = [...]uint8{0, 21, 40}
 )
 
 func (i ComputationKind) String() string {
 	switch {
 	case i == 0:
 		return _ComputationKind_name_0
-	case 1001 <= i && i <= 1003:
+	case 1001 <= i && i <= 1004:
 		i -= 1001
 		return _ComputationKind_name_1[_ComputationKind_index_1[i]:_ComputationKind_index_1[i+1]]
 	case 1010 <= i && i <= 1012:
@@ -63,12 +68,15 @@ func (i ComputationKind) String() string {
 	case 1040 <= i && i <= 1042:
 		i -= 1040
 		return _ComputationKind_name_4[_ComputationKind_index_4[i]:_ComputationKind_index_4[i+1]]
+	case 1055 <= i && i <= 1056:
+		i -= 1055
+		return _ComputationKind_name_5[_ComputationKind_index_5[i]:_ComputationKind_index_5[i+1]]
 	case 1100 <= i && i <= 1102:
 		i -= 1100
-		return _ComputationKind_name_5[_ComputationKind_index_5[i]:_ComputationKind_index_5[i+1]]
+		return _ComputationKind_name_6[_ComputationKind_index_6[i]:_ComputationKind_index_6[i+1]]
 	case 1108 <= i && i <= 1109:
 		i -= 1108
-		return _ComputationKind_name_6[_ComputationKind_index_6[i]:_ComputationKind_index_6[i+1]]
+		return _ComputationKind_name_7[_ComputationKind_index_7[i]:_ComputationKind_index_7[i+1]]
 	default:
 		return "ComputationKind(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -0,0 +1,72 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=MemoryKind -trimprefix=MemoryKind
+
+// MemoryKind captures kind of memory that would be used for metering memory
+type MemoryKind uint
+
+const (
+	MemoryKindUnknown MemoryKind = iota
+
+	// interpreter values
+
+	// MemoryKindString is the memory used by a string.
+	// The amount is the number of bytes of the string
+	MemoryKindString
+	// MemoryKindStringValue is the memory used by a string value, excluding its bytes.
+	// The amount is the number of string values
+	MemoryKindStringValue
+	// MemoryKindStringCharacterIndex is the memory used to find occurrences in a string
+	// which start and end at character boundaries.
+	// The amount is the number of bytes of the string plus one
+	MemoryKindStringCharacterIndex
+	// MemoryKindArray is the memory used by an array, excluding its elements.
+	// The amount is the number of arrays
+	MemoryKindArray
+	// MemoryKindArrayElement is the memory used by the elements of an array.
+	// The amount is the number of elements
+	MemoryKindArrayElement
+	// MemoryKindDictionary is the memory used by a dictionary, excluding its entries.
+	// The amount is the number of dictionaries
+	MemoryKindDictionary
+	// MemoryKindDictionaryEntry is the memory used by the entries of a dictionary.
+	// The amount is the number of entries
+	MemoryKindDictionaryEntry
+	// MemoryKindComposite is the memory used by a composite, excluding its fields.
+	// The amount is the number of composites
+	MemoryKindComposite
+	// MemoryKindCompositeField is the memory used by the fields of a composite.
+	// The amount is the number of fields
+	MemoryKindCompositeField
+	// MemoryKindBigInt is the memory used by an arbitrary-precision integer.
+	// The amount is the number of bytes of the integer
+	MemoryKindBigInt
+
+	// parsing and checking
+
+	// MemoryKindASTElement is the memory used by the AST of a parsed program.
+	// The amount is the number of elements of the AST
+	MemoryKindASTElement
+	// MemoryKindElaboration is the memory used by the elaboration of a checked program.
+	// The elaboration records information for the elements of the AST,
+	// so the amount is the number of elements of the checked AST
+	MemoryKindElaboration
+)
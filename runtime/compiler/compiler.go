@@ -377,6 +377,11 @@ func (compiler *Compiler) VisitEnumCaseDeclaration(_ *ast.EnumCaseDeclaration) a
 	panic(errors.NewUnreachableError())
 }
 
+func (compiler *Compiler) VisitTypeAliasDeclaration(_ *ast.TypeAliasDeclaration) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func compileBinaryOperation(operation ast.Operation) ir.BinOp {
 	// TODO: add remaining operations
 	switch operation {
@@ -0,0 +1,294 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+// TestRuntimeConcurrentScriptExecution executes scripts in parallel on one runtime,
+// sharing the programs of the imported contracts.
+// Run with the race detector enabled
+//
+func TestRuntimeConcurrentScriptExecution(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	address := common.Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}
+
+	contract := []byte(`
+      pub contract Test {
+
+          pub struct Point {
+              pub let x: Int
+              pub let y: Int
+
+              init(x: Int, y: Int) {
+                  self.x = x
+                  self.y = y
+              }
+          }
+
+          pub resource Counter {
+              pub var count: Int
+
+              init() {
+                  self.count = 0
+              }
+
+              pub fun increment() {
+                  self.count = self.count + 1
+                  emit Incremented(count: self.count)
+              }
+          }
+
+          pub enum Direction: UInt8 {
+              pub case up
+              pub case down
+          }
+
+          pub event Incremented(count: Int)
+
+          pub let origin: Point
+
+          init() {
+              self.origin = Point(x: 0, y: 0)
+              self.account.save(<-create Counter(), to: /storage/counter)
+              self.account.link<&Counter>(/public/counter, target: /storage/counter)
+          }
+
+          pub fun sum(_ values: [Int]): Int {
+              var sum = 0
+              for value in values {
+                  sum = sum + value
+              }
+              return sum
+          }
+      }
+    `)
+
+	accountCodes := map[common.LocationID][]byte{}
+	ledger := newTestLedger(nil, nil)
+
+	newRuntimeInterface := func(ledger testLedger) *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage: ledger,
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			resolveLocation: func(identifiers []Identifier, location Location) ([]ResolvedLocation, error) {
+				if _, ok := location.(common.AddressLocation); !ok {
+					return []ResolvedLocation{
+						{
+							Location:    location,
+							Identifiers: identifiers,
+						},
+					}, nil
+				}
+				return singleIdentifierLocationResolver(t)(identifiers, location)
+			},
+			getAccountContractCode: func(address Address, name string) ([]byte, error) {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				return accountCodes[location.ID()], nil
+			},
+			updateAccountContractCode: func(address Address, name string, code []byte) error {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				accountCodes[location.ID()] = code
+				return nil
+			},
+			emitEvent: func(_ cadence.Event) error {
+				return nil
+			},
+			decodeArgument: func(b []byte, _ cadence.Type) (cadence.Value, error) {
+				return jsoncdc.Decode(b)
+			},
+		}
+	}
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: utils.DeploymentTransaction("Test", contract),
+		},
+		Context{
+			Interface: newRuntimeInterface(ledger),
+			Location:  common.TransactionLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	// The programs are shared by all executions
+
+	var programsLock sync.Mutex
+	programs := map[common.LocationID]*interpreter.Program{}
+
+	getProgram := func(location Location) (*interpreter.Program, error) {
+		programsLock.Lock()
+		defer programsLock.Unlock()
+
+		return programs[location.ID()], nil
+	}
+
+	setProgram := func(location Location, program *interpreter.Program) error {
+		programsLock.Lock()
+		defer programsLock.Unlock()
+
+		programs[location.ID()] = program
+		return nil
+	}
+
+	script := []byte(`
+      import Test from 0x1
+      import Crypto
+
+      pub fun main(n: Int): [AnyStruct] {
+          let point = Test.Point(x: n, y: n * 2)
+
+          let values: [Int] = []
+          var i = 0
+          while i < n {
+              values.append(i)
+              i = i + 1
+          }
+
+          let counter = getAccount(0x1)
+              .getCapability<&Test.Counter>(/public/counter)
+              .borrow()!
+          counter.increment()
+
+          let names: {String: Int} = {"a": 1, "b": 2}
+
+          return [
+              point.y,
+              Test.sum(values),
+              Test.origin.x,
+              counter.count,
+              names["a"]!,
+              (point as AnyStruct as? Test.Point)?.x ?? -1,
+              point.getType().identifier,
+              Type<[Test.Point]>().identifier,
+              point.isInstance(Type<Test.Point>()),
+              Test.Direction(rawValue: 1)!.rawValue,
+              String.encodeHex([1, 2]),
+              Crypto.KeyList().get(keyIndex: 0) == nil,
+              "n=".concat(n.toString()).toLower(),
+              HashAlgorithm.SHA3_256.rawValue
+          ]
+      }
+    `)
+
+	const concurrency = 10
+	const iterations = 20
+
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+
+	errs := make(chan error, concurrency)
+
+	for worker := 0; worker < concurrency; worker++ {
+
+		go func(worker int) {
+			defer wg.Done()
+
+			// Each execution reads from its own copy of the ledger,
+			// as scripts may write to it when committing
+
+			workerLedger := newTestLedger(nil, nil)
+			for key, value := range ledger.storedValues {
+				workerLedger.storedValues[key] = value
+			}
+
+			runtimeInterface := newRuntimeInterface(workerLedger)
+			runtimeInterface.getProgram = getProgram
+			runtimeInterface.setProgram = setProgram
+
+			for iteration := 0; iteration < iterations; iteration++ {
+				n := worker + iteration
+
+				argument, err := jsoncdc.Encode(cadence.NewInt(n))
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				result, err := runtime.ExecuteScript(
+					Script{
+						Source:    script,
+						Arguments: [][]byte{argument},
+					},
+					Context{
+						Interface: runtimeInterface,
+						Location:  common.ScriptLocation{byte(worker), byte(iteration)},
+					},
+				)
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				expected := cadence.NewArray([]cadence.Value{
+					cadence.NewInt(n * 2),
+					cadence.NewInt(n * (n - 1) / 2),
+					cadence.NewInt(0),
+					// The counter of each worker is incremented in each iteration
+					cadence.NewInt(iteration+1),
+					cadence.NewInt(1),
+					cadence.NewInt(n),
+					cadence.String("A.0000000000000001.Test.Point"),
+					cadence.String("[A.0000000000000001.Test.Point]"),
+					cadence.NewBool(true),
+					cadence.NewUInt8(1),
+					cadence.String("0102"),
+					cadence.NewBool(true),
+					cadence.String(fmt.Sprintf("n=%d", n)),
+					cadence.NewUInt8(3),
+				})
+
+				if !assert.Equal(t, expected, result) {
+					return
+				}
+			}
+		}(worker)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
@@ -19,6 +19,8 @@
 package runtime
 
 import (
+	goContext "context"
+
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 )
@@ -27,8 +29,11 @@ type Context struct {
 	Interface         Interface
 	Location          Location
 	PredeclaredValues []ValueDeclaration
-	codes             map[common.LocationID]string
-	programs          map[common.LocationID]*ast.Program
+	// cancellationContext is the Go context which cancels the execution, if any,
+	// see Runtime.ExecuteScriptWithContext and Runtime.ExecuteTransactionWithContext
+	cancellationContext goContext.Context
+	codes               map[common.LocationID]string
+	programs            map[common.LocationID]*ast.Program
 }
 
 func (c Context) SetCode(location common.Location, code string) {
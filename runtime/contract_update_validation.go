@@ -23,6 +23,7 @@ import (
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -34,6 +35,11 @@ type ContractUpdateValidator struct {
 	rootDecl     ast.Declaration
 	currentDecl  ast.Declaration
 	errors       []error
+	// elaborations of the old and new program, if the programs were checked.
+	// Types are compared as resolved by the checker, if both are available,
+	// e.g. so a type alias and the aliased type are considered equal
+	oldElaboration *sema.Elaboration
+	newElaboration *sema.Elaboration
 }
 
 // ContractUpdateValidator should implement ast.TypeEqualityChecker
@@ -41,18 +47,23 @@ var _ ast.TypeEqualityChecker = &ContractUpdateValidator{}
 
 // NewContractUpdateValidator initializes and returns a validator, without performing any validation.
 // Invoke the `Validate()` method of the validator returned, to start validating the contract.
+//
+// The elaboration of the old program may be nil, e.g. if the existing code does not type-check anymore.
+// In that case, the types of the old and new program are compared syntactically.
 func NewContractUpdateValidator(
 	location Location,
 	contractName string,
-	oldProgram *ast.Program,
-	newProgram *ast.Program,
+	oldProgram *interpreter.Program,
+	newProgram *interpreter.Program,
 ) *ContractUpdateValidator {
 
 	return &ContractUpdateValidator{
-		location:     location,
-		oldProgram:   oldProgram,
-		newProgram:   newProgram,
-		contractName: contractName,
+		location:       location,
+		oldProgram:     oldProgram.Program,
+		newProgram:     newProgram.Program,
+		contractName:   contractName,
+		oldElaboration: oldProgram.Elaboration,
+		newElaboration: newProgram.Elaboration,
 	}
 }
 
@@ -169,12 +180,42 @@ func (validator *ContractUpdateValidator) checkFields(oldDeclaration ast.Declara
 			continue
 		}
 
-		validator.checkField(oldField, newField)
+		validator.checkField(oldDeclaration, newDeclaration, oldField, newField)
 	}
 }
 
-func (validator *ContractUpdateValidator) checkField(oldField *ast.FieldDeclaration, newField *ast.FieldDeclaration) {
+func (validator *ContractUpdateValidator) checkField(
+	oldDeclaration ast.Declaration,
+	newDeclaration ast.Declaration,
+	oldField *ast.FieldDeclaration,
+	newField *ast.FieldDeclaration,
+) {
+	oldType := fieldType(validator.oldElaboration, oldDeclaration, oldField)
+	newType := fieldType(validator.newElaboration, newDeclaration, newField)
+
+	// If the types are the same once resolved, the field was not changed,
+	// even if it is declared differently, e.g. using a type alias.
+	//
+	// NOTE: compare the type IDs, as the old and new program are checked separately,
+	// so e.g. the types declared in the contract are different type instances
+
+	if oldType != nil && newType != nil && oldType.ID() == newType.ID() {
+		return
+	}
+
 	err := oldField.TypeAnnotation.Type.CheckEqual(newField.TypeAnnotation.Type, validator)
+
+	// The types might be declared the same, but resolve to different types,
+	// e.g. if an aliased type was changed
+
+	if err == nil && oldType != nil && newType != nil {
+		err = &ResolvedTypeMismatchError{
+			ExpectedType: oldType,
+			FoundType:    newType,
+			Range:        ast.NewRangeFromPositioned(newField.TypeAnnotation.Type),
+		}
+	}
+
 	if err != nil {
 		validator.report(&FieldMismatchError{
 			DeclName:  validator.currentDecl.DeclarationIdentifier().Identifier,
@@ -517,6 +558,15 @@ func (validator *ContractUpdateValidator) checkConformances(
 	// Therefore, below check for multiple conformances is only applicable
 	// for non-enum type composite declarations. i.e: structs, resources, etc.
 
+	if validator.oldElaboration != nil && validator.newElaboration != nil {
+		oldCompositeType := validator.oldElaboration.CompositeDeclarationTypes[oldDecl]
+		newCompositeType := validator.newElaboration.CompositeDeclarationTypes[newDecl]
+		if oldCompositeType != nil && newCompositeType != nil {
+			validator.checkResolvedConformances(oldCompositeType, newCompositeType, newDecl)
+			return
+		}
+	}
+
 	oldConformances := oldDecl.Conformances
 	newConformances := newDecl.Conformances
 
@@ -547,6 +597,51 @@ func (validator *ContractUpdateValidator) checkConformances(
 	}
 }
 
+// checkResolvedConformances checks the conformances of the composite types,
+// as resolved by the checker, e.g. so a conformance to a type alias
+// and a conformance to the aliased interface are considered equal
+//
+func (validator *ContractUpdateValidator) checkResolvedConformances(
+	oldCompositeType *sema.CompositeType,
+	newCompositeType *sema.CompositeType,
+	newDecl *ast.CompositeDeclaration,
+) {
+	// NOTE: compare the type IDs, as the old and new program are checked separately
+
+	// The raw type of an enum is declared as its conformance
+
+	oldEnumRawType := oldCompositeType.EnumRawType
+	newEnumRawType := newCompositeType.EnumRawType
+	if (oldEnumRawType == nil) != (newEnumRawType == nil) ||
+		(oldEnumRawType != nil && oldEnumRawType.ID() != newEnumRawType.ID()) {
+
+		validator.report(&ConformanceMismatchError{
+			DeclName: newDecl.Identifier.Identifier,
+			Range:    ast.NewRangeFromPositioned(newDecl.Identifier),
+		})
+
+		return
+	}
+
+	newConformanceIDs := make(map[sema.TypeID]struct{}, len(newCompositeType.ExplicitInterfaceConformances))
+	for _, newConformance := range newCompositeType.ExplicitInterfaceConformances {
+		newConformanceIDs[newConformance.ID()] = struct{}{}
+	}
+
+	// All the existing conformances must have a match. Order is not important.
+	// Having extra new conformance is OK. See: https://github.com/onflow/cadence/issues/1394
+	for _, oldConformance := range oldCompositeType.ExplicitInterfaceConformances {
+		if _, ok := newConformanceIDs[oldConformance.ID()]; !ok {
+			validator.report(&ConformanceMismatchError{
+				DeclName: newDecl.Identifier.Identifier,
+				Range:    ast.NewRangeFromPositioned(newDecl.Identifier),
+			})
+
+			return
+		}
+	}
+}
+
 func (validator *ContractUpdateValidator) report(err error) {
 	if err == nil {
 		return
@@ -626,3 +721,44 @@ func containsEnums(declaration ast.Declaration) bool {
 
 	return false
 }
+
+// fieldType returns the type of the given field of the given declaration,
+// as resolved by the checker, or nil if the program was not checked.
+//
+func fieldType(
+	elaboration *sema.Elaboration,
+	declaration ast.Declaration,
+	field *ast.FieldDeclaration,
+) sema.Type {
+	if elaboration == nil {
+		return nil
+	}
+
+	var members *sema.StringMemberOrderedMap
+
+	switch declaration := declaration.(type) {
+	case *ast.CompositeDeclaration:
+		compositeType := elaboration.CompositeDeclarationTypes[declaration]
+		if compositeType == nil {
+			return nil
+		}
+		members = compositeType.Members
+
+	case *ast.InterfaceDeclaration:
+		interfaceType := elaboration.InterfaceDeclarationTypes[declaration]
+		if interfaceType == nil {
+			return nil
+		}
+		members = interfaceType.Members
+
+	default:
+		return nil
+	}
+
+	member, ok := members.Get(field.Identifier.Identifier)
+	if !ok {
+		return nil
+	}
+
+	return member.TypeAnnotation.Type
+}
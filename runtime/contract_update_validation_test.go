@@ -1830,6 +1830,194 @@ func TestRuntimeContractUpdateValidation(t *testing.T) {
 			assertMissingDeclarationError(t, childErrors[1], "B")
 		}
 	})
+
+	t.Run("replace field type with type alias", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub resource interface Receiver {}
+                pub resource interface Balance {}
+                pub resource Vault: Receiver, Balance {}
+
+                pub var vault: Capability<&Vault{Receiver, Balance}>?
+                pub var amounts: [UFix64]
+
+                init() {
+                    self.vault = nil
+                    self.amounts = []
+                }
+            }
+        `
+
+		const newCode = `
+            pub typealias Amount = UFix64
+
+            pub contract Test {
+                pub resource interface Receiver {}
+                pub resource interface Balance {}
+                pub resource Vault: Receiver, Balance {}
+
+                pub typealias VaultRef = &Vault{Receiver, Balance}
+                pub typealias Amounts = [Amount]
+
+                pub var vault: Capability<Test.VaultRef>?
+                pub var amounts: Amounts
+
+                init() {
+                    self.vault = nil
+                    self.amounts = []
+                }
+            }
+        `
+
+		err := testDeployAndUpdate(t, contractValidationEnabled, "Test", oldCode, newCode)
+		require.NoError(t, err)
+	})
+
+	t.Run("replace type alias with aliased type", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub typealias Amounts = [UFix64]
+
+                pub var amounts: Amounts
+
+                init() {
+                    self.amounts = []
+                }
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub var amounts: [UFix64]
+
+                init() {
+                    self.amounts = []
+                }
+            }
+        `
+
+		err := testDeployAndUpdate(t, contractValidationEnabled, "Test", oldCode, newCode)
+		require.NoError(t, err)
+	})
+
+	t.Run("change aliased type", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub typealias Amounts = [UFix64]
+
+                pub var amounts: Amounts
+
+                init() {
+                    self.amounts = []
+                }
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub typealias Amounts = [UInt64]
+
+                pub var amounts: Amounts
+
+                init() {
+                    self.amounts = []
+                }
+            }
+        `
+
+		err := testDeployAndUpdate(t, contractValidationEnabled, "Test", oldCode, newCode)
+		require.Error(t, err)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertResolvedFieldTypeMismatchError(t, cause, "Test", "amounts", "[UFix64]", "[UInt64]")
+	})
+
+	t.Run("replace imported type alias with aliased type", func(t *testing.T) {
+
+		t.Parallel()
+
+		const importedCode = `
+            pub contract Imported {
+                pub typealias Amounts = [UFix64]
+            }
+        `
+
+		const oldCode = `
+            import Imported from 0x42
+
+            pub contract Test {
+                pub var amounts: Imported.Amounts
+
+                init() {
+                    self.amounts = []
+                }
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub var amounts: [UFix64]
+
+                init() {
+                    self.amounts = []
+                }
+            }
+        `
+
+		executeTransaction := newContractDeploymentTransactor(t, contractValidationEnabled)
+
+		err := executeTransaction(newContractAddTransaction("Imported", importedCode))
+		require.NoError(t, err)
+
+		err = executeTransaction(newContractAddTransaction("Test", oldCode))
+		require.NoError(t, err)
+
+		err = executeTransaction(newContractUpdateTransaction("Test", newCode))
+		require.NoError(t, err)
+	})
+
+	t.Run("type alias shadowed by nested type", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub resource Vault {}
+
+                pub var vault: @Vault?
+
+                init() {
+                    self.vault <- nil
+                }
+            }
+        `
+
+		const newCode = `
+            pub typealias Vault = UInt64
+
+            pub contract Test {
+                pub resource Vault {}
+
+                pub var vault: @Vault?
+
+                init() {
+                    self.vault <- nil
+                }
+            }
+        `
+
+		err := testDeployAndUpdate(t, contractValidationEnabled, "Test", oldCode, newCode)
+		require.NoError(t, err)
+	})
 }
 
 func assertContractRemovalError(t *testing.T, err error, name string) {
@@ -1883,6 +2071,27 @@ func assertFieldTypeMismatchError(
 	assert.Equal(t, foundType, typeMismatchError.FoundType.String())
 }
 
+func assertResolvedFieldTypeMismatchError(
+	t *testing.T,
+	err error,
+	erroneousDeclName string,
+	fieldName string,
+	expectedType string,
+	foundType string,
+) {
+	var fieldMismatchError *FieldMismatchError
+	require.ErrorAs(t, err, &fieldMismatchError)
+
+	assert.Equal(t, fieldName, fieldMismatchError.FieldName)
+	assert.Equal(t, erroneousDeclName, fieldMismatchError.DeclName)
+
+	var typeMismatchError *ResolvedTypeMismatchError
+	require.ErrorAs(t, fieldMismatchError.Err, &typeMismatchError)
+
+	assert.Equal(t, expectedType, typeMismatchError.ExpectedType.QualifiedString())
+	assert.Equal(t, foundType, typeMismatchError.FoundType.QualifiedString())
+}
+
 func assertConformanceMismatchError(
 	t *testing.T,
 	err error,
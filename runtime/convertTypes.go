@@ -203,6 +203,10 @@ func exportConstantSizedType(t *sema.ConstantSizedType, results map[sema.TypeID]
 
 func exportCompositeType(t *sema.CompositeType, results map[sema.TypeID]cadence.Type) (result cadence.CompositeType) {
 
+	// The members of an instantiated generic composite type are initialized lazily
+
+	t.InitializeInstantiatedMembers()
+
 	fieldMembers := make([]*sema.Member, 0, len(t.Fields))
 
 	for _, identifier := range t.Fields {
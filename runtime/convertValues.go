@@ -441,6 +441,12 @@ func exportEvent(event exportableEvent, seenReferences seenReferences) (cadence.
 	return cadence.NewEvent(fields).WithType(eventType), nil
 }
 
+// ImportValue converts a Cadence value to a runtime value.
+// The expected type is optional and used to infer the static types of nested values.
+func ImportValue(inter *interpreter.Interpreter, value cadence.Value, expectedType sema.Type) (interpreter.Value, error) {
+	return importValue(inter, value, expectedType)
+}
+
 // importValue converts a Cadence value to a runtime value.
 func importValue(inter *interpreter.Interpreter, value cadence.Value, expectedType sema.Type) (interpreter.Value, error) {
 	switch v := value.(type) {
@@ -18,21 +18,182 @@
 
 package runtime
 
-import "github.com/onflow/cadence/runtime/common"
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// CoveragePosition is the position of a statement or branching element in a location.
+// When used as a key of a JSON object, it is encoded as `line:column`.
+//
+type CoveragePosition struct {
+	Line   int
+	Column int
+}
+
+func NewCoveragePosition(position ast.Position) CoveragePosition {
+	return CoveragePosition{
+		Line:   position.Line,
+		Column: position.Column,
+	}
+}
+
+// Less returns true if the position is before the given position
+//
+func (p CoveragePosition) Less(other CoveragePosition) bool {
+	if p.Line != other.Line {
+		return p.Line < other.Line
+	}
+	return p.Column < other.Column
+}
+
+func (p CoveragePosition) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+func (p CoveragePosition) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *CoveragePosition) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "%d:%d", &p.Line, &p.Column)
+	return err
+}
+
+// CoverageRange is the range of a branching element in a location.
+// Nested branching elements may start at the same position,
+// e.g. the conditional expression in `(a ? b : c) ?? d`,
+// so they are identified by their range.
+// When used as a key of a JSON object, it is encoded as `line:column-line:column`.
+//
+type CoverageRange struct {
+	Start CoveragePosition
+	End   CoveragePosition
+}
+
+func NewCoverageRange(positioned ast.HasPosition) CoverageRange {
+	return CoverageRange{
+		Start: NewCoveragePosition(positioned.StartPosition()),
+		End:   NewCoveragePosition(positioned.EndPosition()),
+	}
+}
+
+func (r CoverageRange) String() string {
+	return fmt.Sprintf("%s-%s", r.Start, r.End)
+}
+
+func (r CoverageRange) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *CoverageRange) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(
+		string(text),
+		"%d:%d-%d:%d",
+		&r.Start.Line,
+		&r.Start.Column,
+		&r.End.Line,
+		&r.End.Column,
+	)
+	return err
+}
 
 // LocationCoverage records coverage information for a location
 //
 type LocationCoverage struct {
+	// LineHits is the number of hits per line.
+	// Lines which contain statements, but which were not hit, have zero hits
 	LineHits map[int]int `json:"line_hits"`
+	// StatementHits is the number of hits per statement
+	StatementHits map[CoveragePosition]int `json:"statement_hits"`
+	// BranchHits is the number of hits per branch of each branching element,
+	// i.e. if statements, switch statements, conditional expressions,
+	// and nil-coalescing expressions.
+	// See interpreter.OnBranchFunc for the meaning of the branch indices
+	BranchHits map[CoverageRange][]int `json:"branch_hits"`
 }
 
 func (c *LocationCoverage) AddLineHit(line int) {
 	c.LineHits[line]++
 }
 
+func (c *LocationCoverage) AddStatementHit(position CoveragePosition) {
+	c.StatementHits[position]++
+	c.AddLineHit(position.Line)
+}
+
+func (c *LocationCoverage) AddBranchHit(branchRange CoverageRange, branch int) {
+	hits := c.BranchHits[branchRange]
+	if branch >= len(hits) {
+		newHits := make([]int, branch+1)
+		copy(newHits, hits)
+		hits = newHits
+	}
+	hits[branch]++
+	c.BranchHits[branchRange] = hits
+}
+
+// addStatement records the given statement as coverable
+//
+func (c *LocationCoverage) addStatement(position ast.Position) {
+	coveragePosition := NewCoveragePosition(position)
+	if _, ok := c.StatementHits[coveragePosition]; !ok {
+		c.StatementHits[coveragePosition] = 0
+	}
+	if _, ok := c.LineHits[position.Line]; !ok {
+		c.LineHits[position.Line] = 0
+	}
+}
+
+func (c *LocationCoverage) addSwitchCaseStatements(switchCase *ast.SwitchCase) {
+	for _, statement := range switchCase.Statements {
+		c.addStatement(statement.StartPosition())
+	}
+}
+
+// addBranches records the given branching element as coverable
+//
+func (c *LocationCoverage) addBranches(element ast.Element, count int) {
+	branchRange := NewCoverageRange(element)
+	if _, ok := c.BranchHits[branchRange]; !ok {
+		c.BranchHits[branchRange] = make([]int, count)
+	}
+}
+
+// CoveredLines returns the number of lines with at least one hit
+//
+func (c *LocationCoverage) CoveredLines() int {
+	count := 0
+	for _, hits := range c.LineHits {
+		if hits > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// CoveredBranches returns the number of branches with at least one hit
+// and the total number of branches
+//
+func (c *LocationCoverage) CoveredBranches() (covered int, total int) {
+	for _, hits := range c.BranchHits {
+		total += len(hits)
+		for _, branchHits := range hits {
+			if branchHits > 0 {
+				covered++
+			}
+		}
+	}
+	return
+}
+
 func NewLocationCoverage() *LocationCoverage {
 	return &LocationCoverage{
-		LineHits: map[int]int{},
+		LineHits:      map[int]int{},
+		StatementHits: map[CoveragePosition]int{},
+		BranchHits:    map[CoverageRange][]int{},
 	}
 }
 
@@ -40,20 +201,124 @@ func NewLocationCoverage() *LocationCoverage {
 //
 type CoverageReport struct {
 	Coverage map[common.LocationID]*LocationCoverage `json:"coverage"`
+	// inspected is the set of locations whose programs were inspected
+	inspected map[common.LocationID]struct{}
 }
 
-func (r *CoverageReport) AddLineHit(location common.Location, line int) {
+func (r *CoverageReport) locationCoverage(location common.Location) *LocationCoverage {
 	locationID := location.ID()
 	locationCoverage := r.Coverage[locationID]
 	if locationCoverage == nil {
 		locationCoverage = NewLocationCoverage()
 		r.Coverage[locationID] = locationCoverage
 	}
-	locationCoverage.AddLineHit(line)
+	return locationCoverage
+}
+
+func (r *CoverageReport) AddLineHit(location common.Location, line int) {
+	r.locationCoverage(location).AddLineHit(line)
+}
+
+func (r *CoverageReport) AddStatementHit(location common.Location, statement ast.Statement) {
+	position := NewCoveragePosition(statement.StartPosition())
+	r.locationCoverage(location).AddStatementHit(position)
+}
+
+func (r *CoverageReport) AddBranchHit(location common.Location, element ast.Element, branch int) {
+	branchRange := NewCoverageRange(element)
+	r.locationCoverage(location).AddBranchHit(branchRange, branch)
+}
+
+// InspectProgram records the coverable statements, lines, and branches
+// of the given program, so that the ones that are never hit are reported.
+// Programs are only inspected once per location.
+//
+func (r *CoverageReport) InspectProgram(location common.Location, program *ast.Program) {
+	locationID := location.ID()
+	if _, ok := r.inspected[locationID]; ok {
+		return
+	}
+	r.inspected[locationID] = struct{}{}
+
+	locationCoverage := r.locationCoverage(location)
+
+	var walker ast.Walker
+
+	// Conditions are evaluated like statements,
+	// but are not walked
+
+	addConditions := func(conditions *ast.Conditions) {
+		if conditions == nil {
+			return
+		}
+		for _, condition := range *conditions {
+			locationCoverage.addStatement(condition.Test.StartPosition())
+			ast.Walk(walker, condition.Test)
+		}
+	}
+
+	walker = coverageWalker(func(element ast.Element) {
+		switch element := element.(type) {
+		case *ast.Block:
+			for _, statement := range element.Statements {
+				locationCoverage.addStatement(statement.StartPosition())
+			}
+
+		case *ast.FunctionBlock:
+			addConditions(element.PreConditions)
+			addConditions(element.PostConditions)
+
+		case *ast.TransactionDeclaration:
+			addConditions(element.PreConditions)
+			addConditions(element.PostConditions)
+
+		case *ast.IfStatement:
+			locationCoverage.addBranches(element, 2)
+
+		case *ast.ConditionalExpression:
+			locationCoverage.addBranches(element, 2)
+
+		case *ast.BinaryExpression:
+			if element.Operation == ast.OperationNilCoalesce {
+				locationCoverage.addBranches(element, 2)
+			}
+
+		case *ast.SwitchStatement:
+			// If there is no default case,
+			// no case might match, which is an additional branch
+
+			count := len(element.Cases)
+			hasDefault := false
+			for _, switchCase := range element.Cases {
+				locationCoverage.addSwitchCaseStatements(switchCase)
+				if switchCase.Expression == nil {
+					hasDefault = true
+				}
+			}
+			if !hasDefault {
+				count++
+			}
+			locationCoverage.addBranches(element, count)
+		}
+	})
+
+	ast.Walk(walker, program)
+}
+
+// coverageWalker is an ast.Walker which calls the function for each element
+//
+type coverageWalker func(ast.Element)
+
+func (w coverageWalker) Walk(element ast.Element) ast.Walker {
+	if element != nil {
+		w(element)
+	}
+	return w
 }
 
 func NewCoverageReport() *CoverageReport {
 	return &CoverageReport{
-		Coverage: map[common.LocationID]*LocationCoverage{},
+		Coverage:  map[common.LocationID]*LocationCoverage{},
+		inspected: map[common.LocationID]struct{}{},
 	}
 }
@@ -0,0 +1,180 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+const coberturaDocType = `<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">`
+
+const coberturaPackageName = "cadence"
+
+type coberturaCoverage struct {
+	XMLName         xml.Name           `xml:"coverage"`
+	LineRate        float64            `xml:"line-rate,attr"`
+	BranchRate      float64            `xml:"branch-rate,attr"`
+	LinesCovered    int                `xml:"lines-covered,attr"`
+	LinesValid      int                `xml:"lines-valid,attr"`
+	BranchesCovered int                `xml:"branches-covered,attr"`
+	BranchesValid   int                `xml:"branches-valid,attr"`
+	Complexity      float64            `xml:"complexity,attr"`
+	Version         string             `xml:"version,attr"`
+	Timestamp       int64              `xml:"timestamp,attr"`
+	Packages        []coberturaPackage `xml:"packages>package"`
+}
+
+type coberturaPackage struct {
+	Name       string           `xml:"name,attr"`
+	LineRate   float64          `xml:"line-rate,attr"`
+	BranchRate float64          `xml:"branch-rate,attr"`
+	Complexity float64          `xml:"complexity,attr"`
+	Classes    []coberturaClass `xml:"classes>class"`
+}
+
+type coberturaClass struct {
+	Name       string          `xml:"name,attr"`
+	Filename   string          `xml:"filename,attr"`
+	LineRate   float64         `xml:"line-rate,attr"`
+	BranchRate float64         `xml:"branch-rate,attr"`
+	Complexity float64         `xml:"complexity,attr"`
+	Methods    struct{}        `xml:"methods"`
+	Lines      []coberturaLine `xml:"lines>line"`
+}
+
+type coberturaLine struct {
+	Number            int    `xml:"number,attr"`
+	Hits              int    `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr"`
+	ConditionCoverage string `xml:"condition-coverage,attr,omitempty"`
+}
+
+func coverageRate(covered, total int) float64 {
+	if total == 0 {
+		return 1
+	}
+	return float64(covered) / float64(total)
+}
+
+// WriteCobertura writes the coverage report in the Cobertura XML format.
+// Each location is reported as a class, and the location IDs are used as the file names.
+//
+func (r *CoverageReport) WriteCobertura(w io.Writer, timestamp time.Time) error {
+
+	coverage := coberturaCoverage{
+		Timestamp: timestamp.UnixNano() / int64(time.Millisecond),
+	}
+
+	pkg := coberturaPackage{
+		Name: coberturaPackageName,
+	}
+
+	for _, locationID := range r.sortedLocationIDs() {
+		locationCoverage := r.Coverage[locationID]
+
+		// Group the branches by line
+
+		type lineBranches struct {
+			covered int
+			total   int
+		}
+
+		branchesByLine := map[int]*lineBranches{}
+
+		for branchRange, hits := range locationCoverage.BranchHits {
+			line := branchRange.Start.Line
+			branches := branchesByLine[line]
+			if branches == nil {
+				branches = &lineBranches{}
+				branchesByLine[line] = branches
+			}
+
+			branches.total += len(hits)
+			for _, branchHits := range hits {
+				if branchHits > 0 {
+					branches.covered++
+				}
+			}
+		}
+
+		linesCovered := locationCoverage.CoveredLines()
+		linesValid := len(locationCoverage.LineHits)
+		branchesCovered, branchesValid := locationCoverage.CoveredBranches()
+
+		class := coberturaClass{
+			Name:       string(locationID),
+			Filename:   string(locationID),
+			LineRate:   coverageRate(linesCovered, linesValid),
+			BranchRate: coverageRate(branchesCovered, branchesValid),
+		}
+
+		for _, line := range locationCoverage.sortedLines() {
+			coberturaLine := coberturaLine{
+				Number: line,
+				Hits:   locationCoverage.LineHits[line],
+			}
+
+			if branches, ok := branchesByLine[line]; ok && branches.total > 0 {
+				coberturaLine.Branch = true
+				coberturaLine.ConditionCoverage = fmt.Sprintf(
+					"%d%% (%d/%d)",
+					branches.covered*100/branches.total,
+					branches.covered,
+					branches.total,
+				)
+			}
+
+			class.Lines = append(class.Lines, coberturaLine)
+		}
+
+		pkg.Classes = append(pkg.Classes, class)
+
+		coverage.LinesCovered += linesCovered
+		coverage.LinesValid += linesValid
+		coverage.BranchesCovered += branchesCovered
+		coverage.BranchesValid += branchesValid
+	}
+
+	coverage.LineRate = coverageRate(coverage.LinesCovered, coverage.LinesValid)
+	coverage.BranchRate = coverageRate(coverage.BranchesCovered, coverage.BranchesValid)
+
+	pkg.LineRate = coverage.LineRate
+	pkg.BranchRate = coverage.BranchRate
+
+	coverage.Packages = []coberturaPackage{pkg}
+
+	_, err := io.WriteString(w, xml.Header+coberturaDocType+"\n")
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	err = encoder.Encode(coverage)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
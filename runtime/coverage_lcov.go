@@ -0,0 +1,135 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// WriteLCOV writes the coverage report in the LCOV tracefile format.
+// The location IDs are used as the source file names.
+//
+func (r *CoverageReport) WriteLCOV(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+
+	for _, locationID := range r.sortedLocationIDs() {
+		locationCoverage := r.Coverage[locationID]
+
+		_, err := fmt.Fprintf(writer, "TN:\nSF:%s\n", locationID)
+		if err != nil {
+			return err
+		}
+
+		// Branches
+
+		for block, branchRange := range locationCoverage.sortedBranchRanges() {
+			hits := locationCoverage.BranchHits[branchRange]
+
+			// LCOV uses `-` for branches of blocks that were never executed
+			executed := false
+			for _, branchHits := range hits {
+				if branchHits > 0 {
+					executed = true
+					break
+				}
+			}
+
+			for branch, branchHits := range hits {
+				taken := "-"
+				if executed {
+					taken = fmt.Sprint(branchHits)
+				}
+
+				_, err = fmt.Fprintf(writer, "BRDA:%d,%d,%d,%s\n", branchRange.Start.Line, block, branch, taken)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		coveredBranches, totalBranches := locationCoverage.CoveredBranches()
+
+		_, err = fmt.Fprintf(writer, "BRF:%d\nBRH:%d\n", totalBranches, coveredBranches)
+		if err != nil {
+			return err
+		}
+
+		// Lines
+
+		for _, line := range locationCoverage.sortedLines() {
+			_, err = fmt.Fprintf(writer, "DA:%d,%d\n", line, locationCoverage.LineHits[line])
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = fmt.Fprintf(
+			writer,
+			"LF:%d\nLH:%d\nend_of_record\n",
+			len(locationCoverage.LineHits),
+			locationCoverage.CoveredLines(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+func (r *CoverageReport) sortedLocationIDs() []common.LocationID {
+	locationIDs := make([]common.LocationID, 0, len(r.Coverage))
+	for locationID := range r.Coverage {
+		locationIDs = append(locationIDs, locationID)
+	}
+	sort.Slice(locationIDs, func(i, j int) bool {
+		return locationIDs[i] < locationIDs[j]
+	})
+	return locationIDs
+}
+
+func (c *LocationCoverage) sortedLines() []int {
+	lines := make([]int, 0, len(c.LineHits))
+	for line := range c.LineHits {
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+	return lines
+}
+
+func (c *LocationCoverage) sortedBranchRanges() []CoverageRange {
+	ranges := make([]CoverageRange, 0, len(c.BranchHits))
+	for branchRange := range c.BranchHits {
+		ranges = append(ranges, branchRange)
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		a := ranges[i]
+		b := ranges[j]
+		if a.Start != b.Start {
+			return a.Start.Less(b.Start)
+		}
+		return a.End.Less(b.End)
+	})
+	return ranges
+}
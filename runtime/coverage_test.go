@@ -21,13 +21,16 @@ package runtime
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
 )
 
 func TestRuntimeCoverage(t *testing.T) {
@@ -101,13 +104,30 @@ func TestRuntimeCoverage(t *testing.T) {
                 "4": 1,
                 "5": 42,
                 "7": 1
-              }
+              },
+              "statement_hits": {
+                "3:8": 1,
+                "4:8": 1,
+                "5:10": 42,
+                "7:8": 1
+              },
+              "branch_hits": {}
             },
             "t.00": {
               "line_hits": {
                 "5": 1,
                 "6": 1,
+                "7": 0,
                 "9": 1
+              },
+              "statement_hits": {
+                "5:10": 1,
+                "6:10": 1,
+                "7:12": 0,
+                "9:10": 1
+              },
+              "branch_hits": {
+                "6:10-8:10": [0, 1]
               }
             }
           }
@@ -116,3 +136,277 @@ func TestRuntimeCoverage(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestRuntimeCoverageBranches(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	script := []byte(`
+      pub fun classify(_ n: Int): String {
+          switch n {
+          case 0:
+              return "zero"
+          case 1:
+              return "one"
+          }
+          return n > 1 ? "many" : "negative"
+      }
+
+      pub fun main(): String {
+          let x: Int? = nil
+          let y = x ?? 2
+          if let z = x {
+              return classify(z)
+          }
+          var s = classify(y)
+          s = s.concat(classify(1))
+          return s
+      }
+    `)
+
+	runtimeInterface := &testRuntimeInterface{}
+
+	coverageReport := NewCoverageReport()
+
+	runtime.SetCoverageReport(coverageReport)
+
+	location := common.ScriptLocation{0x1}
+
+	value, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  location,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.String("manyone"), value)
+
+	locationCoverage := coverageReport.Coverage[location.ID()]
+	require.NotNil(t, locationCoverage)
+
+	assert.Equal(t,
+		map[CoverageRange][]int{
+			// switch: case 0, case 1, no case matched
+			{
+				Start: CoveragePosition{Line: 3, Column: 10},
+				End:   CoveragePosition{Line: 8, Column: 10},
+			}: {0, 1, 1},
+			// conditional expression
+			{
+				Start: CoveragePosition{Line: 9, Column: 17},
+				End:   CoveragePosition{Line: 9, Column: 43},
+			}: {1, 0},
+			// nil-coalescing expression
+			{
+				Start: CoveragePosition{Line: 14, Column: 18},
+				End:   CoveragePosition{Line: 14, Column: 23},
+			}: {0, 1},
+			// if-let statement
+			{
+				Start: CoveragePosition{Line: 15, Column: 10},
+				End:   CoveragePosition{Line: 17, Column: 10},
+			}: {0, 1},
+		},
+		locationCoverage.BranchHits,
+	)
+
+	assert.Equal(t,
+		map[int]int{
+			3:  2,
+			5:  0,
+			7:  1,
+			9:  1,
+			13: 1,
+			14: 1,
+			15: 1,
+			16: 0,
+			18: 1,
+			19: 1,
+			20: 1,
+		},
+		locationCoverage.LineHits,
+	)
+
+	coveredBranches, totalBranches := locationCoverage.CoveredBranches()
+	assert.Equal(t, 5, coveredBranches)
+	assert.Equal(t, 9, totalBranches)
+}
+
+func TestRuntimeCoverageNestedBranches(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	// The conditional expression and the nil-coalescing expression
+	// start at the same position
+
+	script := []byte(`
+      pub fun main(): Int {
+          let a = true
+          let b: Int? = nil
+          return (a ? b : 1) ?? 2
+      }
+    `)
+
+	runtimeInterface := &testRuntimeInterface{}
+
+	coverageReport := NewCoverageReport()
+
+	runtime.SetCoverageReport(coverageReport)
+
+	location := common.ScriptLocation{0x1}
+
+	value, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  location,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.NewInt(2), value)
+
+	locationCoverage := coverageReport.Coverage[location.ID()]
+	require.NotNil(t, locationCoverage)
+
+	assert.Equal(t,
+		map[CoverageRange][]int{
+			// conditional expression
+			{
+				Start: CoveragePosition{Line: 5, Column: 18},
+				End:   CoveragePosition{Line: 5, Column: 26},
+			}: {1, 0},
+			// nil-coalescing expression
+			{
+				Start: CoveragePosition{Line: 5, Column: 18},
+				End:   CoveragePosition{Line: 5, Column: 32},
+			}: {0, 1},
+		},
+		locationCoverage.BranchHits,
+	)
+
+	coveredBranches, totalBranches := locationCoverage.CoveredBranches()
+	assert.Equal(t, 2, coveredBranches)
+	assert.Equal(t, 4, totalBranches)
+}
+
+func newTestCoverageReport() *CoverageReport {
+	coverageReport := NewCoverageReport()
+
+	program, err := parser2.ParseProgram(`
+      pub fun test(_ a: Bool): Int {
+          if a {
+              return 1
+          }
+          return 2
+      }
+    `)
+	if err != nil {
+		panic(err)
+	}
+
+	location := common.StringLocation("test")
+
+	coverageReport.InspectProgram(location, program)
+
+	locationCoverage := coverageReport.Coverage[location.ID()]
+	locationCoverage.AddStatementHit(CoveragePosition{Line: 3, Column: 10})
+	locationCoverage.AddBranchHit(
+		CoverageRange{
+			Start: CoveragePosition{Line: 3, Column: 10},
+			End:   CoveragePosition{Line: 5, Column: 10},
+		},
+		1,
+	)
+	locationCoverage.AddStatementHit(CoveragePosition{Line: 6, Column: 10})
+
+	return coverageReport
+}
+
+func TestCoverageReportLCOV(t *testing.T) {
+
+	t.Parallel()
+
+	coverageReport := newTestCoverageReport()
+
+	var builder strings.Builder
+	err := coverageReport.WriteLCOV(&builder)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		`TN:
+SF:S.test
+BRDA:3,0,0,0
+BRDA:3,0,1,1
+BRF:2
+BRH:1
+DA:3,1
+DA:4,0
+DA:6,1
+LF:3
+LH:2
+end_of_record
+`,
+		builder.String(),
+	)
+}
+
+func TestCoverageReportCobertura(t *testing.T) {
+
+	t.Parallel()
+
+	coverageReport := newTestCoverageReport()
+
+	var builder strings.Builder
+	err := coverageReport.WriteCobertura(&builder, time.Unix(1, 0))
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">
+<coverage line-rate="0.6666666666666666" branch-rate="0.5" lines-covered="2" lines-valid="3" branches-covered="1" branches-valid="2" complexity="0" version="" timestamp="1000">
+  <packages>
+    <package name="cadence" line-rate="0.6666666666666666" branch-rate="0.5" complexity="0">
+      <classes>
+        <class name="S.test" filename="S.test" line-rate="0.6666666666666666" branch-rate="0.5" complexity="0">
+          <methods></methods>
+          <lines>
+            <line number="3" hits="1" branch="true" condition-coverage="50% (1/2)"></line>
+            <line number="4" hits="0" branch="false"></line>
+            <line number="6" hits="1" branch="false"></line>
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>
+`,
+		builder.String(),
+	)
+}
+
+func TestCoverageReportJSONRoundTrip(t *testing.T) {
+
+	t.Parallel()
+
+	coverageReport := newTestCoverageReport()
+
+	encoded, err := json.Marshal(coverageReport)
+	require.NoError(t, err)
+
+	decoded := NewCoverageReport()
+	err = json.Unmarshal(encoded, decoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, coverageReport.Coverage, decoded.Coverage)
+}
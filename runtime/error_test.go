@@ -275,7 +275,13 @@ func TestRuntimeError(t *testing.T) {
 				" --> imported:5:16\n"+
 				"  |\n"+
 				"5 |                 a + b\n"+
-				"  |                 ^^^^^\n",
+				"  |                 ^^^^^\n"+
+				"\n"+
+				"note: stack trace, most recent call first\n"+
+				" --> 01:5:16\n"+
+				"  |\n"+
+				"5 |                 add()\n"+
+				"  |                 ----- call of function `add`\n",
 		)
 	})
 
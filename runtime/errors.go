@@ -74,6 +74,82 @@ func (e CallStackLimitExceededError) Error() string {
 	)
 }
 
+// UnsupportedSimulationOperationError
+
+// UnsupportedSimulationOperationError is reported when a simulated transaction
+// performs an operation which cannot be simulated
+//
+type UnsupportedSimulationOperationError struct {
+	Operation string
+}
+
+func (e UnsupportedSimulationOperationError) Error() string {
+	return fmt.Sprintf(
+		"cannot %s when simulating a transaction",
+		e.Operation,
+	)
+}
+
+// ExecutionCancelledError
+
+// ExecutionCancelledError is reported when the execution of a script or transaction
+// is aborted because its Go context is done, e.g. because it was cancelled or its deadline was exceeded.
+// The location range is the location where the execution stopped
+//
+type ExecutionCancelledError struct {
+	Err error
+	interpreter.LocationRange
+}
+
+func (e ExecutionCancelledError) Error() string {
+	return fmt.Sprintf("execution cancelled: %s", e.Err)
+}
+
+func (e ExecutionCancelledError) Unwrap() error {
+	return e.Err
+}
+
+// ReadOnlyStorageWriteError
+
+// ReadOnlyStorageWriteError is reported when a read-only storage is committed,
+// but values were written to account storage
+//
+type ReadOnlyStorageWriteError struct{}
+
+func (ReadOnlyStorageWriteError) Error() string {
+	return "cannot write to read-only storage"
+}
+
+// InterfaceReplayError is returned by ReplayingInterface
+// when a call does not match the next recorded call
+//
+type InterfaceReplayError struct {
+	Index    int
+	Function string
+	// Recorded is the recorded call, or nil if all recorded calls were already replayed
+	Recorded         *InterfaceCall
+	ArgumentMismatch bool
+}
+
+func (e InterfaceReplayError) Error() string {
+	var reason string
+	switch {
+	case e.Recorded == nil:
+		reason = "no more recorded calls"
+	case e.ArgumentMismatch:
+		reason = "arguments differ from recorded arguments"
+	default:
+		reason = fmt.Sprintf("recorded call is of `%s`", e.Recorded.Function)
+	}
+
+	return fmt.Sprintf(
+		"cannot replay call %d of `%s`: %s",
+		e.Index,
+		e.Function,
+		reason,
+	)
+}
+
 // InvalidTransactionCountError
 
 type InvalidTransactionCountError struct {
@@ -358,6 +434,22 @@ func (e *TypeMismatchError) Error() string {
 	)
 }
 
+// ResolvedTypeMismatchError is reported during a contract update, when a type of the new program
+// is declared like the existing type, but resolves to a different type,
+// e.g. because the aliased type of a type alias was changed.
+type ResolvedTypeMismatchError struct {
+	ExpectedType sema.Type
+	FoundType    sema.Type
+	ast.Range
+}
+
+func (e *ResolvedTypeMismatchError) Error() string {
+	return fmt.Sprintf("incompatible types. expected `%s`, found `%s`",
+		e.ExpectedType.QualifiedString(),
+		e.FoundType.QualifiedString(),
+	)
+}
+
 // ExtraneousFieldError is reported during a contract update, when an updated composite
 // declaration has more fields than the existing declaration.
 type ExtraneousFieldError struct {
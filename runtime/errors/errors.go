@@ -61,6 +61,20 @@ type ErrorNote interface {
 	Message() string
 }
 
+// HasStackTrace is an interface for errors that provide a call stack trace
+//
+type HasStackTrace interface {
+	StackTraceFrames() []StackTraceFrame
+}
+
+// StackTraceFrame is a frame of a call stack trace, starting with the most recent frame.
+//
+// Frames may also provide a position (ast.HasPosition) and a location (common.HasImportLocation)
+//
+type StackTraceFrame interface {
+	Message() string
+}
+
 // ParentError is an error that contains one or more child errors.
 type ParentError interface {
 	error
@@ -46,9 +46,15 @@ type Interface interface {
 	// it may NOT return something different or nothing (!) after SetProgram was called.
 	//
 	// This is not a caching function!
+	// See ProgramCache for a cache which can be used to implement this function.
+	//
+	// Programs are not modified after they are checked,
+	// so the same program may be returned to multiple concurrent executions.
 	//
 	GetProgram(Location) (*interpreter.Program, error)
 	// SetProgram sets the program for the given location.
+	//
+	// SetProgram may be called concurrently, if scripts are executed concurrently.
 	SetProgram(Location, *interpreter.Program) error
 	// GetValue gets a value for the given key in the storage, owned by the given account.
 	GetValue(owner, key []byte) (value []byte, err error)
@@ -87,6 +93,9 @@ type Interface interface {
 	// MeterComputation is a callback method for metering computation, it returns error
 	// when computation passes the limit (set by the environment)
 	MeterComputation(operationType common.ComputationKind, intensity uint) error
+	// MeterMemory is a callback method for metering memory, it returns error
+	// when memory usage passes the limit (set by the environment)
+	MeterMemory(kind common.MemoryKind, amount uint) error
 	// DecodeArgument decodes a transaction argument against the given type.
 	DecodeArgument(argument []byte, argumentType cadence.Type) (cadence.Value, error)
 	// GetCurrentBlockHeight returns the current block height.
@@ -21,6 +21,7 @@ package interpreter
 import (
 	"fmt"
 
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -49,6 +50,103 @@ func NewAuthAccountValue(
 	contractsConstructor func() Value,
 	keysConstructor func() Value,
 ) Value {
+	return newAuthAccountValue(
+		address,
+		accountBalanceGet,
+		accountAvailableBalanceGet,
+		storageUsedGet,
+		storageCapacityGet,
+		addPublicKeyFunction,
+		removePublicKeyFunction,
+		contractsConstructor,
+		keysConstructor,
+	)
+}
+
+// NewReadOnlyAuthAccountValue constructs an auth account value which cannot be modified.
+//
+// The functions which modify the account, e.g. `save`, `load`, `link`, `unlink`,
+// and `addPublicKey`, fail with a ReadOnlyAuthAccountError.
+// The contracts and keys values returned by the given constructors
+// should be read-only as well, see NewReadOnlyAuthAccountFunction.
+//
+func NewReadOnlyAuthAccountValue(
+	address AddressValue,
+	accountBalanceGet func() UFix64Value,
+	accountAvailableBalanceGet func() UFix64Value,
+	storageUsedGet func(interpreter *Interpreter) UInt64Value,
+	storageCapacityGet func() UInt64Value,
+	contractsConstructor func() Value,
+	keysConstructor func() Value,
+) Value {
+	value := newAuthAccountValue(
+		address,
+		accountBalanceGet,
+		accountAvailableBalanceGet,
+		storageUsedGet,
+		storageCapacityGet,
+		NewReadOnlyAuthAccountFunction(
+			sema.AuthAccountAddPublicKeyField,
+			sema.AuthAccountTypeAddPublicKeyFunctionType,
+		),
+		NewReadOnlyAuthAccountFunction(
+			sema.AuthAccountRemovePublicKeyField,
+			sema.AuthAccountTypeRemovePublicKeyFunctionType,
+		),
+		contractsConstructor,
+		keysConstructor,
+	)
+
+	// NOTE: fields take precedence over computed fields
+
+	value.Fields[sema.AuthAccountSaveField] = NewReadOnlyAuthAccountFunction(
+		sema.AuthAccountSaveField,
+		sema.AuthAccountTypeSaveFunctionType,
+	)
+	value.Fields[sema.AuthAccountLoadField] = NewReadOnlyAuthAccountFunction(
+		sema.AuthAccountLoadField,
+		sema.AuthAccountTypeLoadFunctionType,
+	)
+	value.Fields[sema.AuthAccountLinkField] = NewReadOnlyAuthAccountFunction(
+		sema.AuthAccountLinkField,
+		sema.AuthAccountTypeLinkFunctionType,
+	)
+	value.Fields[sema.AuthAccountUnlinkField] = NewReadOnlyAuthAccountFunction(
+		sema.AuthAccountUnlinkField,
+		sema.AuthAccountTypeUnlinkFunctionType,
+	)
+
+	return value
+}
+
+// NewReadOnlyAuthAccountFunction returns a function of a read-only auth account
+// which fails with a ReadOnlyAuthAccountError when it is called.
+//
+// The given operation is the name of the function, e.g. `contracts.add`
+//
+func NewReadOnlyAuthAccountFunction(operation string, functionType *sema.FunctionType) *HostFunctionValue {
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+			panic(ReadOnlyAuthAccountError{
+				Operation:     operation,
+				LocationRange: invocation.GetLocationRange(),
+			})
+		},
+		functionType,
+	)
+}
+
+func newAuthAccountValue(
+	address AddressValue,
+	accountBalanceGet func() UFix64Value,
+	accountAvailableBalanceGet func() UFix64Value,
+	storageUsedGet func(interpreter *Interpreter) UInt64Value,
+	storageCapacityGet func() UInt64Value,
+	addPublicKeyFunction FunctionValue,
+	removePublicKeyFunction FunctionValue,
+	contractsConstructor func() Value,
+	keysConstructor func() Value,
+) *SimpleCompositeValue {
 
 	fields := map[string]Value{
 		sema.AuthAccountAddressField:         address,
@@ -113,6 +211,36 @@ func NewAuthAccountValue(
 		sema.AuthAccountGetLinkTargetField: func(inter *Interpreter, _ func() LocationRange) Value {
 			return inter.accountGetLinkTargetFunction(address)
 		},
+		sema.AuthAccountStoragePathsField: func(inter *Interpreter, _ func() LocationRange) Value {
+			return inter.accountPaths(address, common.PathDomainStorage, PrimitiveStaticTypeStoragePath)
+		},
+		sema.AuthAccountPublicPathsField: func(inter *Interpreter, _ func() LocationRange) Value {
+			return inter.accountPaths(address, common.PathDomainPublic, PrimitiveStaticTypePublicPath)
+		},
+		sema.AuthAccountPrivatePathsField: func(inter *Interpreter, _ func() LocationRange) Value {
+			return inter.accountPaths(address, common.PathDomainPrivate, PrimitiveStaticTypePrivatePath)
+		},
+		sema.AuthAccountForEachStoredField: func(inter *Interpreter, _ func() LocationRange) Value {
+			return inter.accountForEachFunction(
+				address,
+				common.PathDomainStorage,
+				sema.AccountTypeForEachStoredFunctionType,
+			)
+		},
+		sema.AuthAccountForEachPublicField: func(inter *Interpreter, _ func() LocationRange) Value {
+			return inter.accountForEachFunction(
+				address,
+				common.PathDomainPublic,
+				sema.AccountTypeForEachPublicFunctionType,
+			)
+		},
+		sema.AuthAccountForEachPrivateField: func(inter *Interpreter, _ func() LocationRange) Value {
+			return inter.accountForEachFunction(
+				address,
+				common.PathDomainPrivate,
+				sema.AccountTypeForEachPrivateFunctionType,
+			)
+		},
 	}
 
 	var str string
@@ -199,6 +327,16 @@ func NewPublicAccountValue(
 		sema.PublicAccountGetTargetLinkField: func(inter *Interpreter, _ func() LocationRange) Value {
 			return inter.accountGetLinkTargetFunction(address)
 		},
+		sema.PublicAccountPublicPathsField: func(inter *Interpreter, _ func() LocationRange) Value {
+			return inter.accountPaths(address, common.PathDomainPublic, PrimitiveStaticTypePublicPath)
+		},
+		sema.PublicAccountForEachPublicField: func(inter *Interpreter, _ func() LocationRange) Value {
+			return inter.accountForEachFunction(
+				address,
+				common.PathDomainPublic,
+				sema.AccountTypeForEachPublicFunctionType,
+			)
+		},
 	}
 
 	var str string
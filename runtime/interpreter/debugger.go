@@ -19,26 +19,105 @@
 package interpreter
 
 import (
+	"fmt"
+	"sort"
+	"sync"
 	"sync/atomic"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 )
 
+// Stop is a pause of the execution of the program,
+// before the given statement is executed
+//
 type Stop struct {
 	Interpreter *Interpreter
 	Statement   ast.Statement
+	// Breakpoint is the breakpoint which caused the stop, if any
+	Breakpoint *Breakpoint
+	// ConditionError is the error which occurred when the condition of the breakpoint was evaluated, if any.
+	// The execution is stopped when the condition cannot be evaluated
+	ConditionError error
 }
 
+// Breakpoint is a line breakpoint.
+// The execution is stopped before a statement starting at the line is executed
+//
+type Breakpoint struct {
+	ID       int
+	Location common.Location
+	Line     int
+	// Condition is an optional Cadence expression.
+	// If it is given, the execution is only stopped if the condition evaluates to `true`
+	Condition ast.Expression
+}
+
+// StackFrame is a frame of the call stack of the program
+//
+type StackFrame struct {
+	// Interpreter is the interpreter of the program which contains the function of the frame
+	Interpreter *Interpreter
+	// FunctionName is the name of the function of the frame.
+	// It is empty for function expressions and for code which is not in a function
+	FunctionName string
+	// Statement is the statement which is executed in the frame.
+	// For frames which are not the innermost frame, it is the statement which contains the invocation
+	Statement ast.Statement
+	// Activation is the activation of the frame, which contains the variables in scope
+	Activation *VariableActivation
+}
+
+// invocationFrame is the record of an invocation of an interpreted function
+//
+type invocationFrame struct {
+	functionName string
+	// callerInterpreter, callerStatement, and callerActivation
+	// are the interpreter, statement, and activation of the invoking frame
+	callerInterpreter *Interpreter
+	callerStatement   ast.Statement
+	callerActivation  *VariableActivation
+}
+
+type stepMode uint8
+
+const (
+	stepModeNone stepMode = iota
+	// stepModeIn stops at the next statement
+	stepModeIn
+	// stepModeOver stops at the next statement in the current function or a caller
+	stepModeOver
+	// stepModeOut stops at the next statement in a caller
+	stepModeOut
+)
+
 type Debugger struct {
 	pauseRequested uint32
-	stops          chan Stop
-	continues      chan struct{}
+	// evaluating is set while an expression is evaluated by the debugger.
+	// Statements and invocations are then not tracked
+	evaluating uint32
+	// stopped is set while the program is stopped
+	stopped   uint32
+	stops     chan Stop
+	continues chan struct{}
+
+	// frames is the call stack of the program.
+	// It is only accessed by the goroutine of the program,
+	// or while the program is stopped
+	frames []invocationFrame
+
+	mutex            sync.Mutex
+	stepMode         stepMode
+	stepDepth        int
+	breakpoints      map[common.LocationID]map[int]*Breakpoint
+	lastBreakpointID int
 }
 
 func NewDebugger() *Debugger {
 	return &Debugger{
-		stops:     make(chan Stop),
-		continues: make(chan struct{}),
+		stops:       make(chan Stop),
+		continues:   make(chan struct{}, 1),
+		breakpoints: map[common.LocationID]map[int]*Breakpoint{},
 	}
 }
 
@@ -47,18 +126,100 @@ func (d *Debugger) Stops() <-chan Stop {
 }
 
 func (d *Debugger) onStatement(interpreter *Interpreter, statement ast.Statement) {
-	if !d.PauseRequested() {
+	if d.isEvaluating() {
 		return
 	}
 
-	d.stops <- Stop{
+	stop, ok := d.stopAt(interpreter, statement)
+	if !ok {
+		return
+	}
+
+	atomic.StoreUint32(&d.stopped, 1)
+
+	d.stops <- stop
+
+	<-d.continues
+}
+
+// stopAt determines if the execution should be stopped before the given statement is executed
+//
+func (d *Debugger) stopAt(interpreter *Interpreter, statement ast.Statement) (Stop, bool) {
+	stop := Stop{
 		Interpreter: interpreter,
 		Statement:   statement,
 	}
 
-	d.resetPauseRequest()
+	if d.PauseRequested() {
+		d.resetPauseRequest()
+		d.resetStep()
+		return stop, true
+	}
 
-	<-d.continues
+	if d.stepCompleted() {
+		d.resetStep()
+		return stop, true
+	}
+
+	breakpoint := d.breakpointAt(interpreter.Location, statement.StartPosition().Line)
+	if breakpoint == nil {
+		return stop, false
+	}
+
+	if breakpoint.Condition != nil {
+		frame := StackFrame{
+			Interpreter: interpreter,
+			Statement:   statement,
+			Activation:  interpreter.activations.Current(),
+		}
+
+		value, err := d.Evaluate(frame, breakpoint.Condition)
+		if err == nil {
+			boolValue, ok := value.(BoolValue)
+			if !ok {
+				err = fmt.Errorf("breakpoint condition is not a boolean: %s", value)
+			} else if !boolValue {
+				return stop, false
+			}
+		}
+
+		stop.ConditionError = err
+	}
+
+	d.resetStep()
+	stop.Breakpoint = breakpoint
+	return stop, true
+}
+
+func (d *Debugger) onInvocationStart(function *InterpretedFunctionValue, invocation Invocation) {
+	if d.isEvaluating() {
+		return
+	}
+
+	frame := invocationFrame{
+		functionName: function.Name,
+	}
+
+	caller := invocation.Interpreter
+	if caller != nil {
+		frame.callerInterpreter = caller
+		frame.callerStatement = caller.statement
+		frame.callerActivation = caller.activations.Current()
+	}
+
+	d.frames = append(d.frames, frame)
+}
+
+func (d *Debugger) onInvocationEnd() {
+	if d.isEvaluating() {
+		return
+	}
+
+	d.frames = d.frames[:len(d.frames)-1]
+}
+
+func (d *Debugger) isEvaluating() bool {
+	return atomic.LoadUint32(&d.evaluating) == 1
 }
 
 func (d *Debugger) PauseRequested() bool {
@@ -73,13 +234,16 @@ func (d *Debugger) RequestPause() {
 	atomic.StoreUint32(&d.pauseRequested, 1)
 }
 
+// Continue continues the execution of the stopped program.
+// Returns false if the program is not stopped
+//
 func (d *Debugger) Continue() bool {
-	select {
-	case d.continues <- struct{}{}:
-		return true
-	default:
+	if !atomic.CompareAndSwapUint32(&d.stopped, 1, 0) {
 		return false
 	}
+
+	d.continues <- struct{}{}
+	return true
 }
 
 func (d *Debugger) Pause() Stop {
@@ -87,12 +251,250 @@ func (d *Debugger) Pause() Stop {
 	return <-d.Stops()
 }
 
+// StepIn continues the execution of the stopped program,
+// and stops at the next statement, which might be in an invoked function.
+//
+// If the program is not stopped, StepIn and the other step functions
+// stop the execution at the next statement, like Pause
+//
+func (d *Debugger) StepIn() Stop {
+	return d.step(stepModeIn)
+}
+
+// StepOver continues the execution of the stopped program,
+// and stops at the next statement in the current function or a caller,
+// i.e. invoked functions are executed without stopping, unless a breakpoint is hit
+//
+func (d *Debugger) StepOver() Stop {
+	return d.step(stepModeOver)
+}
+
+// StepOut continues the execution of the stopped program,
+// and stops at the next statement in a caller of the current function
+//
+func (d *Debugger) StepOut() Stop {
+	return d.step(stepModeOut)
+}
+
+// Next is an alias for StepOver
+//
 func (d *Debugger) Next() Stop {
-	d.RequestPause()
-	d.Continue()
+	return d.StepOver()
+}
+
+// RequestStepIn is like StepIn, but does not wait for the stop.
+// Returns false if the program is not stopped
+//
+func (d *Debugger) RequestStepIn() bool {
+	return d.requestStep(stepModeIn)
+}
+
+// RequestStepOver is like StepOver, but does not wait for the stop.
+// Returns false if the program is not stopped
+//
+func (d *Debugger) RequestStepOver() bool {
+	return d.requestStep(stepModeOver)
+}
+
+// RequestStepOut is like StepOut, but does not wait for the stop.
+// Returns false if the program is not stopped
+//
+func (d *Debugger) RequestStepOut() bool {
+	return d.requestStep(stepModeOut)
+}
+
+func (d *Debugger) step(mode stepMode) Stop {
+	if !d.requestStep(mode) {
+		// The program is not stopped, so there is no step to take.
+		// Stop at the next statement instead, like Pause
+		d.RequestPause()
+	}
 	return <-d.Stops()
 }
 
+func (d *Debugger) requestStep(mode stepMode) bool {
+	d.mutex.Lock()
+	d.stepMode = mode
+	d.stepDepth = len(d.frames)
+	d.mutex.Unlock()
+
+	if !d.Continue() {
+		d.resetStep()
+		return false
+	}
+
+	return true
+}
+
+func (d *Debugger) resetStep() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.stepMode = stepModeNone
+}
+
+// stepCompleted returns true if a step was requested,
+// and the current invocation depth satisfies it
+//
+func (d *Debugger) stepCompleted() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	depth := len(d.frames)
+
+	switch d.stepMode {
+	case stepModeIn:
+		return true
+	case stepModeOver:
+		return depth <= d.stepDepth
+	case stepModeOut:
+		return depth < d.stepDepth
+	default:
+		return false
+	}
+}
+
+// AddBreakpoint adds a breakpoint at the given line of the program at the given location.
+// If a condition is given, the execution is only stopped if the condition evaluates to `true`.
+//
+// An existing breakpoint at the same line is replaced
+//
+func (d *Debugger) AddBreakpoint(location common.Location, line int, condition ast.Expression) *Breakpoint {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.lastBreakpointID++
+
+	breakpoint := &Breakpoint{
+		ID:        d.lastBreakpointID,
+		Location:  location,
+		Line:      line,
+		Condition: condition,
+	}
+
+	locationID := location.ID()
+	lines, ok := d.breakpoints[locationID]
+	if !ok {
+		lines = map[int]*Breakpoint{}
+		d.breakpoints[locationID] = lines
+	}
+	lines[line] = breakpoint
+
+	return breakpoint
+}
+
+// RemoveBreakpoint removes the breakpoint with the given ID.
+// Returns false if there is no such breakpoint
+//
+func (d *Debugger) RemoveBreakpoint(id int) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for locationID, lines := range d.breakpoints { //nolint:maprangecheck
+		for line, breakpoint := range lines { //nolint:maprangecheck
+			if breakpoint.ID != id {
+				continue
+			}
+
+			delete(lines, line)
+			if len(lines) == 0 {
+				delete(d.breakpoints, locationID)
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClearBreakpoints removes all breakpoints of the program at the given location
+//
+func (d *Debugger) ClearBreakpoints(location common.Location) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	delete(d.breakpoints, location.ID())
+}
+
+// Breakpoints returns all breakpoints, sorted by ID
+//
+func (d *Debugger) Breakpoints() []*Breakpoint {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var breakpoints []*Breakpoint
+	for _, lines := range d.breakpoints { //nolint:maprangecheck
+		for _, breakpoint := range lines { //nolint:maprangecheck
+			breakpoints = append(breakpoints, breakpoint)
+		}
+	}
+
+	sort.Slice(breakpoints, func(i, j int) bool {
+		return breakpoints[i].ID < breakpoints[j].ID
+	})
+
+	return breakpoints
+}
+
+func (d *Debugger) breakpointAt(location common.Location, line int) *Breakpoint {
+	if location == nil {
+		return nil
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	lines, ok := d.breakpoints[location.ID()]
+	if !ok {
+		return nil
+	}
+
+	return lines[line]
+}
+
+// StackFrames returns the call stack of the stopped program, starting with the innermost frame
+//
+func (d *Debugger) StackFrames(stop Stop) []StackFrame {
+	count := len(d.frames)
+
+	if count == 0 {
+		return []StackFrame{
+			{
+				Interpreter: stop.Interpreter,
+				Statement:   stop.Statement,
+				Activation:  d.CurrentActivation(stop.Interpreter),
+			},
+		}
+	}
+
+	frames := make([]StackFrame, 0, count)
+
+	frames = append(frames, StackFrame{
+		Interpreter:  stop.Interpreter,
+		FunctionName: d.frames[count-1].functionName,
+		Statement:    stop.Statement,
+		Activation:   d.CurrentActivation(stop.Interpreter),
+	})
+
+	// The state of each calling frame was recorded when it invoked the next frame
+
+	for i := count - 1; i > 0; i-- {
+		invoked := d.frames[i]
+		if invoked.callerInterpreter == nil {
+			break
+		}
+
+		frames = append(frames, StackFrame{
+			Interpreter:  invoked.callerInterpreter,
+			FunctionName: d.frames[i-1].functionName,
+			Statement:    invoked.callerStatement,
+			Activation:   invoked.callerActivation,
+		})
+	}
+
+	return frames
+}
+
 func (d *Debugger) CurrentActivation(interpreter *Interpreter) *VariableActivation {
 	return interpreter.activations.Current()
 }
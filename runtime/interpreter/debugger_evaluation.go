@@ -0,0 +1,185 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"sync/atomic"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// debuggerEvaluationLocation is the location of the expressions evaluated by the debugger
+//
+const debuggerEvaluationLocation = common.IdentifierLocation("debugger")
+
+const debuggerEvaluationIdentifier = "result"
+
+// Evaluate evaluates the given Cadence expression in the scope of the given stack frame.
+//
+// The expression is checked against the types of the values of the variables it refers to.
+// Access modifiers are ignored, so e.g. private fields can be inspected
+//
+func (d *Debugger) Evaluate(frame StackFrame, expression ast.Expression) (result Value, err error) {
+	inter := frame.Interpreter
+
+	// Statements and invocations which are executed during the evaluation,
+	// e.g. when a function is called, are not tracked
+
+	atomic.StoreUint32(&d.evaluating, 1)
+	defer atomic.StoreUint32(&d.evaluating, 0)
+
+	defer inter.RecoverErrors(func(internalErr error) {
+		err = internalErr
+	})
+
+	declaration := &ast.VariableDeclaration{
+		IsConstant: true,
+		Identifier: ast.Identifier{
+			Identifier: debuggerEvaluationIdentifier,
+		},
+		Value: expression,
+		Transfer: &ast.Transfer{
+			Operation: ast.TransferOperationCopy,
+		},
+	}
+
+	program := ast.NewProgram([]ast.Declaration{declaration})
+
+	checker, err := sema.NewChecker(
+		program,
+		debuggerEvaluationLocation,
+		sema.WithPredeclaredValues(frameValueDeclarations(frame, expression)),
+		sema.WithAccessCheckMode(sema.AccessCheckModeNone),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checker.Check()
+	if err != nil {
+		return nil, err
+	}
+
+	subInterpreter, err := inter.NewSubInterpreter(
+		&Program{
+			Program:     program,
+			Elaboration: checker.Elaboration,
+		},
+		// NOTE: no location, so the interpreter is not registered
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	subInterpreter.activations.PushNewWithParent(frame.Activation)
+	defer subInterpreter.activations.Pop()
+
+	return subInterpreter.evalExpression(expression), nil
+}
+
+// frameValueDeclarations returns the declarations of the variables of the given frame
+// which are referred to by the given expression
+//
+func frameValueDeclarations(frame StackFrame, expression ast.Expression) []sema.ValueDeclaration {
+	var declarations []sema.ValueDeclaration
+
+	declared := map[string]struct{}{}
+
+	ast.Inspect(expression, func(element ast.Element) bool {
+		identifierExpression, ok := element.(*ast.IdentifierExpression)
+		if !ok {
+			return true
+		}
+
+		name := identifierExpression.Identifier.Identifier
+		if _, ok := declared[name]; ok {
+			return true
+		}
+		declared[name] = struct{}{}
+
+		variable := frame.Activation.Find(name)
+		if variable == nil {
+			return true
+		}
+
+		// Built-in values, e.g. conversion functions, are already declared
+		if sema.BaseValueActivation.Find(name) != nil {
+			return true
+		}
+
+		value := variable.GetValue()
+		ty, err := frame.Interpreter.ConvertStaticToSemaType(value.StaticType())
+		if err != nil {
+			return true
+		}
+
+		declarations = append(declarations, debuggerValueDeclaration{
+			name: name,
+			ty:   ty,
+		})
+
+		return true
+	})
+
+	return declarations
+}
+
+// debuggerValueDeclaration is the declaration of a variable of a stack frame
+//
+type debuggerValueDeclaration struct {
+	name string
+	ty   sema.Type
+}
+
+var _ sema.ValueDeclaration = debuggerValueDeclaration{}
+
+func (v debuggerValueDeclaration) ValueDeclarationName() string {
+	return v.name
+}
+
+func (v debuggerValueDeclaration) ValueDeclarationType() sema.Type {
+	return v.ty
+}
+
+func (debuggerValueDeclaration) ValueDeclarationDocString() string {
+	return ""
+}
+
+func (debuggerValueDeclaration) ValueDeclarationKind() common.DeclarationKind {
+	return common.DeclarationKindConstant
+}
+
+func (debuggerValueDeclaration) ValueDeclarationPosition() ast.Position {
+	return ast.Position{}
+}
+
+func (debuggerValueDeclaration) ValueDeclarationIsConstant() bool {
+	return true
+}
+
+func (debuggerValueDeclaration) ValueDeclarationArgumentLabels() []string {
+	return nil
+}
+
+func (debuggerValueDeclaration) ValueDeclarationAvailable(_ common.Location) bool {
+	return true
+}
@@ -1134,7 +1134,9 @@ func decodeCompositeStaticType(dec *cbor.StreamDecoder) (StaticType, error) {
 		return nil, err
 	}
 
-	if size != expectedLength {
+	// The type arguments are optional, see encodedGenericCompositeStaticTypeLength
+
+	if size != expectedLength && size != encodedGenericCompositeStaticTypeLength {
 		return nil, fmt.Errorf(
 			"invalid composite static type encoding: expected [%d]interface{}, got [%d]interface{}",
 			expectedLength,
@@ -1160,7 +1162,50 @@ func decodeCompositeStaticType(dec *cbor.StreamDecoder) (StaticType, error) {
 		return nil, err
 	}
 
-	return NewCompositeStaticType(location, qualifiedIdentifier), nil
+	staticType := NewCompositeStaticType(location, qualifiedIdentifier)
+
+	if size == encodedGenericCompositeStaticTypeLength {
+		// Decode type arguments at array index encodedCompositeStaticTypeTypeArgumentsFieldKey
+		staticType.TypeArguments, err = decodeStaticTypeArguments(dec)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid composite static type type arguments encoding: %w",
+				err,
+			)
+		}
+	}
+
+	return staticType, nil
+}
+
+// decodeStaticTypeArguments decodes an array of static types,
+// see encodeStaticTypeArguments
+//
+func decodeStaticTypeArguments(dec *cbor.StreamDecoder) ([]StaticType, error) {
+	size, err := dec.DecodeArrayHead()
+	if err != nil {
+		if e, ok := err.(*cbor.WrongTypeError); ok {
+			return nil, fmt.Errorf(
+				"expected array, got %s",
+				e.ActualType.String(),
+			)
+		}
+		return nil, err
+	}
+
+	if size == 0 {
+		return nil, fmt.Errorf("expected at least one type argument")
+	}
+
+	typeArguments := make([]StaticType, size)
+	for i := 0; i < int(size); i++ {
+		typeArguments[i], err = decodeStaticType(dec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return typeArguments, nil
 }
 
 func decodeInterfaceStaticType(dec *cbor.StreamDecoder) (InterfaceStaticType, error) {
@@ -1505,7 +1550,11 @@ func decodeCompositeTypeInfo(dec *cbor.StreamDecoder) (atree.TypeInfo, error) {
 		return nil, err
 	}
 
-	if length != encodedCompositeTypeInfoLength {
+	// The type arguments are optional, see encodedGenericCompositeTypeInfoLength
+
+	if length != encodedCompositeTypeInfoLength &&
+		length != encodedGenericCompositeTypeInfoLength {
+
 		return nil, fmt.Errorf(
 			"invalid composite type info: expected %d elements, got %d",
 			encodedCompositeTypeInfoLength, length,
@@ -1534,9 +1583,21 @@ func decodeCompositeTypeInfo(dec *cbor.StreamDecoder) (atree.TypeInfo, error) {
 		)
 	}
 
+	var typeArguments []StaticType
+	if length == encodedGenericCompositeTypeInfoLength {
+		typeArguments, err = decodeStaticTypeArguments(dec)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid composite type info type arguments: %w",
+				err,
+			)
+		}
+	}
+
 	return compositeTypeInfo{
 		location:            location,
 		qualifiedIdentifier: qualifiedIdentifier,
 		kind:                common.CompositeKind(kind),
+		typeArguments:       typeArguments,
 	}, nil
 }
@@ -1009,12 +1009,18 @@ func (t OptionalStaticType) Encode(e *cbor.StreamEncoder) error {
 const (
 	// encodedCompositeStaticTypeLocationFieldKey            uint64 = 0
 	// encodedCompositeStaticTypeQualifiedIdentifierFieldKey uint64 = 1
+	// encodedCompositeStaticTypeTypeArgumentsFieldKey       uint64 = 2
 
 	// !!! *WARNING* !!!
 	//
 	// encodedCompositeStaticTypeLength MUST be updated when new element is added.
 	// It is used to verify encoded composite static type length during decoding.
 	encodedCompositeStaticTypeLength = 2
+
+	// encodedGenericCompositeStaticTypeLength is the length of an encoded composite static type
+	// which has type arguments, i.e. the type arguments are only encoded if there are any,
+	// so the encoding of non-generic composite static types is unchanged.
+	encodedGenericCompositeStaticTypeLength = 3
 )
 
 // Encode encodes CompositeStaticType as
@@ -1023,16 +1029,29 @@ const (
 // 			Content: cborArray{
 //				encodedCompositeStaticTypeLocationFieldKey:            Location(v.Location),
 //				encodedCompositeStaticTypeQualifiedIdentifierFieldKey: string(v.QualifiedIdentifier),
+//				encodedCompositeStaticTypeTypeArgumentsFieldKey:       []StaticType(v.TypeArguments), // optional
 //		},
 // }
 func (t CompositeStaticType) Encode(e *cbor.StreamEncoder) error {
+	hasTypeArguments := len(t.TypeArguments) > 0
+
 	// Encode tag number and array head
-	err := e.EncodeRawBytes([]byte{
-		// tag number
-		0xd8, CBORTagCompositeStaticType,
-		// array, 2 items follow
-		0x82,
-	})
+	var err error
+	if hasTypeArguments {
+		err = e.EncodeRawBytes([]byte{
+			// tag number
+			0xd8, CBORTagCompositeStaticType,
+			// array, 3 items follow
+			0x83,
+		})
+	} else {
+		err = e.EncodeRawBytes([]byte{
+			// tag number
+			0xd8, CBORTagCompositeStaticType,
+			// array, 2 items follow
+			0x82,
+		})
+	}
 	if err != nil {
 		return err
 	}
@@ -1044,7 +1063,34 @@ func (t CompositeStaticType) Encode(e *cbor.StreamEncoder) error {
 	}
 
 	// Encode qualified identifier at array index encodedCompositeStaticTypeQualifiedIdentifierFieldKey
-	return e.EncodeString(t.QualifiedIdentifier)
+	err = e.EncodeString(t.QualifiedIdentifier)
+	if err != nil {
+		return err
+	}
+
+	if !hasTypeArguments {
+		return nil
+	}
+
+	// Encode type arguments (as array) at array index encodedCompositeStaticTypeTypeArgumentsFieldKey
+	return encodeStaticTypeArguments(e, t.TypeArguments)
+}
+
+// encodeStaticTypeArguments encodes the given type arguments as an array of static types
+//
+func encodeStaticTypeArguments(e *cbor.StreamEncoder, typeArguments []StaticType) error {
+	err := e.EncodeArrayHead(uint64(len(typeArguments)))
+	if err != nil {
+		return err
+	}
+	for _, typeArgument := range typeArguments {
+		// Encode type argument as array element
+		err = EncodeStaticType(e, typeArgument)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // NOTE: NEVER change, only add/increment; ensure uint64
@@ -1304,19 +1350,37 @@ type compositeTypeInfo struct {
 	location            common.Location
 	qualifiedIdentifier string
 	kind                common.CompositeKind
+	typeArguments       []StaticType
 }
 
 var _ atree.TypeInfo = compositeTypeInfo{}
 
 const encodedCompositeTypeInfoLength = 3
 
+// encodedGenericCompositeTypeInfoLength is the length of an encoded composite type info
+// which has type arguments. The type arguments are only encoded if there are any
+//
+const encodedGenericCompositeTypeInfoLength = 4
+
 func (c compositeTypeInfo) Encode(e *cbor.StreamEncoder) error {
-	err := e.EncodeRawBytes([]byte{
-		// tag number
-		0xd8, CBORTagCompositeValue,
-		// array, 3 items follow
-		0x83,
-	})
+	hasTypeArguments := len(c.typeArguments) > 0
+
+	var err error
+	if hasTypeArguments {
+		err = e.EncodeRawBytes([]byte{
+			// tag number
+			0xd8, CBORTagCompositeValue,
+			// array, 4 items follow
+			0x84,
+		})
+	} else {
+		err = e.EncodeRawBytes([]byte{
+			// tag number
+			0xd8, CBORTagCompositeValue,
+			// array, 3 items follow
+			0x83,
+		})
+	}
 	if err != nil {
 		return err
 	}
@@ -1336,15 +1400,34 @@ func (c compositeTypeInfo) Encode(e *cbor.StreamEncoder) error {
 		return err
 	}
 
+	if hasTypeArguments {
+		err = encodeStaticTypeArguments(e, c.typeArguments)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (c compositeTypeInfo) Equal(o atree.TypeInfo) bool {
 	other, ok := o.(compositeTypeInfo)
-	return ok &&
-		common.LocationsMatch(c.location, other.location) &&
-		c.qualifiedIdentifier == other.qualifiedIdentifier &&
-		c.kind == other.kind
+	if !ok ||
+		!common.LocationsMatch(c.location, other.location) ||
+		c.qualifiedIdentifier != other.qualifiedIdentifier ||
+		c.kind != other.kind ||
+		len(c.typeArguments) != len(other.typeArguments) {
+
+		return false
+	}
+
+	for i, typeArgument := range c.typeArguments {
+		if !typeArgument.Equal(other.typeArguments[i]) {
+			return false
+		}
+	}
+
+	return true
 }
 
 // EmptyTypeInfo
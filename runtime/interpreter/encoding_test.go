@@ -3296,6 +3296,61 @@ func TestEncodeDecodeLinkValue(t *testing.T) {
 		)
 	})
 
+	t.Run("composite, struct, type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		staticType := NewCompositeStaticType(
+			utils.TestLocation,
+			"Pair",
+		)
+		staticType.TypeArguments = []StaticType{
+			PrimitiveStaticTypeBool,
+			PrimitiveStaticTypeString,
+		}
+
+		value := LinkValue{
+			TargetPath: publicPathValue,
+			Type:       staticType,
+		}
+
+		//nolint:gocritic
+		encoded := append(
+			expectedLinkEncodingPrefix[:],
+			// tag
+			0xd8, CBORTagCompositeStaticType,
+			// array, 3 items follow
+			0x83,
+			// tag
+			0xd8, CBORTagStringLocation,
+			// UTF-8 string, length 4
+			0x64,
+			// t, e, s, t
+			0x74, 0x65, 0x73, 0x74,
+			// UTF-8 string, length 4
+			0x64,
+			// Pair
+			0x50, 0x61, 0x69, 0x72,
+			// array, 2 items follow
+			0x82,
+			// tag
+			0xd8, CBORTagPrimitiveStaticType,
+			// bool
+			0x6,
+			// tag
+			0xd8, CBORTagPrimitiveStaticType,
+			// string
+			0x8,
+		)
+
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value:   value,
+				encoded: encoded,
+			},
+		)
+	})
+
 	t.Run("interface, struct, qualified identifier", func(t *testing.T) {
 
 		t.Parallel()
@@ -24,6 +24,7 @@ import (
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -47,6 +48,9 @@ func (e *unsupportedOperation) Error() string {
 type Error struct {
 	Err      error
 	Location common.Location
+	// StackTrace is the call stack at the time the error occurred,
+	// starting with the most recent invocation
+	StackTrace []StackTraceFrame
 }
 
 func (e Error) Unwrap() error {
@@ -65,6 +69,14 @@ func (e Error) ImportLocation() common.Location {
 	return e.Location
 }
 
+func (e Error) StackTraceFrames() []errors.StackTraceFrame {
+	frames := make([]errors.StackTraceFrame, 0, len(e.StackTrace))
+	for _, frame := range e.StackTrace {
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
 // PositionedError wraps an unpositioned error with position info
 //
 type PositionedError struct {
@@ -606,3 +618,18 @@ func (e InvalidPublicKeyError) Error() string {
 func (e InvalidPublicKeyError) Unwrap() error {
 	return e.Err
 }
+
+// ReadOnlyAuthAccountError is reported when a read-only auth account is modified,
+// e.g. when a script saves a value to the storage of an account
+//
+type ReadOnlyAuthAccountError struct {
+	Operation string
+	LocationRange
+}
+
+func (e ReadOnlyAuthAccountError) Error() string {
+	return fmt.Sprintf(
+		"cannot call `%s`: account is read-only",
+		e.Operation,
+	)
+}
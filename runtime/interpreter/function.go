@@ -53,10 +53,16 @@ type FunctionValue interface {
 // InterpretedFunctionValue
 //
 type InterpretedFunctionValue struct {
-	Interpreter      *Interpreter
-	ParameterList    *ast.ParameterList
-	Type             *sema.FunctionType
-	Activation       *VariableActivation
+	Interpreter *Interpreter
+	// Name is the name of the function, e.g. `foo` or `Test.R.foo`.
+	// It is empty for function expressions
+	Name          string
+	ParameterList *ast.ParameterList
+	Type          *sema.FunctionType
+	Activation    *VariableActivation
+	// TypeArguments are the type arguments which are in scope of the function's declaration,
+	// e.g. for a function expression in a generic function
+	TypeArguments    *sema.TypeParameterTypeOrderedMap
 	BeforeStatements []ast.Statement
 	PreConditions    ast.Conditions
 	Statements       []ast.Statement
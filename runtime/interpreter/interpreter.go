@@ -23,8 +23,11 @@ import (
 	goErrors "errors"
 	"fmt"
 	"math"
+	"math/big"
 	goRuntime "runtime"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/onflow/atree"
 	"github.com/opentracing/opentracing-go"
@@ -95,6 +98,23 @@ type OnStatementFunc func(
 	statement ast.Statement,
 )
 
+// OnBranchFunc is a function that is triggered when a branch of a branching element is about to be taken.
+//
+// The element is an if statement, a switch statement, a conditional expression,
+// or a nil-coalescing binary expression. The branch is the index of the taken branch:
+//   - If statements and conditional expressions:
+//     0 for the then-branch, 1 for the else-branch (even if there is no else-block)
+//   - Switch statements: the index of the matching case,
+//     or the number of cases if no case matched
+//   - Nil-coalescing expressions:
+//     0 if the left-hand side is not nil, 1 if the right-hand side is evaluated
+//
+type OnBranchFunc func(
+	inter *Interpreter,
+	element ast.Element,
+	branch int,
+)
+
 // OnLoopIterationFunc is a function that is triggered when a loop iteration is about to be executed.
 //
 type OnLoopIterationFunc func(
@@ -141,6 +161,14 @@ type OnMeterComputationFunc func(
 	intensity uint,
 )
 
+// OnMeterMemoryFunc is a function that is called when some memory is about to be used.
+// amount captures the amount of memory of the given kind, e.g. the number of bytes of a string,
+// see common.MemoryKind.
+type OnMeterMemoryFunc func(
+	kind common.MemoryKind,
+	amount uint,
+)
+
 // InjectedCompositeFieldsHandlerFunc is a function that handles storage reads.
 //
 type InjectedCompositeFieldsHandlerFunc func(
@@ -333,12 +361,14 @@ type Interpreter struct {
 	Storage                        Storage
 	onEventEmitted                 OnEventEmittedFunc
 	onStatement                    OnStatementFunc
+	onBranch                       OnBranchFunc
 	onLoopIteration                OnLoopIterationFunc
 	onFunctionInvocation           OnFunctionInvocationFunc
 	onInvokedFunctionReturn        OnInvokedFunctionReturnFunc
 	onRecordTrace                  OnRecordTraceFunc
 	onResourceOwnerChange          OnResourceOwnerChangeFunc
 	onMeterComputation             OnMeterComputationFunc
+	onMeterMemory                  OnMeterMemoryFunc
 	injectedCompositeFieldsHandler InjectedCompositeFieldsHandlerFunc
 	contractValueHandler           ContractValueHandlerFunc
 	importLocationHandler          ImportLocationHandlerFunc
@@ -354,9 +384,13 @@ type Interpreter struct {
 	interpreted                    bool
 	statement                      ast.Statement
 	debugger                       *Debugger
-	atreeValueValidationEnabled    bool
-	atreeStorageValidationEnabled  bool
-	tracingEnabled                 bool
+	callStack                      *callStack
+	// typeArguments are the type arguments which are in scope
+	// of the currently executed function, if any
+	typeArguments                 *sema.TypeParameterTypeOrderedMap
+	atreeValueValidationEnabled   bool
+	atreeStorageValidationEnabled bool
+	tracingEnabled                bool
 	// TODO: ideally this would be a weak map, but Go has no weak references
 	referencedResourceKindedValues       ReferencedResourceKindedValues
 	invalidatedResourceValidationEnabled bool
@@ -385,6 +419,16 @@ func WithOnStatementHandler(handler OnStatementFunc) Option {
 	}
 }
 
+// WithOnBranchHandler returns an interpreter option which sets
+// the given function as the branch handler.
+//
+func WithOnBranchHandler(handler OnBranchFunc) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetOnBranchHandler(handler)
+		return nil
+	}
+}
+
 // WithOnLoopIterationHandler returns an interpreter option which sets
 // the given function as the loop iteration handler.
 //
@@ -435,6 +479,16 @@ func WithOnResourceOwnerChangeHandler(handler OnResourceOwnerChangeFunc) Option
 	}
 }
 
+// WithOnMeterMemoryFuncHandler returns an interpreter option which sets
+// the given function as the meter memory handler.
+//
+func WithOnMeterMemoryFuncHandler(handler OnMeterMemoryFunc) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetOnMeterMemoryHandler(handler)
+		return nil
+	}
+}
+
 // WithOnMeterComputationFuncHandler returns an interpreter option which sets
 // the given function as the meter computation handler.
 //
@@ -652,6 +706,15 @@ func withReferencedResourceKindedValues(referencedResourceKindedValues Reference
 	}
 }
 
+// withCallStack returns an interpreter option which sets the given call stack
+//
+func withCallStack(callStack *callStack) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.callStack = callStack
+		return nil
+	}
+}
+
 // WithDebugger returns an interpreter option which sets the given debugger
 //
 func WithDebugger(debugger *Debugger) Option {
@@ -692,6 +755,7 @@ func NewInterpreter(program *Program, location common.Location, options ...Optio
 			TypeRequirementCodes: map[sema.TypeID]WrapperCode{},
 		}),
 		withReferencedResourceKindedValues(map[atree.StorageID]map[ReferenceTrackedResourceKindedValue]struct{}{}),
+		withCallStack(&callStack{}),
 		WithInvalidatedResourceValidationEnabled(true),
 	}
 
@@ -724,6 +788,12 @@ func (interpreter *Interpreter) SetOnStatementHandler(function OnStatementFunc)
 	interpreter.onStatement = function
 }
 
+// SetOnBranchHandler sets the function that is triggered when a branch is about to be taken.
+//
+func (interpreter *Interpreter) SetOnBranchHandler(function OnBranchFunc) {
+	interpreter.onBranch = function
+}
+
 // SetOnLoopIterationHandler sets the function that is triggered when a loop iteration is about to be executed.
 //
 func (interpreter *Interpreter) SetOnLoopIterationHandler(function OnLoopIterationFunc) {
@@ -760,6 +830,12 @@ func (interpreter *Interpreter) SetOnMeterComputationHandler(function OnMeterCom
 	interpreter.onMeterComputation = function
 }
 
+// SetOnMeterMemoryHandler sets the function that is triggered when memory is about to be used.
+//
+func (interpreter *Interpreter) SetOnMeterMemoryHandler(function OnMeterMemoryFunc) {
+	interpreter.onMeterMemory = function
+}
+
 // SetStorage sets the value that is used for storage operations.
 func (interpreter *Interpreter) SetStorage(storage Storage) {
 	interpreter.Storage = storage
@@ -1120,9 +1196,13 @@ func (interpreter *Interpreter) RecoverErrors(onError func(error)) {
 				}
 			}
 
+			// The error is wrapped while the invocations which led to it are still on the call stack,
+			// as errors are recovered in each statement
+
 			err = Error{
-				Err:      err,
-				Location: interpreter.Location,
+				Err:        err,
+				Location:   interpreter.Location,
+				StackTrace: interpreter.callStack.stackTrace(),
 			}
 		}
 
@@ -1280,9 +1360,11 @@ func (interpreter *Interpreter) functionDeclarationValue(
 
 	return &InterpretedFunctionValue{
 		Interpreter:      interpreter,
+		Name:             declaration.Identifier.Identifier,
 		ParameterList:    declaration.ParameterList,
 		Type:             functionType,
 		Activation:       lexicalScope,
+		TypeArguments:    interpreter.typeArguments,
 		BeforeStatements: beforeStatements,
 		PreConditions:    preConditions,
 		Statements:       declaration.FunctionBlock.Block.Statements,
@@ -1564,8 +1646,9 @@ func (interpreter *Interpreter) declareNonEnumCompositeValue(
 	compositeType := interpreter.Program.Elaboration.CompositeDeclarationTypes[declaration]
 
 	constructorType := &sema.FunctionType{
-		IsConstructor: true,
-		Parameters:    compositeType.ConstructorParameters,
+		IsConstructor:  true,
+		TypeParameters: compositeType.TypeParameters(),
+		Parameters:     compositeType.ConstructorParameters,
 		ReturnTypeAnnotation: &sema.TypeAnnotation{
 			Type: compositeType,
 		},
@@ -1716,10 +1799,28 @@ func (interpreter *Interpreter) declareNonEnumCompositeValue(
 					)
 				}
 
-				value := NewCompositeValue(
+				// Instances of generic composite types are created
+				// with the type arguments of the constructor invocation
+
+				var typeArguments []StaticType
+
+				typeParameters := compositeType.TypeParameters()
+				if len(typeParameters) > 0 {
+					typeArguments = make([]StaticType, len(typeParameters))
+					for i, typeParameter := range typeParameters {
+						typeArgument, ok := invocation.TypeParameterTypes.Get(typeParameter)
+						if !ok {
+							panic(errors.NewUnreachableError())
+						}
+						typeArguments[i] = ConvertSemaToStaticType(typeArgument)
+					}
+				}
+
+				value := NewGenericCompositeValue(
 					interpreter,
 					location,
 					qualifiedIdentifier,
+					typeArguments,
 					declaration.CompositeKind,
 					fields,
 					address,
@@ -1929,6 +2030,7 @@ func (interpreter *Interpreter) compositeInitializerFunction(
 
 	return &InterpretedFunctionValue{
 		Interpreter:      interpreter,
+		Name:             interpreter.compositeMemberFunctionName(compositeDeclaration, common.DeclarationKindInitializer.Keywords()),
 		ParameterList:    parameterList,
 		Type:             functionType,
 		Activation:       lexicalScope,
@@ -1972,6 +2074,7 @@ func (interpreter *Interpreter) compositeDestructorFunction(
 
 	return &InterpretedFunctionValue{
 		Interpreter:      interpreter,
+		Name:             interpreter.compositeMemberFunctionName(compositeDeclaration, common.DeclarationKindDestructor.Keywords()),
 		Type:             emptyFunctionType,
 		Activation:       lexicalScope,
 		BeforeStatements: beforeStatements,
@@ -1992,6 +2095,7 @@ func (interpreter *Interpreter) compositeFunctions(
 		name := functionDeclaration.Identifier.Identifier
 		functions[name] =
 			interpreter.compositeFunction(
+				interpreter.compositeMemberFunctionName(compositeDeclaration, name),
 				functionDeclaration,
 				lexicalScope,
 			)
@@ -2026,7 +2130,22 @@ func (interpreter *Interpreter) functionWrappers(
 	return functionWrappers
 }
 
+// compositeMemberFunctionName returns the name of the member function with the given name
+// of the given composite declaration, qualified with the name of the composite, e.g. `Test.R.foo`
+//
+func (interpreter *Interpreter) compositeMemberFunctionName(
+	compositeDeclaration *ast.CompositeDeclaration,
+	name string,
+) string {
+	compositeType := interpreter.Program.Elaboration.CompositeDeclarationTypes[compositeDeclaration]
+	if compositeType == nil {
+		return name
+	}
+	return compositeType.QualifiedIdentifier() + "." + name
+}
+
 func (interpreter *Interpreter) compositeFunction(
+	name string,
 	functionDeclaration *ast.FunctionDeclaration,
 	lexicalScope *VariableActivation,
 ) *InterpretedFunctionValue {
@@ -2056,6 +2175,7 @@ func (interpreter *Interpreter) compositeFunction(
 
 	return &InterpretedFunctionValue{
 		Interpreter:      interpreter,
+		Name:             name,
 		ParameterList:    parameterList,
 		Type:             functionType,
 		Activation:       lexicalScope,
@@ -2076,6 +2196,11 @@ func (interpreter *Interpreter) VisitEnumCaseDeclaration(_ *ast.EnumCaseDeclarat
 	panic(errors.NewUnreachableError())
 }
 
+func (interpreter *Interpreter) VisitTypeAliasDeclaration(_ *ast.TypeAliasDeclaration) ast.Repr {
+	// type aliases aren't interpreted
+	panic(errors.NewUnreachableError())
+}
+
 func (interpreter *Interpreter) checkValueTransferTargetType(value Value, targetType sema.Type) bool {
 
 	if targetType == nil {
@@ -2118,6 +2243,9 @@ func (interpreter *Interpreter) transferAndConvert(
 	getLocationRange func() LocationRange,
 ) Value {
 
+	valueType = interpreter.substituteTypeArguments(valueType)
+	targetType = interpreter.substituteTypeArguments(targetType)
+
 	transferredValue := value.Transfer(
 		interpreter,
 		getLocationRange,
@@ -2620,6 +2748,7 @@ func (interpreter *Interpreter) NewSubInterpreter(
 		WithPredeclaredValues(interpreter.PredeclaredValues),
 		WithOnEventEmittedHandler(interpreter.onEventEmitted),
 		WithOnStatementHandler(interpreter.onStatement),
+		WithOnBranchHandler(interpreter.onBranch),
 		WithOnLoopIterationHandler(interpreter.onLoopIteration),
 		WithOnFunctionInvocationHandler(interpreter.onFunctionInvocation),
 		WithOnInvokedFunctionReturnHandler(interpreter.onInvokedFunctionReturn),
@@ -2632,6 +2761,7 @@ func (interpreter *Interpreter) NewSubInterpreter(
 		WithAtreeStorageValidationEnabled(interpreter.atreeStorageValidationEnabled),
 		withTypeCodes(interpreter.typeCodes),
 		withReferencedResourceKindedValues(interpreter.referencedResourceKindedValues),
+		withCallStack(interpreter.callStack),
 		WithPublicAccountHandler(interpreter.publicAccountHandler),
 		WithPublicKeyValidationHandler(interpreter.PublicKeyValidationHandler),
 		WithSignatureVerificationHandler(interpreter.SignatureVerificationHandler),
@@ -2647,6 +2777,7 @@ func (interpreter *Interpreter) NewSubInterpreter(
 		WithOnRecordTraceHandler(interpreter.onRecordTrace),
 		WithOnResourceOwnerChangeHandler(interpreter.onResourceOwnerChange),
 		WithOnMeterComputationFuncHandler(interpreter.onMeterComputation),
+		WithOnMeterMemoryFuncHandler(interpreter.onMeterMemory),
 	}
 
 	return NewInterpreter(
@@ -3416,12 +3547,85 @@ var stringFunction = func() Value {
 				}
 
 				bytes, _ := ByteArrayValueToByteSlice(argument)
+				invocation.Interpreter.ReportMemoryUsage(
+					common.MemoryKindString,
+					uint(hex.EncodedLen(len(bytes))),
+				)
+				invocation.Interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
 				return NewStringValue(hex.EncodeToString(bytes))
 			},
 			sema.StringTypeEncodeHexFunctionType,
 		),
 	)
 
+	addMember(
+		sema.StringTypeJoinFunctionName,
+		NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				stringArray, ok := invocation.Arguments[0].(*ArrayValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				separator, ok := invocation.Arguments[1].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				strs := make([]string, 0, stringArray.Count())
+				length := 0
+
+				stringArray.Iterate(func(element Value) (resume bool) {
+					str, ok := element.(*StringValue)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+
+					if len(strs) > 0 {
+						length += len(separator.Str)
+					}
+					length += len(str.Str)
+
+					strs = append(strs, str.Str)
+					return true
+				})
+
+				invocation.Interpreter.ReportMemoryUsage(
+					common.MemoryKindString,
+					uint(length),
+				)
+				invocation.Interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
+				return NewStringValue(strings.Join(strs, separator.Str))
+			},
+			sema.StringTypeJoinFunctionType,
+		),
+	)
+
+	addMember(
+		sema.StringTypeFromUTF8FunctionName,
+		NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				argument, ok := invocation.Arguments[0].(*ArrayValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				bytes, _ := ByteArrayValueToByteSlice(argument)
+				if !utf8.Valid(bytes) {
+					return NilValue{}
+				}
+
+				invocation.Interpreter.ReportMemoryUsage(
+					common.MemoryKindString,
+					uint(len(bytes)),
+				)
+				invocation.Interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
+				return NewSomeValueNonCopying(NewStringValue(string(bytes)))
+			},
+			sema.StringTypeFromUTF8FunctionType,
+		),
+	)
+
 	return functionValue
 }()
 
@@ -4010,6 +4214,145 @@ func (interpreter *Interpreter) authAccountUnlinkFunction(addressValue AddressVa
 	)
 }
 
+// storedPaths returns the paths under which values are stored in the given domain of the given account.
+//
+// The paths are returned in the iteration order of the storage map.
+// Each iterated entry is metered
+//
+func (interpreter *Interpreter) storedPaths(address common.Address, domain common.PathDomain) []PathValue {
+	storageMap := interpreter.Storage.GetStorageMap(address, domain.Identifier())
+	iterator := storageMap.Iterator()
+
+	var paths []PathValue
+
+	for {
+		identifier := iterator.NextKey()
+		if identifier == "" {
+			break
+		}
+
+		interpreter.ReportComputation(common.ComputationKindStorageIteration, 1)
+
+		paths = append(
+			paths,
+			PathValue{
+				Domain:     domain,
+				Identifier: identifier,
+			},
+		)
+	}
+
+	return paths
+}
+
+func (interpreter *Interpreter) accountPaths(
+	addressValue AddressValue,
+	domain common.PathDomain,
+	pathType StaticType,
+) *ArrayValue {
+
+	paths := interpreter.storedPaths(addressValue.ToAddress(), domain)
+
+	values := make([]Value, len(paths))
+	for i, path := range paths {
+		values[i] = path
+	}
+
+	return NewArrayValue(
+		interpreter,
+		VariableSizedStaticType{
+			Type: pathType,
+		},
+		common.Address{},
+		values...,
+	)
+}
+
+// accountForEachFunction returns a function which iterates over the values stored in the given domain of the given account.
+//
+// The iteration is performed over the paths which are stored when the iteration starts,
+// so mutations of the storage during the iteration do not affect the iteration:
+// Values which are removed before they are visited are skipped,
+// and values which are added during the iteration are not visited.
+//
+func (interpreter *Interpreter) accountForEachFunction(
+	addressValue AddressValue,
+	domain common.PathDomain,
+	functionType *sema.FunctionType,
+) *HostFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	callbackType, ok := functionType.Parameters[0].TypeAnnotation.Type.(*sema.FunctionType)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	argumentTypes := make([]sema.Type, len(callbackType.Parameters))
+	for i, parameter := range callbackType.Parameters {
+		argumentTypes[i] = parameter.TypeAnnotation.Type
+	}
+
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+			function, ok := invocation.Arguments[0].(FunctionValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			paths := interpreter.storedPaths(address, domain)
+
+			for _, path := range paths {
+
+				value := interpreter.ReadStored(address, domain.Identifier(), path.Identifier)
+				if value == nil {
+					continue
+				}
+
+				result := function.invoke(
+					Invocation{
+						Arguments: []Value{
+							path,
+							TypeValue{
+								Type: storedValueStaticType(value),
+							},
+						},
+						ArgumentTypes:    argumentTypes,
+						GetLocationRange: invocation.GetLocationRange,
+						Interpreter:      invocation.Interpreter,
+					},
+				)
+
+				shouldContinue, ok := result.(BoolValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				if !shouldContinue {
+					break
+				}
+			}
+
+			return VoidValue{}
+		},
+		functionType,
+	)
+}
+
+// storedValueStaticType returns the type of the given stored value.
+// Links have the type of the capability they provide
+//
+func storedValueStaticType(value Value) StaticType {
+	if link, ok := value.(LinkValue); ok {
+		return CapabilityStaticType{
+			BorrowType: link.Type,
+		}
+	}
+
+	return value.StaticType()
+}
+
 func (interpreter *Interpreter) capabilityBorrowFunction(
 	addressValue AddressValue,
 	pathValue PathValue,
@@ -4335,6 +4678,12 @@ func (interpreter *Interpreter) reportLoopIteration(pos ast.HasPosition) {
 	}
 }
 
+func (interpreter *Interpreter) reportBranch(element ast.Element, branch int) {
+	if interpreter.onBranch != nil {
+		interpreter.onBranch(interpreter, element, branch)
+	}
+}
+
 func (interpreter *Interpreter) reportFunctionInvocation(line int) {
 	if interpreter.onMeterComputation != nil {
 		interpreter.onMeterComputation(common.ComputationKindFunctionInvocation, 1)
@@ -4358,6 +4707,35 @@ func (interpreter *Interpreter) ReportComputation(compKind common.ComputationKin
 	}
 }
 
+func (interpreter *Interpreter) ReportMemoryUsage(kind common.MemoryKind, amount uint) {
+	if interpreter.onMeterMemory != nil {
+		interpreter.onMeterMemory(kind, amount)
+	}
+}
+
+// reportBigIntMemoryUsage reports the memory usage of the given value,
+// if it is an arbitrary-precision integer, i.e. an Int or UInt
+//
+func (interpreter *Interpreter) reportBigIntMemoryUsage(value Value) Value {
+	var bigInt *big.Int
+	switch value := value.(type) {
+	case IntValue:
+		bigInt = value.BigInt
+	case UIntValue:
+		bigInt = value.BigInt
+	default:
+		return value
+	}
+
+	interpreter.ReportMemoryUsage(common.MemoryKindBigInt, bigIntByteLength(bigInt))
+
+	return value
+}
+
+func bigIntByteLength(value *big.Int) uint {
+	return uint(value.BitLen()+7) / 8
+}
+
 // getMember gets the member value by the given identifier from the given Value depending on its type.
 // May return nil if the member does not exist.
 func (interpreter *Interpreter) getMember(self Value, getLocationRange func() LocationRange, identifier string) Value {
@@ -200,7 +200,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Plus(right)
+		return interpreter.reportBigIntMemoryUsage(left.Plus(right))
 
 	case ast.OperationMinus:
 		left, leftOk := leftValue.(NumberValue)
@@ -208,7 +208,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Minus(right)
+		return interpreter.reportBigIntMemoryUsage(left.Minus(right))
 
 	case ast.OperationMod:
 		left, leftOk := leftValue.(NumberValue)
@@ -216,7 +216,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Mod(right)
+		return interpreter.reportBigIntMemoryUsage(left.Mod(right))
 
 	case ast.OperationMul:
 		left, leftOk := leftValue.(NumberValue)
@@ -224,7 +224,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Mul(right)
+		return interpreter.reportBigIntMemoryUsage(left.Mul(right))
 
 	case ast.OperationDiv:
 		left, leftOk := leftValue.(NumberValue)
@@ -232,7 +232,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.Div(right)
+		return interpreter.reportBigIntMemoryUsage(left.Div(right))
 
 	case ast.OperationBitwiseOr:
 		left, leftOk := leftValue.(IntegerValue)
@@ -240,7 +240,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseOr(right)
+		return interpreter.reportBigIntMemoryUsage(left.BitwiseOr(right))
 
 	case ast.OperationBitwiseXor:
 		left, leftOk := leftValue.(IntegerValue)
@@ -248,7 +248,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseXor(right)
+		return interpreter.reportBigIntMemoryUsage(left.BitwiseXor(right))
 
 	case ast.OperationBitwiseAnd:
 		left, leftOk := leftValue.(IntegerValue)
@@ -256,7 +256,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseAnd(right)
+		return interpreter.reportBigIntMemoryUsage(left.BitwiseAnd(right))
 
 	case ast.OperationBitwiseLeftShift:
 		left, leftOk := leftValue.(IntegerValue)
@@ -264,7 +264,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseLeftShift(right)
+		return interpreter.reportBigIntMemoryUsage(left.BitwiseLeftShift(right))
 
 	case ast.OperationBitwiseRightShift:
 		left, leftOk := leftValue.(IntegerValue)
@@ -272,7 +272,7 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		if !leftOk || !rightOk {
 			error(right)
 		}
-		return left.BitwiseRightShift(right)
+		return interpreter.reportBigIntMemoryUsage(left.BitwiseRightShift(right))
 
 	case ast.OperationLess:
 		left, leftOk := leftValue.(NumberValue)
@@ -357,9 +357,12 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 
 		// only evaluate right-hand side if left-hand side is nil
 		if some, ok := leftValue.(*SomeValue); ok {
+			interpreter.reportBranch(expression, 0)
 			return some.InnerValue(interpreter, getLocationRange)
 		}
 
+		interpreter.reportBranch(expression, 1)
+
 		value := rightValue()
 
 		rightType := interpreter.Program.Elaboration.BinaryExpressionRightTypes[expression]
@@ -415,7 +418,7 @@ func (interpreter *Interpreter) VisitUnaryExpression(expression *ast.UnaryExpres
 		if !ok {
 			panic(errors.NewUnreachableError())
 		}
-		return integerValue.Negate()
+		return interpreter.reportBigIntMemoryUsage(integerValue.Negate())
 
 	case ast.OperationMove:
 		interpreter.invalidateResource(value)
@@ -448,7 +451,7 @@ func (interpreter *Interpreter) VisitIntegerExpression(expression *ast.IntegerEx
 
 	// The ranges are checked at the checker level.
 	// Hence it is safe to create the value without validation.
-	return NewIntValue(value, typ)
+	return interpreter.reportBigIntMemoryUsage(NewIntValue(value, typ))
 
 }
 
@@ -541,6 +544,9 @@ func (interpreter *Interpreter) VisitStringExpression(expression *ast.StringExpr
 		return NewCharacterValue(expression.Value)
 	}
 
+	interpreter.ReportMemoryUsage(common.MemoryKindString, uint(len(expression.Value)))
+	interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
+
 	return NewStringValue(expression.Value)
 }
 
@@ -549,6 +555,7 @@ func (interpreter *Interpreter) VisitArrayExpression(expression *ast.ArrayExpres
 
 	argumentTypes := interpreter.Program.Elaboration.ArrayExpressionArgumentTypes[expression]
 	arrayType := interpreter.Program.Elaboration.ArrayExpressionArrayType[expression]
+	arrayType = interpreter.substituteTypeArguments(arrayType).(sema.ArrayType)
 	elementType := arrayType.ElementType(false)
 
 	copies := make([]Value, len(values))
@@ -575,6 +582,7 @@ func (interpreter *Interpreter) VisitDictionaryExpression(expression *ast.Dictio
 
 	entryTypes := interpreter.Program.Elaboration.DictionaryExpressionEntryTypes[expression]
 	dictionaryType := interpreter.Program.Elaboration.DictionaryExpressionType[expression]
+	dictionaryType = interpreter.substituteTypeArguments(dictionaryType).(*sema.DictionaryType)
 
 	var keyValuePairs []Value
 
@@ -631,8 +639,10 @@ func (interpreter *Interpreter) VisitConditionalExpression(expression *ast.Condi
 		panic(errors.NewUnreachableError())
 	}
 	if value {
+		interpreter.reportBranch(expression, 0)
 		return interpreter.evalExpression(expression.Then)
 	} else {
+		interpreter.reportBranch(expression, 1)
 		return interpreter.evalExpression(expression.Else)
 	}
 }
@@ -700,6 +710,28 @@ func (interpreter *Interpreter) VisitInvocationExpression(invocationExpression *
 	parameterTypes :=
 		interpreter.Program.Elaboration.InvocationExpressionParameterTypes[invocationExpression]
 
+	// The types may refer to type parameters which are in scope,
+	// e.g. when a function is invoked in a generic function
+
+	if interpreter.typeArguments != nil {
+		if typeParameterTypes != nil {
+			substitutedTypeParameterTypes := sema.NewTypeParameterTypeOrderedMap()
+			typeParameterTypes.Foreach(func(typeParameter *sema.TypeParameter, ty sema.Type) {
+				substitutedTypeParameterTypes.Set(
+					typeParameter,
+					interpreter.substituteTypeArguments(ty),
+				)
+			})
+			typeParameterTypes = substitutedTypeParameterTypes
+		}
+
+		substitutedArgumentTypes := make([]sema.Type, len(argumentTypes))
+		for i, argumentType := range argumentTypes {
+			substitutedArgumentTypes[i] = interpreter.substituteTypeArguments(argumentType)
+		}
+		argumentTypes = substitutedArgumentTypes
+	}
+
 	line := invocationExpression.StartPosition().Line
 
 	interpreter.reportFunctionInvocation(line)
@@ -785,6 +817,7 @@ func (interpreter *Interpreter) VisitFunctionExpression(expression *ast.Function
 		ParameterList:    expression.ParameterList,
 		Type:             functionType,
 		Activation:       lexicalScope,
+		TypeArguments:    interpreter.typeArguments,
 		BeforeStatements: beforeStatements,
 		PreConditions:    preConditions,
 		Statements:       statements,
@@ -798,6 +831,7 @@ func (interpreter *Interpreter) VisitCastingExpression(expression *ast.CastingEx
 	getLocationRange := locationRangeGetter(interpreter.Location, expression.Expression)
 
 	expectedType := interpreter.Program.Elaboration.CastingTargetTypes[expression]
+	expectedType = interpreter.substituteTypeArguments(expectedType)
 
 	switch expression.Operation {
 	case ast.OperationFailableCast, ast.OperationForceCast:
@@ -833,6 +867,7 @@ func (interpreter *Interpreter) VisitCastingExpression(expression *ast.CastingEx
 
 	case ast.OperationCast:
 		staticValueType := interpreter.Program.Elaboration.CastingStaticValueTypes[expression]
+		staticValueType = interpreter.substituteTypeArguments(staticValueType)
 		// The cast may upcast to an optional type, e.g. `1 as Int?`, so box
 		return interpreter.ConvertAndBox(getLocationRange, value, staticValueType, expectedType)
 
@@ -860,6 +895,7 @@ func (interpreter *Interpreter) VisitDestroyExpression(expression *ast.DestroyEx
 func (interpreter *Interpreter) VisitReferenceExpression(referenceExpression *ast.ReferenceExpression) ast.Repr {
 
 	borrowType := interpreter.Program.Elaboration.ReferenceExpressionBorrowTypes[referenceExpression]
+	borrowType = interpreter.substituteTypeArguments(borrowType)
 
 	result := interpreter.evalExpression(referenceExpression.Expression)
 
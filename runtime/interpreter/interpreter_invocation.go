@@ -114,6 +114,26 @@ func (interpreter *Interpreter) invokeInterpretedFunction(
 	invocation Invocation,
 ) Value {
 
+	if interpreter.debugger != nil {
+		interpreter.debugger.onInvocationStart(function, invocation)
+		defer interpreter.debugger.onInvocationEnd()
+	}
+
+	interpreter.callStack.push(function, invocation.GetLocationRange)
+	defer interpreter.callStack.pop()
+
+	// Bring the type arguments of the invocation into scope, if any,
+	// so the type parameters in the types of the function body can be substituted
+
+	typeArguments := interpreter.invocationTypeArguments(function, invocation)
+	if typeArguments != interpreter.typeArguments {
+		previousTypeArguments := interpreter.typeArguments
+		interpreter.typeArguments = typeArguments
+		defer func() {
+			interpreter.typeArguments = previousTypeArguments
+		}()
+	}
+
 	// Start a new activation record.
 	// Lexical scope: use the function declaration's activation record,
 	// not the current one (which would be dynamic scope)
@@ -128,6 +148,75 @@ func (interpreter *Interpreter) invokeInterpretedFunction(
 	return interpreter.invokeInterpretedFunctionActivated(function, invocation.Arguments)
 }
 
+// invocationTypeArguments returns the type arguments which are in scope
+// during the invocation of the given function:
+// The type arguments in scope of the function's declaration,
+// the type arguments of the type of `self`, if it is an instance of a generic composite type,
+// and the type arguments of the invocation, if the function is generic.
+//
+func (interpreter *Interpreter) invocationTypeArguments(
+	function *InterpretedFunctionValue,
+	invocation Invocation,
+) *sema.TypeParameterTypeOrderedMap {
+
+	var selfType *sema.CompositeType
+	if self, ok := invocation.Self.(*CompositeValue); ok && len(self.TypeArguments) > 0 {
+		dynamicType := self.DynamicType(interpreter, SeenReferences{}).(CompositeDynamicType)
+		selfType = dynamicType.StaticType.(*sema.CompositeType)
+	}
+
+	invocationTypeArguments := invocation.TypeParameterTypes
+	if len(function.Type.TypeParameters) == 0 {
+		invocationTypeArguments = nil
+	}
+
+	if selfType == nil &&
+		(invocationTypeArguments == nil || invocationTypeArguments.Len() == 0) {
+
+		return function.TypeArguments
+	}
+
+	typeArguments := sema.NewTypeParameterTypeOrderedMap()
+
+	setTypeArgument := func(typeParameter *sema.TypeParameter, ty sema.Type) {
+		typeArguments.Set(typeParameter, ty)
+	}
+
+	if function.TypeArguments != nil {
+		function.TypeArguments.Foreach(setTypeArgument)
+	}
+
+	if selfType != nil {
+		selfTypeArguments := selfType.TypeArguments()
+		for i, typeParameter := range selfType.GenericType().TypeParameters() {
+			typeArguments.Set(typeParameter, selfTypeArguments[i])
+		}
+	}
+
+	if invocationTypeArguments != nil {
+		invocationTypeArguments.Foreach(setTypeArgument)
+	}
+
+	return typeArguments
+}
+
+// substituteTypeArguments returns the given type,
+// with the type parameters substituted with the type arguments which are in scope, if any
+//
+func (interpreter *Interpreter) substituteTypeArguments(ty sema.Type) sema.Type {
+	typeArguments := interpreter.typeArguments
+	if ty == nil || typeArguments == nil {
+		return ty
+	}
+
+	resolvedType := ty.Resolve(typeArguments)
+	if resolvedType == nil {
+		return ty
+	}
+
+	return resolvedType
+}
+
 // NOTE: assumes the function's activation (or an extension of it) is pushed!
 //
 func (interpreter *Interpreter) invokeInterpretedFunctionActivated(
@@ -147,7 +236,7 @@ func (interpreter *Interpreter) invokeInterpretedFunctionActivated(
 			return interpreter.visitStatements(function.Statements)
 		},
 		function.PostConditions,
-		function.Type.ReturnTypeAnnotation.Type,
+		interpreter.substituteTypeArguments(function.Type.ReturnTypeAnnotation.Type),
 	)
 }
 
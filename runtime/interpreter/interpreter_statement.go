@@ -37,10 +37,6 @@ func (interpreter *Interpreter) evalStatement(statement ast.Statement) interface
 
 	interpreter.statement = statement
 
-	if interpreter.onMeterComputation != nil {
-		interpreter.onMeterComputation(common.ComputationKindStatement, 1)
-	}
-
 	if interpreter.debugger != nil {
 		interpreter.debugger.onStatement(interpreter, statement)
 	}
@@ -49,6 +45,10 @@ func (interpreter *Interpreter) evalStatement(statement ast.Statement) interface
 		interpreter.onStatement(interpreter, statement)
 	}
 
+	if interpreter.onMeterComputation != nil {
+		interpreter.onMeterComputation(common.ComputationKindStatement, 1)
+	}
+
 	return statement.Accept(interpreter)
 }
 
@@ -96,17 +96,17 @@ func (interpreter *Interpreter) VisitContinueStatement(_ *ast.ContinueStatement)
 func (interpreter *Interpreter) VisitIfStatement(statement *ast.IfStatement) ast.Repr {
 	switch test := statement.Test.(type) {
 	case ast.Expression:
-		return interpreter.visitIfStatementWithTestExpression(test, statement.Then, statement.Else)
+		return interpreter.visitIfStatementWithTestExpression(statement, test)
 	case *ast.VariableDeclaration:
-		return interpreter.visitIfStatementWithVariableDeclaration(test, statement.Then, statement.Else)
+		return interpreter.visitIfStatementWithVariableDeclaration(statement, test)
 	default:
 		panic(errors.NewUnreachableError())
 	}
 }
 
 func (interpreter *Interpreter) visitIfStatementWithTestExpression(
+	statement *ast.IfStatement,
 	test ast.Expression,
-	thenBlock, elseBlock *ast.Block,
 ) controlReturn {
 
 	value, ok := interpreter.evalExpression(test).(BoolValue)
@@ -115,9 +115,13 @@ func (interpreter *Interpreter) visitIfStatementWithTestExpression(
 	}
 	var result interface{}
 	if value {
-		result = thenBlock.Accept(interpreter)
-	} else if elseBlock != nil {
-		result = elseBlock.Accept(interpreter)
+		interpreter.reportBranch(statement, 0)
+		result = statement.Then.Accept(interpreter)
+	} else {
+		interpreter.reportBranch(statement, 1)
+		if statement.Else != nil {
+			result = statement.Else.Accept(interpreter)
+		}
 	}
 
 	if ret, ok := result.(controlReturn); ok {
@@ -127,8 +131,8 @@ func (interpreter *Interpreter) visitIfStatementWithTestExpression(
 }
 
 func (interpreter *Interpreter) visitIfStatementWithVariableDeclaration(
+	statement *ast.IfStatement,
 	declaration *ast.VariableDeclaration,
-	thenBlock, elseBlock *ast.Block,
 ) controlReturn {
 
 	// NOTE: It is *REQUIRED* that the getter for the value is used
@@ -188,9 +192,13 @@ func (interpreter *Interpreter) visitIfStatementWithVariableDeclaration(
 			transferredUnwrappedValue,
 		)
 
-		result = thenBlock.Accept(interpreter)
-	} else if elseBlock != nil {
-		result = elseBlock.Accept(interpreter)
+		interpreter.reportBranch(statement, 0)
+		result = statement.Then.Accept(interpreter)
+	} else {
+		interpreter.reportBranch(statement, 1)
+		if statement.Else != nil {
+			result = statement.Else.Accept(interpreter)
+		}
 	}
 
 	if ret, ok := result.(controlReturn); ok {
@@ -206,9 +214,11 @@ func (interpreter *Interpreter) VisitSwitchStatement(switchStatement *ast.Switch
 		panic(errors.NewUnreachableError())
 	}
 
-	for _, switchCase := range switchStatement.Cases {
+	for i, switchCase := range switchStatement.Cases {
 
 		runStatements := func() ast.Repr {
+			interpreter.reportBranch(switchStatement, i)
+
 			// NOTE: the new block ensures that a new scope is introduced
 
 			block := &ast.Block{
@@ -255,6 +265,8 @@ func (interpreter *Interpreter) VisitSwitchStatement(switchStatement *ast.Switch
 		// then try the next case
 	}
 
+	interpreter.reportBranch(switchStatement, len(switchStatement.Cases))
+
 	return nil
 }
 
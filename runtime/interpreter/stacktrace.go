@@ -0,0 +1,112 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// StackTraceFrame is a frame of the call stack trace of an error,
+// the invocation of a Cadence function.
+//
+// The location range is the range of the invocation, if any
+//
+type StackTraceFrame struct {
+	// FunctionName is the name of the invoked function, if any
+	FunctionName string
+	// FunctionLocation is the location of the program which declares the invoked function
+	FunctionLocation common.Location
+	LocationRange
+}
+
+var _ errors.StackTraceFrame = StackTraceFrame{}
+
+func (f StackTraceFrame) Message() string {
+	if f.FunctionName == "" {
+		return "call of function"
+	}
+	return fmt.Sprintf("call of function `%s`", f.FunctionName)
+}
+
+// callStackEntry is the invocation of a function on the call stack.
+// The location range of the invocation is only determined
+// when a stack trace is produced
+//
+type callStackEntry struct {
+	function         *InterpretedFunctionValue
+	getLocationRange func() LocationRange
+}
+
+// callStack is the stack of the Cadence functions which are currently invoked.
+// It is shared by all interpreters of a program and its imports
+//
+type callStack struct {
+	entries []callStackEntry
+}
+
+func (s *callStack) push(function *InterpretedFunctionValue, getLocationRange func() LocationRange) {
+	s.entries = append(
+		s.entries,
+		callStackEntry{
+			function:         function,
+			getLocationRange: getLocationRange,
+		},
+	)
+}
+
+func (s *callStack) pop() {
+	lastIndex := len(s.entries) - 1
+	s.entries[lastIndex] = callStackEntry{}
+	s.entries = s.entries[:lastIndex]
+}
+
+// stackTrace returns the frames of the call stack,
+// starting with the most recent invocation
+//
+func (s *callStack) stackTrace() []StackTraceFrame {
+	count := len(s.entries)
+	if count == 0 {
+		return nil
+	}
+
+	frames := make([]StackTraceFrame, 0, count)
+	for i := count - 1; i >= 0; i-- {
+		entry := s.entries[i]
+
+		frame := StackTraceFrame{
+			FunctionName:     entry.function.Name,
+			FunctionLocation: entry.function.Interpreter.Location,
+		}
+		if entry.getLocationRange != nil {
+			frame.LocationRange = entry.getLocationRange()
+		}
+
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+// CallStack returns the frames of the call stack of the Cadence functions which are currently invoked,
+// starting with the most recent invocation
+//
+func (interpreter *Interpreter) CallStack() []StackTraceFrame {
+	return interpreter.callStack.stackTrace()
+}
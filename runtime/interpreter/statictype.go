@@ -49,7 +49,11 @@ type StaticType interface {
 type CompositeStaticType struct {
 	Location            common.Location
 	QualifiedIdentifier string
-	TypeID              common.TypeID
+	// TypeID is the type ID of the (generic) composite type,
+	// i.e. it does not include the type arguments
+	TypeID common.TypeID
+	// TypeArguments are the type arguments of an instantiated generic composite type
+	TypeArguments []StaticType
 }
 
 var _ StaticType = CompositeStaticType{}
@@ -68,19 +72,42 @@ func NewCompositeStaticType(location common.Location, qualifiedIdentifier string
 func (CompositeStaticType) isStaticType() {}
 
 func (t CompositeStaticType) String() string {
+	var result string
 	if t.Location == nil {
-		return t.QualifiedIdentifier
+		result = t.QualifiedIdentifier
+	} else {
+		result = string(t.TypeID)
 	}
-	return string(t.TypeID)
+
+	if len(t.TypeArguments) > 0 {
+		typeArguments := make([]string, len(t.TypeArguments))
+
+		for i, typeArgument := range t.TypeArguments {
+			typeArguments[i] = typeArgument.String()
+		}
+
+		result = fmt.Sprintf("%s<%s>", result, strings.Join(typeArguments, ", "))
+	}
+
+	return result
 }
 
 func (t CompositeStaticType) Equal(other StaticType) bool {
 	otherCompositeType, ok := other.(CompositeStaticType)
-	if !ok {
+	if !ok ||
+		otherCompositeType.TypeID != t.TypeID ||
+		len(otherCompositeType.TypeArguments) != len(t.TypeArguments) {
+
 		return false
 	}
 
-	return otherCompositeType.TypeID == t.TypeID
+	for i, typeArgument := range t.TypeArguments {
+		if !typeArgument.Equal(otherCompositeType.TypeArguments[i]) {
+			return false
+		}
+	}
+
+	return true
 }
 
 // InterfaceStaticType
@@ -344,11 +371,7 @@ func (t CapabilityStaticType) Equal(other StaticType) bool {
 func ConvertSemaToStaticType(t sema.Type) StaticType {
 	switch t := t.(type) {
 	case *sema.CompositeType:
-		return CompositeStaticType{
-			Location:            t.Location,
-			QualifiedIdentifier: t.QualifiedIdentifier(),
-			TypeID:              t.ID(),
-		}
+		return ConvertSemaCompositeTypeToStaticCompositeType(t)
 
 	case *sema.InterfaceType:
 		return ConvertSemaInterfaceTypeToStaticInterfaceType(t)
@@ -431,6 +454,34 @@ func ConvertSemaReferenceTyoeToStaticReferenceType(t *sema.ReferenceType) Refere
 	}
 }
 
+func ConvertSemaCompositeTypeToStaticCompositeType(t *sema.CompositeType) CompositeStaticType {
+
+	// An instantiated generic composite type is represented
+	// by the generic composite type and the type arguments
+
+	genericType := t.GenericType()
+	if genericType == nil {
+		return CompositeStaticType{
+			Location:            t.Location,
+			QualifiedIdentifier: t.QualifiedIdentifier(),
+			TypeID:              t.ID(),
+		}
+	}
+
+	semaTypeArguments := t.TypeArguments()
+	typeArguments := make([]StaticType, len(semaTypeArguments))
+	for i, typeArgument := range semaTypeArguments {
+		typeArguments[i] = ConvertSemaToStaticType(typeArgument)
+	}
+
+	return CompositeStaticType{
+		Location:            genericType.Location,
+		QualifiedIdentifier: genericType.QualifiedIdentifier(),
+		TypeID:              genericType.ID(),
+		TypeArguments:       typeArguments,
+	}
+}
+
 func ConvertSemaInterfaceTypeToStaticInterfaceType(t *sema.InterfaceType) InterfaceStaticType {
 	return InterfaceStaticType{
 		Location:            t.Location,
@@ -445,7 +496,20 @@ func ConvertStaticToSemaType(
 ) (_ sema.Type, err error) {
 	switch t := typ.(type) {
 	case CompositeStaticType:
-		return getComposite(t.Location, t.QualifiedIdentifier, t.TypeID)
+		compositeType, err := getComposite(t.Location, t.QualifiedIdentifier, t.TypeID)
+		if err != nil || len(t.TypeArguments) == 0 {
+			return compositeType, err
+		}
+
+		typeArguments := make([]sema.Type, len(t.TypeArguments))
+		for i, typeArgument := range t.TypeArguments {
+			typeArguments[i], err = ConvertStaticToSemaType(typeArgument, getInterface, getComposite)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return compositeType.Instantiate(typeArguments, nil), nil
 
 	case InterfaceStaticType:
 		return getInterface(t.Location, t.QualifiedIdentifier)
@@ -73,6 +73,7 @@ func ConvertStoredValue(value atree.Value) (Value, error) {
 				Location:            typeInfo.location,
 				QualifiedIdentifier: typeInfo.qualifiedIdentifier,
 				Kind:                typeInfo.kind,
+				TypeArguments:       typeInfo.typeArguments,
 			}, nil
 
 		default:
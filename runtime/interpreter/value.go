@@ -24,8 +24,11 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/onflow/atree"
 	"github.com/rivo/uniseg"
@@ -938,6 +941,11 @@ func (v *StringValue) GetMember(interpreter *Interpreter, _ func() LocationRange
 				if !ok {
 					panic(errors.NewUnreachableError())
 				}
+				interpreter.ReportMemoryUsage(
+					common.MemoryKindString,
+					uint(len(v.Str)+len(otherArray.Str)),
+				)
+				interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
 				return v.Concat(otherArray)
 			},
 			sema.StringTypeConcatFunctionType,
@@ -956,7 +964,13 @@ func (v *StringValue) GetMember(interpreter *Interpreter, _ func() LocationRange
 					panic(errors.NewUnreachableError())
 				}
 
-				return v.Slice(from, to, invocation.GetLocationRange)
+				result := v.Slice(from, to, invocation.GetLocationRange)
+				interpreter.ReportMemoryUsage(
+					common.MemoryKindString,
+					uint(len(result.(*StringValue).Str)),
+				)
+				interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
+				return result
 			},
 			sema.StringTypeSliceFunctionType,
 		)
@@ -972,10 +986,92 @@ func (v *StringValue) GetMember(interpreter *Interpreter, _ func() LocationRange
 	case "toLower":
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
+				interpreter.ReportMemoryUsage(common.MemoryKindString, uint(v.mappedLength(unicode.ToLower)))
+				interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
 				return v.ToLower()
 			},
 			sema.StringTypeToLowerFunctionType,
 		)
+
+	case "toUpper":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				interpreter.ReportMemoryUsage(common.MemoryKindString, uint(v.mappedLength(unicode.ToUpper)))
+				interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
+				return v.ToUpper()
+			},
+			sema.StringTypeToUpperFunctionType,
+		)
+
+	case "trim":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				result := v.Trim()
+				interpreter.ReportMemoryUsage(common.MemoryKindString, uint(len(result.Str)))
+				interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
+				return result
+			},
+			sema.StringTypeTrimFunctionType,
+		)
+
+	case "split":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				separator, ok := invocation.Arguments[0].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				// The parts of the string are at most as long as the string itself
+				interpreter.ReportMemoryUsage(common.MemoryKindString, uint(len(v.Str)))
+				return v.Split(invocation.Interpreter, separator)
+			},
+			sema.StringTypeSplitFunctionType,
+		)
+
+	case "contains":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other, ok := invocation.Arguments[0].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Contains(invocation.Interpreter, other)
+			},
+			sema.StringTypeContainsFunctionType,
+		)
+
+	case "index":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other, ok := invocation.Arguments[0].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.IndexOf(invocation.Interpreter, other)
+			},
+			sema.StringTypeIndexFunctionType,
+		)
+
+	case "replaceAll":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				original, ok := invocation.Arguments[0].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				replacement, ok := invocation.Arguments[1].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.ReplaceAll(invocation.Interpreter, original, replacement)
+			},
+			sema.StringTypeReplaceAllFunctionType,
+		)
 	}
 
 	return nil
@@ -1009,6 +1105,260 @@ func (v *StringValue) ToLower() *StringValue {
 	return NewStringValue(strings.ToLower(v.Str))
 }
 
+func (v *StringValue) ToUpper() *StringValue {
+	return NewStringValue(strings.ToUpper(v.Str))
+}
+
+// mappedLength returns the number of bytes of the string
+// after the given mapping is applied to each code point, e.g. unicode.ToUpper,
+// without allocating the mapped string
+//
+func (v *StringValue) mappedLength(mapping func(rune) rune) int {
+	length := 0
+	for _, r := range v.Str {
+		length += utf8.RuneLen(mapping(r))
+	}
+	return length
+}
+
+// characterIndices returns, for each byte offset of the string, including the end of the string,
+// the index of the character (grapheme cluster) which starts at the offset,
+// or -1 if the offset is not at a character boundary.
+// The computation reported for the indices also covers searching the string for occurrences
+//
+func (v *StringValue) characterIndices(interpreter *Interpreter) []int {
+	interpreter.ReportComputation(common.ComputationKindSearchStringValue, uint(len(v.Str)))
+	interpreter.ReportMemoryUsage(common.MemoryKindStringCharacterIndex, uint(len(v.Str)+1))
+
+	indices := make([]int, len(v.Str)+1)
+	for i := range indices {
+		indices[i] = -1
+	}
+
+	index := 0
+
+	v.prepareGraphemes()
+	for v.graphemes.Next() {
+		start, _ := v.graphemes.Positions()
+		indices[start] = index
+		index++
+	}
+	indices[len(v.Str)] = index
+
+	v.length = index
+
+	return indices
+}
+
+// forEachOccurrence calls the given function with the byte offsets of the start and the end
+// of each non-overlapping occurrence of the given non-empty string in the string,
+// which starts and ends at a character boundary, until the function returns false
+//
+func (v *StringValue) forEachOccurrence(
+	other string,
+	characterIndices []int,
+	f func(start, end int) (resume bool),
+) {
+	offset := 0
+	for offset < len(v.Str) {
+		index := strings.Index(v.Str[offset:], other)
+		if index < 0 {
+			return
+		}
+
+		start := offset + index
+		end := start + len(other)
+
+		if characterIndices[start] < 0 || characterIndices[end] < 0 {
+			// The occurrence is only part of a character, e.g. a letter followed by a combining mark
+			offset = start + 1
+			continue
+		}
+
+		if !f(start, end) {
+			return
+		}
+
+		offset = end
+	}
+}
+
+// Split returns the substrings of the string which are separated by the given separator.
+// Only occurrences of the separator which start and end at character boundaries separate the string.
+// If the separator is empty, the string is split into its characters
+//
+func (v *StringValue) Split(interpreter *Interpreter, separator *StringValue) *ArrayValue {
+	var values []Value
+
+	if len(separator.Str) == 0 {
+		v.prepareGraphemes()
+		for v.graphemes.Next() {
+			interpreter.ReportComputation(common.ComputationKindSplitStringValue, 1)
+			interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
+			values = append(values, NewStringValue(v.graphemes.Str()))
+		}
+	} else {
+		start := 0
+
+		v.forEachOccurrence(
+			separator.Str,
+			v.characterIndices(interpreter),
+			func(occurrenceStart, occurrenceEnd int) bool {
+				interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
+				values = append(values, NewStringValue(v.Str[start:occurrenceStart]))
+				start = occurrenceEnd
+				return true
+			},
+		)
+
+		interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
+		values = append(values, NewStringValue(v.Str[start:]))
+	}
+
+	return NewArrayValue(
+		interpreter,
+		StringArrayStaticType,
+		common.Address{},
+		values...,
+	)
+}
+
+// Contains returns true if the string contains the given string,
+// starting and ending at character boundaries
+//
+func (v *StringValue) Contains(interpreter *Interpreter, other *StringValue) BoolValue {
+	if len(other.Str) == 0 {
+		return true
+	}
+
+	var found bool
+
+	v.forEachOccurrence(
+		other.Str,
+		v.characterIndices(interpreter),
+		func(_, _ int) bool {
+			found = true
+			return false
+		},
+	)
+
+	return BoolValue(found)
+}
+
+// IndexOf returns the character index of the first occurrence of the given string,
+// starting and ending at character boundaries, or nil if the string does not contain it
+//
+func (v *StringValue) IndexOf(interpreter *Interpreter, other *StringValue) OptionalValue {
+	if len(other.Str) == 0 {
+		return NewSomeValueNonCopying(NewIntValueFromInt64(0))
+	}
+
+	characterIndices := v.characterIndices(interpreter)
+
+	var result OptionalValue = NilValue{}
+
+	v.forEachOccurrence(
+		other.Str,
+		characterIndices,
+		func(start, _ int) bool {
+			index := characterIndices[start]
+			result = NewSomeValueNonCopying(NewIntValueFromInt64(int64(index)))
+			return false
+		},
+	)
+
+	return result
+}
+
+// ReplaceAll returns a new string in which all occurrences of the given original string,
+// starting and ending at character boundaries, are replaced with the given replacement string.
+// If the original string is empty, the string is returned unchanged.
+//
+// The memory of the new string is reported before it is built
+//
+func (v *StringValue) ReplaceAll(interpreter *Interpreter, original *StringValue, replacement *StringValue) *StringValue {
+	if len(original.Str) == 0 {
+		interpreter.ReportMemoryUsage(common.MemoryKindString, uint(len(v.Str)))
+		interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
+		return NewStringValue(v.Str)
+	}
+
+	characterIndices := v.characterIndices(interpreter)
+
+	occurrences := 0
+
+	v.forEachOccurrence(
+		original.Str,
+		characterIndices,
+		func(_, _ int) bool {
+			occurrences++
+			return true
+		},
+	)
+
+	length := len(v.Str) + occurrences*(len(replacement.Str)-len(original.Str))
+
+	interpreter.ReportMemoryUsage(common.MemoryKindString, uint(length))
+	interpreter.ReportMemoryUsage(common.MemoryKindStringValue, 1)
+
+	var sb strings.Builder
+	sb.Grow(length)
+
+	start := 0
+
+	v.forEachOccurrence(
+		original.Str,
+		characterIndices,
+		func(occurrenceStart, occurrenceEnd int) bool {
+			sb.WriteString(v.Str[start:occurrenceStart])
+			sb.WriteString(replacement.Str)
+			start = occurrenceEnd
+			return true
+		},
+	)
+
+	sb.WriteString(v.Str[start:])
+
+	return NewStringValue(sb.String())
+}
+
+// Trim returns the string with the leading and trailing whitespace characters removed.
+// A character is whitespace if all its code points are whitespace,
+// so e.g. a space followed by a combining mark is not removed
+//
+func (v *StringValue) Trim() *StringValue {
+	start := len(v.Str)
+	end := 0
+
+	v.prepareGraphemes()
+	for v.graphemes.Next() {
+		if isWhitespaceCharacter(v.graphemes.Str()) {
+			continue
+		}
+
+		characterStart, characterEnd := v.graphemes.Positions()
+		if characterStart < start {
+			start = characterStart
+		}
+		end = characterEnd
+	}
+
+	if start >= end {
+		return NewStringValue("")
+	}
+
+	return NewStringValue(v.Str[start:end])
+}
+
+func isWhitespaceCharacter(character string) bool {
+	for _, r := range character {
+		if !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
 func (v *StringValue) Storable(storage atree.SlabStorage, address atree.Address, maxInlineSize uint64) (atree.Storable, error) {
 	return maybeLargeImmutableStorable(v, storage, address, maxInlineSize)
 }
@@ -1056,6 +1406,8 @@ func (*StringValue) ChildStorables() []atree.Storable {
 
 var ByteArrayStaticType = ConvertSemaArrayTypeToStaticArrayType(sema.ByteArrayType)
 
+var StringArrayStaticType = ConvertSemaArrayTypeToStaticArrayType(sema.StringArrayType)
+
 // DecodeHex hex-decodes this string and returns an array of UInt8 values
 //
 func (v *StringValue) DecodeHex(interpreter *Interpreter) *ArrayValue {
@@ -1190,6 +1542,9 @@ func NewArrayValueWithIterator(
 		array: array,
 	}
 
+	interpreter.ReportMemoryUsage(common.MemoryKindArray, 1)
+	interpreter.ReportMemoryUsage(common.MemoryKindArrayElement, uint(v.Count()))
+
 	return v
 }
 
@@ -1475,6 +1830,8 @@ func (v *ArrayValue) Append(interpreter *Interpreter, getLocationRange func() Lo
 
 	interpreter.checkContainerMutation(v.Type.ElementType(), element, getLocationRange)
 
+	interpreter.ReportMemoryUsage(common.MemoryKindArrayElement, 1)
+
 	element = element.Transfer(
 		interpreter,
 		getLocationRange,
@@ -1521,6 +1878,8 @@ func (v *ArrayValue) Insert(interpreter *Interpreter, getLocationRange func() Lo
 
 	interpreter.checkContainerMutation(v.Type.ElementType(), element, getLocationRange)
 
+	interpreter.ReportMemoryUsage(common.MemoryKindArrayElement, 1)
+
 	element = element.Transfer(
 		interpreter,
 		getLocationRange,
@@ -1820,6 +2179,145 @@ func (v *ArrayValue) GetMember(interpreter *Interpreter, getLocationRange func()
 				v.SemaType(interpreter).ElementType(false),
 			),
 		)
+
+	case "map":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				transformFunction, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				typeParameterPair := invocation.TypeParameterTypes.Oldest()
+				if typeParameterPair == nil {
+					panic(errors.NewUnreachableError())
+				}
+
+				resultElementType := ConvertSemaToStaticType(typeParameterPair.Value)
+
+				var resultType ArrayStaticType
+				if constantSizedType, ok := v.Type.(ConstantSizedStaticType); ok {
+					resultType = ConstantSizedStaticType{
+						Type: resultElementType,
+						Size: constantSizedType.Size,
+					}
+				} else {
+					resultType = VariableSizedStaticType{
+						Type: resultElementType,
+					}
+				}
+
+				return v.Map(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					transformFunction,
+					resultType,
+				)
+			},
+			sema.ArrayMapFunctionType(
+				v.SemaType(interpreter),
+			),
+		)
+
+	case "filter":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				isIncludedFunction, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Filter(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					isIncludedFunction,
+				)
+			},
+			sema.ArrayFilterFunctionType(
+				v.SemaType(interpreter).ElementType(false),
+			),
+		)
+
+	case "reduce":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				initial := invocation.Arguments[0]
+
+				combineFunction, ok := invocation.Arguments[1].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				typeParameterPair := invocation.TypeParameterTypes.Oldest()
+				if typeParameterPair == nil {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Reduce(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					initial,
+					typeParameterPair.Value,
+					combineFunction,
+				)
+			},
+			sema.ArrayReduceFunctionType(
+				v.SemaType(interpreter).ElementType(false),
+			),
+		)
+
+	case "reverse":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.Reverse(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+				)
+			},
+			sema.ArrayReverseFunctionType(
+				v.SemaType(interpreter),
+			),
+		)
+
+	case "forEach":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				bodyFunction, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				v.ForEach(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					bodyFunction,
+				)
+				return VoidValue{}
+			},
+			sema.ArrayForEachFunctionType(
+				v.SemaType(interpreter).ElementType(false),
+			),
+		)
+
+	case "sort":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				areInIncreasingOrderFunction, ok := invocation.Arguments[0].(FunctionValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				v.Sort(
+					invocation.Interpreter,
+					invocation.GetLocationRange,
+					areInIncreasingOrderFunction,
+				)
+				return VoidValue{}
+			},
+			sema.ArraySortFunctionType(
+				v.SemaType(interpreter).ElementType(false),
+			),
+		)
 	}
 
 	return nil
@@ -1979,6 +2477,9 @@ func (v *ArrayValue) Transfer(
 
 	if needsStoreTo || !isResourceKinded {
 
+		interpreter.ReportMemoryUsage(common.MemoryKindArray, 1)
+		interpreter.ReportMemoryUsage(common.MemoryKindArrayElement, uint(v.Count()))
+
 		iterator, err := v.array.Iterator()
 		if err != nil {
 			panic(ExternalError{err})
@@ -2243,6 +2744,304 @@ func (v *ArrayValue) Slice(
 	)
 }
 
+// iterateElements calls the given function with a copy of each element of the array, in order.
+//
+// The array itself is not copied. Like the arguments of a function invocation,
+// only the elements passed to the given function are copied,
+// so functions passed to the higher-order functions of the array
+// cannot mutate the elements of the array.
+//
+// Only the elements the array contains when the iteration starts are visited.
+//
+func (v *ArrayValue) iterateElements(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	f func(index int, element Value),
+) {
+	count := v.Count()
+
+	for index := 0; index < count; index++ {
+		interpreter.ReportComputation(common.ComputationKindLoop, 1)
+
+		element := v.Get(interpreter, getLocationRange, index).
+			Transfer(
+				interpreter,
+				getLocationRange,
+				atree.Address{},
+				false,
+				nil,
+			)
+
+		f(index, element)
+	}
+}
+
+// invokeArrayFunctionArgument invokes the given function,
+// which was passed to one of the higher-order functions of an array
+//
+func invokeArrayFunctionArgument(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	function FunctionValue,
+	arguments []Value,
+	argumentTypes []sema.Type,
+) Value {
+	return function.invoke(
+		Invocation{
+			Arguments:        arguments,
+			ArgumentTypes:    argumentTypes,
+			GetLocationRange: getLocationRange,
+			Interpreter:      interpreter,
+		},
+	)
+}
+
+// Map returns a new array of the given type, which contains the results
+// of invoking the given function with each element of the array, in order
+//
+func (v *ArrayValue) Map(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	transformFunction FunctionValue,
+	resultType ArrayStaticType,
+) Value {
+
+	argumentTypes := []sema.Type{
+		v.SemaType(interpreter).ElementType(false),
+	}
+
+	results := make([]Value, 0, v.Count())
+
+	v.iterateElements(interpreter, getLocationRange, func(_ int, element Value) {
+		result := invokeArrayFunctionArgument(
+			interpreter,
+			getLocationRange,
+			transformFunction,
+			[]Value{element},
+			argumentTypes,
+		)
+		results = append(results, result)
+	})
+
+	return NewArrayValue(
+		interpreter,
+		resultType,
+		common.Address{},
+		results...,
+	)
+}
+
+// Filter returns a new variable-sized array, which contains the elements of the array
+// for which the given function returns true, in order
+//
+func (v *ArrayValue) Filter(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	isIncludedFunction FunctionValue,
+) Value {
+
+	elementType := v.Type.ElementType()
+
+	argumentTypes := []sema.Type{
+		v.SemaType(interpreter).ElementType(false),
+	}
+
+	var includedElements []Value
+
+	// NOTE: the elements passed to the function are already copies,
+	// so the included elements can be moved into the result as-is
+
+	v.iterateElements(interpreter, getLocationRange, func(_ int, element Value) {
+		result := invokeArrayFunctionArgument(
+			interpreter,
+			getLocationRange,
+			isIncludedFunction,
+			[]Value{element},
+			argumentTypes,
+		)
+
+		isIncluded, ok := result.(BoolValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		if isIncluded {
+			includedElements = append(includedElements, element)
+		}
+	})
+
+	var index int
+	count := len(includedElements)
+
+	return NewArrayValueWithIterator(
+		interpreter,
+		VariableSizedStaticType{
+			Type: elementType,
+		},
+		common.Address{},
+		func() Value {
+			if index >= count {
+				return nil
+			}
+
+			element := includedElements[index]
+
+			index++
+
+			return element
+		},
+	)
+}
+
+// Reduce returns the result of combining the elements of the array, in order,
+// by invoking the given function with the accumulated value and each element,
+// starting with the given initial value
+//
+func (v *ArrayValue) Reduce(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	initial Value,
+	resultType sema.Type,
+	combineFunction FunctionValue,
+) Value {
+
+	argumentTypes := []sema.Type{
+		resultType,
+		v.SemaType(interpreter).ElementType(false),
+	}
+
+	accumulator := initial
+
+	v.iterateElements(interpreter, getLocationRange, func(_ int, element Value) {
+		accumulator = invokeArrayFunctionArgument(
+			interpreter,
+			getLocationRange,
+			combineFunction,
+			[]Value{accumulator, element},
+			argumentTypes,
+		)
+	})
+
+	return accumulator
+}
+
+// Reverse returns a new array of the same type,
+// which contains the elements of the array in reverse order
+//
+func (v *ArrayValue) Reverse(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+) Value {
+
+	index := v.Count() - 1
+
+	return NewArrayValueWithIterator(
+		interpreter,
+		v.Type,
+		common.Address{},
+		func() Value {
+			if index < 0 {
+				return nil
+			}
+
+			interpreter.ReportComputation(common.ComputationKindLoop, 1)
+
+			element := v.Get(interpreter, getLocationRange, index)
+
+			index--
+
+			return element.Transfer(
+				interpreter,
+				getLocationRange,
+				atree.Address{},
+				false,
+				nil,
+			)
+		},
+	)
+}
+
+// ForEach invokes the given function with each element of the array, in order
+//
+func (v *ArrayValue) ForEach(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	bodyFunction FunctionValue,
+) {
+
+	argumentTypes := []sema.Type{
+		v.SemaType(interpreter).ElementType(false),
+	}
+
+	v.iterateElements(interpreter, getLocationRange, func(_ int, element Value) {
+		invokeArrayFunctionArgument(
+			interpreter,
+			getLocationRange,
+			bodyFunction,
+			[]Value{element},
+			argumentTypes,
+		)
+	})
+}
+
+// Sort sorts the array in place, using the given function as the comparison between elements.
+// The sort is stable, i.e. elements which are ordered equally keep their relative order
+//
+func (v *ArrayValue) Sort(
+	interpreter *Interpreter,
+	getLocationRange func() LocationRange,
+	areInIncreasingOrderFunction FunctionValue,
+) {
+
+	elementType := v.SemaType(interpreter).ElementType(false)
+
+	argumentTypes := []sema.Type{
+		elementType,
+		elementType,
+	}
+
+	count := v.Count()
+
+	elements := make([]Value, 0, count)
+	indices := make([]int, 0, count)
+
+	v.iterateElements(interpreter, getLocationRange, func(index int, element Value) {
+		elements = append(elements, element)
+		indices = append(indices, index)
+	})
+
+	// Sort the indices of the elements, instead of the elements,
+	// so only the elements which changed their position have to be written back
+
+	sort.SliceStable(indices, func(i, j int) bool {
+		result := invokeArrayFunctionArgument(
+			interpreter,
+			getLocationRange,
+			areInIncreasingOrderFunction,
+			[]Value{
+				elements[indices[i]],
+				elements[indices[j]],
+			},
+			argumentTypes,
+		)
+
+		areInIncreasingOrder, ok := result.(BoolValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		return bool(areInIncreasingOrder)
+	})
+
+	for index, elementIndex := range indices {
+		if elementIndex == index {
+			continue
+		}
+
+		v.Set(interpreter, getLocationRange, index, elements[elementIndex])
+	}
+}
+
 // NumberValue
 //
 type NumberValue interface {
@@ -11642,16 +12441,19 @@ type CompositeValue struct {
 	Location            common.Location
 	QualifiedIdentifier string
 	Kind                common.CompositeKind
-	InjectedFields      map[string]Value
-	ComputedFields      map[string]ComputedField
-	NestedVariables     map[string]*Variable
-	Functions           map[string]FunctionValue
-	Destructor          FunctionValue
-	Stringer            func(value *CompositeValue, seenReferences SeenReferences) string
-	isDestroyed         bool
-	typeID              common.TypeID
-	staticType          StaticType
-	dynamicType         DynamicType
+	// TypeArguments are the type arguments of the value's type,
+	// if the value is an instance of a generic composite type
+	TypeArguments   []StaticType
+	InjectedFields  map[string]Value
+	ComputedFields  map[string]ComputedField
+	NestedVariables map[string]*Variable
+	Functions       map[string]FunctionValue
+	Destructor      FunctionValue
+	Stringer        func(value *CompositeValue, seenReferences SeenReferences) string
+	isDestroyed     bool
+	typeID          common.TypeID
+	staticType      StaticType
+	dynamicType     DynamicType
 }
 
 type ComputedField func(*Interpreter, func() LocationRange) Value
@@ -11669,8 +12471,32 @@ func NewCompositeValue(
 	fields []CompositeField,
 	address common.Address,
 ) *CompositeValue {
+	return NewGenericCompositeValue(
+		interpreter,
+		location,
+		qualifiedIdentifier,
+		nil,
+		kind,
+		fields,
+		address,
+	)
+}
+
+// NewGenericCompositeValue returns a new composite value
+// which is an instance of the generic composite type with the given type arguments.
+//
+func NewGenericCompositeValue(
+	interpreter *Interpreter,
+	location common.Location,
+	qualifiedIdentifier string,
+	typeArguments []StaticType,
+	kind common.CompositeKind,
+	fields []CompositeField,
+	address common.Address,
+) *CompositeValue {
 
 	interpreter.ReportComputation(common.ComputationKindCreateCompositeValue, 1)
+	interpreter.ReportMemoryUsage(common.MemoryKindComposite, 1)
 
 	var v *CompositeValue
 	if interpreter.tracingEnabled {
@@ -11704,6 +12530,7 @@ func NewCompositeValue(
 			location:            location,
 			qualifiedIdentifier: qualifiedIdentifier,
 			kind:                kind,
+			typeArguments:       typeArguments,
 		},
 	)
 	if err != nil {
@@ -11715,6 +12542,7 @@ func NewCompositeValue(
 		Location:            location,
 		QualifiedIdentifier: qualifiedIdentifier,
 		Kind:                kind,
+		TypeArguments:       typeArguments,
 	}
 
 	for _, field := range fields {
@@ -11770,6 +12598,14 @@ func (v *CompositeValue) DynamicType(interpreter *Interpreter, _ SeenReferences)
 		if err != nil {
 			panic(err)
 		}
+
+		if len(v.TypeArguments) > 0 {
+			staticType, err = interpreter.ConvertStaticToSemaType(v.StaticType())
+			if err != nil {
+				panic(err)
+			}
+		}
+
 		v.dynamicType = CompositeDynamicType{
 			StaticType: staticType,
 		}
@@ -11785,6 +12621,7 @@ func (v *CompositeValue) StaticType() StaticType {
 			Location:            v.Location,
 			QualifiedIdentifier: v.QualifiedIdentifier,
 			TypeID:              v.TypeID(),
+			TypeArguments:       v.TypeArguments,
 		}
 	}
 	return v.staticType
@@ -12093,7 +12930,9 @@ func (v *CompositeValue) SetMember(
 	}
 	interpreter.maybeValidateAtreeValue(v.dictionary)
 
-	if existingStorable != nil {
+	if existingStorable == nil {
+		interpreter.ReportMemoryUsage(common.MemoryKindCompositeField, 1)
+	} else {
 		existingValue := StoredValue(existingStorable, interpreter.Storage)
 
 		existingValue.DeepRemove(interpreter)
@@ -12288,10 +13127,21 @@ func (v *CompositeValue) ConformsToDynamicType(
 	}
 
 	compositeType, ok := compositeDynamicType.StaticType.(*sema.CompositeType)
-	if !ok ||
-		v.Kind != compositeType.Kind ||
-		v.TypeID() != compositeType.ID() {
+	if !ok || v.Kind != compositeType.Kind {
+		return false
+	}
+
+	// The type ID of the value does not include the type arguments, if any,
+	// so compare the static types of instances of generic composite types
 
+	if len(v.TypeArguments) > 0 {
+		if !v.StaticType().Equal(ConvertSemaToStaticType(compositeType)) {
+			return false
+		}
+
+		compositeType.InitializeInstantiatedMembers()
+
+	} else if v.TypeID() != compositeType.ID() {
 		return false
 	}
 
@@ -12421,6 +13271,10 @@ func (v *CompositeValue) Transfer(
 	isResourceKinded := v.IsResourceKinded(interpreter)
 
 	if needsStoreTo || !isResourceKinded {
+
+		interpreter.ReportMemoryUsage(common.MemoryKindComposite, 1)
+		interpreter.ReportMemoryUsage(common.MemoryKindCompositeField, uint(v.dictionary.Count()))
+
 		iterator, err := v.dictionary.Iterator()
 		if err != nil {
 			panic(ExternalError{err})
@@ -12511,6 +13365,7 @@ func (v *CompositeValue) Transfer(
 			Location:            v.Location,
 			QualifiedIdentifier: v.QualifiedIdentifier,
 			Kind:                v.Kind,
+			TypeArguments:       v.TypeArguments,
 			InjectedFields:      v.InjectedFields,
 			ComputedFields:      v.ComputedFields,
 			NestedVariables:     v.NestedVariables,
@@ -12588,6 +13443,7 @@ func (v *CompositeValue) Clone(interpreter *Interpreter) Value {
 		Location:            v.Location,
 		QualifiedIdentifier: v.QualifiedIdentifier,
 		Kind:                v.Kind,
+		TypeArguments:       v.TypeArguments,
 		InjectedFields:      v.InjectedFields,
 		ComputedFields:      v.ComputedFields,
 		NestedVariables:     v.NestedVariables,
@@ -12757,6 +13613,7 @@ func NewDictionaryValueWithAddress(
 ) *DictionaryValue {
 
 	interpreter.ReportComputation(common.ComputationKindCreateDictionaryValue, 1)
+	interpreter.ReportMemoryUsage(common.MemoryKindDictionary, 1)
 
 	var v *DictionaryValue
 
@@ -13326,6 +14183,7 @@ func (v *DictionaryValue) Insert(
 	interpreter.maybeValidateAtreeValue(v.dictionary)
 
 	if existingValueStorable == nil {
+		interpreter.ReportMemoryUsage(common.MemoryKindDictionaryEntry, 1)
 		return NilValue{}
 	}
 
@@ -13517,6 +14375,9 @@ func (v *DictionaryValue) Transfer(
 
 	if needsStoreTo || !isResourceKinded {
 
+		interpreter.ReportMemoryUsage(common.MemoryKindDictionary, 1)
+		interpreter.ReportMemoryUsage(common.MemoryKindDictionaryEntry, uint(v.Count()))
+
 		valueComparator := newValueComparator(interpreter, getLocationRange)
 		hashInputProvider := newHashInputProvider(interpreter, getLocationRange)
 
@@ -0,0 +1,102 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence/runtime/analysis"
+)
+
+// Analyzers are the registered lint analyzers, by name
+//
+var Analyzers = map[string]*analysis.Analyzer{}
+
+// RegisterAnalyzer registers the given analyzer,
+// so it can be enabled and disabled by name.
+//
+// Panics if an analyzer with the same name is already registered
+//
+func RegisterAnalyzer(analyzer *analysis.Analyzer) {
+	if _, ok := Analyzers[analyzer.Name]; ok {
+		panic(fmt.Errorf("duplicate analyzer: %s", analyzer.Name))
+	}
+	Analyzers[analyzer.Name] = analyzer
+}
+
+// UnknownAnalyzerError is returned when an analyzer is selected which is not registered
+//
+type UnknownAnalyzerError struct {
+	Name string
+}
+
+func (e UnknownAnalyzerError) Error() string {
+	return fmt.Sprintf("unknown analyzer: %s", e.Name)
+}
+
+// SelectAnalyzers returns the registered analyzers, sorted by name.
+//
+// If names of enabled analyzers are given, only these analyzers are returned.
+// The analyzers with the given disabled names are never returned
+//
+func SelectAnalyzers(enabled []string, disabled []string) ([]*analysis.Analyzer, error) {
+
+	for _, names := range [][]string{enabled, disabled} {
+		for _, name := range names {
+			if _, ok := Analyzers[name]; !ok {
+				return nil, UnknownAnalyzerError{Name: name}
+			}
+		}
+	}
+
+	isEnabled := func(name string) bool {
+		if len(enabled) == 0 {
+			return true
+		}
+		for _, enabledName := range enabled {
+			if name == enabledName {
+				return true
+			}
+		}
+		return false
+	}
+
+	isDisabled := func(name string) bool {
+		for _, disabledName := range disabled {
+			if name == disabledName {
+				return true
+			}
+		}
+		return false
+	}
+
+	var analyzers []*analysis.Analyzer
+	for name, analyzer := range Analyzers {
+		if isEnabled(name) && !isDisabled(name) {
+			analyzers = append(analyzers, analyzer)
+		}
+	}
+
+	sort.Slice(analyzers, func(i, j int) bool {
+		return analyzers[i].Name < analyzers[j].Name
+	})
+
+	return analyzers, nil
+}
@@ -0,0 +1,267 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"strings"
+
+	"github.com/onflow/cadence/runtime/analysis"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// The analyzers in this file report the hints of the checker
+
+// RedundantCastAnalyzer reports static casts which are not necessary,
+// as the type of the expression is already the target type
+//
+var RedundantCastAnalyzer = &analysis.Analyzer{
+	Name:        "redundant-cast",
+	Description: "Detects static casts which are not necessary",
+	Run: func(pass *analysis.Pass) interface{} {
+		program := pass.Program
+
+		for _, hint := range program.Hints {
+			hint, ok := hint.(*sema.UnnecessaryCastHint)
+			if !ok {
+				continue
+			}
+
+			diagnostic := analysis.Diagnostic{
+				Message: hint.Hint(),
+				Range:   hint.Range,
+			}
+
+			// The range of the hint is the type annotation of the cast.
+			// Remove the cast operator and the type annotation
+
+			castingExpression := findCastingExpression(
+				program.Program,
+				func(expression *ast.CastingExpression) bool {
+					return expression.TypeAnnotation.StartPosition() == hint.StartPos
+				},
+			)
+			if castingExpression != nil {
+				diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+					{
+						Message: "Remove unnecessary cast",
+						TextEdits: []analysis.TextEdit{
+							{
+								Range: ast.Range{
+									StartPos: castingExpression.Expression.EndPosition().Shifted(1),
+									EndPos:   castingExpression.TypeAnnotation.EndPosition(),
+								},
+							},
+						},
+					},
+				}
+			}
+
+			pass.Report(diagnostic)
+		}
+
+		return nil
+	},
+}
+
+// AlwaysSucceedingCastAnalyzer reports failable casts and force casts
+// which always succeed, as the type of the expression is a subtype of the target type
+//
+var AlwaysSucceedingCastAnalyzer = &analysis.Analyzer{
+	Name:        "always-succeeding-cast",
+	Description: "Detects failable casts and force casts which always succeed",
+	Run: func(pass *analysis.Pass) interface{} {
+		program := pass.Program
+
+		for _, hint := range program.Hints {
+			switch hint := hint.(type) {
+			case *sema.AlwaysSucceedingFailableCastHint:
+				pass.Report(analysis.Diagnostic{
+					Message: hint.Hint(),
+					Range:   hint.Range,
+				})
+
+			case *sema.AlwaysSucceedingForceCastHint:
+				diagnostic := analysis.Diagnostic{
+					Message: hint.Hint(),
+					Range:   hint.Range,
+				}
+
+				// The range of the hint is the casting expression.
+				// A force cast which always succeeds can be replaced with a static cast,
+				// by removing the `!` of the operator
+
+				castingExpression := findCastingExpression(
+					program.Program,
+					func(expression *ast.CastingExpression) bool {
+						return expression.StartPosition() == hint.StartPos &&
+							expression.EndPosition() == hint.EndPos
+					},
+				)
+				if castingExpression != nil {
+					operatorRange, ok := castOperatorRange(program.Code, castingExpression)
+					if ok {
+						diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+							{
+								Message: "Replace with static cast",
+								TextEdits: []analysis.TextEdit{
+									{
+										Replacement: ast.OperationCast.Symbol(),
+										Range:       operatorRange,
+									},
+								},
+							},
+						}
+					}
+				}
+
+				pass.Report(diagnostic)
+			}
+		}
+
+		return nil
+	},
+}
+
+// UnnecessaryForceAnalyzer reports force operators which are applied to non-optional values
+//
+var UnnecessaryForceAnalyzer = &analysis.Analyzer{
+	Name:        "unnecessary-force",
+	Description: "Detects force operators which are applied to non-optional values",
+	Run: func(pass *analysis.Pass) interface{} {
+		for _, hint := range pass.Program.Hints {
+			hint, ok := hint.(*sema.RemovalHint)
+			if !ok {
+				continue
+			}
+
+			pass.Report(analysis.Diagnostic{
+				Message: hint.Hint(),
+				Range:   hint.Range,
+				SuggestedFixes: []analysis.SuggestedFix{
+					{
+						Message: "Remove unnecessary code",
+						TextEdits: []analysis.TextEdit{
+							{
+								Range: hint.Range,
+							},
+						},
+					},
+				},
+			})
+		}
+
+		return nil
+	},
+}
+
+// LiteralConversionAnalyzer reports conversions of number literals
+// which can be replaced with a literal of the target type
+//
+var LiteralConversionAnalyzer = &analysis.Analyzer{
+	Name:        "literal-conversion",
+	Description: "Detects conversions of number literals which can be replaced with literals",
+	Run: func(pass *analysis.Pass) interface{} {
+		for _, hint := range pass.Program.Hints {
+			hint, ok := hint.(*sema.ReplacementHint)
+			if !ok {
+				continue
+			}
+
+			replacement := hint.Expression.String()
+
+			pass.Report(analysis.Diagnostic{
+				Message: hint.Hint(),
+				Range:   hint.Range,
+				SuggestedFixes: []analysis.SuggestedFix{
+					{
+						Message: "Replace with `" + replacement + "`",
+						TextEdits: []analysis.TextEdit{
+							{
+								Replacement: replacement,
+								Range:       hint.Range,
+							},
+						},
+					},
+				},
+			})
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	RegisterAnalyzer(RedundantCastAnalyzer)
+	RegisterAnalyzer(AlwaysSucceedingCastAnalyzer)
+	RegisterAnalyzer(UnnecessaryForceAnalyzer)
+	RegisterAnalyzer(LiteralConversionAnalyzer)
+}
+
+// findCastingExpression returns the first casting expression in the given program
+// which satisfies the given predicate, if any
+//
+func findCastingExpression(
+	program *ast.Program,
+	predicate func(expression *ast.CastingExpression) bool,
+) (
+	result *ast.CastingExpression,
+) {
+	ast.Inspect(program, func(element ast.Element) bool {
+		if result != nil {
+			return false
+		}
+
+		if castingExpression, ok := element.(*ast.CastingExpression); ok &&
+			predicate(castingExpression) {
+
+			result = castingExpression
+			return false
+		}
+
+		return true
+	})
+
+	return
+}
+
+// castOperatorRange returns the range of the operator of the given casting expression,
+// which is located between the expression and the type annotation
+//
+func castOperatorRange(code string, expression *ast.CastingExpression) (ast.Range, bool) {
+	startPos := expression.Expression.EndPosition().Shifted(1)
+	endOffset := expression.TypeAnnotation.StartPosition().Offset
+
+	if startPos.Offset < 0 || endOffset > len(code) || startPos.Offset > endOffset {
+		return ast.Range{}, false
+	}
+
+	symbol := expression.Operation.Symbol()
+	index := strings.Index(code[startPos.Offset:endOffset], symbol)
+	if index < 0 {
+		return ast.Range{}, false
+	}
+
+	operatorStartPos := startPos.Shifted(index)
+
+	return ast.Range{
+		StartPos: operatorStartPos,
+		EndPos:   operatorStartPos.Shifted(len(symbol) - 1),
+	}, true
+}
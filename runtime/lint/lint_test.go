@@ -0,0 +1,412 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/analysis"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/lint"
+)
+
+const testLocation = common.StringLocation("test")
+
+// testAnalyzer runs the given analyzer on the given code,
+// and returns the reported diagnostics and the program
+//
+func testAnalyzer(
+	t *testing.T,
+	code string,
+	analyzer *analysis.Analyzer,
+) (
+	[]analysis.Diagnostic,
+	*analysis.Program,
+) {
+	programs, err := analysis.Load(
+		&analysis.Config{
+			ResolveCode: func(
+				_ common.Location,
+				_ common.Location,
+				_ ast.Range,
+			) (
+				string,
+				error,
+			) {
+				return code, nil
+			},
+		},
+		testLocation,
+	)
+	require.NoError(t, err)
+
+	program := programs[testLocation.ID()]
+
+	var diagnostics []analysis.Diagnostic
+	program.Run(
+		[]*analysis.Analyzer{analyzer},
+		func(diagnostic analysis.Diagnostic) {
+			diagnostics = append(diagnostics, diagnostic)
+		},
+	)
+
+	return diagnostics, program
+}
+
+// applyFirstFix applies the first suggested fix of the given diagnostic
+//
+func applyFirstFix(t *testing.T, program *analysis.Program, diagnostic analysis.Diagnostic) string {
+	require.NotEmpty(t, diagnostic.SuggestedFixes)
+	return analysis.ApplyTextEdits(program.Code, diagnostic.SuggestedFixes[0].TextEdits)
+}
+
+func TestRedundantCastAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics, program := testAnalyzer(t,
+		`
+          pub fun test() {
+              let x = true as Bool
+          }
+        `,
+		lint.RedundantCastAnalyzer,
+	)
+
+	require.Len(t, diagnostics, 1)
+
+	diagnostic := diagnostics[0]
+	assert.Equal(t, testLocation, diagnostic.Location)
+	assert.Equal(t, "redundant-cast", diagnostic.Category)
+	assert.Equal(t, 3, diagnostic.StartPos.Line)
+
+	assert.Equal(t,
+		`
+          pub fun test() {
+              let x = true
+          }
+        `,
+		applyFirstFix(t, program, diagnostic),
+	)
+}
+
+func TestAlwaysSucceedingCastAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("force cast", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics, program := testAnalyzer(t,
+			`
+              pub fun test() {
+                  let x = 1 as! Int
+              }
+            `,
+			lint.AlwaysSucceedingCastAnalyzer,
+		)
+
+		require.Len(t, diagnostics, 1)
+
+		diagnostic := diagnostics[0]
+		assert.Equal(t, "always-succeeding-cast", diagnostic.Category)
+
+		assert.Equal(t,
+			`
+              pub fun test() {
+                  let x = 1 as Int
+              }
+            `,
+			applyFirstFix(t, program, diagnostic),
+		)
+	})
+
+	t.Run("failable cast", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics, _ := testAnalyzer(t,
+			`
+              pub fun test() {
+                  let x = 1 as? Int
+              }
+            `,
+			lint.AlwaysSucceedingCastAnalyzer,
+		)
+
+		require.Len(t, diagnostics, 1)
+
+		// Replacing the failable cast would change the type of the expression
+
+		assert.Empty(t, diagnostics[0].SuggestedFixes)
+	})
+}
+
+func TestUnnecessaryForceAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics, program := testAnalyzer(t,
+		`
+          pub fun test() {
+              let x = 1
+              let y = x!
+          }
+        `,
+		lint.UnnecessaryForceAnalyzer,
+	)
+
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "unnecessary-force", diagnostics[0].Category)
+
+	assert.Equal(t,
+		`
+          pub fun test() {
+              let x = 1
+              let y = x
+          }
+        `,
+		applyFirstFix(t, program, diagnostics[0]),
+	)
+}
+
+func TestLiteralConversionAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics, program := testAnalyzer(t,
+		`
+          pub fun test() {
+              let x = UInt8(1)
+          }
+        `,
+		lint.LiteralConversionAnalyzer,
+	)
+
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "literal-conversion", diagnostics[0].Category)
+
+	assert.Equal(t,
+		`
+          pub fun test() {
+              let x = (1 as UInt8)
+          }
+        `,
+		applyFirstFix(t, program, diagnostics[0]),
+	)
+}
+
+func TestSelectAnalyzers(t *testing.T) {
+
+	t.Parallel()
+
+	names := func(analyzers []*analysis.Analyzer) []string {
+		result := make([]string, 0, len(analyzers))
+		for _, analyzer := range analyzers {
+			result = append(result, analyzer.Name)
+		}
+		return result
+	}
+
+	t.Run("all", func(t *testing.T) {
+
+		t.Parallel()
+
+		analyzers, err := lint.SelectAnalyzers(nil, nil)
+		require.NoError(t, err)
+
+		assert.Len(t, analyzers, len(lint.Analyzers))
+		assert.IsIncreasing(t, names(analyzers))
+	})
+
+	t.Run("enabled and disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		analyzers, err := lint.SelectAnalyzers(
+			[]string{"redundant-cast", "unnecessary-force"},
+			[]string{"unnecessary-force"},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"redundant-cast"}, names(analyzers))
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := lint.SelectAnalyzers(nil, []string{"unknown"})
+		require.Equal(t, lint.UnknownAnalyzerError{Name: "unknown"}, err)
+	})
+}
+
+func TestPublicAuthAccountCapabilityAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics, _ := testAnalyzer(t,
+		`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.link<&AuthAccount>(/public/account, target: /storage/account)
+                  signer.link<&AuthAccount>(/private/account, target: /storage/account)
+                  signer.link<&Int>(/public/int, target: /storage/int)
+              }
+          }
+        `,
+		lint.PublicAuthAccountCapabilityAnalyzer,
+	)
+
+	require.Len(t, diagnostics, 1)
+
+	diagnostic := diagnostics[0]
+	assert.Equal(t, "public-auth-account-capability", diagnostic.Category)
+	assert.Equal(t, analysis.SeverityError, diagnostic.Severity)
+	assert.Equal(t, 4, diagnostic.StartPos.Line)
+}
+
+func TestPublicResourceReferenceLinkAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics, _ := testAnalyzer(t,
+		`
+          pub resource interface Receiver {}
+
+          pub resource Vault: Receiver {}
+
+          pub fun test(account: AuthAccount) {
+              account.link<&Vault>(/public/vault, target: /storage/vault)
+              account.link<auth &Vault{Receiver}>(/public/auth, target: /storage/vault)
+              account.link<&Vault{Receiver}>(/public/receiver, target: /storage/vault)
+              account.link<&Vault>(/private/vault, target: /storage/vault)
+          }
+        `,
+		lint.PublicResourceReferenceLinkAnalyzer,
+	)
+
+	require.Len(t, diagnostics, 2)
+
+	assert.Equal(t, "public-resource-reference-link", diagnostics[0].Category)
+	assert.Equal(t, analysis.SeverityWarning, diagnostics[0].Severity)
+	assert.Equal(t, 7, diagnostics[0].StartPos.Line)
+	assert.Contains(t, diagnostics[0].Message, "unrestricted")
+
+	assert.Equal(t, 8, diagnostics[1].StartPos.Line)
+	assert.Contains(t, diagnostics[1].Message, "authorized")
+}
+
+func TestPublicMutableFieldAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics, _ := testAnalyzer(t,
+		`
+          pub contract Test {
+              pub(set) var count: Int
+              pub let ids: [UInt64]
+              pub let names: {String: String}?
+              access(contract) let hidden: [Int]
+              pub let value: Int
+
+              pub struct S {
+                  pub let ids: [UInt64]
+
+                  init() {
+                      self.ids = []
+                  }
+              }
+
+              init() {
+                  self.count = 0
+                  self.ids = []
+                  self.names = nil
+                  self.hidden = []
+                  self.value = 1
+              }
+          }
+        `,
+		lint.PublicMutableFieldAnalyzer,
+	)
+
+	require.Len(t, diagnostics, 3)
+
+	for i, identifier := range []string{"count", "ids", "names"} {
+		diagnostic := diagnostics[i]
+		assert.Equal(t, "public-mutable-field", diagnostic.Category)
+		assert.Equal(t, analysis.SeverityWarning, diagnostic.Severity)
+		assert.Equal(t, i+3, diagnostic.StartPos.Line)
+		assert.Contains(t, diagnostic.Message, "`"+identifier+"`")
+	}
+}
+
+func TestAuthAccountParameterAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics, _ := testAnalyzer(t,
+		`
+          pub contract Test {
+
+              pub resource R {
+                  init(account: AuthAccount) {}
+              }
+
+              pub fun test(account: AuthAccount, other: &AuthAccount?, value: Int) {}
+
+              init(account: AuthAccount) {}
+          }
+        `,
+		lint.AuthAccountParameterAnalyzer,
+	)
+
+	require.Len(t, diagnostics, 3)
+
+	assert.Equal(t, "auth-account-parameter", diagnostics[0].Category)
+	assert.Equal(t, analysis.SeverityWarning, diagnostics[0].Severity)
+	assert.Equal(t, 5, diagnostics[0].StartPos.Line)
+
+	assert.Equal(t, 8, diagnostics[1].StartPos.Line)
+	assert.Equal(t, "parameter `account` has type `AuthAccount`", diagnostics[1].Message)
+
+	assert.Equal(t, 8, diagnostics[2].StartPos.Line)
+	assert.Equal(t, "parameter `other` has type `&AuthAccount?`", diagnostics[2].Message)
+}
+
+func TestAuthAccountParameterAnalyzer_Transaction(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics, _ := testAnalyzer(t,
+		`
+          transaction {
+              prepare(signer: AuthAccount) {}
+          }
+        `,
+		lint.AuthAccountParameterAnalyzer,
+	)
+
+	assert.Empty(t, diagnostics)
+}
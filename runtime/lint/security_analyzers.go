@@ -0,0 +1,333 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/analysis"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// The analyzers in this file detect the security anti-patterns
+// described in docs/anti-patterns.mdx
+
+// PublicAuthAccountCapabilityAnalyzer reports links of references to `AuthAccount` at public paths.
+// Anyone can borrow such a capability, and gain full access to the account
+//
+var PublicAuthAccountCapabilityAnalyzer = &analysis.Analyzer{
+	Name:        "public-auth-account-capability",
+	Description: "Detects public capabilities to `AuthAccount` values",
+	Run: func(pass *analysis.Pass) interface{} {
+		inspectPublicLinks(pass.Program, func(invocation *ast.InvocationExpression, borrowType sema.Type) {
+			if !containsAuthAccount(borrowType) {
+				return
+			}
+
+			pass.Report(analysis.Diagnostic{
+				Severity:         analysis.SeverityError,
+				Message:          "public capability to `AuthAccount`",
+				SecondaryMessage: "anyone can borrow this capability and gain full access to the account",
+				Range:            ast.NewRangeFromPositioned(invocation),
+			})
+		})
+
+		return nil
+	},
+}
+
+// PublicResourceReferenceLinkAnalyzer reports links of references at public paths,
+// which leak the full functionality of the linked resource (e.g. a `&Vault` reference,
+// which allows anyone to withdraw), or which are authorized, and can therefore be downcast
+//
+var PublicResourceReferenceLinkAnalyzer = &analysis.Analyzer{
+	Name:        "public-resource-reference-link",
+	Description: "Detects public capabilities which leak unrestricted or authorized references to resources",
+	Run: func(pass *analysis.Pass) interface{} {
+		inspectPublicLinks(pass.Program, func(invocation *ast.InvocationExpression, borrowType sema.Type) {
+			referenceType, ok := borrowType.(*sema.ReferenceType)
+			if !ok || containsAuthAccount(referenceType) {
+				return
+			}
+
+			var message, secondaryMessage string
+
+			if referenceType.Authorized {
+				message = fmt.Sprintf(
+					"public capability to authorized reference `%s`",
+					referenceType.QualifiedString(),
+				)
+				secondaryMessage = "anyone can downcast the reference. use an unauthorized reference"
+			} else if compositeType, ok := referenceType.Type.(*sema.CompositeType); ok &&
+				compositeType.Kind == common.CompositeKindResource {
+
+				message = fmt.Sprintf(
+					"public capability to unrestricted reference `%s`",
+					referenceType.QualifiedString(),
+				)
+				secondaryMessage = "restrict the reference to the interfaces which should be public, e.g. `&Vault{Receiver}`"
+			} else {
+				return
+			}
+
+			pass.Report(analysis.Diagnostic{
+				Severity:         analysis.SeverityWarning,
+				Message:          message,
+				SecondaryMessage: secondaryMessage,
+				Range:            ast.NewRangeFromPositioned(invocation),
+			})
+		})
+
+		return nil
+	},
+}
+
+// PublicMutableFieldAnalyzer reports fields of contracts which can be modified by anyone:
+// Publicly settable fields, and public fields with array or dictionary types,
+// as the elements of such fields can be modified
+//
+var PublicMutableFieldAnalyzer = &analysis.Analyzer{
+	Name:        "public-mutable-field",
+	Description: "Detects publicly settable fields, and public array and dictionary fields of contracts",
+	Run: func(pass *analysis.Pass) interface{} {
+		program := pass.Program
+
+		ast.Inspect(program.Program, func(element ast.Element) bool {
+			declaration, ok := element.(*ast.CompositeDeclaration)
+			if !ok {
+				return true
+			}
+
+			if declaration.CompositeKind != common.CompositeKindContract {
+				return true
+			}
+
+			compositeType := program.Elaboration.CompositeDeclarationTypes[declaration]
+			if compositeType == nil {
+				return true
+			}
+
+			for _, field := range declaration.Members.Fields() {
+				reportPublicMutableField(pass, compositeType, field)
+			}
+
+			return true
+		})
+
+		return nil
+	},
+}
+
+func reportPublicMutableField(
+	pass *analysis.Pass,
+	compositeType *sema.CompositeType,
+	field *ast.FieldDeclaration,
+) {
+	identifier := field.Identifier.Identifier
+
+	switch field.Access {
+	case ast.AccessPublicSettable:
+		pass.Report(analysis.Diagnostic{
+			Severity:         analysis.SeverityWarning,
+			Message:          fmt.Sprintf("field `%s` is publicly settable", identifier),
+			SecondaryMessage: "anyone can set the field. consider using `pub` instead of `pub(set)`",
+			Range:            ast.NewRangeFromPositioned(field.Identifier),
+		})
+
+	case ast.AccessPublic:
+		member, ok := compositeType.Members.Get(identifier)
+		if !ok {
+			return
+		}
+
+		fieldType := member.TypeAnnotation.Type
+		for {
+			optionalType, ok := fieldType.(*sema.OptionalType)
+			if !ok {
+				break
+			}
+			fieldType = optionalType.Type
+		}
+
+		var kind string
+		switch fieldType.(type) {
+		case sema.ArrayType:
+			kind = "array"
+		case *sema.DictionaryType:
+			kind = "dictionary"
+		default:
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Severity:         analysis.SeverityWarning,
+			Message:          fmt.Sprintf("public %s field `%s`", kind, identifier),
+			SecondaryMessage: "anyone can modify the elements. consider using `access(contract)` or `access(self)`",
+			Range:            ast.NewRangeFromPositioned(field.Identifier),
+		})
+	}
+}
+
+// AuthAccountParameterAnalyzer reports functions which have `AuthAccount` parameters.
+// Passing an `AuthAccount` to a function gives the function full access to the account.
+//
+// The prepare blocks of transactions and the initializers of contracts are not reported
+//
+var AuthAccountParameterAnalyzer = &analysis.Analyzer{
+	Name:        "auth-account-parameter",
+	Description: "Detects functions which have `AuthAccount` parameters",
+	Run: func(pass *analysis.Pass) interface{} {
+		program := pass.Program
+		elaboration := program.Elaboration
+
+		reportParameters := func(parameterList *ast.ParameterList, functionType *sema.FunctionType) {
+			if parameterList == nil || functionType == nil {
+				return
+			}
+
+			for i, parameter := range parameterList.Parameters {
+				if i >= len(functionType.Parameters) {
+					break
+				}
+
+				parameterType := functionType.Parameters[i].TypeAnnotation.Type
+				if !containsAuthAccount(parameterType) {
+					continue
+				}
+
+				pass.Report(analysis.Diagnostic{
+					Severity: analysis.SeverityWarning,
+					Message: fmt.Sprintf(
+						"parameter `%s` has type `%s`",
+						parameter.Identifier.Identifier,
+						parameterType.QualifiedString(),
+					),
+					SecondaryMessage: "the function gains full access to the account. " +
+						"consider passing a capability or a reference instead",
+					Range: ast.NewRangeFromPositioned(parameter),
+				})
+			}
+		}
+
+		ast.Inspect(program.Program, func(element ast.Element) bool {
+			switch declaration := element.(type) {
+			case *ast.TransactionDeclaration:
+				return false
+
+			case *ast.FunctionDeclaration:
+				reportParameters(
+					declaration.ParameterList,
+					elaboration.FunctionDeclarationFunctionTypes[declaration],
+				)
+
+			case *ast.CompositeDeclaration:
+				if declaration.CompositeKind == common.CompositeKindContract {
+					break
+				}
+
+				for _, initializer := range declaration.Members.Initializers() {
+					reportParameters(
+						initializer.FunctionDeclaration.ParameterList,
+						elaboration.ConstructorFunctionTypes[initializer],
+					)
+				}
+			}
+
+			return true
+		})
+
+		return nil
+	},
+}
+
+func init() {
+	RegisterAnalyzer(PublicAuthAccountCapabilityAnalyzer)
+	RegisterAnalyzer(PublicResourceReferenceLinkAnalyzer)
+	RegisterAnalyzer(PublicMutableFieldAnalyzer)
+	RegisterAnalyzer(AuthAccountParameterAnalyzer)
+}
+
+// inspectPublicLinks calls the given function for each invocation of `AuthAccount.link`
+// in the given program which links a capability at a public path
+//
+func inspectPublicLinks(
+	program *analysis.Program,
+	f func(invocation *ast.InvocationExpression, borrowType sema.Type),
+) {
+	elaboration := program.Elaboration
+
+	ast.Inspect(program.Program, func(element ast.Element) bool {
+		invocation, ok := element.(*ast.InvocationExpression)
+		if !ok {
+			return true
+		}
+
+		memberExpression, ok := invocation.InvokedExpression.(*ast.MemberExpression)
+		if !ok || memberExpression.Identifier.Identifier != sema.AuthAccountLinkField {
+			return true
+		}
+
+		memberInfo, ok := elaboration.MemberExpressionMemberInfos[memberExpression]
+		if !ok || !isAuthAccountType(memberInfo.AccessedType) {
+			return true
+		}
+
+		argumentTypes := elaboration.InvocationExpressionArgumentTypes[invocation]
+		if len(argumentTypes) == 0 || !argumentTypes[0].Equal(sema.PublicPathType) {
+			return true
+		}
+
+		typeArguments := elaboration.InvocationExpressionTypeArguments[invocation]
+		if typeArguments == nil || typeArguments.Len() == 0 {
+			return true
+		}
+
+		f(invocation, typeArguments.Oldest().Value)
+
+		return true
+	})
+}
+
+func isAuthAccountType(ty sema.Type) bool {
+	if referenceType, ok := ty.(*sema.ReferenceType); ok {
+		ty = referenceType.Type
+	}
+	return ty.Equal(sema.AuthAccountType)
+}
+
+// containsAuthAccount returns true if the given type is `AuthAccount`,
+// or an optional, reference, array, or dictionary of it
+//
+func containsAuthAccount(ty sema.Type) bool {
+	switch ty := ty.(type) {
+	case *sema.OptionalType:
+		return containsAuthAccount(ty.Type)
+	case *sema.ReferenceType:
+		return containsAuthAccount(ty.Type)
+	case sema.ArrayType:
+		return containsAuthAccount(ty.ElementType(false))
+	case *sema.DictionaryType:
+		return containsAuthAccount(ty.KeyType) ||
+			containsAuthAccount(ty.ValueType)
+	default:
+		return ty.Equal(sema.AuthAccountType)
+	}
+}
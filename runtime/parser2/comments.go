@@ -0,0 +1,428 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser2
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2/lexer"
+)
+
+// ParseProgramWithComments parses the given input like ParseProgram,
+// and additionally attaches the comments of the input, and the information about empty lines,
+// to the declarations, statements, blocks, and switch cases of the program.
+//
+// This parse mode allows programs to be parsed and printed again without losing comments.
+// See ast.Comments for details
+//
+func ParseProgramWithComments(input string) (program *ast.Program, err error) {
+	program, err = ParseProgram(input)
+	if err != nil {
+		return nil, err
+	}
+
+	attachComments(input, program)
+
+	return program, nil
+}
+
+// commentNode is a node of the tree of elements to which comments can be attached
+//
+type commentNode struct {
+	// element is the element of the node, or nil for the program
+	element  ast.Commented
+	children []*commentNode
+}
+
+func (n *commentNode) startOffset() int {
+	return n.element.StartPosition().Offset
+}
+
+func (n *commentNode) endOffset() int {
+	return n.element.EndPosition().Offset
+}
+
+// commentNodeWalker is an ast.Walker which builds the tree of comment nodes
+//
+type commentNodeWalker struct {
+	parent *commentNode
+	// ignored are the elements which are not printed as declarations or statements,
+	// and therefore are not nodes themselves, e.g. the variable declaration in an if statement
+	ignored map[ast.Element]struct{}
+}
+
+func (w commentNodeWalker) Walk(element ast.Element) ast.Walker {
+	if element == nil {
+		return nil
+	}
+
+	if _, ok := w.ignored[element]; ok {
+		return w
+	}
+
+	if functionBlock, ok := element.(*ast.FunctionBlock); ok {
+		// The conditions of a function block are not elements, so they are not walked,
+		// but comments can be attached to them.
+		// They are inside of the block, before the statements
+
+		blockWalker := w.walkNode(functionBlock.Block)
+		blockWalker.walkConditions(functionBlock.PreConditions)
+		blockWalker.walkConditions(functionBlock.PostConditions)
+		for _, statement := range functionBlock.Block.Statements {
+			ast.Walk(blockWalker, statement)
+		}
+
+		return nil
+	}
+
+	commentedElement, ok := element.(ast.CommentedElement)
+	if !ok {
+		return w
+	}
+
+	nodeWalker := w.walkNode(commentedElement)
+
+	switch element := element.(type) {
+	case *ast.IfStatement:
+		if variableDeclaration, ok := element.Test.(*ast.VariableDeclaration); ok {
+			w.ignored[variableDeclaration] = struct{}{}
+		}
+
+	case *ast.TransactionDeclaration:
+		// The conditions of a transaction are not walked,
+		// but comments can be attached to them
+		nodeWalker.walkConditions(element.PreConditions)
+		nodeWalker.walkConditions(element.PostConditions)
+
+	case *ast.CompositeDeclaration:
+		// Events are printed without their members,
+		// so all comments inside of an event are attached to the event itself
+		if element.CompositeKind == common.CompositeKindEvent {
+			return nil
+		}
+
+	case *ast.SwitchStatement:
+		// Switch cases are not elements, so they are not walked,
+		// but comments can be attached to them
+		ast.Walk(nodeWalker, element.Expression)
+
+		for _, switchCase := range element.Cases {
+			caseWalker := nodeWalker.walkNode(switchCase)
+			// The default case has no expression
+			if switchCase.Expression != nil {
+				ast.Walk(caseWalker, switchCase.Expression)
+			}
+			for _, statement := range switchCase.Statements {
+				ast.Walk(caseWalker, statement)
+			}
+		}
+
+		return nil
+	}
+
+	return nodeWalker
+}
+
+// walkNode adds a node for the given element to the parent,
+// and returns the walker for the children of the node
+//
+func (w commentNodeWalker) walkNode(element ast.Commented) commentNodeWalker {
+	node := &commentNode{
+		element: element,
+	}
+	w.parent.children = append(w.parent.children, node)
+
+	return commentNodeWalker{
+		parent:  node,
+		ignored: w.ignored,
+	}
+}
+
+func (w commentNodeWalker) walkConditions(conditions *ast.Conditions) {
+	if conditions == nil {
+		return
+	}
+
+	for _, condition := range *conditions {
+		conditionWalker := w.walkNode(condition)
+		ast.Walk(conditionWalker, condition.Test)
+		if condition.Message != nil {
+			ast.Walk(conditionWalker, condition.Message)
+		}
+	}
+}
+
+func attachComments(input string, program *ast.Program) {
+
+	root := &commentNode{}
+
+	ast.Walk(
+		commentNodeWalker{
+			parent:  root,
+			ignored: map[ast.Element]struct{}{},
+		},
+		program,
+	)
+
+	// Every element gets comments, so the empty lines before it are known,
+	// even if no comments are attached to it
+
+	var initialize func(node *commentNode)
+	initialize = func(node *commentNode) {
+		sort.SliceStable(node.children, func(i, j int) bool {
+			return node.children[i].startOffset() < node.children[j].startOffset()
+		})
+
+		for _, child := range node.children {
+			child.element.SetElementComments(&ast.Comments{
+				BlankLineBefore: hasBlankLineBefore(input, child.startOffset()),
+			})
+			initialize(child)
+		}
+	}
+	initialize(root)
+
+	for _, comment := range lexComments(input) {
+		root.attach(comment, program)
+	}
+}
+
+// attach attaches the given comment to the innermost node which contains it:
+//
+// A comment which directly follows the preceding node, without code in between,
+// is a trailing comment of it, if it is on the same line as the end of the node,
+// or if it is not directly followed by the following node, e.g. it is followed by a closing brace.
+// Comments are never trailing comments of a block which is followed by another node,
+// e.g. a comment between the blocks of an if statement.
+//
+// Otherwise, a comment is a leading comment of the following node,
+// or if there is none, a trailing comment of the preceding node.
+// If the node has no children, the comment is an inner comment of the node.
+//
+func (n *commentNode) attach(comment sourceComment, program *ast.Program) {
+	var preceding, following *commentNode
+
+	for _, child := range n.children {
+		if child.startOffset() <= comment.StartPos.Offset &&
+			comment.EndPos.Offset <= child.endOffset() {
+
+			child.attach(comment, program)
+			return
+		}
+
+		if child.endOffset() < comment.StartPos.Offset {
+			preceding = child
+		} else if following == nil && child.startOffset() > comment.EndPos.Offset {
+			following = child
+		}
+	}
+
+	switch {
+	case preceding.directlyPrecedes(comment) &&
+		(!comment.OwnLine || !following.directlyFollows(comment)) &&
+		!(preceding.isBlock() && following != nil):
+
+		preceding.attachTrailing(comment)
+
+	case n.isBlock() && preceding == nil && !following.directlyFollows(comment):
+		// A comment at the start of a function block which is followed by the conditions
+		// is an inner comment of the block, which is printed before the conditions
+		n.attachInner(comment)
+
+	case following != nil:
+		following.attachLeading(comment)
+
+	case preceding != nil:
+		preceding.attachTrailing(comment)
+
+	case n.element != nil:
+		n.attachInner(comment)
+
+	default:
+		comments := program.Comments()
+		if comments == nil {
+			comments = &ast.Comments{}
+			program.SetComments(comments)
+		}
+		comments.Inner = append(comments.Inner, comment.Comment)
+	}
+}
+
+// directlyPrecedes returns true if the node is not nil,
+// and there is no code between the end of the node and the given comment
+//
+func (n *commentNode) directlyPrecedes(comment sourceComment) bool {
+	return n != nil &&
+		n.endOffset() >= comment.precedingCodeEndOffset
+}
+
+// directlyFollows returns true if the node is not nil,
+// and there is no code between the given comment and the start of the node
+//
+func (n *commentNode) directlyFollows(comment sourceComment) bool {
+	return n != nil &&
+		n.startOffset() <= comment.followingCodeStartOffset
+}
+
+func (n *commentNode) isBlock() bool {
+	_, ok := n.element.(*ast.Block)
+	return ok
+}
+
+func (n *commentNode) attachInner(comment sourceComment) {
+	comments := n.element.ElementComments()
+	comments.Inner = append(comments.Inner, comment.Comment)
+}
+
+// attachLeading attaches the given comment as a leading comment.
+// Blocks have no leading comments, as their braces are printed by their parent.
+// Instead, the comment becomes an inner comment of the block
+//
+func (n *commentNode) attachLeading(comment sourceComment) {
+	if n.isBlock() {
+		n.attachInner(comment)
+		return
+	}
+	comments := n.element.ElementComments()
+	comments.Leading = append(comments.Leading, comment.Comment)
+}
+
+// attachTrailing attaches the given comment as a trailing comment.
+// Blocks have no trailing comments, as their braces are printed by their parent.
+// Instead, the comment becomes an inner comment of the block
+//
+func (n *commentNode) attachTrailing(comment sourceComment) {
+	if n.isBlock() {
+		n.attachInner(comment)
+		return
+	}
+	comments := n.element.ElementComments()
+	comments.Trailing = append(comments.Trailing, comment.Comment)
+}
+
+// sourceComment is a comment in the input
+//
+type sourceComment struct {
+	*ast.Comment
+	// precedingCodeEndOffset is the offset at which the last token before the comment,
+	// which is neither whitespace nor a comment, ended
+	precedingCodeEndOffset int
+	// followingCodeStartOffset is the offset at which the first token after the comment,
+	// which is neither whitespace nor a comment, starts
+	followingCodeStartOffset int
+}
+
+// lexComments returns all comments in the given input, in order.
+//
+func lexComments(input string) (comments []sourceComment) {
+	tokens := lexer.Lex(input)
+
+	// lastCodeLine is the line on which the last token which is not a comment ended
+	lastCodeLine := 0
+	lastCodeEndOffset := -1
+
+	// Block comments can be nested
+	blockCommentDepth := 0
+	var blockCommentStartPos ast.Position
+
+	// firstFollowing is the index of the first comment
+	// for which the following code is not known yet
+	firstFollowing := 0
+
+	setFollowingCodeStartOffset := func(offset int) {
+		for i := firstFollowing; i < len(comments); i++ {
+			comments[i].followingCodeStartOffset = offset
+		}
+		firstFollowing = len(comments)
+	}
+
+	addComment := func(startPos, endPos ast.Position) {
+		comments = append(comments, sourceComment{
+			Comment: &ast.Comment{
+				Text:            strings.TrimRight(input[startPos.Offset:endPos.Offset+1], " \t\r"),
+				OwnLine:         lastCodeLine < startPos.Line,
+				BlankLineBefore: hasBlankLineBefore(input, startPos.Offset),
+				Range: ast.Range{
+					StartPos: startPos,
+					EndPos:   endPos,
+				},
+			},
+			precedingCodeEndOffset: lastCodeEndOffset,
+		})
+	}
+
+	for {
+		token := tokens.Next()
+
+		switch token.Type {
+		case lexer.TokenEOF:
+			setFollowingCodeStartOffset(len(input))
+			return
+
+		case lexer.TokenSpace,
+			lexer.TokenBlockCommentContent:
+
+			continue
+
+		case lexer.TokenLineComment:
+			addComment(token.StartPos, token.EndPos)
+
+		case lexer.TokenBlockCommentStart:
+			if blockCommentDepth == 0 {
+				blockCommentStartPos = token.StartPos
+			}
+			blockCommentDepth++
+
+		case lexer.TokenBlockCommentEnd:
+			blockCommentDepth--
+			if blockCommentDepth == 0 {
+				addComment(blockCommentStartPos, token.EndPos)
+			}
+
+		default:
+			setFollowingCodeStartOffset(token.StartPos.Offset)
+			lastCodeLine = token.EndPos.Line
+			lastCodeEndOffset = token.EndPos.Offset
+		}
+	}
+}
+
+// hasBlankLineBefore returns true if the given offset
+// is only preceded by whitespace containing an empty line
+//
+func hasBlankLineBefore(input string, offset int) bool {
+	newlines := 0
+	for i := offset - 1; i >= 0; i-- {
+		switch input[i] {
+		case '\n':
+			newlines++
+			if newlines > 1 {
+				return true
+			}
+		case ' ', '\t', '\r':
+			continue
+		default:
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,288 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestParseProgramWithComments(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("not attached by default", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := ParseProgram(`
+          // test
+          fun test() {}
+        `)
+		require.NoError(t, err)
+
+		assert.Nil(t, program.FunctionDeclarations()[0].Comments)
+		assert.Nil(t, program.Comments())
+	})
+
+	t.Run("leading, trailing, and inner", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := ParseProgramWithComments(`
+// leading
+
+/// doc
+fun test() { // block
+    let x = 1 /* x */ // one
+    // after
+} // trailing
+
+// end
+`)
+		require.NoError(t, err)
+
+		commentTexts := func(comments []*ast.Comment) (texts []string) {
+			for _, comment := range comments {
+				texts = append(texts, comment.Text)
+			}
+			return
+		}
+
+		functionDeclaration := program.FunctionDeclarations()[0]
+		functionComments := functionDeclaration.Comments
+
+		assert.Equal(t,
+			[]string{"// leading", "/// doc"},
+			commentTexts(functionComments.Leading),
+		)
+		assert.Equal(t,
+			[]string{"// trailing", "// end"},
+			commentTexts(functionComments.Trailing),
+		)
+		assert.True(t, functionComments.Trailing[1].OwnLine)
+		assert.True(t, functionComments.Trailing[1].BlankLineBefore)
+
+		block := functionDeclaration.FunctionBlock.Block
+
+		assert.Empty(t, block.Comments.Inner)
+
+		variableDeclaration := block.Statements[0].(*ast.VariableDeclaration)
+
+		assert.Equal(t,
+			[]string{"// block"},
+			commentTexts(variableDeclaration.Comments.Leading),
+		)
+		assert.Equal(t,
+			[]string{"/* x */", "// one", "// after"},
+			commentTexts(variableDeclaration.Comments.Trailing),
+		)
+		assert.False(t, variableDeclaration.Comments.Trailing[0].OwnLine)
+		assert.True(t, variableDeclaration.Comments.Trailing[2].OwnLine)
+	})
+
+	t.Run("only comments", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := ParseProgramWithComments(`
+          // a
+          /* b */
+        `)
+		require.NoError(t, err)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.Comments{
+				Inner: []*ast.Comment{
+					{
+						Text:    "// a",
+						OwnLine: true,
+						Range: ast.Range{
+							StartPos: ast.Position{Offset: 11, Line: 2, Column: 10},
+							EndPos:   ast.Position{Offset: 14, Line: 2, Column: 13},
+						},
+					},
+					{
+						Text:    "/* b */",
+						OwnLine: true,
+						Range: ast.Range{
+							StartPos: ast.Position{Offset: 26, Line: 3, Column: 10},
+							EndPos:   ast.Position{Offset: 32, Line: 3, Column: 16},
+						},
+					},
+				},
+			},
+			program.Comments(),
+		)
+	})
+}
+
+func TestParseProgramWithComments_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, code string, expected string) {
+
+		format := func(code string) string {
+			program, err := ParseProgramWithComments(code)
+			require.NoError(t, err)
+
+			var builder strings.Builder
+			prettier.Prettier(&builder, program.Doc(), 80, "    ")
+			return builder.String()
+		}
+
+		actual := format(code)
+		require.Equal(t, expected, actual)
+
+		// Formatting is idempotent
+
+		require.Equal(t, expected, format(actual))
+	}
+
+	t.Run("declarations", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+// leading
+import A from 0x1 // trailing
+
+
+/// Doc
+pub contract C {
+
+    // field
+    pub let x: Int   // x
+
+    /* block */
+    init() {
+        // TODO
+
+
+        self.x = 1 /* inline */ // end
+        // after
+    }
+
+    pub fun empty() {
+        // nothing here
+    }
+}
+
+// at end
+`,
+			`// leading
+import A from 0x1 // trailing
+
+/// Doc
+pub contract C {
+    // field
+    pub let x: Int // x
+
+    /* block */
+    init() {
+        // TODO
+
+        self.x = 1 /* inline */ // end
+        // after
+    }
+
+    pub fun empty() {
+        // nothing here
+    }
+}
+
+// at end`,
+		)
+	})
+
+	t.Run("conditions and if statement", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+fun test(x: Int): Int {
+    pre {
+        // positive
+        x > 0: "x must be positive"
+    }
+    if x > 1 {
+        return 1 // one
+    } else /* other */ {
+        // two
+        return 2
+    }
+}
+`,
+			`fun test(x: Int): Int {
+    pre {
+        // positive
+        x > 0: "x must be positive"
+    }
+    if x > 1 {
+        return 1 // one
+    } else {
+        /* other */
+        // two
+        return 2
+    }
+}`,
+		)
+	})
+
+	t.Run("switch statement", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+fun test(x: Int) {
+    switch x {
+    // first
+    case 1: // one
+        return
+    default:
+        // none
+        return
+    }
+}
+`,
+			`fun test(x: Int) {
+    switch x {
+        // first
+        case 1:
+            // one
+            return
+        default:
+            // none
+            return
+    }
+}`,
+		)
+	})
+}
@@ -88,6 +88,9 @@ func parseDeclaration(p *parser, docString string) ast.Declaration {
 			case keywordStruct, keywordResource, keywordContract, keywordEnum:
 				return parseCompositeOrInterfaceDeclaration(p, access, accessPos, docString)
 
+			case keywordTypeAlias:
+				return parseTypeAliasDeclaration(p, access, accessPos, docString)
+
 			case KeywordTransaction:
 				if access != ast.AccessNotSpecified {
 					panic(fmt.Errorf("invalid access modifier for transaction"))
@@ -675,6 +678,65 @@ func parseEventDeclaration(
 	}
 }
 
+// parseTypeAliasDeclaration parses a type alias declaration.
+//
+//     typeAliasDeclaration : access? 'typealias' identifier '=' type
+//
+func parseTypeAliasDeclaration(
+	p *parser,
+	access ast.Access,
+	accessPos *ast.Position,
+	docString string,
+) *ast.TypeAliasDeclaration {
+
+	startPos := p.current.StartPos
+	if accessPos != nil {
+		startPos = *accessPos
+	}
+
+	// Skip the `typealias` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	if !p.current.Is(lexer.TokenIdentifier) {
+		panic(fmt.Errorf(
+			"expected identifier after start of type alias declaration, got %s",
+			p.current.Type,
+		))
+	}
+
+	identifier := tokenToIdentifier(p.current)
+
+	// Skip the identifier
+	p.next()
+	p.skipSpaceAndComments(true)
+
+	if !p.current.Is(lexer.TokenEqual) {
+		panic(fmt.Errorf(
+			"expected %s after identifier of type alias declaration, got %s",
+			lexer.TokenEqual,
+			p.current.Type,
+		))
+	}
+
+	// Skip the equal sign
+	p.next()
+	p.skipSpaceAndComments(true)
+
+	ty := parseType(p, lowestBindingPower)
+
+	return &ast.TypeAliasDeclaration{
+		Access:     access,
+		Identifier: identifier,
+		Type:       ty,
+		DocString:  docString,
+		Range: ast.Range{
+			StartPos: startPos,
+			EndPos:   ty.EndPosition(),
+		},
+	}
+}
+
 // parseCompositeKind parses a composite kind.
 //
 //     compositeKind : 'struct' | 'resource' | 'contract' | 'enum'
@@ -766,7 +828,7 @@ func parseFieldWithVariableKind(
 //
 //     conformances : ':' nominalType ( ',' nominalType )*
 //
-//     compositeDeclaration : compositeKind identifier conformances?
+//     compositeDeclaration : compositeKind identifier typeParameterList? conformances?
 //                            '{' membersAndNestedDeclarations '}'
 //
 //     interfaceDeclaration : compositeKind 'interface' identifier conformances?
@@ -823,6 +885,8 @@ func parseCompositeOrInterfaceDeclaration(
 		}
 	}
 
+	typeParameterList := parseTypeParameterList(p)
+
 	p.skipSpaceAndComments(true)
 
 	var conformances []*ast.NominalType
@@ -857,6 +921,10 @@ func parseCompositeOrInterfaceDeclaration(
 	}
 
 	if isInterface {
+		if typeParameterList != nil {
+			panic(fmt.Errorf("unexpected type parameters for interface"))
+		}
+
 		// TODO: remove once interface conformances are supported
 		if len(conformances) > 0 {
 			// TODO: improve
@@ -873,13 +941,14 @@ func parseCompositeOrInterfaceDeclaration(
 		}
 	} else {
 		return &ast.CompositeDeclaration{
-			Access:        access,
-			CompositeKind: compositeKind,
-			Identifier:    identifier,
-			Conformances:  conformances,
-			Members:       members,
-			DocString:     docString,
-			Range:         declarationRange,
+			Access:            access,
+			CompositeKind:     compositeKind,
+			Identifier:        identifier,
+			TypeParameterList: typeParameterList,
+			Conformances:      conformances,
+			Members:           members,
+			DocString:         docString,
+			Range:             declarationRange,
 		}
 	}
 }
@@ -960,6 +1029,9 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 			case keywordStruct, keywordResource, keywordContract, keywordEnum:
 				return parseCompositeOrInterfaceDeclaration(p, access, accessPos, docString)
 
+			case keywordTypeAlias:
+				return parseTypeAliasDeclaration(p, access, accessPos, docString)
+
 			case keywordPriv, keywordPub, keywordAccess:
 				if access != ast.AccessNotSpecified {
 					panic(fmt.Errorf("unexpected access modifier"))
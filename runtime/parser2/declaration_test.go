@@ -956,6 +956,113 @@ func TestParseFunctionDeclaration(t *testing.T) {
 			result,
 		)
 	})
+	t.Run("with type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("fun foo<T, U: @AnyResource>() { }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.FunctionDeclaration{
+					Identifier: ast.Identifier{
+						Identifier: "foo",
+						Pos:        ast.Position{Line: 1, Column: 4, Offset: 4},
+					},
+					TypeParameterList: &ast.TypeParameterList{
+						TypeParameters: []*ast.TypeParameter{
+							{
+								Identifier: ast.Identifier{
+									Identifier: "T",
+									Pos:        ast.Position{Line: 1, Column: 8, Offset: 8},
+								},
+							},
+							{
+								Identifier: ast.Identifier{
+									Identifier: "U",
+									Pos:        ast.Position{Line: 1, Column: 11, Offset: 11},
+								},
+								TypeBound: &ast.TypeAnnotation{
+									IsResource: true,
+									Type: &ast.NominalType{
+										Identifier: ast.Identifier{
+											Identifier: "AnyResource",
+											Pos:        ast.Position{Line: 1, Column: 15, Offset: 15},
+										},
+									},
+									StartPos: ast.Position{Line: 1, Column: 14, Offset: 14},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 7, Offset: 7},
+							EndPos:   ast.Position{Line: 1, Column: 26, Offset: 26},
+						},
+					},
+					ParameterList: &ast.ParameterList{
+						Parameters: nil,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 27, Offset: 27},
+							EndPos:   ast.Position{Line: 1, Column: 28, Offset: 28},
+						},
+					},
+					ReturnTypeAnnotation: &ast.TypeAnnotation{
+						IsResource: false,
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Identifier: "",
+								Pos:        ast.Position{Line: 1, Column: 28, Offset: 28},
+							},
+						},
+						StartPos: ast.Position{Line: 1, Column: 28, Offset: 28},
+					},
+					FunctionBlock: &ast.FunctionBlock{
+						Block: &ast.Block{
+							Range: ast.Range{
+								StartPos: ast.Position{Line: 1, Column: 30, Offset: 30},
+								EndPos:   ast.Position{Line: 1, Column: 32, Offset: 32},
+							},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("with type parameters, missing type parameter after comma", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("fun foo<T,>() { }")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "missing type parameter after comma",
+					Pos:     ast.Position{Offset: 10, Line: 1, Column: 10},
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("with type parameters, missing end", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("fun foo<T")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "missing '>' at end of type parameter list",
+					Pos:     ast.Position{Offset: 9, Line: 1, Column: 9},
+				},
+			},
+			errs,
+		)
+	})
 }
 
 func TestParseAccess(t *testing.T) {
@@ -1981,6 +2088,51 @@ func TestParseCompositeDeclaration(t *testing.T) {
 			result,
 		)
 	})
+	t.Run("struct, type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("struct Pair<A, B> { }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.CompositeDeclaration{
+					CompositeKind: common.CompositeKindStructure,
+					Identifier: ast.Identifier{
+						Identifier: "Pair",
+						Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
+					},
+					TypeParameterList: &ast.TypeParameterList{
+						TypeParameters: []*ast.TypeParameter{
+							{
+								Identifier: ast.Identifier{
+									Identifier: "A",
+									Pos:        ast.Position{Line: 1, Column: 12, Offset: 12},
+								},
+							},
+							{
+								Identifier: ast.Identifier{
+									Identifier: "B",
+									Pos:        ast.Position{Line: 1, Column: 15, Offset: 15},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 11, Offset: 11},
+							EndPos:   ast.Position{Line: 1, Column: 16, Offset: 16},
+						},
+					},
+					Members: &ast.Members{},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 20, Offset: 20},
+					},
+				},
+			},
+			result,
+		)
+	})
 }
 
 func TestParseInterfaceDeclaration(t *testing.T) {
@@ -2265,6 +2417,21 @@ func TestParseInterfaceDeclaration(t *testing.T) {
 			result,
 		)
 	})
+	t.Run("type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("struct interface S<T> { }")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "unexpected type parameters for interface",
+					Pos:     ast.Position{Offset: 25, Line: 1, Column: 25},
+				},
+			},
+			errs,
+		)
+	})
 }
 
 func TestParseTransactionDeclaration(t *testing.T) {
@@ -4974,3 +5141,117 @@ func TestParseInvalidAccessModifiers(t *testing.T) {
 		)
 	})
 }
+
+func TestParseTypeAliasDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("restricted type", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("pub typealias Receivers = {Receiver, Balance}")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.TypeAliasDeclaration{
+					Access: ast.AccessPublic,
+					Identifier: ast.Identifier{
+						Identifier: "Receivers",
+						Pos:        ast.Position{Offset: 14, Line: 1, Column: 14},
+					},
+					Type: &ast.RestrictedType{
+						Restrictions: []*ast.NominalType{
+							{
+								Identifier: ast.Identifier{
+									Identifier: "Receiver",
+									Pos:        ast.Position{Offset: 27, Line: 1, Column: 27},
+								},
+							},
+							{
+								Identifier: ast.Identifier{
+									Identifier: "Balance",
+									Pos:        ast.Position{Offset: 37, Line: 1, Column: 37},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Offset: 26, Line: 1, Column: 26},
+							EndPos:   ast.Position{Offset: 44, Line: 1, Column: 44},
+						},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+						EndPos:   ast.Position{Offset: 44, Line: 1, Column: 44},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("contract C { typealias R = &Int }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.CompositeDeclaration{
+					CompositeKind: common.CompositeKindContract,
+					Identifier: ast.Identifier{
+						Identifier: "C",
+						Pos:        ast.Position{Offset: 9, Line: 1, Column: 9},
+					},
+					Members: ast.NewMembers(
+						[]ast.Declaration{
+							&ast.TypeAliasDeclaration{
+								Identifier: ast.Identifier{
+									Identifier: "R",
+									Pos:        ast.Position{Offset: 23, Line: 1, Column: 23},
+								},
+								Type: &ast.ReferenceType{
+									Type: &ast.NominalType{
+										Identifier: ast.Identifier{
+											Identifier: "Int",
+											Pos:        ast.Position{Offset: 28, Line: 1, Column: 28},
+										},
+									},
+									StartPos: ast.Position{Offset: 27, Line: 1, Column: 27},
+								},
+								Range: ast.Range{
+									StartPos: ast.Position{Offset: 13, Line: 1, Column: 13},
+									EndPos:   ast.Position{Offset: 30, Line: 1, Column: 30},
+								},
+							},
+						},
+					),
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+						EndPos:   ast.Position{Offset: 32, Line: 1, Column: 32},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing equal sign", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("typealias T Int")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "expected '=' after identifier of type alias declaration, got identifier",
+					Pos:     ast.Position{Offset: 12, Line: 1, Column: 12},
+				},
+			},
+			errs,
+		)
+	})
+}
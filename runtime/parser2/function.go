@@ -177,6 +177,116 @@ func parseParameter(p *parser) *ast.Parameter {
 	}
 }
 
+// parseTypeParameterList parses an optional type parameter list
+// of a function or composite declaration.
+//
+//     typeParameterList : '<' ( typeParameter ( ',' typeParameter )* )? '>'
+//
+//     typeParameter : identifier ( ':' typeAnnotation )?
+//
+func parseTypeParameterList(p *parser) *ast.TypeParameterList {
+
+	p.skipSpaceAndComments(true)
+
+	if !p.current.Is(lexer.TokenLess) {
+		return nil
+	}
+
+	startPos := p.current.StartPos
+	// Skip the opening angle bracket
+	p.next()
+
+	var typeParameters []*ast.TypeParameter
+	var endPos ast.Position
+
+	expectTypeParameter := true
+
+	atEnd := false
+	for !atEnd {
+		p.skipSpaceAndComments(true)
+		switch p.current.Type {
+		case lexer.TokenIdentifier:
+			if !expectTypeParameter {
+				panic(fmt.Errorf(
+					"expected comma or end of type parameter list, got %s",
+					p.current.Type,
+				))
+			}
+			typeParameter := parseTypeParameter(p)
+			typeParameters = append(typeParameters, typeParameter)
+			expectTypeParameter = false
+
+		case lexer.TokenComma:
+			if expectTypeParameter {
+				panic(fmt.Errorf(
+					"expected type parameter or end of type parameter list, got %s",
+					p.current.Type,
+				))
+			}
+			// Skip the comma
+			p.next()
+			expectTypeParameter = true
+
+		case lexer.TokenGreater:
+			if expectTypeParameter && len(typeParameters) > 0 {
+				p.report(fmt.Errorf("missing type parameter after comma"))
+			}
+			endPos = p.current.EndPos
+			// Skip the closing angle bracket
+			p.next()
+			atEnd = true
+
+		case lexer.TokenEOF:
+			panic(fmt.Errorf(
+				"missing %s at end of type parameter list",
+				lexer.TokenGreater,
+			))
+
+		default:
+			if expectTypeParameter {
+				panic(fmt.Errorf(
+					"expected type parameter or end of type parameter list, got %s",
+					p.current.Type,
+				))
+			} else {
+				panic(fmt.Errorf(
+					"expected comma or end of type parameter list, got %s",
+					p.current.Type,
+				))
+			}
+		}
+	}
+
+	return &ast.TypeParameterList{
+		TypeParameters: typeParameters,
+		Range: ast.Range{
+			StartPos: startPos,
+			EndPos:   endPos,
+		},
+	}
+}
+
+func parseTypeParameter(p *parser) *ast.TypeParameter {
+	identifier := tokenToIdentifier(p.current)
+	// Skip the identifier
+	p.next()
+
+	var typeBound *ast.TypeAnnotation
+
+	p.skipSpaceAndComments(true)
+	if p.current.Is(lexer.TokenColon) {
+		// Skip the colon
+		p.next()
+		p.skipSpaceAndComments(true)
+		typeBound = parseTypeAnnotation(p)
+	}
+
+	return &ast.TypeParameter{
+		Identifier: identifier,
+		TypeBound:  typeBound,
+	}
+}
+
 func parseFunctionDeclaration(
 	p *parser,
 	functionBlockIsOptional bool,
@@ -206,12 +316,15 @@ func parseFunctionDeclaration(
 	// Skip the identifier
 	p.next()
 
+	typeParameterList := parseTypeParameterList(p)
+
 	parameterList, returnTypeAnnotation, functionBlock :=
 		parseFunctionParameterListAndRest(p, functionBlockIsOptional)
 
 	return &ast.FunctionDeclaration{
 		Access:               access,
 		Identifier:           identifier,
+		TypeParameterList:    typeParameterList,
 		ParameterList:        parameterList,
 		ReturnTypeAnnotation: returnTypeAnnotation,
 		FunctionBlock:        functionBlock,
@@ -754,7 +754,21 @@ func parseNominalTypeInvocationRemainder(p *parser) *ast.InvocationExpression {
 	identifier := p.mustOne(lexer.TokenIdentifier)
 	ty := parseNominalTypeRemainder(p, identifier)
 
+	// The type might be instantiated, e.g. `create Box<@R>(...)`
+
+	var typeArguments []*ast.TypeAnnotation
+
 	p.skipSpaceAndComments(true)
+	if p.current.Is(lexer.TokenLess) {
+		// Skip the opening angle bracket
+		p.next()
+
+		typeArguments = parseCommaSeparatedTypeAnnotations(p, lexer.TokenGreater)
+		p.mustOne(lexer.TokenGreater)
+
+		p.skipSpaceAndComments(true)
+	}
+
 	parenOpenToken := p.mustOne(lexer.TokenParenOpen)
 	argumentsStartPos := parenOpenToken.EndPos
 	arguments, endPos := parseArgumentListRemainder(p)
@@ -772,6 +786,7 @@ func parseNominalTypeInvocationRemainder(p *parser) *ast.InvocationExpression {
 
 	return &ast.InvocationExpression{
 		InvokedExpression: invokedExpression,
+		TypeArguments:     typeArguments,
 		Arguments:         arguments,
 		ArgumentsStartPos: argumentsStartPos,
 		EndPos:            endPos,
@@ -49,16 +49,41 @@ func colorizeMeta(meta string) string {
 	return aurora.Blue(meta).String()
 }
 
+func colorizeWarning(message string) string {
+	return aurora.Colorize(message, aurora.YellowFg|aurora.BrightFg|aurora.BoldFm).String()
+}
+
 const errorPrefix = "error"
+const warningPrefix = "warning"
+const notePrefix = "note"
 const excerptArrow = "--> "
 const excerptDots = "... "
 const maxLineLength = 500
 
+// PrefixedError is an error which is not printed with the prefix `error`,
+// but with a custom prefix, e.g. the severity of a diagnostic
+//
+type PrefixedError interface {
+	error
+	Prefix() string
+}
+
 func FormatErrorMessage(message string, useColor bool) string {
+	return formatMessage(errorPrefix, message, useColor)
+}
+
+func formatMessage(prefix string, message string, useColor bool) string {
 	// prepare prefix
-	formattedErrorPrefix := errorPrefix
+	formattedErrorPrefix := prefix
 	if useColor {
-		formattedErrorPrefix = colorizeError(errorPrefix)
+		switch prefix {
+		case errorPrefix:
+			formattedErrorPrefix = colorizeError(prefix)
+		case warningPrefix:
+			formattedErrorPrefix = colorizeWarning(prefix)
+		default:
+			formattedErrorPrefix = colorizeNote(prefix)
+		}
 	}
 
 	// prepare message
@@ -180,6 +205,10 @@ func (p ErrorPrettyPrinter) PrettyPrintError(err error, location common.Location
 				}
 			}
 
+			if err, ok := err.(errors.HasStackTrace); ok {
+				p.prettyPrintStackTrace(err.StackTraceFrames(), codes)
+			}
+
 			return nil
 		}
 
@@ -202,7 +231,12 @@ func (p ErrorPrettyPrinter) PrettyPrintError(err error, location common.Location
 
 func (p ErrorPrettyPrinter) prettyPrintError(err error, location common.Location, code string) {
 
-	p.writeString(FormatErrorMessage(err.Error(), p.useColor))
+	prefix := errorPrefix
+	if prefixedError, ok := err.(PrefixedError); ok {
+		prefix = prefixedError.Prefix()
+	}
+
+	p.writeString(formatMessage(prefix, err.Error(), p.useColor))
 
 	message := ""
 	if secondaryError, ok := err.(errors.SecondaryError); ok {
@@ -226,6 +260,38 @@ func (p ErrorPrettyPrinter) prettyPrintError(err error, location common.Location
 	p.writeCodeExcerpts(excerpts, location, code)
 }
 
+// prettyPrintStackTrace prints the frames of the given stack trace
+// which have a location, each with an excerpt of the code
+//
+func (p ErrorPrettyPrinter) prettyPrintStackTrace(frames []errors.StackTraceFrame, codes map[common.LocationID]string) {
+	printedHeader := false
+
+	for _, frame := range frames {
+
+		frameWithLocation, ok := frame.(common.HasImportLocation)
+		if !ok {
+			continue
+		}
+
+		location := frameWithLocation.ImportLocation()
+		if location == nil {
+			continue
+		}
+
+		if !printedHeader {
+			p.writeString("\n")
+			p.writeString(formatMessage(notePrefix, "stack trace, most recent call first", p.useColor))
+			printedHeader = true
+		}
+
+		excerpts := []excerpt{
+			newExcerpt(frame, frame.Message(), false),
+		}
+
+		p.writeCodeExcerpts(excerpts, location, codes[location.ID()])
+	}
+}
+
 func (p ErrorPrettyPrinter) writeCodeExcerpts(
 	excerpts []excerpt,
 	location common.Location,
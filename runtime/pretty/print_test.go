@@ -26,6 +26,7 @@ import (
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
 )
 
 type testError struct {
@@ -69,3 +70,134 @@ func TestPrintBrokenCode(t *testing.T) {
 			" --> test:3:0\n",
 		sb.String())
 }
+
+type testPrefixedError struct {
+	testError
+}
+
+func (testPrefixedError) Prefix() string {
+	return "warning"
+}
+
+func TestPrintPrefixedError(t *testing.T) {
+	const code = `pub resource R {}`
+
+	location := common.StringLocation("test")
+
+	var sb strings.Builder
+	printer := NewErrorPrettyPrinter(&sb, false)
+	err := printer.PrettyPrintError(
+		testPrefixedError{
+			testError: testError{
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 13},
+					EndPos:   ast.Position{Line: 1, Column: 13},
+				},
+			},
+		},
+		location,
+		map[common.LocationID]string{
+			location.ID(): code,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t,
+		"warning: test error\n"+
+			" --> test:1:13\n"+
+			"  |\n"+
+			"1 | pub resource R {}\n"+
+			"  |              ^\n",
+		sb.String())
+}
+
+type testStackTraceFrame struct {
+	ast.Range
+	location common.Location
+}
+
+func (f testStackTraceFrame) Message() string {
+	return "test frame"
+}
+
+func (f testStackTraceFrame) ImportLocation() common.Location {
+	return f.location
+}
+
+type testStackTraceError struct {
+	err    error
+	frames []errors.StackTraceFrame
+}
+
+func (e testStackTraceError) Error() string {
+	return e.err.Error()
+}
+
+func (e testStackTraceError) ChildErrors() []error {
+	return []error{e.err}
+}
+
+func (e testStackTraceError) StackTraceFrames() []errors.StackTraceFrame {
+	return e.frames
+}
+
+func TestPrintStackTrace(t *testing.T) {
+	const code = "fun a() {\n    b()\n}\n"
+	const importedCode = "fun b() {\n    c()\n}\n"
+
+	location := common.StringLocation("test")
+	importedLocation := common.StringLocation("imported")
+
+	var sb strings.Builder
+	printer := NewErrorPrettyPrinter(&sb, false)
+	err := printer.PrettyPrintError(
+		testStackTraceError{
+			err: testError{
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 2, Column: 4},
+					EndPos:   ast.Position{Line: 2, Column: 6},
+				},
+			},
+			frames: []errors.StackTraceFrame{
+				testStackTraceFrame{
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 2, Column: 4},
+						EndPos:   ast.Position{Line: 2, Column: 6},
+					},
+					location: importedLocation,
+				},
+				testStackTraceFrame{
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 2, Column: 4},
+						EndPos:   ast.Position{Line: 2, Column: 6},
+					},
+					location: location,
+				},
+				// frames without a location are not printed
+				testStackTraceFrame{},
+			},
+		},
+		importedLocation,
+		map[common.LocationID]string{
+			location.ID():         code,
+			importedLocation.ID(): importedCode,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t,
+		"error: test error\n"+
+			" --> imported:2:4\n"+
+			"  |\n"+
+			"2 |     c()\n"+
+			"  |     ^^^\n"+
+			"\n"+
+			"note: stack trace, most recent call first\n"+
+			" --> imported:2:4\n"+
+			"  |\n"+
+			"2 |     c()\n"+
+			"  |     --- test frame\n"+
+			" --> test:2:4\n"+
+			"  |\n"+
+			"2 |     b()\n"+
+			"  |     --- test frame\n",
+		sb.String())
+}
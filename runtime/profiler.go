@@ -0,0 +1,204 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// profileFrame is a frame of a profiled call stack:
+// the line which is executed in a function.
+//
+// The function name is empty for code which is not in a function,
+// e.g. the top-level code of a program
+//
+type profileFrame struct {
+	location common.Location
+	function string
+	line     int
+}
+
+// profileSample is the computation and wall time attributed to a call stack
+//
+type profileSample struct {
+	// stack are the frames of the call stack, starting with the most recent invocation
+	stack       []profileFrame
+	computation int64
+	wallTime    int64
+}
+
+// Profiler attributes the computation used during executions,
+// and optionally the wall time, to Cadence functions and lines.
+//
+// The computation metered and the time spent between two statements, function invocations,
+// or function returns, is attributed to the call stack of the earlier one.
+// As computation is metered deterministically, the computation profile of an execution
+// is deterministic, unlike the wall time profile.
+//
+// The profile can be written in the pprof format, see WritePprof.
+//
+// A profiler is not safe for concurrent use.
+//
+type Profiler struct {
+	wallTimeEnabled bool
+	samples         map[string]*profileSample
+	// sampleKeys are the keys of the samples, in the order the samples were added
+	sampleKeys  []string
+	stack       []profileFrame
+	computation uint64
+	lastTime    time.Time
+	// depth is the number of nested executions, e.g. contract deployments in a transaction
+	depth int
+}
+
+// NewProfiler returns a new profiler.
+// If wall time is enabled, the time spent is profiled in addition to the computation used
+//
+func NewProfiler(wallTimeEnabled bool) *Profiler {
+	return &Profiler{
+		wallTimeEnabled: wallTimeEnabled,
+		samples:         map[string]*profileSample{},
+	}
+}
+
+// start starts profiling the execution of the program at the given location.
+// Nested executions are profiled as part of the outer execution
+//
+func (p *Profiler) start(location common.Location) {
+	p.depth++
+	if p.depth > 1 {
+		return
+	}
+
+	p.stack = []profileFrame{
+		{location: location},
+	}
+	p.computation = 0
+	if p.wallTimeEnabled {
+		p.lastTime = time.Now()
+	}
+}
+
+// stop stops profiling the current execution
+//
+func (p *Profiler) stop() {
+	p.depth--
+	if p.depth > 0 {
+		return
+	}
+
+	p.flush()
+	p.stack = nil
+}
+
+func (p *Profiler) reportComputation(intensity uint) {
+	p.computation += uint64(intensity)
+}
+
+// reportLine attributes the computation and time since the last report to the previous call stack,
+// and then profiles the given line of the currently invoked function
+//
+func (p *Profiler) reportLine(inter *interpreter.Interpreter, line int) {
+	p.flush()
+	p.stack = p.currentStack(inter, line)
+}
+
+func (p *Profiler) currentStack(inter *interpreter.Interpreter, line int) []profileFrame {
+	frames := inter.CallStack()
+
+	stack := make([]profileFrame, 0, len(frames)+1)
+
+	leaf := profileFrame{
+		location: inter.Location,
+		line:     line,
+	}
+	if len(frames) > 0 {
+		leaf.function = frames[0].FunctionName
+	}
+	stack = append(stack, leaf)
+
+	// The location range of each frame is the invocation,
+	// i.e. the line in the calling function
+
+	for i, frame := range frames {
+		if frame.Location == nil {
+			continue
+		}
+
+		caller := profileFrame{
+			location: frame.Location,
+			line:     frame.StartPos.Line,
+		}
+		if i+1 < len(frames) {
+			caller.function = frames[i+1].FunctionName
+		}
+		stack = append(stack, caller)
+	}
+
+	return stack
+}
+
+func (p *Profiler) flush() {
+	var wallTime int64
+	if p.wallTimeEnabled {
+		now := time.Now()
+		wallTime = now.Sub(p.lastTime).Nanoseconds()
+		p.lastTime = now
+	}
+
+	if len(p.stack) == 0 || (p.computation == 0 && wallTime == 0) {
+		return
+	}
+
+	key := profileStackKey(p.stack)
+
+	sample, ok := p.samples[key]
+	if !ok {
+		sample = &profileSample{
+			stack: p.stack,
+		}
+		p.samples[key] = sample
+		p.sampleKeys = append(p.sampleKeys, key)
+	}
+
+	sample.computation += int64(p.computation)
+	sample.wallTime += wallTime
+
+	p.computation = 0
+}
+
+func profileStackKey(stack []profileFrame) string {
+	var sb strings.Builder
+	for _, frame := range stack {
+		if frame.location != nil {
+			sb.WriteString(string(frame.location.ID()))
+		}
+		sb.WriteByte(0)
+		sb.WriteString(frame.function)
+		sb.WriteByte(0)
+		sb.WriteString(strconv.Itoa(frame.line))
+		sb.WriteByte(1)
+	}
+	return sb.String()
+}
@@ -0,0 +1,277 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+)
+
+// Field numbers of the messages of the pprof profile format,
+// see https://github.com/google/pprof/blob/main/proto/profile.proto
+
+const (
+	pprofProfileSampleType        = 1
+	pprofProfileSample            = 2
+	pprofProfileLocation          = 4
+	pprofProfileFunction          = 5
+	pprofProfileStringTable       = 6
+	pprofProfileDefaultSampleType = 14
+
+	pprofValueTypeType = 1
+	pprofValueTypeUnit = 2
+
+	pprofSampleLocationID = 1
+	pprofSampleValue      = 2
+
+	pprofLocationID   = 1
+	pprofLocationLine = 4
+
+	pprofLineFunctionID = 1
+	pprofLineLine       = 2
+
+	pprofFunctionID         = 1
+	pprofFunctionName       = 2
+	pprofFunctionSystemName = 3
+	pprofFunctionFilename   = 4
+)
+
+// protobufEncoder encodes protocol buffer messages
+//
+type protobufEncoder struct {
+	buffer  bytes.Buffer
+	scratch [binary.MaxVarintLen64]byte
+}
+
+func (e *protobufEncoder) varint(value uint64) {
+	n := binary.PutUvarint(e.scratch[:], value)
+	e.buffer.Write(e.scratch[:n])
+}
+
+func (e *protobufEncoder) tag(field int, wireType int) {
+	e.varint(uint64(field)<<3 | uint64(wireType))
+}
+
+func (e *protobufEncoder) uint64(field int, value uint64) {
+	if value == 0 {
+		return
+	}
+	e.tag(field, 0)
+	e.varint(value)
+}
+
+func (e *protobufEncoder) int64(field int, value int64) {
+	e.uint64(field, uint64(value))
+}
+
+func (e *protobufEncoder) bytes(field int, value []byte) {
+	e.tag(field, 2)
+	e.varint(uint64(len(value)))
+	e.buffer.Write(value)
+}
+
+func (e *protobufEncoder) string(field int, value string) {
+	e.tag(field, 2)
+	e.varint(uint64(len(value)))
+	e.buffer.WriteString(value)
+}
+
+func (e *protobufEncoder) packedUint64s(field int, values []uint64) {
+	var packed protobufEncoder
+	for _, value := range values {
+		packed.varint(value)
+	}
+	e.bytes(field, packed.buffer.Bytes())
+}
+
+func (e *protobufEncoder) packedInt64s(field int, values []int64) {
+	var packed protobufEncoder
+	for _, value := range values {
+		packed.varint(uint64(value))
+	}
+	e.bytes(field, packed.buffer.Bytes())
+}
+
+func (e *protobufEncoder) message(field int, encode func(e *protobufEncoder)) {
+	var message protobufEncoder
+	encode(&message)
+	e.bytes(field, message.buffer.Bytes())
+}
+
+// pprofWriter builds the tables of a pprof profile
+//
+type pprofWriter struct {
+	encoder   protobufEncoder
+	strings   map[string]int64
+	functions map[pprofFunctionKey]uint64
+	locations map[pprofLocationKey]uint64
+	// stringTable are the strings of the profile, in the order of their indices
+	stringTable []string
+}
+
+type pprofFunctionKey struct {
+	name     string
+	filename string
+}
+
+type pprofLocationKey struct {
+	functionID uint64
+	line       int
+}
+
+func (w *pprofWriter) stringIndex(value string) int64 {
+	index, ok := w.strings[value]
+	if !ok {
+		index = int64(len(w.stringTable))
+		w.strings[value] = index
+		w.stringTable = append(w.stringTable, value)
+	}
+	return index
+}
+
+func (w *pprofWriter) valueType(field int, typ string, unit string) {
+	w.encoder.message(field, func(e *protobufEncoder) {
+		e.int64(pprofValueTypeType, w.stringIndex(typ))
+		e.int64(pprofValueTypeUnit, w.stringIndex(unit))
+	})
+}
+
+func (w *pprofWriter) functionID(frame profileFrame) uint64 {
+	var filename string
+	if frame.location != nil {
+		filename = string(frame.location.ID())
+	}
+
+	name := frame.function
+	switch {
+	case name == "":
+		name = filename
+	case filename != "":
+		name = filename + "." + name
+	}
+
+	key := pprofFunctionKey{
+		name:     name,
+		filename: filename,
+	}
+
+	id, ok := w.functions[key]
+	if ok {
+		return id
+	}
+
+	id = uint64(len(w.functions) + 1)
+	w.functions[key] = id
+
+	w.encoder.message(pprofProfileFunction, func(e *protobufEncoder) {
+		e.uint64(pprofFunctionID, id)
+		e.int64(pprofFunctionName, w.stringIndex(name))
+		e.int64(pprofFunctionSystemName, w.stringIndex(name))
+		e.int64(pprofFunctionFilename, w.stringIndex(filename))
+	})
+
+	return id
+}
+
+func (w *pprofWriter) locationID(frame profileFrame) uint64 {
+	functionID := w.functionID(frame)
+
+	key := pprofLocationKey{
+		functionID: functionID,
+		line:       frame.line,
+	}
+
+	id, ok := w.locations[key]
+	if ok {
+		return id
+	}
+
+	id = uint64(len(w.locations) + 1)
+	w.locations[key] = id
+
+	w.encoder.message(pprofProfileLocation, func(e *protobufEncoder) {
+		e.uint64(pprofLocationID, id)
+		e.message(pprofLocationLine, func(e *protobufEncoder) {
+			e.uint64(pprofLineFunctionID, functionID)
+			e.int64(pprofLineLine, int64(frame.line))
+		})
+	})
+
+	return id
+}
+
+// WritePprof writes the profile in the gzip-compressed protocol buffer format of pprof,
+// so it can be analyzed using `go tool pprof`.
+//
+// The profile has the sample type `computation`, and the sample type `wall`
+// if wall time profiling is enabled
+//
+func (p *Profiler) WritePprof(writer io.Writer) error {
+	w := &pprofWriter{
+		strings:   map[string]int64{},
+		functions: map[pprofFunctionKey]uint64{},
+		locations: map[pprofLocationKey]uint64{},
+	}
+
+	// The first string of the string table must be the empty string
+	w.stringIndex("")
+
+	w.valueType(pprofProfileSampleType, "computation", "count")
+	if p.wallTimeEnabled {
+		w.valueType(pprofProfileSampleType, "wall", "nanoseconds")
+	}
+
+	for _, key := range p.sampleKeys {
+		sample := p.samples[key]
+
+		locationIDs := make([]uint64, len(sample.stack))
+		for i, frame := range sample.stack {
+			locationIDs[i] = w.locationID(frame)
+		}
+
+		values := []int64{sample.computation}
+		if p.wallTimeEnabled {
+			values = append(values, sample.wallTime)
+		}
+
+		w.encoder.message(pprofProfileSample, func(e *protobufEncoder) {
+			e.packedUint64s(pprofSampleLocationID, locationIDs)
+			e.packedInt64s(pprofSampleValue, values)
+		})
+	}
+
+	defaultSampleType := w.stringIndex("computation")
+
+	for _, value := range w.stringTable {
+		w.encoder.string(pprofProfileStringTable, value)
+	}
+
+	w.encoder.int64(pprofProfileDefaultSampleType, defaultSampleType)
+
+	gzipWriter := gzip.NewWriter(writer)
+
+	_, err := gzipWriter.Write(w.encoder.buffer.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return gzipWriter.Close()
+}
@@ -0,0 +1,197 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	pprofProfile "github.com/google/pprof/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func TestRuntimeProfiler(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      pub fun fib(_ n: Int): Int {
+          if n < 2 {
+              return n
+          }
+          return fib(n - 1) + fib(n - 2)
+      }
+
+      pub fun main(): Int {
+          var i = 0
+          while i < 3 {
+              i = i + 1
+          }
+          return fib(5)
+      }
+    `)
+
+	var totalComputation uint
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		meterComputation: func(_ common.ComputationKind, intensity uint) error {
+			totalComputation += intensity
+			return nil
+		},
+	}
+
+	profiler := NewProfiler(false)
+
+	runtime := newTestInterpreterRuntime()
+	runtime.SetProfiler(profiler)
+
+	location := common.ScriptLocation{0x1}
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  location,
+		},
+	)
+	require.NoError(t, err)
+
+	// All metered computation is attributed
+
+	var profiledComputation int64
+	for _, sample := range profiler.samples {
+		profiledComputation += sample.computation
+	}
+	require.NotZero(t, totalComputation)
+	assert.Equal(t, int64(totalComputation), profiledComputation)
+
+	// The loop statement and its first iteration are attributed to the loop in `main`.
+	// The further iterations are metered after the loop body,
+	// so they are attributed to the last statement of the body, like the statements of the body.
+	// The recursive invocations are attributed to the recursive call stacks of `fib`
+
+	loopKey := profileStackKey([]profileFrame{
+		{location: location, function: "main", line: 11},
+	})
+	require.Contains(t, profiler.samples, loopKey)
+	assert.Equal(t, int64(2), profiler.samples[loopKey].computation)
+
+	loopBodyKey := profileStackKey([]profileFrame{
+		{location: location, function: "main", line: 12},
+	})
+	require.Contains(t, profiler.samples, loopBodyKey)
+	assert.Equal(t, int64(5), profiler.samples[loopBodyKey].computation)
+
+	recursiveKey := profileStackKey([]profileFrame{
+		{location: location, function: "fib", line: 6},
+		{location: location, function: "fib", line: 6},
+		{location: location, function: "main", line: 14},
+	})
+	require.Contains(t, profiler.samples, recursiveKey)
+	assert.NotZero(t, profiler.samples[recursiveKey].computation)
+
+	t.Run("pprof", func(t *testing.T) {
+
+		t.Parallel()
+
+		var buffer bytes.Buffer
+		err := profiler.WritePprof(&buffer)
+		require.NoError(t, err)
+
+		profile, err := pprofProfile.Parse(&buffer)
+		require.NoError(t, err)
+		require.NoError(t, profile.CheckValid())
+
+		assert.Equal(t,
+			[]*pprofProfile.ValueType{
+				{
+					Type: "computation",
+					Unit: "count",
+				},
+			},
+			profile.SampleType,
+		)
+		assert.Equal(t, "computation", profile.DefaultSampleType)
+
+		mainFunctionName := string(location.ID()) + ".main"
+		fibFunctionName := string(location.ID()) + ".fib"
+
+		functionNames := make([]string, 0, len(profile.Function))
+		for _, function := range profile.Function {
+			functionNames = append(functionNames, function.Name)
+		}
+		assert.ElementsMatch(t,
+			[]string{
+				mainFunctionName,
+				fibFunctionName,
+			},
+			functionNames,
+		)
+
+		// Each call stack is a sample of the profile,
+		// with the same computation as the profiled call stack
+
+		require.Len(t, profile.Sample, len(profiler.samples))
+
+		var profiledComputation int64
+		computations := map[string]int64{}
+
+		for _, sample := range profile.Sample {
+			require.Len(t, sample.Value, 1)
+
+			frames := make([]string, 0, len(sample.Location))
+			for _, location := range sample.Location {
+				require.Len(t, location.Line, 1)
+				line := location.Line[0]
+				frames = append(frames, fmt.Sprintf("%s:%d", line.Function.Name, line.Line))
+			}
+
+			computation := sample.Value[0]
+			computations[strings.Join(frames, " ")] = computation
+			profiledComputation += computation
+		}
+
+		assert.Equal(t, int64(totalComputation), profiledComputation)
+
+		assert.Equal(t,
+			int64(2),
+			computations[fmt.Sprintf("%s:11", mainFunctionName)],
+		)
+		assert.Equal(t,
+			int64(5),
+			computations[fmt.Sprintf("%s:12", mainFunctionName)],
+		)
+		assert.Equal(t,
+			profiler.samples[recursiveKey].computation,
+			computations[fmt.Sprintf(
+				"%[1]s:6 %[1]s:6 %[2]s:14",
+				fibFunctionName,
+				mainFunctionName,
+			)],
+		)
+	})
+}
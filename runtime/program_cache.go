@@ -0,0 +1,417 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// ProgramCacheMetrics receives the hits and misses of a program cache
+//
+type ProgramCacheMetrics interface {
+	ProgramCacheHit(location common.Location)
+	ProgramCacheMiss(location common.Location)
+}
+
+// ProgramCodeFunc returns the current code for the given location,
+// e.g. the account contract code for an address location.
+//
+type ProgramCodeFunc func(location common.Location) ([]byte, error)
+
+type programCacheKey struct {
+	locationID common.LocationID
+	codeHash   [sha256.Size]byte
+}
+
+type programCacheEntry struct {
+	key     programCacheKey
+	program *interpreter.Program
+	// imports are the locations which the program imports, directly or transitively
+	imports map[common.LocationID]struct{}
+}
+
+// importsAny returns true if the program of the entry
+// imports any of the given locations, directly or transitively
+//
+func (e *programCacheEntry) importsAny(locationIDs map[common.LocationID]struct{}) bool {
+	for locationID := range e.imports { //nolint:maprangecheck
+		if _, ok := locationIDs[locationID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isStale returns true if the program of the entry is for the given location,
+// or if it imports the location, directly or transitively
+//
+func (e *programCacheEntry) isStale(locationID common.LocationID) bool {
+	if e.key.locationID == locationID {
+		return true
+	}
+	_, ok := e.imports[locationID]
+	return ok
+}
+
+// ProgramCache is a size-bounded cache of checked programs,
+// which evicts the least recently used program when it is full.
+//
+// Programs are keyed by their location and the hash of their code,
+// so a cached program is never returned for different code.
+// Programs are checked against the programs they import,
+// so when a program is invalidated, the programs which import it,
+// directly or transitively, are invalidated as well.
+//
+// The cache is not used directly by an Interface implementation,
+// but through a ProgramCacheTransaction, which only makes its changes
+// visible to other transactions once it is committed.
+//
+// A program cache is safe for concurrent use.
+//
+type ProgramCache struct {
+	mutex    sync.Mutex
+	capacity int
+	metrics  ProgramCacheMetrics
+	entries  map[programCacheKey]*list.Element
+	order    *list.List
+}
+
+// NewProgramCache returns a new program cache which holds at most the given number of programs.
+//
+// The metrics are optional and may be nil.
+//
+func NewProgramCache(capacity int, metrics ProgramCacheMetrics) *ProgramCache {
+	return &ProgramCache{
+		capacity: capacity,
+		metrics:  metrics,
+		entries:  map[programCacheKey]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// Len returns the number of cached programs
+//
+func (c *ProgramCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.order.Len()
+}
+
+// Invalidate removes all cached programs for the given location,
+// and all cached programs which import the location, directly or transitively
+//
+func (c *ProgramCache) Invalidate(location common.Location) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.invalidate(location.ID())
+}
+
+func (c *ProgramCache) invalidate(locationID common.LocationID) {
+	var next *list.Element
+	for element := c.order.Front(); element != nil; element = next {
+		next = element.Next()
+
+		entry := element.Value.(*programCacheEntry)
+		if !entry.isStale(locationID) {
+			continue
+		}
+
+		c.order.Remove(element)
+		delete(c.entries, entry.key)
+	}
+}
+
+// get returns a copy of the entry for the given key, if any
+//
+func (c *ProgramCache) get(key programCacheKey) (*programCacheEntry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+
+	entry := *element.Value.(*programCacheEntry)
+	return &entry, true
+}
+
+// set adds the given entry to the cache, or updates it,
+// and evicts the least recently used programs if the cache is full.
+//
+// The cache must be locked
+//
+func (c *ProgramCache) set(entry *programCacheEntry) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	if element, ok := c.entries[entry.key]; ok {
+		existing := element.Value.(*programCacheEntry)
+		existing.program = entry.program
+		existing.imports = entry.imports
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[entry.key] = c.order.PushFront(&programCacheEntry{
+		key:     entry.key,
+		program: entry.program,
+		imports: entry.imports,
+	})
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*programCacheEntry).key)
+	}
+}
+
+// NewTransaction returns a new transaction for the cache.
+//
+// The given function is used to get the current code of locations,
+// which is hashed to look up and to store programs.
+//
+func (c *ProgramCache) NewTransaction(getCode ProgramCodeFunc) *ProgramCacheTransaction {
+	return &ProgramCacheTransaction{
+		cache:       c,
+		getCode:     getCode,
+		programs:    map[common.LocationID]*programCacheEntry{},
+		invalidated: map[common.LocationID]struct{}{},
+	}
+}
+
+// ProgramCacheTransaction is a view of a program cache for one execution.
+//
+// Its GetProgram and SetProgram functions can be used to implement
+// the functions of the same name of Interface:
+// Once a program was returned or set for a location,
+// it is returned for the location for the rest of the transaction.
+//
+// The programs set in the transaction, and the invalidations of programs
+// caused by contract code updates and removals, are only applied to the cache
+// when the transaction is committed.
+// An update or removal of a contract also invalidates the programs
+// which import the contract, directly or transitively.
+//
+// A transaction is not safe for concurrent use.
+//
+type ProgramCacheTransaction struct {
+	cache       *ProgramCache
+	getCode     ProgramCodeFunc
+	programs    map[common.LocationID]*programCacheEntry
+	pending     []*programCacheEntry
+	invalidated map[common.LocationID]struct{}
+}
+
+func (t *ProgramCacheTransaction) key(location common.Location) (programCacheKey, error) {
+	code, err := t.getCode(location)
+	if err != nil {
+		return programCacheKey{}, err
+	}
+
+	return programCacheKey{
+		locationID: location.ID(),
+		codeHash:   sha256.Sum256(code),
+	}, nil
+}
+
+func (t *ProgramCacheTransaction) reportAccess(location common.Location, hit bool) {
+	metrics := t.cache.metrics
+	if metrics == nil {
+		return
+	}
+
+	if hit {
+		metrics.ProgramCacheHit(location)
+	} else {
+		metrics.ProgramCacheMiss(location)
+	}
+}
+
+// GetProgram returns the program for the given location, if available
+//
+func (t *ProgramCacheTransaction) GetProgram(location Location) (*interpreter.Program, error) {
+	locationID := location.ID()
+
+	if entry, ok := t.programs[locationID]; ok {
+		t.reportAccess(location, true)
+		return entry.program, nil
+	}
+
+	// Programs for code which was updated or removed in this transaction
+	// must not be taken from the cache
+
+	if _, ok := t.invalidated[locationID]; ok {
+		t.reportAccess(location, false)
+		return nil, nil
+	}
+
+	key, err := t.key(location)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok := t.cache.get(key)
+
+	// Programs which import code which was updated or removed in this transaction
+	// were checked against the old code, so they must not be taken from the cache either
+
+	if ok && entry.importsAny(t.invalidated) {
+		ok = false
+	}
+
+	t.reportAccess(location, ok)
+	if !ok {
+		return nil, nil
+	}
+
+	t.programs[locationID] = entry
+
+	return entry.program, nil
+}
+
+// programImports returns the locations which the given program imports, directly or transitively.
+//
+// The programs of the imported locations were gotten or set in the transaction
+// when the given program was checked, so their imports are known
+//
+func (t *ProgramCacheTransaction) programImports(program *interpreter.Program) map[common.LocationID]struct{} {
+	imports := map[common.LocationID]struct{}{}
+
+	if program.Elaboration == nil {
+		return imports
+	}
+
+	for _, resolvedLocations := range program.Elaboration.ImportDeclarationsResolvedLocations { //nolint:maprangecheck
+		for _, resolvedLocation := range resolvedLocations {
+			locationID := resolvedLocation.Location.ID()
+			imports[locationID] = struct{}{}
+
+			importedEntry, ok := t.programs[locationID]
+			if !ok {
+				continue
+			}
+
+			for importedLocationID := range importedEntry.imports { //nolint:maprangecheck
+				imports[importedLocationID] = struct{}{}
+			}
+		}
+	}
+
+	return imports
+}
+
+// SetProgram sets the program for the given location.
+// The program is added to the cache when the transaction is committed
+//
+func (t *ProgramCacheTransaction) SetProgram(location Location, program *interpreter.Program) error {
+	key, err := t.key(location)
+	if err != nil {
+		return err
+	}
+
+	entry := &programCacheEntry{
+		key:     key,
+		program: program,
+		imports: t.programImports(program),
+	}
+
+	t.programs[key.locationID] = entry
+
+	t.pending = append(t.pending, entry)
+
+	return nil
+}
+
+// UpdateAccountContractCode invalidates the program of the updated account contract,
+// and the programs which import it
+//
+func (t *ProgramCacheTransaction) UpdateAccountContractCode(address Address, name string, _ []byte) error {
+	t.invalidate(common.AddressLocation{
+		Address: address,
+		Name:    name,
+	})
+	return nil
+}
+
+// RemoveAccountContractCode invalidates the program of the removed account contract,
+// and the programs which import it
+//
+func (t *ProgramCacheTransaction) RemoveAccountContractCode(address Address, name string) error {
+	t.invalidate(common.AddressLocation{
+		Address: address,
+		Name:    name,
+	})
+	return nil
+}
+
+func (t *ProgramCacheTransaction) invalidate(location common.Location) {
+	locationID := location.ID()
+
+	t.invalidated[locationID] = struct{}{}
+
+	for programLocationID, entry := range t.programs { //nolint:maprangecheck
+		if entry.isStale(locationID) {
+			delete(t.programs, programLocationID)
+		}
+	}
+
+	// Programs set for the old code, or checked against it, are not added to the cache
+
+	pending := t.pending[:0]
+	for _, entry := range t.pending {
+		if !entry.isStale(locationID) {
+			pending = append(pending, entry)
+		}
+	}
+	t.pending = pending
+}
+
+// Commit applies the invalidations and the programs set in the transaction to the cache.
+//
+// The transaction must not be used after it was committed.
+//
+func (t *ProgramCacheTransaction) Commit() {
+	cache := t.cache
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	for locationID := range t.invalidated { //nolint:maprangecheck
+		cache.invalidate(locationID)
+	}
+
+	for _, entry := range t.pending {
+		cache.set(entry)
+	}
+
+	t.programs = nil
+	t.pending = nil
+	t.invalidated = nil
+}
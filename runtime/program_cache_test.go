@@ -0,0 +1,668 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/checker"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+type testProgramCacheMetrics struct {
+	hits   []common.LocationID
+	misses []common.LocationID
+}
+
+var _ ProgramCacheMetrics = &testProgramCacheMetrics{}
+
+func (m *testProgramCacheMetrics) ProgramCacheHit(location common.Location) {
+	m.hits = append(m.hits, location.ID())
+}
+
+func (m *testProgramCacheMetrics) ProgramCacheMiss(location common.Location) {
+	m.misses = append(m.misses, location.ID())
+}
+
+func TestProgramCache(t *testing.T) {
+
+	t.Parallel()
+
+	locationA := common.StringLocation("A")
+	locationB := common.StringLocation("B")
+	locationC := common.StringLocation("C")
+
+	newCodes := func() map[common.LocationID][]byte {
+		return map[common.LocationID][]byte{
+			locationA.ID(): []byte("a"),
+			locationB.ID(): []byte("b"),
+			locationC.ID(): []byte("c"),
+		}
+	}
+
+	newGetCode := func(codes map[common.LocationID][]byte) ProgramCodeFunc {
+		return func(location common.Location) ([]byte, error) {
+			return codes[location.ID()], nil
+		}
+	}
+
+	t.Run("code hash", func(t *testing.T) {
+
+		t.Parallel()
+
+		codes := newCodes()
+		getCode := newGetCode(codes)
+
+		cache := NewProgramCache(10, nil)
+
+		program := &interpreter.Program{}
+
+		transaction := cache.NewTransaction(getCode)
+		err := transaction.SetProgram(locationA, program)
+		require.NoError(t, err)
+		transaction.Commit()
+
+		transaction = cache.NewTransaction(getCode)
+		cached, err := transaction.GetProgram(locationA)
+		require.NoError(t, err)
+		assert.Same(t, program, cached)
+
+		// A program is not returned for different code
+
+		codes[locationA.ID()] = []byte("a2")
+
+		transaction = cache.NewTransaction(getCode)
+		cached, err = transaction.GetProgram(locationA)
+		require.NoError(t, err)
+		assert.Nil(t, cached)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+
+		t.Parallel()
+
+		getCode := newGetCode(newCodes())
+
+		cache := NewProgramCache(10, nil)
+
+		program := &interpreter.Program{}
+
+		transaction1 := cache.NewTransaction(getCode)
+		transaction2 := cache.NewTransaction(getCode)
+
+		err := transaction1.SetProgram(locationA, program)
+		require.NoError(t, err)
+
+		// The program is visible in the transaction which set it,
+		// but not in other transactions before the commit
+
+		cached, err := transaction1.GetProgram(locationA)
+		require.NoError(t, err)
+		assert.Same(t, program, cached)
+
+		cached, err = transaction2.GetProgram(locationA)
+		require.NoError(t, err)
+		assert.Nil(t, cached)
+
+		assert.Equal(t, 0, cache.Len())
+
+		transaction1.Commit()
+
+		assert.Equal(t, 1, cache.Len())
+
+		cached, err = cache.NewTransaction(getCode).GetProgram(locationA)
+		require.NoError(t, err)
+		assert.Same(t, program, cached)
+	})
+
+	t.Run("eviction", func(t *testing.T) {
+
+		t.Parallel()
+
+		getCode := newGetCode(newCodes())
+
+		cache := NewProgramCache(2, nil)
+
+		programA := &interpreter.Program{}
+		programB := &interpreter.Program{}
+		programC := &interpreter.Program{}
+
+		transaction := cache.NewTransaction(getCode)
+		require.NoError(t, transaction.SetProgram(locationA, programA))
+		require.NoError(t, transaction.SetProgram(locationB, programB))
+		transaction.Commit()
+
+		// Use A, so B is the least recently used program
+
+		cached, err := cache.NewTransaction(getCode).GetProgram(locationA)
+		require.NoError(t, err)
+		assert.Same(t, programA, cached)
+
+		transaction = cache.NewTransaction(getCode)
+		require.NoError(t, transaction.SetProgram(locationC, programC))
+		transaction.Commit()
+
+		assert.Equal(t, 2, cache.Len())
+
+		transaction = cache.NewTransaction(getCode)
+
+		cached, err = transaction.GetProgram(locationA)
+		require.NoError(t, err)
+		assert.Same(t, programA, cached)
+
+		cached, err = transaction.GetProgram(locationB)
+		require.NoError(t, err)
+		assert.Nil(t, cached)
+
+		cached, err = transaction.GetProgram(locationC)
+		require.NoError(t, err)
+		assert.Same(t, programC, cached)
+	})
+
+	t.Run("invalidation", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := common.Address{0x1}
+		location := common.AddressLocation{
+			Address: address,
+			Name:    "Test",
+		}
+
+		getCode := newGetCode(map[common.LocationID][]byte{
+			location.ID(): []byte("test"),
+		})
+
+		cache := NewProgramCache(10, nil)
+
+		program := &interpreter.Program{}
+
+		transaction := cache.NewTransaction(getCode)
+		require.NoError(t, transaction.SetProgram(location, program))
+		transaction.Commit()
+
+		for _, invalidate := range map[string]func(transaction *ProgramCacheTransaction) error{
+			"update": func(transaction *ProgramCacheTransaction) error {
+				return transaction.UpdateAccountContractCode(address, "Test", []byte("test2"))
+			},
+			"remove": func(transaction *ProgramCacheTransaction) error {
+				return transaction.RemoveAccountContractCode(address, "Test")
+			},
+		} {
+			transaction = cache.NewTransaction(getCode)
+			require.NoError(t, transaction.SetProgram(location, program))
+			transaction.Commit()
+
+			transaction = cache.NewTransaction(getCode)
+
+			cached, err := transaction.GetProgram(location)
+			require.NoError(t, err)
+			assert.Same(t, program, cached)
+
+			err = invalidate(transaction)
+			require.NoError(t, err)
+
+			// The program is not returned in the transaction anymore,
+			// but only removed from the cache when the transaction is committed
+
+			cached, err = transaction.GetProgram(location)
+			require.NoError(t, err)
+			assert.Nil(t, cached)
+
+			assert.Equal(t, 1, cache.Len())
+
+			transaction.Commit()
+
+			assert.Equal(t, 0, cache.Len())
+		}
+	})
+
+	t.Run("invalidation of importers", func(t *testing.T) {
+
+		t.Parallel()
+
+		address := common.Address{0x1}
+
+		locationA := common.AddressLocation{Address: address, Name: "A"}
+		locationB := common.AddressLocation{Address: address, Name: "B"}
+		locationC := common.AddressLocation{Address: address, Name: "C"}
+		locationD := common.AddressLocation{Address: address, Name: "D"}
+
+		getCode := newGetCode(map[common.LocationID][]byte{
+			locationA.ID(): []byte("a"),
+			locationB.ID(): []byte("b"),
+			locationC.ID(): []byte("c"),
+			locationD.ID(): []byte("d"),
+		})
+
+		newProgram := func(imports ...common.Location) *interpreter.Program {
+			elaboration := sema.NewElaboration()
+			for _, location := range imports {
+				declaration := &ast.ImportDeclaration{Location: location}
+				elaboration.ImportDeclarationsResolvedLocations[declaration] = []sema.ResolvedLocation{
+					{Location: location},
+				}
+			}
+			return &interpreter.Program{
+				Elaboration: elaboration,
+			}
+		}
+
+		// B imports A, C imports B, D imports nothing
+
+		programA := newProgram()
+		programB := newProgram(locationA)
+		programC := newProgram(locationB)
+		programD := newProgram()
+
+		cache := NewProgramCache(10, nil)
+
+		transaction := cache.NewTransaction(getCode)
+		require.NoError(t, transaction.SetProgram(locationA, programA))
+		require.NoError(t, transaction.SetProgram(locationB, programB))
+		require.NoError(t, transaction.SetProgram(locationC, programC))
+		require.NoError(t, transaction.SetProgram(locationD, programD))
+		transaction.Commit()
+
+		assert.Equal(t, 4, cache.Len())
+
+		// Only get B, so the transitive import of C is only known to the cache
+
+		transaction = cache.NewTransaction(getCode)
+
+		cached, err := transaction.GetProgram(locationB)
+		require.NoError(t, err)
+		assert.Same(t, programB, cached)
+
+		err = transaction.UpdateAccountContractCode(address, "A", []byte("a2"))
+		require.NoError(t, err)
+
+		// The programs which import A, directly or transitively,
+		// are not returned in the transaction anymore
+
+		for _, location := range []common.Location{locationA, locationB, locationC} {
+			cached, err = transaction.GetProgram(location)
+			require.NoError(t, err)
+			assert.Nil(t, cached)
+		}
+
+		cached, err = transaction.GetProgram(locationD)
+		require.NoError(t, err)
+		assert.Same(t, programD, cached)
+
+		transaction.Commit()
+
+		assert.Equal(t, 1, cache.Len())
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+
+		t.Parallel()
+
+		getCode := newGetCode(newCodes())
+
+		metrics := &testProgramCacheMetrics{}
+
+		cache := NewProgramCache(10, metrics)
+
+		transaction := cache.NewTransaction(getCode)
+
+		cached, err := transaction.GetProgram(locationA)
+		require.NoError(t, err)
+		require.Nil(t, cached)
+
+		require.NoError(t, transaction.SetProgram(locationA, &interpreter.Program{}))
+		transaction.Commit()
+
+		transaction = cache.NewTransaction(getCode)
+
+		_, err = transaction.GetProgram(locationA)
+		require.NoError(t, err)
+
+		_, err = transaction.GetProgram(locationB)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]common.LocationID{locationA.ID()},
+			metrics.hits,
+		)
+		assert.Equal(t,
+			[]common.LocationID{locationA.ID(), locationB.ID()},
+			metrics.misses,
+		)
+	})
+}
+
+func TestRuntimeProgramCache(t *testing.T) {
+
+	t.Parallel()
+
+	const helloWorldContract = `
+      pub contract HelloWorld {
+
+          pub fun hello(): String {
+              return "%d"
+          }
+      }
+    `
+
+	const callHelloScript = `
+        import HelloWorld from 0x1
+
+        pub fun main(): String {
+            return HelloWorld.hello()
+        }
+    `
+
+	runtime := newTestInterpreterRuntime()
+
+	address := common.Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}
+
+	accountCodes := map[common.LocationID][]byte{}
+
+	getCode := func(location common.Location) ([]byte, error) {
+		return accountCodes[location.ID()], nil
+	}
+
+	metrics := &testProgramCacheMetrics{}
+
+	cache := NewProgramCache(10, metrics)
+
+	storage := newTestLedger(nil, nil)
+
+	newRuntimeInterface := func(transaction *ProgramCacheTransaction) *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage: storage,
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			resolveLocation: singleIdentifierLocationResolver(t),
+			getCode:         getCode,
+			getAccountContractCode: func(address Address, name string) ([]byte, error) {
+				return getCode(common.AddressLocation{
+					Address: address,
+					Name:    name,
+				})
+			},
+			getProgram: transaction.GetProgram,
+			setProgram: transaction.SetProgram,
+			updateAccountContractCode: func(address Address, name string, code []byte) error {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				accountCodes[location.ID()] = code
+				return transaction.UpdateAccountContractCode(address, name, code)
+			},
+			emitEvent: func(event cadence.Event) error {
+				return nil
+			},
+		}
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	executeTransaction := func(script []byte) {
+		transaction := cache.NewTransaction(getCode)
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: newRuntimeInterface(transaction),
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		transaction.Commit()
+	}
+
+	executeScript := func() cadence.Value {
+		transaction := cache.NewTransaction(getCode)
+
+		result, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(callHelloScript),
+			},
+			Context{
+				Interface: newRuntimeInterface(transaction),
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		transaction.Commit()
+
+		return result
+	}
+
+	executeTransaction(
+		utils.DeploymentTransaction(
+			"HelloWorld",
+			[]byte(fmt.Sprintf(helloWorldContract, 1)),
+		),
+	)
+
+	contractLocationID := common.AddressLocation{
+		Address: address,
+		Name:    "HelloWorld",
+	}.ID()
+
+	// The first script parses and checks the contract,
+	// the second script uses the cached program
+
+	assert.Equal(t, cadence.String("1"), executeScript())
+	assert.Equal(t, cadence.String("1"), executeScript())
+
+	assert.Equal(t, []common.LocationID{contractLocationID}, metrics.misses)
+
+	// Update the contract. The cached program is invalidated
+
+	executeTransaction(
+		utils.UpdateTransaction(
+			"HelloWorld",
+			[]byte(fmt.Sprintf(helloWorldContract, 2)),
+		),
+	)
+
+	metrics.misses = nil
+
+	assert.Equal(t, cadence.String("2"), executeScript())
+	assert.Equal(t, cadence.String("2"), executeScript())
+
+	assert.Equal(t, []common.LocationID{contractLocationID}, metrics.misses)
+}
+
+func TestRuntimeProgramCacheImporterInvalidation(t *testing.T) {
+
+	t.Parallel()
+
+	const contractA = `
+      pub contract A {
+
+          pub fun hello(): %s {
+              return %s
+          }
+      }
+    `
+
+	const contractB = `
+      import A from 0x1
+
+      pub contract B {
+
+          pub fun hello(): String {
+              return A.hello()
+          }
+      }
+    `
+
+	const callHelloScript = `
+        import B from 0x1
+
+        pub fun main(): String {
+            return B.hello()
+        }
+    `
+
+	runtime := newTestInterpreterRuntime()
+
+	address := common.Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}
+
+	accountCodes := map[common.LocationID][]byte{}
+
+	getCode := func(location common.Location) ([]byte, error) {
+		return accountCodes[location.ID()], nil
+	}
+
+	metrics := &testProgramCacheMetrics{}
+
+	cache := NewProgramCache(10, metrics)
+
+	storage := newTestLedger(nil, nil)
+
+	newRuntimeInterface := func(transaction *ProgramCacheTransaction) *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage: storage,
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			resolveLocation: singleIdentifierLocationResolver(t),
+			getCode:         getCode,
+			getAccountContractCode: func(address Address, name string) ([]byte, error) {
+				return getCode(common.AddressLocation{
+					Address: address,
+					Name:    name,
+				})
+			},
+			getProgram: transaction.GetProgram,
+			setProgram: transaction.SetProgram,
+			updateAccountContractCode: func(address Address, name string, code []byte) error {
+				location := common.AddressLocation{
+					Address: address,
+					Name:    name,
+				}
+				accountCodes[location.ID()] = code
+				return transaction.UpdateAccountContractCode(address, name, code)
+			},
+			emitEvent: func(event cadence.Event) error {
+				return nil
+			},
+		}
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	executeTransaction := func(script []byte) {
+		transaction := cache.NewTransaction(getCode)
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: newRuntimeInterface(transaction),
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		transaction.Commit()
+	}
+
+	executeScript := func() (cadence.Value, error) {
+		transaction := cache.NewTransaction(getCode)
+
+		result, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(callHelloScript),
+			},
+			Context{
+				Interface: newRuntimeInterface(transaction),
+				Location:  common.ScriptLocation{},
+			},
+		)
+
+		transaction.Commit()
+
+		return result, err
+	}
+
+	executeTransaction(
+		utils.DeploymentTransaction(
+			"A",
+			[]byte(fmt.Sprintf(contractA, "String", `"1"`)),
+		),
+	)
+
+	executeTransaction(
+		utils.DeploymentTransaction(
+			"B",
+			[]byte(contractB),
+		),
+	)
+
+	contractBLocationID := common.AddressLocation{
+		Address: address,
+		Name:    "B",
+	}.ID()
+
+	result, err := executeScript()
+	require.NoError(t, err)
+	assert.Equal(t, cadence.String("1"), result)
+
+	// Update A with a change which is incompatible with B.
+	// The cached program of B was checked against the old A,
+	// so it must not be used anymore, but B must be checked again
+
+	executeTransaction(
+		utils.UpdateTransaction(
+			"A",
+			[]byte(fmt.Sprintf(contractA, "Int", "2")),
+		),
+	)
+
+	metrics.misses = nil
+
+	_, err = executeScript()
+	require.Error(t, err)
+
+	// B cannot be imported, so it is also not declared in the script
+	errs := checker.ExpectCheckerErrors(t, err, 2)
+
+	var importedProgramError *sema.ImportedProgramError
+	require.ErrorAs(t, errs[0], &importedProgramError)
+	assert.Equal(t, contractBLocationID, importedProgramError.Location.ID())
+
+	importedErrs := checker.ExpectCheckerErrors(t, importedProgramError.Err, 1)
+	require.IsType(t, &sema.TypeMismatchError{}, importedErrs[0])
+
+	assert.Contains(t, metrics.misses, contractBLocationID)
+}
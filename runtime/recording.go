@@ -0,0 +1,1010 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// InterfaceCall is a call of a function of Interface,
+// with its JSON-encoded arguments and results, and the message of the returned error, if any
+//
+type InterfaceCall struct {
+	Function  string
+	Arguments json.RawMessage `json:",omitempty"`
+	Results   json.RawMessage `json:",omitempty"`
+	Error     string          `json:",omitempty"`
+}
+
+// InterfaceRecording is the sequence of calls of the functions of Interface
+// performed during an execution, as recorded by RecordingInterface,
+// and replayed by ReplayingInterface
+//
+type InterfaceRecording struct {
+	Calls []InterfaceCall
+}
+
+// ReadInterfaceRecording reads a recording from the given reader
+//
+func ReadInterfaceRecording(reader io.Reader) (*InterfaceRecording, error) {
+	var recording InterfaceRecording
+	err := json.NewDecoder(reader).Decode(&recording)
+	if err != nil {
+		return nil, err
+	}
+	return &recording, nil
+}
+
+// ReadInterfaceRecordingFile reads a recording from the file at the given path
+//
+func ReadInterfaceRecordingFile(path string) (*InterfaceRecording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadInterfaceRecording(file)
+}
+
+// Write writes the recording to the given writer
+//
+func (r *InterfaceRecording) Write(writer io.Writer) error {
+	return json.NewEncoder(writer).Encode(r)
+}
+
+// WriteFile writes the recording to the file at the given path
+//
+func (r *InterfaceRecording) WriteFile(path string) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	return r.Write(file)
+}
+
+// Arguments returns the encoded arguments which were decoded during the recorded execution,
+// i.e. the arguments of the executed script or transaction
+//
+func (r *InterfaceRecording) Arguments() ([][]byte, error) {
+	var arguments [][]byte
+
+	for _, call := range r.Calls {
+		if call.Function != "DecodeArgument" {
+			continue
+		}
+
+		var callArguments []json.RawMessage
+		err := json.Unmarshal(call.Arguments, &callArguments)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(callArguments) < 1 {
+			return nil, fmt.Errorf("cannot decode recorded arguments of `%s`", call.Function)
+		}
+
+		var argument []byte
+		err = json.Unmarshal(callArguments[0], &argument)
+		if err != nil {
+			return nil, err
+		}
+
+		arguments = append(arguments, argument)
+	}
+
+	return arguments, nil
+}
+
+// recordedIdentifier is the encoding of an identifier.
+// ast.Identifier is encoded with its range, which cannot be decoded
+//
+type recordedIdentifier struct {
+	Identifier string
+	Pos        ast.Position
+}
+
+func newRecordedIdentifiers(identifiers []Identifier) []recordedIdentifier {
+	result := make([]recordedIdentifier, len(identifiers))
+	for i, identifier := range identifiers {
+		result[i] = recordedIdentifier(identifier)
+	}
+	return result
+}
+
+func (r recordedIdentifier) identifier() Identifier {
+	return Identifier(r)
+}
+
+type recordedResolvedLocation struct {
+	Location    json.RawMessage
+	Identifiers []recordedIdentifier
+}
+
+// decodeRecordedLocation decodes a location from the JSON encoding
+// produced by the MarshalJSON functions of the location types
+//
+func decodeRecordedLocation(data json.RawMessage) (common.Location, error) {
+	var location struct {
+		Type        string
+		Address     string
+		Name        string
+		String      string
+		Identifier  string
+		Transaction string
+		Script      string
+	}
+
+	err := json.Unmarshal(data, &location)
+	if err != nil {
+		return nil, err
+	}
+
+	switch location.Type {
+	case "AddressLocation":
+		address, err := common.HexToAddress(location.Address)
+		if err != nil {
+			return nil, err
+		}
+		return common.AddressLocation{
+			Address: address,
+			Name:    location.Name,
+		}, nil
+
+	case "StringLocation":
+		return common.StringLocation(location.String), nil
+
+	case "IdentifierLocation":
+		return common.IdentifierLocation(location.Identifier), nil
+
+	case "TransactionLocation":
+		transaction, err := hex.DecodeString(location.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		return common.TransactionLocation(transaction), nil
+
+	case "ScriptLocation":
+		script, err := hex.DecodeString(location.Script)
+		if err != nil {
+			return nil, err
+		}
+		return common.ScriptLocation(script), nil
+
+	case "REPLLocation":
+		return common.REPLLocation{}, nil
+
+	default:
+		return nil, fmt.Errorf("cannot decode recorded location of type %q", location.Type)
+	}
+}
+
+func encodeInterfaceCallValues(values []interface{}) (json.RawMessage, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(values)
+}
+
+func encodeInterfaceCallError(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// RecordingInterface is an Interface which wraps another interface,
+// and records all calls of the wrapped interface and their results,
+// so the execution can be replayed using a ReplayingInterface.
+//
+// Programs are not recorded: GetProgram only returns the programs set using SetProgram,
+// so all imported programs are parsed and checked, and their code is recorded.
+//
+// Calls of RecordTrace, ImplementationDebugLog, and ResourceOwnerChanged are not recorded.
+//
+type RecordingInterface struct {
+	runtimeInterface Interface
+	recording        InterfaceRecording
+	programs         map[common.LocationID]*interpreter.Program
+	err              error
+}
+
+var _ Interface = &RecordingInterface{}
+
+// NewRecordingInterface returns a new interface which records the calls of the given interface
+//
+func NewRecordingInterface(runtimeInterface Interface) *RecordingInterface {
+	return &RecordingInterface{
+		runtimeInterface: runtimeInterface,
+		programs:         map[common.LocationID]*interpreter.Program{},
+	}
+}
+
+// Recording returns the recorded calls.
+//
+// An error is returned if the arguments or results of a call could not be encoded
+//
+func (i *RecordingInterface) Recording() (*InterfaceRecording, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+	return &i.recording, nil
+}
+
+func (i *RecordingInterface) record(function string, arguments []interface{}, results []interface{}, err error) {
+	encodedArguments, encodingErr := encodeInterfaceCallValues(arguments)
+	if encodingErr == nil {
+		var encodedResults json.RawMessage
+		encodedResults, encodingErr = encodeInterfaceCallValues(results)
+		if encodingErr == nil {
+			i.recording.Calls = append(
+				i.recording.Calls,
+				InterfaceCall{
+					Function:  function,
+					Arguments: encodedArguments,
+					Results:   encodedResults,
+					Error:     encodeInterfaceCallError(err),
+				},
+			)
+			return
+		}
+	}
+
+	if i.err == nil {
+		i.err = fmt.Errorf("cannot record call of `%s`: %w", function, encodingErr)
+	}
+}
+
+func (i *RecordingInterface) ResolveLocation(identifiers []Identifier, location Location) ([]ResolvedLocation, error) {
+	resolvedLocations, err := i.runtimeInterface.ResolveLocation(identifiers, location)
+
+	recordedLocations := make([]recordedResolvedLocation, len(resolvedLocations))
+	for index, resolvedLocation := range resolvedLocations {
+		encodedLocation, encodingErr := json.Marshal(resolvedLocation.Location)
+		if encodingErr != nil && i.err == nil {
+			i.err = encodingErr
+		}
+
+		recordedLocations[index] = recordedResolvedLocation{
+			Location:    encodedLocation,
+			Identifiers: newRecordedIdentifiers(resolvedLocation.Identifiers),
+		}
+	}
+
+	i.record(
+		"ResolveLocation",
+		[]interface{}{newRecordedIdentifiers(identifiers), location},
+		[]interface{}{recordedLocations},
+		err,
+	)
+	return resolvedLocations, err
+}
+
+func (i *RecordingInterface) GetCode(location Location) ([]byte, error) {
+	code, err := i.runtimeInterface.GetCode(location)
+	i.record("GetCode", []interface{}{location}, []interface{}{code}, err)
+	return code, err
+}
+
+func (i *RecordingInterface) GetProgram(location Location) (*interpreter.Program, error) {
+	return i.programs[location.ID()], nil
+}
+
+func (i *RecordingInterface) SetProgram(location Location, program *interpreter.Program) error {
+	i.programs[location.ID()] = program
+	return nil
+}
+
+func (i *RecordingInterface) GetValue(owner, key []byte) ([]byte, error) {
+	value, err := i.runtimeInterface.GetValue(owner, key)
+	i.record("GetValue", []interface{}{owner, key}, []interface{}{value}, err)
+	return value, err
+}
+
+func (i *RecordingInterface) SetValue(owner, key, value []byte) error {
+	err := i.runtimeInterface.SetValue(owner, key, value)
+	i.record("SetValue", []interface{}{owner, key, value}, nil, err)
+	return err
+}
+
+func (i *RecordingInterface) ValueExists(owner, key []byte) (bool, error) {
+	exists, err := i.runtimeInterface.ValueExists(owner, key)
+	i.record("ValueExists", []interface{}{owner, key}, []interface{}{exists}, err)
+	return exists, err
+}
+
+func (i *RecordingInterface) AllocateStorageIndex(owner []byte) (atree.StorageIndex, error) {
+	index, err := i.runtimeInterface.AllocateStorageIndex(owner)
+	i.record("AllocateStorageIndex", []interface{}{owner}, []interface{}{index}, err)
+	return index, err
+}
+
+func (i *RecordingInterface) CreateAccount(payer Address) (Address, error) {
+	address, err := i.runtimeInterface.CreateAccount(payer)
+	i.record("CreateAccount", []interface{}{payer}, []interface{}{address}, err)
+	return address, err
+}
+
+func (i *RecordingInterface) AddEncodedAccountKey(address Address, publicKey []byte) error {
+	err := i.runtimeInterface.AddEncodedAccountKey(address, publicKey)
+	i.record("AddEncodedAccountKey", []interface{}{address, publicKey}, nil, err)
+	return err
+}
+
+func (i *RecordingInterface) RevokeEncodedAccountKey(address Address, index int) ([]byte, error) {
+	publicKey, err := i.runtimeInterface.RevokeEncodedAccountKey(address, index)
+	i.record("RevokeEncodedAccountKey", []interface{}{address, index}, []interface{}{publicKey}, err)
+	return publicKey, err
+}
+
+func (i *RecordingInterface) AddAccountKey(
+	address Address,
+	publicKey *PublicKey,
+	hashAlgo HashAlgorithm,
+	weight int,
+) (*AccountKey, error) {
+	accountKey, err := i.runtimeInterface.AddAccountKey(address, publicKey, hashAlgo, weight)
+	i.record(
+		"AddAccountKey",
+		[]interface{}{address, publicKey, hashAlgo, weight},
+		[]interface{}{accountKey},
+		err,
+	)
+	return accountKey, err
+}
+
+func (i *RecordingInterface) GetAccountKey(address Address, index int) (*AccountKey, error) {
+	accountKey, err := i.runtimeInterface.GetAccountKey(address, index)
+	i.record("GetAccountKey", []interface{}{address, index}, []interface{}{accountKey}, err)
+	return accountKey, err
+}
+
+func (i *RecordingInterface) RevokeAccountKey(address Address, index int) (*AccountKey, error) {
+	accountKey, err := i.runtimeInterface.RevokeAccountKey(address, index)
+	i.record("RevokeAccountKey", []interface{}{address, index}, []interface{}{accountKey}, err)
+	return accountKey, err
+}
+
+func (i *RecordingInterface) UpdateAccountContractCode(address Address, name string, code []byte) error {
+	err := i.runtimeInterface.UpdateAccountContractCode(address, name, code)
+	i.record("UpdateAccountContractCode", []interface{}{address, name, code}, nil, err)
+	return err
+}
+
+func (i *RecordingInterface) GetAccountContractCode(address Address, name string) ([]byte, error) {
+	code, err := i.runtimeInterface.GetAccountContractCode(address, name)
+	i.record("GetAccountContractCode", []interface{}{address, name}, []interface{}{code}, err)
+	return code, err
+}
+
+func (i *RecordingInterface) RemoveAccountContractCode(address Address, name string) error {
+	err := i.runtimeInterface.RemoveAccountContractCode(address, name)
+	i.record("RemoveAccountContractCode", []interface{}{address, name}, nil, err)
+	return err
+}
+
+func (i *RecordingInterface) GetSigningAccounts() ([]Address, error) {
+	addresses, err := i.runtimeInterface.GetSigningAccounts()
+	i.record("GetSigningAccounts", nil, []interface{}{addresses}, err)
+	return addresses, err
+}
+
+func (i *RecordingInterface) ProgramLog(message string) error {
+	err := i.runtimeInterface.ProgramLog(message)
+	i.record("ProgramLog", []interface{}{message}, nil, err)
+	return err
+}
+
+func (i *RecordingInterface) EmitEvent(event cadence.Event) error {
+	err := i.runtimeInterface.EmitEvent(event)
+
+	encodedEvent, encodingErr := jsoncdc.Encode(event)
+	if encodingErr != nil && i.err == nil {
+		i.err = encodingErr
+	}
+
+	i.record("EmitEvent", []interface{}{json.RawMessage(encodedEvent)}, nil, err)
+	return err
+}
+
+func (i *RecordingInterface) GenerateUUID() (uint64, error) {
+	uuid, err := i.runtimeInterface.GenerateUUID()
+	i.record("GenerateUUID", nil, []interface{}{uuid}, err)
+	return uuid, err
+}
+
+func (i *RecordingInterface) MeterComputation(operationType common.ComputationKind, intensity uint) error {
+	err := i.runtimeInterface.MeterComputation(operationType, intensity)
+	i.record("MeterComputation", []interface{}{operationType, intensity}, nil, err)
+	return err
+}
+
+func (i *RecordingInterface) MeterMemory(kind common.MemoryKind, amount uint) error {
+	err := i.runtimeInterface.MeterMemory(kind, amount)
+	i.record("MeterMemory", []interface{}{kind, amount}, nil, err)
+	return err
+}
+
+func (i *RecordingInterface) DecodeArgument(argument []byte, argumentType cadence.Type) (cadence.Value, error) {
+	value, err := i.runtimeInterface.DecodeArgument(argument, argumentType)
+
+	var results []interface{}
+	if value != nil {
+		encodedValue, encodingErr := jsoncdc.Encode(value)
+		if encodingErr != nil && i.err == nil {
+			i.err = encodingErr
+		}
+		results = []interface{}{json.RawMessage(encodedValue)}
+	}
+
+	i.record(
+		"DecodeArgument",
+		[]interface{}{argument, typeIDOrEmpty(argumentType)},
+		results,
+		err,
+	)
+	return value, err
+}
+
+func typeIDOrEmpty(ty cadence.Type) string {
+	if ty == nil {
+		return ""
+	}
+	return ty.ID()
+}
+
+func (i *RecordingInterface) GetCurrentBlockHeight() (uint64, error) {
+	height, err := i.runtimeInterface.GetCurrentBlockHeight()
+	i.record("GetCurrentBlockHeight", nil, []interface{}{height}, err)
+	return height, err
+}
+
+func (i *RecordingInterface) GetBlockAtHeight(height uint64) (Block, bool, error) {
+	block, exists, err := i.runtimeInterface.GetBlockAtHeight(height)
+	i.record("GetBlockAtHeight", []interface{}{height}, []interface{}{block, exists}, err)
+	return block, exists, err
+}
+
+func (i *RecordingInterface) UnsafeRandom() (uint64, error) {
+	random, err := i.runtimeInterface.UnsafeRandom()
+	i.record("UnsafeRandom", nil, []interface{}{random}, err)
+	return random, err
+}
+
+func (i *RecordingInterface) VerifySignature(
+	signature []byte,
+	tag string,
+	signedData []byte,
+	publicKey []byte,
+	signatureAlgorithm SignatureAlgorithm,
+	hashAlgorithm HashAlgorithm,
+) (bool, error) {
+	valid, err := i.runtimeInterface.VerifySignature(
+		signature,
+		tag,
+		signedData,
+		publicKey,
+		signatureAlgorithm,
+		hashAlgorithm,
+	)
+	i.record(
+		"VerifySignature",
+		[]interface{}{signature, tag, signedData, publicKey, signatureAlgorithm, hashAlgorithm},
+		[]interface{}{valid},
+		err,
+	)
+	return valid, err
+}
+
+func (i *RecordingInterface) Hash(data []byte, tag string, hashAlgorithm HashAlgorithm) ([]byte, error) {
+	digest, err := i.runtimeInterface.Hash(data, tag, hashAlgorithm)
+	i.record("Hash", []interface{}{data, tag, hashAlgorithm}, []interface{}{digest}, err)
+	return digest, err
+}
+
+func (i *RecordingInterface) GetAccountBalance(address common.Address) (uint64, error) {
+	balance, err := i.runtimeInterface.GetAccountBalance(address)
+	i.record("GetAccountBalance", []interface{}{address}, []interface{}{balance}, err)
+	return balance, err
+}
+
+func (i *RecordingInterface) GetAccountAvailableBalance(address common.Address) (uint64, error) {
+	balance, err := i.runtimeInterface.GetAccountAvailableBalance(address)
+	i.record("GetAccountAvailableBalance", []interface{}{address}, []interface{}{balance}, err)
+	return balance, err
+}
+
+func (i *RecordingInterface) GetStorageUsed(address Address) (uint64, error) {
+	used, err := i.runtimeInterface.GetStorageUsed(address)
+	i.record("GetStorageUsed", []interface{}{address}, []interface{}{used}, err)
+	return used, err
+}
+
+func (i *RecordingInterface) GetStorageCapacity(address Address) (uint64, error) {
+	capacity, err := i.runtimeInterface.GetStorageCapacity(address)
+	i.record("GetStorageCapacity", []interface{}{address}, []interface{}{capacity}, err)
+	return capacity, err
+}
+
+func (i *RecordingInterface) ImplementationDebugLog(message string) error {
+	return i.runtimeInterface.ImplementationDebugLog(message)
+}
+
+func (i *RecordingInterface) ValidatePublicKey(key *PublicKey) error {
+	err := i.runtimeInterface.ValidatePublicKey(key)
+	i.record("ValidatePublicKey", []interface{}{key}, nil, err)
+	return err
+}
+
+func (i *RecordingInterface) GetAccountContractNames(address Address) ([]string, error) {
+	names, err := i.runtimeInterface.GetAccountContractNames(address)
+	i.record("GetAccountContractNames", []interface{}{address}, []interface{}{names}, err)
+	return names, err
+}
+
+func (i *RecordingInterface) RecordTrace(
+	operation string,
+	location common.Location,
+	duration time.Duration,
+	logs []opentracing.LogRecord,
+) {
+	i.runtimeInterface.RecordTrace(operation, location, duration, logs)
+}
+
+func (i *RecordingInterface) BLSVerifyPOP(publicKey *PublicKey, signature []byte) (bool, error) {
+	valid, err := i.runtimeInterface.BLSVerifyPOP(publicKey, signature)
+	i.record("BLSVerifyPOP", []interface{}{publicKey, signature}, []interface{}{valid}, err)
+	return valid, err
+}
+
+func (i *RecordingInterface) BLSAggregateSignatures(signatures [][]byte) ([]byte, error) {
+	signature, err := i.runtimeInterface.BLSAggregateSignatures(signatures)
+	i.record("BLSAggregateSignatures", []interface{}{signatures}, []interface{}{signature}, err)
+	return signature, err
+}
+
+func (i *RecordingInterface) BLSAggregatePublicKeys(publicKeys []*PublicKey) (*PublicKey, error) {
+	publicKey, err := i.runtimeInterface.BLSAggregatePublicKeys(publicKeys)
+	i.record("BLSAggregatePublicKeys", []interface{}{publicKeys}, []interface{}{publicKey}, err)
+	return publicKey, err
+}
+
+func (i *RecordingInterface) ResourceOwnerChanged(
+	interpreter *interpreter.Interpreter,
+	resource *interpreter.CompositeValue,
+	oldOwner common.Address,
+	newOwner common.Address,
+) {
+	i.runtimeInterface.ResourceOwnerChanged(interpreter, resource, oldOwner, newOwner)
+}
+
+// ReplayingInterface is an Interface which replays the calls recorded by a RecordingInterface.
+//
+// Each call must match the next recorded call, i.e. it must be a call of the same function
+// with the same arguments, otherwise an InterfaceReplayError is returned.
+// The results and error of the recorded call are returned.
+//
+// Like for RecordingInterface, GetProgram only returns the programs set using SetProgram,
+// and calls of RecordTrace, ImplementationDebugLog, and ResourceOwnerChanged are ignored.
+//
+type ReplayingInterface struct {
+	recording *InterfaceRecording
+	next      int
+	programs  map[common.LocationID]*interpreter.Program
+}
+
+var _ Interface = &ReplayingInterface{}
+
+// NewReplayingInterface returns a new interface which replays the given recording
+//
+func NewReplayingInterface(recording *InterfaceRecording) *ReplayingInterface {
+	return &ReplayingInterface{
+		recording: recording,
+		programs:  map[common.LocationID]*interpreter.Program{},
+	}
+}
+
+// Remaining returns the number of recorded calls which were not replayed yet
+//
+func (i *ReplayingInterface) Remaining() int {
+	return len(i.recording.Calls) - i.next
+}
+
+// replay checks that the call of the given function with the given arguments
+// matches the next recorded call, and decodes the recorded results into the given result pointers.
+//
+// The recorded error is returned, if any
+//
+func (i *ReplayingInterface) replay(function string, arguments []interface{}, results ...interface{}) error {
+	index := i.next
+
+	if index >= len(i.recording.Calls) {
+		return InterfaceReplayError{
+			Index:    index,
+			Function: function,
+		}
+	}
+
+	call := i.recording.Calls[index]
+
+	if call.Function != function {
+		return InterfaceReplayError{
+			Index:    index,
+			Function: function,
+			Recorded: &call,
+		}
+	}
+
+	encodedArguments, err := encodeInterfaceCallValues(arguments)
+	if err != nil {
+		return err
+	}
+
+	if !equalJSON(encodedArguments, call.Arguments) {
+		return InterfaceReplayError{
+			Index:            index,
+			Function:         function,
+			Recorded:         &call,
+			ArgumentMismatch: true,
+		}
+	}
+
+	i.next++
+
+	if len(results) > 0 && len(call.Results) > 0 {
+		var encodedResults []json.RawMessage
+		err = json.Unmarshal(call.Results, &encodedResults)
+		if err != nil {
+			return err
+		}
+
+		if len(encodedResults) != len(results) {
+			return fmt.Errorf(
+				"cannot replay call %d of `%s`: expected %d results, got %d",
+				index,
+				function,
+				len(results),
+				len(encodedResults),
+			)
+		}
+
+		for resultIndex, encodedResult := range encodedResults {
+			err = json.Unmarshal(encodedResult, results[resultIndex])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if call.Error != "" {
+		return errors.New(call.Error)
+	}
+
+	return nil
+}
+
+func equalJSON(a, b json.RawMessage) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+
+	var compactA, compactB bytes.Buffer
+	if json.Compact(&compactA, a) != nil ||
+		json.Compact(&compactB, b) != nil {
+
+		return false
+	}
+
+	return bytes.Equal(compactA.Bytes(), compactB.Bytes())
+}
+
+func (i *ReplayingInterface) ResolveLocation(identifiers []Identifier, location Location) ([]ResolvedLocation, error) {
+	var recordedLocations []recordedResolvedLocation
+	err := i.replay(
+		"ResolveLocation",
+		[]interface{}{newRecordedIdentifiers(identifiers), location},
+		&recordedLocations,
+	)
+
+	if recordedLocations == nil {
+		return nil, err
+	}
+
+	resolvedLocations := make([]ResolvedLocation, len(recordedLocations))
+	for index, recordedLocation := range recordedLocations {
+		resolvedLocation, decodingErr := decodeRecordedLocation(recordedLocation.Location)
+		if decodingErr != nil {
+			return nil, decodingErr
+		}
+
+		resolvedIdentifiers := make([]Identifier, len(recordedLocation.Identifiers))
+		for identifierIndex, identifier := range recordedLocation.Identifiers {
+			resolvedIdentifiers[identifierIndex] = identifier.identifier()
+		}
+
+		resolvedLocations[index] = ResolvedLocation{
+			Location:    resolvedLocation,
+			Identifiers: resolvedIdentifiers,
+		}
+	}
+
+	return resolvedLocations, err
+}
+
+func (i *ReplayingInterface) GetCode(location Location) (code []byte, err error) {
+	err = i.replay("GetCode", []interface{}{location}, &code)
+	return
+}
+
+func (i *ReplayingInterface) GetProgram(location Location) (*interpreter.Program, error) {
+	return i.programs[location.ID()], nil
+}
+
+func (i *ReplayingInterface) SetProgram(location Location, program *interpreter.Program) error {
+	i.programs[location.ID()] = program
+	return nil
+}
+
+func (i *ReplayingInterface) GetValue(owner, key []byte) (value []byte, err error) {
+	err = i.replay("GetValue", []interface{}{owner, key}, &value)
+	return
+}
+
+func (i *ReplayingInterface) SetValue(owner, key, value []byte) error {
+	return i.replay("SetValue", []interface{}{owner, key, value})
+}
+
+func (i *ReplayingInterface) ValueExists(owner, key []byte) (exists bool, err error) {
+	err = i.replay("ValueExists", []interface{}{owner, key}, &exists)
+	return
+}
+
+func (i *ReplayingInterface) AllocateStorageIndex(owner []byte) (index atree.StorageIndex, err error) {
+	err = i.replay("AllocateStorageIndex", []interface{}{owner}, &index)
+	return
+}
+
+func (i *ReplayingInterface) CreateAccount(payer Address) (address Address, err error) {
+	err = i.replay("CreateAccount", []interface{}{payer}, &address)
+	return
+}
+
+func (i *ReplayingInterface) AddEncodedAccountKey(address Address, publicKey []byte) error {
+	return i.replay("AddEncodedAccountKey", []interface{}{address, publicKey})
+}
+
+func (i *ReplayingInterface) RevokeEncodedAccountKey(address Address, index int) (publicKey []byte, err error) {
+	err = i.replay("RevokeEncodedAccountKey", []interface{}{address, index}, &publicKey)
+	return
+}
+
+func (i *ReplayingInterface) AddAccountKey(
+	address Address,
+	publicKey *PublicKey,
+	hashAlgo HashAlgorithm,
+	weight int,
+) (
+	accountKey *AccountKey,
+	err error,
+) {
+	err = i.replay(
+		"AddAccountKey",
+		[]interface{}{address, publicKey, hashAlgo, weight},
+		&accountKey,
+	)
+	return
+}
+
+func (i *ReplayingInterface) GetAccountKey(address Address, index int) (accountKey *AccountKey, err error) {
+	err = i.replay("GetAccountKey", []interface{}{address, index}, &accountKey)
+	return
+}
+
+func (i *ReplayingInterface) RevokeAccountKey(address Address, index int) (accountKey *AccountKey, err error) {
+	err = i.replay("RevokeAccountKey", []interface{}{address, index}, &accountKey)
+	return
+}
+
+func (i *ReplayingInterface) UpdateAccountContractCode(address Address, name string, code []byte) error {
+	return i.replay("UpdateAccountContractCode", []interface{}{address, name, code})
+}
+
+func (i *ReplayingInterface) GetAccountContractCode(address Address, name string) (code []byte, err error) {
+	err = i.replay("GetAccountContractCode", []interface{}{address, name}, &code)
+	return
+}
+
+func (i *ReplayingInterface) RemoveAccountContractCode(address Address, name string) error {
+	return i.replay("RemoveAccountContractCode", []interface{}{address, name})
+}
+
+func (i *ReplayingInterface) GetSigningAccounts() (addresses []Address, err error) {
+	err = i.replay("GetSigningAccounts", nil, &addresses)
+	return
+}
+
+func (i *ReplayingInterface) ProgramLog(message string) error {
+	return i.replay("ProgramLog", []interface{}{message})
+}
+
+func (i *ReplayingInterface) EmitEvent(event cadence.Event) error {
+	encodedEvent, err := jsoncdc.Encode(event)
+	if err != nil {
+		return err
+	}
+
+	return i.replay("EmitEvent", []interface{}{json.RawMessage(encodedEvent)})
+}
+
+func (i *ReplayingInterface) GenerateUUID() (uuid uint64, err error) {
+	err = i.replay("GenerateUUID", nil, &uuid)
+	return
+}
+
+func (i *ReplayingInterface) MeterComputation(operationType common.ComputationKind, intensity uint) error {
+	return i.replay("MeterComputation", []interface{}{operationType, intensity})
+}
+
+func (i *ReplayingInterface) MeterMemory(kind common.MemoryKind, amount uint) error {
+	return i.replay("MeterMemory", []interface{}{kind, amount})
+}
+
+func (i *ReplayingInterface) DecodeArgument(argument []byte, argumentType cadence.Type) (cadence.Value, error) {
+	var encodedValue json.RawMessage
+	err := i.replay(
+		"DecodeArgument",
+		[]interface{}{argument, typeIDOrEmpty(argumentType)},
+		&encodedValue,
+	)
+
+	if encodedValue == nil {
+		return nil, err
+	}
+
+	value, decodingErr := jsoncdc.Decode(encodedValue)
+	if decodingErr != nil {
+		return nil, decodingErr
+	}
+
+	return value, err
+}
+
+func (i *ReplayingInterface) GetCurrentBlockHeight() (height uint64, err error) {
+	err = i.replay("GetCurrentBlockHeight", nil, &height)
+	return
+}
+
+func (i *ReplayingInterface) GetBlockAtHeight(height uint64) (block Block, exists bool, err error) {
+	err = i.replay("GetBlockAtHeight", []interface{}{height}, &block, &exists)
+	return
+}
+
+func (i *ReplayingInterface) UnsafeRandom() (random uint64, err error) {
+	err = i.replay("UnsafeRandom", nil, &random)
+	return
+}
+
+func (i *ReplayingInterface) VerifySignature(
+	signature []byte,
+	tag string,
+	signedData []byte,
+	publicKey []byte,
+	signatureAlgorithm SignatureAlgorithm,
+	hashAlgorithm HashAlgorithm,
+) (
+	valid bool,
+	err error,
+) {
+	err = i.replay(
+		"VerifySignature",
+		[]interface{}{signature, tag, signedData, publicKey, signatureAlgorithm, hashAlgorithm},
+		&valid,
+	)
+	return
+}
+
+func (i *ReplayingInterface) Hash(data []byte, tag string, hashAlgorithm HashAlgorithm) (digest []byte, err error) {
+	err = i.replay("Hash", []interface{}{data, tag, hashAlgorithm}, &digest)
+	return
+}
+
+func (i *ReplayingInterface) GetAccountBalance(address common.Address) (balance uint64, err error) {
+	err = i.replay("GetAccountBalance", []interface{}{address}, &balance)
+	return
+}
+
+func (i *ReplayingInterface) GetAccountAvailableBalance(address common.Address) (balance uint64, err error) {
+	err = i.replay("GetAccountAvailableBalance", []interface{}{address}, &balance)
+	return
+}
+
+func (i *ReplayingInterface) GetStorageUsed(address Address) (used uint64, err error) {
+	err = i.replay("GetStorageUsed", []interface{}{address}, &used)
+	return
+}
+
+func (i *ReplayingInterface) GetStorageCapacity(address Address) (capacity uint64, err error) {
+	err = i.replay("GetStorageCapacity", []interface{}{address}, &capacity)
+	return
+}
+
+func (i *ReplayingInterface) ImplementationDebugLog(_ string) error {
+	return nil
+}
+
+func (i *ReplayingInterface) ValidatePublicKey(key *PublicKey) error {
+	return i.replay("ValidatePublicKey", []interface{}{key})
+}
+
+func (i *ReplayingInterface) GetAccountContractNames(address Address) (names []string, err error) {
+	err = i.replay("GetAccountContractNames", []interface{}{address}, &names)
+	return
+}
+
+func (i *ReplayingInterface) RecordTrace(_ string, _ common.Location, _ time.Duration, _ []opentracing.LogRecord) {
+	// NO-OP
+}
+
+func (i *ReplayingInterface) BLSVerifyPOP(publicKey *PublicKey, signature []byte) (valid bool, err error) {
+	err = i.replay("BLSVerifyPOP", []interface{}{publicKey, signature}, &valid)
+	return
+}
+
+func (i *ReplayingInterface) BLSAggregateSignatures(signatures [][]byte) (signature []byte, err error) {
+	err = i.replay("BLSAggregateSignatures", []interface{}{signatures}, &signature)
+	return
+}
+
+func (i *ReplayingInterface) BLSAggregatePublicKeys(publicKeys []*PublicKey) (publicKey *PublicKey, err error) {
+	err = i.replay("BLSAggregatePublicKeys", []interface{}{publicKeys}, &publicKey)
+	return
+}
+
+func (i *ReplayingInterface) ResourceOwnerChanged(
+	_ *interpreter.Interpreter,
+	_ *interpreter.CompositeValue,
+	_ common.Address,
+	_ common.Address,
+) {
+	// NO-OP
+}
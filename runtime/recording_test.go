@@ -0,0 +1,212 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestRuntimeRecordAndReplay(t *testing.T) {
+
+	t.Parallel()
+
+	const contract = `
+      pub contract Test {
+
+          pub event Value(value: Int)
+
+          pub fun emitValue(_ value: Int) {
+              emit Value(value: value)
+          }
+      }
+    `
+
+	const transaction = `
+      import Test from 0x1
+
+      transaction(value: Int) {
+
+          prepare(signer: AuthAccount) {
+              log(unsafeRandom())
+              signer.save(value, to: /storage/value)
+              Test.emitValue(value)
+          }
+      }
+    `
+
+	address := common.Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}
+
+	accountCodes := map[common.LocationID][]byte{}
+
+	var logs []string
+	var events []cadence.Event
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getAccountContractCode: func(address Address, name string) ([]byte, error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			return accountCodes[location.ID()], nil
+		},
+		updateAccountContractCode: func(address Address, name string, code []byte) error {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			accountCodes[location.ID()] = code
+			return nil
+		},
+		unsafeRandom: func() (uint64, error) {
+			return 42, nil
+		},
+		log: func(message string) {
+			logs = append(logs, message)
+		},
+		emitEvent: func(event cadence.Event) error {
+			events = append(events, event)
+			return nil
+		},
+		decodeArgument: func(b []byte, t cadence.Type) (cadence.Value, error) {
+			return jsoncdc.Decode(b)
+		},
+	}
+
+	runtime := newTestInterpreterRuntime()
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: utils.DeploymentTransaction("Test", []byte(contract)),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	// Record the execution of the transaction
+
+	events = nil
+
+	recordingInterface := NewRecordingInterface(runtimeInterface)
+
+	location := nextTransactionLocation()
+
+	script := Script{
+		Source: []byte(transaction),
+		Arguments: [][]byte{
+			jsoncdc.MustEncode(cadence.NewInt(7)),
+		},
+	}
+
+	err = runtime.ExecuteTransaction(
+		script,
+		Context{
+			Interface: recordingInterface,
+			Location:  location,
+		},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"42"}, logs)
+	require.Len(t, events, 1)
+
+	recording, err := recordingInterface.Recording()
+	require.NoError(t, err)
+
+	var buffer bytes.Buffer
+	err = recording.Write(&buffer)
+	require.NoError(t, err)
+
+	t.Run("replay", func(t *testing.T) {
+
+		t.Parallel()
+
+		recording, err := ReadInterfaceRecording(bytes.NewReader(buffer.Bytes()))
+		require.NoError(t, err)
+
+		arguments, err := recording.Arguments()
+		require.NoError(t, err)
+		assert.Equal(t, script.Arguments, arguments)
+
+		replayingInterface := NewReplayingInterface(recording)
+
+		err = newTestInterpreterRuntime().ExecuteTransaction(
+			Script{
+				Source:    script.Source,
+				Arguments: arguments,
+			},
+			Context{
+				Interface: replayingInterface,
+				Location:  location,
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, replayingInterface.Remaining())
+	})
+
+	t.Run("diverging", func(t *testing.T) {
+
+		t.Parallel()
+
+		recording, err := ReadInterfaceRecording(bytes.NewReader(buffer.Bytes()))
+		require.NoError(t, err)
+
+		replayingInterface := NewReplayingInterface(recording)
+
+		// The transaction is executed with a different argument
+
+		err = newTestInterpreterRuntime().ExecuteTransaction(
+			Script{
+				Source: script.Source,
+				Arguments: [][]byte{
+					jsoncdc.MustEncode(cadence.NewInt(8)),
+				},
+			},
+			Context{
+				Interface: replayingInterface,
+				Location:  location,
+			},
+		)
+		require.Error(t, err)
+
+		var replayErr InterfaceReplayError
+		require.ErrorAs(t, err, &replayErr)
+		assert.Equal(t, "DecodeArgument", replayErr.Function)
+		assert.True(t, replayErr.ArgumentMismatch)
+	})
+}
@@ -19,6 +19,7 @@
 package runtime
 
 import (
+	goContext "context"
 	"errors"
 	"fmt"
 	goRuntime "runtime"
@@ -45,6 +46,10 @@ type Script struct {
 type importResolutionResults map[common.LocationID]bool
 
 // Runtime is a runtime capable of executing Cadence.
+//
+// Scripts may be executed concurrently on the same runtime,
+// as long as the options of the runtime are not changed at the same time,
+// and no coverage report or profiler is set.
 type Runtime interface {
 	// ExecuteScript executes the given script.
 	//
@@ -52,12 +57,32 @@ type Runtime interface {
 	// or if the execution fails.
 	ExecuteScript(Script, Context) (cadence.Value, error)
 
+	// ExecuteScriptWithContext executes the given script, like ExecuteScript.
+	//
+	// The execution is aborted with an ExecutionCancelledError
+	// when the given Go context is done, e.g. when it is cancelled or its deadline is exceeded.
+	ExecuteScriptWithContext(goContext.Context, Script, Context) (cadence.Value, error)
+
 	// ExecuteTransaction executes the given transaction.
 	//
 	// This function returns an error if the program has errors (e.g syntax errors, type errors),
 	// or if the execution fails.
 	ExecuteTransaction(Script, Context) error
 
+	// ExecuteTransactionWithContext executes the given transaction, like ExecuteTransaction.
+	//
+	// The execution is aborted with an ExecutionCancelledError
+	// when the given Go context is done, e.g. when it is cancelled or its deadline is exceeded.
+	ExecuteTransactionWithContext(goContext.Context, Script, Context) error
+
+	// SimulateTransaction executes the given transaction,
+	// without writing any changes to the ledger or emitting any events.
+	//
+	// This function returns the effects of the transaction, see TransactionSimulation,
+	// or an error if the program has errors (e.g syntax errors, type errors),
+	// or if the execution fails.
+	SimulateTransaction(Script, Context) (*TransactionSimulation, error)
+
 	// InvokeContractFunction invokes a contract function with the given arguments.
 	//
 	// This function returns an error if the execution fails.
@@ -81,6 +106,11 @@ type Runtime interface {
 	//
 	SetCoverageReport(coverageReport *CoverageReport)
 
+	// SetProfiler activates profiling using the given profiler.
+	// Passing nil disables profiling (default).
+	//
+	SetProfiler(profiler *Profiler)
+
 	// SetContractUpdateValidationEnabled configures if contract update validation is enabled.
 	//
 	SetContractUpdateValidationEnabled(enabled bool)
@@ -98,6 +128,14 @@ type Runtime interface {
 	// SetResourceOwnerChangeHandlerEnabled configures if the resource owner change callback is enabled.
 	SetResourceOwnerChangeHandlerEnabled(enabled bool)
 
+	// SetScriptAuthAccountReadOnly configures if the auth accounts
+	// which scripts obtain using the `getAuthAccount` function are read-only.
+	SetScriptAuthAccountReadOnly(readOnly bool)
+
+	// SetDebugger sets the debugger which is used by the interpreter.
+	// Passing nil disables debugging (default).
+	SetDebugger(debugger *interpreter.Debugger)
+
 	// ReadStored reads the value stored at the given path
 	//
 	ReadStored(address common.Address, path cadence.Path, context Context) (cadence.Value, error)
@@ -118,6 +156,7 @@ var validTopLevelDeclarationsInTransaction = []common.DeclarationKind{
 	common.DeclarationKindImport,
 	common.DeclarationKindFunction,
 	common.DeclarationKindTransaction,
+	common.DeclarationKindTypeAlias,
 }
 
 var validTopLevelDeclarationsInAccountCode = []common.DeclarationKind{
@@ -125,6 +164,7 @@ var validTopLevelDeclarationsInAccountCode = []common.DeclarationKind{
 	common.DeclarationKindImport,
 	common.DeclarationKindContract,
 	common.DeclarationKindContractInterface,
+	common.DeclarationKindTypeAlias,
 }
 
 func validTopLevelDeclarations(location common.Location) []common.DeclarationKind {
@@ -159,11 +199,14 @@ func reportMetric(
 // interpreterRuntime is a interpreter-based version of the Flow runtime.
 type interpreterRuntime struct {
 	coverageReport                       *CoverageReport
+	profiler                             *Profiler
 	contractUpdateValidationEnabled      bool
 	atreeValidationEnabled               bool
 	tracingEnabled                       bool
 	resourceOwnerChangeHandlerEnabled    bool
 	invalidatedResourceValidationEnabled bool
+	scriptAuthAccountReadOnly            bool
+	debugger                             *interpreter.Debugger
 }
 
 type Option func(Runtime)
@@ -213,6 +256,24 @@ func WithResourceOwnerChangeCallbackEnabled(enabled bool) Option {
 	}
 }
 
+// WithScriptAuthAccountReadOnly returns a runtime option
+// that configures if the auth accounts obtained by scripts are read-only.
+//
+func WithScriptAuthAccountReadOnly(readOnly bool) Option {
+	return func(runtime Runtime) {
+		runtime.SetScriptAuthAccountReadOnly(readOnly)
+	}
+}
+
+// WithDebugger returns a runtime option
+// that sets the debugger which is used by the interpreter.
+//
+func WithDebugger(debugger *interpreter.Debugger) Option {
+	return func(runtime Runtime) {
+		runtime.SetDebugger(debugger)
+	}
+}
+
 // NewInterpreterRuntime returns a interpreter-based version of the Flow runtime.
 func NewInterpreterRuntime(options ...Option) Runtime {
 	runtime := &interpreterRuntime{}
@@ -244,6 +305,10 @@ func (r *interpreterRuntime) SetCoverageReport(coverageReport *CoverageReport) {
 	r.coverageReport = coverageReport
 }
 
+func (r *interpreterRuntime) SetProfiler(profiler *Profiler) {
+	r.profiler = profiler
+}
+
 func (r *interpreterRuntime) SetContractUpdateValidationEnabled(enabled bool) {
 	r.contractUpdateValidationEnabled = enabled
 }
@@ -264,6 +329,14 @@ func (r *interpreterRuntime) SetResourceOwnerChangeHandlerEnabled(enabled bool)
 	r.resourceOwnerChangeHandlerEnabled = enabled
 }
 
+func (r *interpreterRuntime) SetScriptAuthAccountReadOnly(readOnly bool) {
+	r.scriptAuthAccountReadOnly = readOnly
+}
+
+func (r *interpreterRuntime) SetDebugger(debugger *interpreter.Debugger) {
+	r.debugger = debugger
+}
+
 func (r *interpreterRuntime) ExecuteScript(script Script, context Context) (val cadence.Value, err error) {
 	defer r.Recover(
 		func(internalErr error) {
@@ -274,7 +347,14 @@ func (r *interpreterRuntime) ExecuteScript(script Script, context Context) (val
 
 	context.InitializeCodesAndPrograms()
 
-	storage := NewStorage(context.Interface)
+	var storage *Storage
+	if r.scriptAuthAccountReadOnly {
+		// Scripts obtain read-only auth accounts,
+		// so ensure the account storage is not modified
+		storage = NewReadOnlyStorage(context.Interface)
+	} else {
+		storage = NewStorage(context.Interface)
+	}
 
 	var checkerOptions []sema.Option
 	var interpreterOptions []interpreter.Option
@@ -364,6 +444,15 @@ func (r *interpreterRuntime) ExecuteScript(script Script, context Context) (val
 	return result, nil
 }
 
+func (r *interpreterRuntime) ExecuteScriptWithContext(
+	ctx goContext.Context,
+	script Script,
+	context Context,
+) (cadence.Value, error) {
+	context.cancellationContext = ctx
+	return r.ExecuteScript(script, context)
+}
+
 func (r *interpreterRuntime) commitStorage(storage *Storage, inter *interpreter.Interpreter) error {
 	const commitContractUpdates = true
 	err := storage.Commit(inter, commitContractUpdates)
@@ -440,6 +529,11 @@ func (r *interpreterRuntime) interpret(
 
 	var result interpreter.Value
 
+	if r.profiler != nil {
+		r.profiler.start(context.Location)
+		defer r.profiler.stop()
+	}
+
 	reportMetric(
 		func() {
 			err = inter.Interpret()
@@ -642,6 +736,15 @@ func (r *interpreterRuntime) convertArgument(
 	return argument
 }
 
+func (r *interpreterRuntime) ExecuteTransactionWithContext(
+	ctx goContext.Context,
+	script Script,
+	context Context,
+) error {
+	context.cancellationContext = ctx
+	return r.ExecuteTransaction(script, context)
+}
+
 func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context) (err error) {
 	defer r.Recover(
 		func(internalErr error) {
@@ -654,6 +757,31 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 
 	storage := NewStorage(context.Interface)
 
+	inter, err := r.executeTransaction(script, context, storage)
+	if err != nil {
+		return err
+	}
+
+	// Write back all stored values, which were actually just cached, back into storage
+	err = r.commitStorage(storage, inter)
+	if err != nil {
+		return newError(err, context)
+	}
+
+	return nil
+}
+
+// executeTransaction executes the given transaction, without committing the storage.
+// The returned errors are already wrapped in an Error
+//
+func (r *interpreterRuntime) executeTransaction(
+	script Script,
+	context Context,
+	storage *Storage,
+) (
+	*interpreter.Interpreter,
+	error,
+) {
 	var interpreterOptions []interpreter.Option
 	var checkerOptions []sema.Option
 
@@ -674,7 +802,7 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 		importResolutionResults{},
 	)
 	if err != nil {
-		return newError(err, context)
+		return nil, newError(err, context)
 	}
 
 	transactions := program.Elaboration.TransactionTypes
@@ -683,7 +811,7 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 		err = InvalidTransactionCountError{
 			Count: transactionCount,
 		}
-		return newError(err, context)
+		return nil, newError(err, context)
 	}
 
 	transactionType := transactions[0]
@@ -693,7 +821,7 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 		authorizers, err = context.Interface.GetSigningAccounts()
 	})
 	if err != nil {
-		return newError(err, context)
+		return nil, newError(err, context)
 	}
 	// check parameter count
 
@@ -706,7 +834,7 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 			Expected: transactionParameterCount,
 			Actual:   argumentCount,
 		}
-		return newError(err, context)
+		return nil, newError(err, context)
 	}
 
 	transactionAuthorizerCount := len(transactionType.PrepareParameters)
@@ -715,7 +843,7 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 			Expected: transactionAuthorizerCount,
 			Actual:   authorizerCount,
 		}
-		return newError(err, context)
+		return nil, newError(err, context)
 	}
 
 	// gather authorizers
@@ -753,16 +881,10 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 		),
 	)
 	if err != nil {
-		return newError(err, context)
-	}
-
-	// Write back all stored values, which were actually just cached, back into storage
-	err = r.commitStorage(storage, inter)
-	if err != nil {
-		return newError(err, context)
+		return nil, newError(err, context)
 	}
 
-	return nil
+	return inter, nil
 }
 
 func wrapPanic(f func()) {
@@ -1068,6 +1190,13 @@ func (r *interpreterRuntime) parseAndCheckProgram(
 		context.SetProgram(context.Location, parse)
 	}
 
+	elementCount := astElementCount(parse)
+
+	err = meterMemory(context.Interface, common.MemoryKindASTElement, elementCount)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check
 
 	elaboration, err := r.check(parse, context, functions, values, checkerOptions, checkedImports)
@@ -1075,6 +1204,11 @@ func (r *interpreterRuntime) parseAndCheckProgram(
 		return nil, wrapError(err)
 	}
 
+	err = meterMemory(context.Interface, common.MemoryKindElaboration, elementCount)
+	if err != nil {
+		return nil, err
+	}
+
 	// Return
 
 	program = &interpreter.Program{
@@ -1094,6 +1228,32 @@ func (r *interpreterRuntime) parseAndCheckProgram(
 	return program, nil
 }
 
+// meterProgramMemory meters the memory used by the AST and the elaboration of the given program,
+// like parseAndCheckProgram does when it parses and checks a program
+//
+func meterProgramMemory(runtimeInterface Interface, program *ast.Program) error {
+	elementCount := astElementCount(program)
+
+	err := meterMemory(runtimeInterface, common.MemoryKindASTElement, elementCount)
+	if err != nil {
+		return err
+	}
+
+	return meterMemory(runtimeInterface, common.MemoryKindElaboration, elementCount)
+}
+
+// astElementCount returns the number of elements of the given program
+//
+func astElementCount(program *ast.Program) (count uint) {
+	ast.Inspect(program, func(element ast.Element) bool {
+		if element != nil {
+			count++
+		}
+		return true
+	})
+	return
+}
+
 func (r *interpreterRuntime) check(
 	program *ast.Program,
 	startContext Context,
@@ -1188,6 +1348,10 @@ func (r *interpreterRuntime) check(
 		return nil, err
 	}
 
+	if r.coverageReport != nil {
+		r.coverageReport.InspectProgram(startContext.Location, program)
+	}
+
 	return elaboration, nil
 }
 
@@ -1270,7 +1434,13 @@ func (r *interpreterRuntime) newInterpreter(
 			r.importLocationHandler(context, functions, values, checkerOptions),
 		),
 		interpreter.WithOnStatementHandler(
-			r.onStatementHandler(),
+			r.onStatementHandler(context),
+		),
+		interpreter.WithOnLoopIterationHandler(
+			r.onLoopIterationHandler(context),
+		),
+		interpreter.WithOnBranchHandler(
+			r.onBranchHandler(),
 		),
 		interpreter.WithPublicAccountHandler(
 			func(_ *interpreter.Interpreter, address interpreter.AddressValue) interpreter.Value {
@@ -1282,6 +1452,7 @@ func (r *interpreterRuntime) newInterpreter(
 			},
 		),
 		interpreter.WithPublicKeyValidationHandler(publicKeyValidator),
+		interpreter.WithDebugger(r.debugger),
 		interpreter.WithBLSCryptoFunctions(
 			func(
 				inter *interpreter.Interpreter,
@@ -1470,6 +1641,14 @@ func (r *interpreterRuntime) getProgram(
 		if err != nil {
 			return nil, err
 		}
+	} else {
+		// Meter the program like a parsed and checked program,
+		// so the metered memory does not depend on the programs cached by the host
+
+		err = meterProgramMemory(context.Interface, program.Program)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	context.SetProgram(context.Location, program.Program)
@@ -1542,18 +1721,30 @@ func (r *interpreterRuntime) meteringInterpreterOptions(runtimeInterface Interfa
 
 	return []interpreter.Option{
 		interpreter.WithOnFunctionInvocationHandler(
-			func(_ *interpreter.Interpreter, _ int) {
+			func(inter *interpreter.Interpreter, line int) {
 				callStackDepth++
 				checkCallStackDepth()
+
+				if r.profiler != nil {
+					r.profiler.reportLine(inter, line)
+				}
 			},
 		),
 		interpreter.WithOnInvokedFunctionReturnHandler(
-			func(_ *interpreter.Interpreter, _ int) {
+			func(inter *interpreter.Interpreter, line int) {
 				callStackDepth--
+
+				if r.profiler != nil {
+					r.profiler.reportLine(inter, line)
+				}
 			},
 		),
 		interpreter.WithOnMeterComputationFuncHandler(
 			func(compKind common.ComputationKind, intensity uint) {
+				if r.profiler != nil {
+					r.profiler.reportComputation(intensity)
+				}
+
 				var err error
 				wrapPanic(func() {
 					err = runtimeInterface.MeterComputation(compKind, intensity)
@@ -1563,9 +1754,24 @@ func (r *interpreterRuntime) meteringInterpreterOptions(runtimeInterface Interfa
 				}
 			},
 		),
+		interpreter.WithOnMeterMemoryFuncHandler(
+			func(kind common.MemoryKind, amount uint) {
+				err := meterMemory(runtimeInterface, kind, amount)
+				if err != nil {
+					panic(err)
+				}
+			},
+		),
 	}
 }
 
+func meterMemory(runtimeInterface Interface, kind common.MemoryKind, amount uint) (err error) {
+	wrapPanic(func() {
+		err = runtimeInterface.MeterMemory(kind, amount)
+	})
+	return
+}
+
 var getAuthAccountFunctionType = &sema.FunctionType{
 	Parameters: []*sema.Parameter{{
 		Label:          sema.ArgumentLabelNotRequired,
@@ -2152,6 +2358,10 @@ func (r *interpreterRuntime) newGetAuthAccountFunction(
 			panic(runtimeErrors.NewUnreachableError())
 		}
 
+		if r.scriptAuthAccountReadOnly {
+			return r.newReadOnlyAuthAccountValue(accountAddress, context, storage)
+		}
+
 		return r.newAuthAccountValue(
 			accountAddress,
 			context,
@@ -2162,6 +2372,62 @@ func (r *interpreterRuntime) newGetAuthAccountFunction(
 	}
 }
 
+func (r *interpreterRuntime) newReadOnlyAuthAccountValue(
+	addressValue interpreter.AddressValue,
+	context Context,
+	storage *Storage,
+) interpreter.Value {
+	return interpreter.NewReadOnlyAuthAccountValue(
+		addressValue,
+		accountBalanceGetFunction(addressValue, context.Interface),
+		accountAvailableBalanceGetFunction(addressValue, context.Interface),
+		storageUsedGetFunction(addressValue, context.Interface, storage),
+		storageCapacityGetFunction(addressValue, context.Interface),
+		func() interpreter.Value {
+			return interpreter.NewAuthAccountContractsValue(
+				addressValue,
+				interpreter.NewReadOnlyAuthAccountFunction(
+					"contracts.add",
+					sema.AuthAccountContractsTypeAddFunctionType,
+				),
+				interpreter.NewReadOnlyAuthAccountFunction(
+					"contracts.update__experimental",
+					sema.AuthAccountContractsTypeUpdateExperimentalFunctionType,
+				),
+				r.newAccountContractsGetFunction(
+					addressValue,
+					context.Interface,
+				),
+				interpreter.NewReadOnlyAuthAccountFunction(
+					"contracts.remove",
+					sema.AuthAccountContractsTypeRemoveFunctionType,
+				),
+				r.newAccountContractsGetNamesFunction(
+					addressValue,
+					context.Interface,
+				),
+			)
+		},
+		func() interpreter.Value {
+			return interpreter.NewAuthAccountKeysValue(
+				addressValue,
+				interpreter.NewReadOnlyAuthAccountFunction(
+					"keys.add",
+					sema.AuthAccountKeysTypeAddFunctionType,
+				),
+				r.newAccountKeysGetFunction(
+					addressValue,
+					context.Interface,
+				),
+				interpreter.NewReadOnlyAuthAccountFunction(
+					"keys.revoke",
+					sema.AuthAccountKeysTypeRevokeFunctionType,
+				),
+			)
+		},
+	)
+}
+
 func (r *interpreterRuntime) newGetAccountFunction(runtimeInterface Interface, storage *Storage) interpreter.HostFunction {
 	return func(invocation interpreter.Invocation) interpreter.Value {
 		accountAddress, ok := invocation.Arguments[0].(interpreter.AddressValue)
@@ -2524,6 +2790,11 @@ func (r *interpreterRuntime) newAuthAccountContractsChangeFunction(
 			var contractInterfaceTypes []*sema.InterfaceType
 
 			program.Elaboration.GlobalTypes.Foreach(func(_ string, variable *sema.Variable) {
+				// Type aliases only refer to types, they do not declare them
+				if variable.DeclarationKind == common.DeclarationKindTypeAlias {
+					return
+				}
+
 				switch ty := variable.Type.(type) {
 				case *sema.CompositeType:
 					if ty.Kind == common.CompositeKindContract {
@@ -2588,11 +2859,30 @@ func (r *interpreterRuntime) newAuthAccountContractsChangeFunction(
 			// Validate the contract update (if enabled)
 
 			if r.contractUpdateValidationEnabled && isUpdate {
-				var oldProgram *ast.Program
-				if cachedProgram != nil {
-					oldProgram = cachedProgram.Program
-				} else {
-					oldProgram, err = parser2.ParseProgram(string(existingCode))
+				oldProgram := cachedProgram
+				if oldProgram == nil {
+					oldProgram, err = r.parseAndCheckProgram(
+						existingCode,
+						context,
+						functions,
+						stdlib.BuiltinValues,
+						checkerOptions,
+						storeProgram,
+						importResolutionResults{},
+					)
+
+					// The existing code might not type-check anymore,
+					// e.g. if an imported contract was updated.
+					// In that case, only compare the declarations of the programs syntactically
+
+					var parsingCheckingErr *ParsingCheckingError
+					if errors.As(err, &parsingCheckingErr) {
+						var parsedProgram *ast.Program
+						parsedProgram, err = parser2.ParseProgram(string(existingCode))
+						oldProgram = &interpreter.Program{
+							Program: parsedProgram,
+						}
+					}
 					handleContractUpdateError(err)
 				}
 
@@ -2600,7 +2890,7 @@ func (r *interpreterRuntime) newAuthAccountContractsChangeFunction(
 					context.Location,
 					nameArgument,
 					oldProgram,
-					program.Program,
+					program,
 				)
 				err = validator.Validate()
 				handleContractUpdateError(err)
@@ -2934,15 +3224,99 @@ func (r *interpreterRuntime) newAccountContractsGetNamesFunction(
 	}
 }
 
-func (r *interpreterRuntime) onStatementHandler() interpreter.OnStatementFunc {
-	if r.coverageReport == nil {
+func (r *interpreterRuntime) onStatementHandler(context Context) interpreter.OnStatementFunc {
+	checkCancellation := newCancellationChecker(context)
+
+	if r.coverageReport == nil && r.profiler == nil && checkCancellation == nil {
 		return nil
 	}
 
 	return func(inter *interpreter.Interpreter, statement ast.Statement) {
 		location := inter.Location
-		line := statement.StartPosition().Line
-		r.coverageReport.AddLineHit(location, line)
+
+		if checkCancellation != nil {
+			checkCancellation(func() interpreter.LocationRange {
+				return interpreter.LocationRange{
+					Location: location,
+					Range:    ast.NewRangeFromPositioned(statement),
+				}
+			})
+		}
+
+		if r.coverageReport != nil {
+			r.coverageReport.InspectProgram(location, inter.Program.Program)
+			r.coverageReport.AddStatementHit(location, statement)
+		}
+
+		if r.profiler != nil {
+			r.profiler.reportLine(inter, statement.StartPosition().Line)
+		}
+	}
+}
+
+func (r *interpreterRuntime) onLoopIterationHandler(context Context) interpreter.OnLoopIterationFunc {
+	checkCancellation := newCancellationChecker(context)
+
+	if r.profiler == nil && checkCancellation == nil {
+		return nil
+	}
+
+	return func(inter *interpreter.Interpreter, line int) {
+		if checkCancellation != nil {
+			checkCancellation(func() interpreter.LocationRange {
+				position := ast.Position{Line: line}
+				return interpreter.LocationRange{
+					Location: inter.Location,
+					Range: ast.Range{
+						StartPos: position,
+						EndPos:   position,
+					},
+				}
+			})
+		}
+
+		if r.profiler != nil {
+			r.profiler.reportLine(inter, line)
+		}
+	}
+}
+
+// newCancellationChecker returns a function which aborts the execution
+// with an ExecutionCancelledError if the Go context of the given context is done.
+// Returns nil if the execution cannot be cancelled
+//
+func newCancellationChecker(context Context) func(getLocationRange func() interpreter.LocationRange) {
+	ctx := context.cancellationContext
+	if ctx == nil {
+		return nil
+	}
+
+	done := ctx.Done()
+	if done == nil {
+		return nil
+	}
+
+	return func(getLocationRange func() interpreter.LocationRange) {
+		select {
+		case <-done:
+			panic(ExecutionCancelledError{
+				Err:           ctx.Err(),
+				LocationRange: getLocationRange(),
+			})
+		default:
+		}
+	}
+}
+
+func (r *interpreterRuntime) onBranchHandler() interpreter.OnBranchFunc {
+	if r.coverageReport == nil {
+		return nil
+	}
+
+	return func(inter *interpreter.Interpreter, element ast.Element, branch int) {
+		location := inter.Location
+		r.coverageReport.InspectProgram(location, inter.Program.Program)
+		r.coverageReport.AddBranchHit(location, element, branch)
 	}
 }
 
@@ -20,6 +20,7 @@ package runtime
 
 import (
 	"bytes"
+	goContext "context"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
@@ -157,6 +158,7 @@ type testRuntimeInterface struct {
 	)
 	generateUUID       func() (uint64, error)
 	meterComputation   func(compKind common.ComputationKind, intensity uint) error
+	meterMemory        func(kind common.MemoryKind, amount uint) error
 	decodeArgument     func(b []byte, t cadence.Type) (cadence.Value, error)
 	programParsed      func(location common.Location, duration time.Duration)
 	programChecked     func(location common.Location, duration time.Duration)
@@ -368,6 +370,13 @@ func (i *testRuntimeInterface) MeterComputation(compKind common.ComputationKind,
 	return i.meterComputation(compKind, intensity)
 }
 
+func (i *testRuntimeInterface) MeterMemory(kind common.MemoryKind, amount uint) error {
+	if i.meterMemory == nil {
+		return nil
+	}
+	return i.meterMemory(kind, amount)
+}
+
 func (i *testRuntimeInterface) DecodeArgument(b []byte, t cadence.Type) (cadence.Value, error) {
 	return i.decodeArgument(b, t)
 }
@@ -7246,3 +7255,265 @@ func TestRuntimeComputationMetring(t *testing.T) {
 		})
 	}
 }
+
+func TestRuntimeMemoryMetering(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      pub fun main(): String {
+          let s = "hello"
+          return s.concat(" world")
+      }
+    `)
+
+	t.Run("usage", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		usages := map[common.MemoryKind]uint{}
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			meterMemory: func(kind common.MemoryKind, amount uint) error {
+				usages[kind] += amount
+				return nil
+			},
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(5+6+11), usages[common.MemoryKindString])
+		assert.NotZero(t, usages[common.MemoryKindASTElement])
+		assert.Equal(t,
+			usages[common.MemoryKindASTElement],
+			usages[common.MemoryKindElaboration],
+		)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		memoryErr := errors.New("memory exceeded limit")
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			meterMemory: func(kind common.MemoryKind, amount uint) error {
+				if kind == common.MemoryKindString && amount > 10 {
+					return memoryErr
+				}
+				return nil
+			},
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.Error(t, err)
+		require.ErrorIs(t, err, memoryErr)
+	})
+
+	t.Run("cached imports", func(t *testing.T) {
+
+		t.Parallel()
+
+		importedScript := []byte(`
+          pub fun answer(): Int {
+              return 42
+          }
+        `)
+
+		importingScript := []byte(`
+          import "imported"
+
+          pub fun main(): Int {
+              return answer()
+          }
+        `)
+
+		execute := func(runtimeInterface *testRuntimeInterface) map[common.MemoryKind]uint {
+			usages := map[common.MemoryKind]uint{}
+
+			runtimeInterface.getCode = func(location Location) ([]byte, error) {
+				switch location {
+				case common.StringLocation("imported"):
+					return importedScript, nil
+				default:
+					return nil, fmt.Errorf("unknown import location: %s", location)
+				}
+			}
+			runtimeInterface.meterMemory = func(kind common.MemoryKind, amount uint) error {
+				usages[kind] += amount
+				return nil
+			}
+
+			_, err := newTestInterpreterRuntime().ExecuteScript(
+				Script{
+					Source: importingScript,
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  common.ScriptLocation{},
+				},
+			)
+			require.NoError(t, err)
+
+			return usages
+		}
+
+		// The host does not cache programs
+
+		uncachedUsages := execute(&testRuntimeInterface{
+			getProgram: func(_ Location) (*interpreter.Program, error) {
+				return nil, nil
+			},
+			setProgram: func(_ Location, _ *interpreter.Program) error {
+				return nil
+			},
+		})
+
+		// The host caches programs. The first execution parses and checks the import,
+		// the second execution uses the cached program
+
+		cachingRuntimeInterface := &testRuntimeInterface{}
+
+		_ = execute(cachingRuntimeInterface)
+		cachedUsages := execute(cachingRuntimeInterface)
+
+		assert.NotZero(t, cachedUsages[common.MemoryKindASTElement])
+		assert.Equal(t, uncachedUsages, cachedUsages)
+	})
+}
+
+func TestRuntimeExecutionCancellation(t *testing.T) {
+
+	t.Parallel()
+
+	newRuntimeInterface := func() *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return nil, nil
+			},
+		}
+	}
+
+	t.Run("cancelled script", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		ctx, cancel := goContext.WithCancel(goContext.Background())
+		cancel()
+
+		_, err := runtime.ExecuteScriptWithContext(
+			ctx,
+			Script{
+				Source: []byte(`
+                  pub fun main() {
+                      while true {}
+                  }
+                `),
+			},
+			Context{
+				Interface: newRuntimeInterface(),
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.Error(t, err)
+		require.ErrorIs(t, err, goContext.Canceled)
+
+		var cancelledErr ExecutionCancelledError
+		require.ErrorAs(t, err, &cancelledErr)
+		assert.Equal(t, common.ScriptLocation{}, cancelledErr.Location)
+		assert.Equal(t, 3, cancelledErr.StartPos.Line)
+	})
+
+	t.Run("deadline exceeded in transaction loop", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		ctx, cancel := goContext.WithTimeout(goContext.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := runtime.ExecuteTransactionWithContext(
+			ctx,
+			Script{
+				Source: []byte(`
+                  transaction {
+                      execute {
+                          var i = 0
+                          while true {
+                              i = i + 1
+                          }
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: newRuntimeInterface(),
+				Location:  common.TransactionLocation{},
+			},
+		)
+		require.Error(t, err)
+		require.ErrorIs(t, err, goContext.DeadlineExceeded)
+
+		var cancelledErr ExecutionCancelledError
+		require.ErrorAs(t, err, &cancelledErr)
+		assert.Equal(t, common.TransactionLocation{}, cancelledErr.Location)
+	})
+
+	t.Run("not cancelled", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		ctx, cancel := goContext.WithCancel(goContext.Background())
+		defer cancel()
+
+		result, err := runtime.ExecuteScriptWithContext(
+			ctx,
+			Script{
+				Source: []byte(`
+                  pub fun main(): Int {
+                      var i = 0
+                      while i < 10 {
+                          i = i + 1
+                      }
+                      return i
+                  }
+                `),
+			},
+			Context{
+				Interface: newRuntimeInterface(),
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(10), result)
+	})
+}
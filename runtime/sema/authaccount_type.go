@@ -41,6 +41,12 @@ const AuthAccountGetCapabilityField = "getCapability"
 const AuthAccountGetLinkTargetField = "getLinkTarget"
 const AuthAccountContractsField = "contracts"
 const AuthAccountKeysField = "keys"
+const AuthAccountStoragePathsField = "storagePaths"
+const AuthAccountPublicPathsField = "publicPaths"
+const AuthAccountPrivatePathsField = "privatePaths"
+const AuthAccountForEachStoredField = "forEachStored"
+const AuthAccountForEachPublicField = "forEachPublic"
+const AuthAccountForEachPrivateField = "forEachPrivate"
 
 // AuthAccountType represents the authorized access to an account.
 // Access to an AuthAccount means having full access to its storage, public keys, and code.
@@ -171,6 +177,42 @@ var AuthAccountType = func() *CompositeType {
 			AuthAccountKeysType,
 			accountTypeKeysFieldDocString,
 		),
+		NewPublicConstantFieldMember(
+			authAccountType,
+			AuthAccountStoragePathsField,
+			AccountTypeStoragePathsFieldType,
+			authAccountTypeStoragePathsFieldDocString,
+		),
+		NewPublicConstantFieldMember(
+			authAccountType,
+			AuthAccountPublicPathsField,
+			AccountTypePublicPathsFieldType,
+			accountTypePublicPathsFieldDocString,
+		),
+		NewPublicConstantFieldMember(
+			authAccountType,
+			AuthAccountPrivatePathsField,
+			AccountTypePrivatePathsFieldType,
+			authAccountTypePrivatePathsFieldDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountType,
+			AuthAccountForEachStoredField,
+			AccountTypeForEachStoredFunctionType,
+			authAccountTypeForEachStoredFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountType,
+			AuthAccountForEachPublicField,
+			AccountTypeForEachPublicFunctionType,
+			accountTypeForEachPublicFunctionDocString,
+		),
+		NewPublicFunctionMember(
+			authAccountType,
+			AuthAccountForEachPrivateField,
+			AccountTypeForEachPrivateFunctionType,
+			authAccountTypeForEachPrivateFunctionDocString,
+		),
 	}
 
 	authAccountType.Members = GetMembersAsMap(members)
@@ -521,6 +563,57 @@ var AccountTypeGetLinkTargetFunctionType = &FunctionType{
 	),
 }
 
+var AccountTypeStoragePathsFieldType = &VariableSizedType{
+	Type: StoragePathType,
+}
+
+var AccountTypePublicPathsFieldType = &VariableSizedType{
+	Type: PublicPathType,
+}
+
+var AccountTypePrivatePathsFieldType = &VariableSizedType{
+	Type: PrivatePathType,
+}
+
+var AccountTypeForEachStoredFunctionType = newAccountForEachFunctionType(StoragePathType)
+
+var AccountTypeForEachPublicFunctionType = newAccountForEachFunctionType(PublicPathType)
+
+var AccountTypeForEachPrivateFunctionType = newAccountForEachFunctionType(PrivatePathType)
+
+// newAccountForEachFunctionType returns the type of a function
+// which iterates over the paths of the given type in the storage of an account.
+//
+// The function is called with each path and the type of the value stored under the path,
+// and returns whether the iteration should continue
+//
+func newAccountForEachFunctionType(pathType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "function",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Identifier:     "path",
+								TypeAnnotation: NewTypeAnnotation(pathType),
+							},
+							{
+								Identifier:     "type",
+								TypeAnnotation: NewTypeAnnotation(MetaType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(BoolType),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
+	}
+}
+
 // AuthAccountKeysType represents the keys associated with an auth account.
 var AuthAccountKeysType = func() *CompositeType {
 
@@ -650,3 +743,38 @@ Retrieves the key at the given index of the account.
 const authAccountKeysTypeRevokeFunctionDocString = `
 Revokes the key at the given index of the account.
 `
+
+const authAccountTypeStoragePathsFieldDocString = `
+All storage paths of the account under which objects are stored
+`
+
+const accountTypePublicPathsFieldDocString = `
+All public paths of the account under which capabilities are linked
+`
+
+const authAccountTypePrivatePathsFieldDocString = `
+All private paths of the account under which capabilities are linked
+`
+
+const storageIterationDocString = `
+
+The iteration stops when the function returns false.
+
+The iteration visits the objects which are stored when the iteration starts.
+Objects which are added or removed during the iteration are not visited.
+`
+
+const authAccountTypeForEachStoredFunctionDocString = `
+Calls the given function for each object in the account's storage,
+with the storage path under which the object is stored and the type of the object.
+` + storageIterationDocString
+
+const accountTypeForEachPublicFunctionDocString = `
+Calls the given function for each capability linked under a public path of the account,
+with the public path and the type of the capability.
+` + storageIterationDocString
+
+const authAccountTypeForEachPrivateFunctionDocString = `
+Calls the given function for each capability linked under a private path of the account,
+with the private path and the type of the capability.
+` + storageIterationDocString
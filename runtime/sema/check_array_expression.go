@@ -94,6 +94,15 @@ func (checker *Checker) VisitArrayExpression(expression *ast.ArrayExpression) as
 		resultType = &VariableSizedType{
 			Type: elementType,
 		}
+
+		// The elements were checked without an expected type,
+		// so nested literals must be constructed with the inferred element type
+		for i, value := range expression.Values {
+			valueType := checker.propagateInferredType(value, elementType)
+			if valueType != nil {
+				argumentTypes[i] = valueType
+			}
+		}
 	}
 
 	checker.Elaboration.ArrayExpressionArrayType[expression] = resultType
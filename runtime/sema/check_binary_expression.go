@@ -175,6 +175,13 @@ func (checker *Checker) VisitBinaryExpression(expression *ast.BinaryExpression)
 				leftIsInvalid, rightIsInvalid,
 			)
 
+			// The result type might be a supertype of the right-hand side,
+			// so construct a literal on the right-hand side with it
+			propagatedRightType := checker.propagateInferredType(expression.Right, resultType)
+			if propagatedRightType != nil {
+				rightType = propagatedRightType
+			}
+
 			checker.Elaboration.BinaryExpressionResultTypes[expression] = resultType
 			checker.Elaboration.BinaryExpressionRightTypes[expression] = rightType
 
@@ -416,37 +423,53 @@ func (checker *Checker) checkBinaryExpressionNilCoalescing(
 	if leftInner == NeverType {
 		return rightType
 	}
-	canNarrow := false
 
-	if !rightIsInvalid {
+	if rightIsInvalid {
+		return leftOptional
+	}
 
-		if rightType.IsResourceType() {
+	if rightType.IsResourceType() {
 
-			checker.report(
-				&InvalidNilCoalescingRightResourceOperandError{
-					Range: ast.NewRangeFromPositioned(expression.Right),
-				},
-			)
-		}
+		checker.report(
+			&InvalidNilCoalescingRightResourceOperandError{
+				Range: ast.NewRangeFromPositioned(expression.Right),
+			},
+		)
+	}
 
-		if !IsSubType(rightType, leftOptional) {
+	if IsSubType(rightType, leftInner) {
+		return leftInner
+	}
 
-			checker.report(
-				&InvalidBinaryOperandError{
-					Operation:    operation,
-					Side:         common.OperandSideRight,
-					ExpectedType: leftOptional,
-					ActualType:   rightType,
-					Range:        ast.NewRangeFromPositioned(expression.Right),
-				},
-			)
-		} else {
-			canNarrow = IsSubType(rightType, leftInner)
-		}
+	if IsSubType(rightType, leftOptional) {
+		return leftOptional
+	}
+
+	// The right-hand side is not a subtype of the left-hand side,
+	// so infer the least common supertype of both sides.
+	// The result is only optional if the right-hand side is optional
+
+	var superType Type
+	if _, ok := rightType.(*OptionalType); ok {
+		superType = LeastCommonSuperType(leftOptional, rightType)
+	} else {
+		superType = LeastCommonSuperType(leftInner, rightType)
 	}
 
-	if !canNarrow {
+	if superType.IsInvalidType() {
+
+		checker.report(
+			&InvalidBinaryOperandError{
+				Operation:    operation,
+				Side:         common.OperandSideRight,
+				ExpectedType: leftOptional,
+				ActualType:   rightType,
+				Range:        ast.NewRangeFromPositioned(expression.Right),
+			},
+		)
+
 		return leftOptional
 	}
-	return leftInner
+
+	return superType
 }
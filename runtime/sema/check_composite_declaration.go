@@ -74,6 +74,8 @@ func (checker *Checker) visitCompositeDeclaration(declaration *ast.CompositeDecl
 		defer checker.leaveValueScope(declaration.EndPosition, false)
 	}
 
+	checker.declareTypeParameters(declaration.TypeParameterList, compositeType.typeParameters)
+
 	checker.declareCompositeNestedTypes(declaration, kind, true)
 
 	var initializationInfo *InitializationInfo
@@ -199,7 +201,7 @@ func (checker *Checker) visitCompositeDeclaration(declaration *ast.CompositeDecl
 	}
 }
 
-// declareCompositeNestedTypes declares the types nested in a composite,
+// declareCompositeNestedTypes declares the types and type aliases nested in a composite,
 // and the constructors for them if `declareConstructors` is true
 // and `kind` is `ContainerKindComposite`.
 //
@@ -283,6 +285,8 @@ func (checker *Checker) declareCompositeNestedTypes(
 			}
 		}
 	})
+
+	checker.declareNestedTypeAliases(declaration.Members)
 }
 
 func (checker *Checker) declareNestedDeclarations(
@@ -441,13 +445,27 @@ func (checker *Checker) declareCompositeType(declaration *ast.CompositeDeclarati
 		)
 	}
 
-	// Resolve conformances
+	// NOTE: The conformances are resolved later in `resolveCompositeConformances`,
+	// after the type aliases are declared, as the conformances may refer to type aliases
 
-	if declaration.CompositeKind == common.CompositeKindEnum {
-		compositeType.EnumRawType = checker.enumRawType(declaration)
-	} else {
-		compositeType.ExplicitInterfaceConformances =
-			checker.explicitInterfaceConformances(declaration, compositeType)
+	// Resolve type parameters.
+	// Only structures and resources may be generic
+
+	if !declaration.TypeParameterList.IsEmpty() {
+		switch declaration.CompositeKind {
+		case common.CompositeKindStructure,
+			common.CompositeKindResource:
+
+			compositeType.typeParameters = checker.typeParameters(declaration.TypeParameterList)
+
+		default:
+			checker.report(
+				&InvalidTypeParametersError{
+					DeclarationKind: declaration.DeclarationKind(),
+					Range:           declaration.TypeParameterList.Range,
+				},
+			)
+		}
 	}
 
 	// Register in elaboration
@@ -488,6 +506,62 @@ func (checker *Checker) declareCompositeType(declaration *ast.CompositeDeclarati
 	return compositeType
 }
 
+// resolveCompositeConformances resolves the explicit interface conformances,
+// or the raw type of an enum, of the given composite declaration,
+// and recursively of all nested composite declarations.
+//
+// NOTE: This function assumes that the composite type and the type aliases were previously declared
+// using `declareCompositeType` and `declareCompositeTypeAliases`.
+//
+func (checker *Checker) resolveCompositeConformances(declaration *ast.CompositeDeclaration) {
+
+	compositeType := checker.Elaboration.CompositeDeclarationTypes[declaration]
+	if compositeType == nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	if declaration.CompositeKind == common.CompositeKindEnum {
+		compositeType.EnumRawType = checker.enumRawType(declaration)
+	} else {
+		compositeType.ExplicitInterfaceConformances =
+			checker.explicitInterfaceConformances(declaration, compositeType)
+	}
+
+	checker.resolveNestedConformances(
+		compositeType,
+		declaration.Members,
+		checker.Elaboration.CompositeNestedDeclarations[declaration],
+		declaration.EndPosition,
+	)
+}
+
+// resolveNestedConformances resolves the conformances of the composite declarations
+// nested in a composite or interface declaration with the given members,
+// in a new scope in which the container's nested types and type aliases are declared.
+//
+func (checker *Checker) resolveNestedConformances(
+	containerType ContainerType,
+	members *ast.Members,
+	nestedDeclarations map[string]ast.Declaration,
+	getEndPosition func() ast.Position,
+) {
+	// Activate a new scope for the nested types and type aliases
+
+	checker.typeActivations.Enter()
+	defer checker.typeActivations.Leave(getEndPosition)
+
+	checker.redeclareNestedTypes(containerType, nestedDeclarations)
+	checker.declareNestedTypeAliases(members)
+
+	for _, nestedDeclaration := range members.Interfaces() {
+		checker.resolveInterfaceNestedConformances(nestedDeclaration)
+	}
+
+	for _, nestedDeclaration := range members.Composites() {
+		checker.resolveCompositeConformances(nestedDeclaration)
+	}
+}
+
 // declareCompositeMembersAndValue declares the members and the value
 // (e.g. constructor function for non-contract types; instance for contracts)
 // for the given composite declaration, and recursively for all nested declarations.
@@ -515,6 +589,8 @@ func (checker *Checker) declareCompositeMembersAndValue(
 		checker.enterValueScope()
 		defer checker.leaveValueScope(declaration.EndPosition, false)
 
+		checker.declareTypeParameters(declaration.TypeParameterList, compositeType.typeParameters)
+
 		checker.declareCompositeNestedTypes(declaration, kind, false)
 
 		// NOTE: determine initializer parameter types while nested types are in scope,
@@ -637,6 +713,11 @@ func (checker *Checker) declareCompositeMembersAndValue(
 
 		compositeType.Members = members
 		compositeType.Fields = fields
+
+		// The members of the instantiations of a generic composite type
+		// can only be declared after the members of the generic composite type
+
+		compositeType.setMembersDeclared()
 		if checker.positionInfoEnabled {
 			checker.memberOrigins[compositeType] = origins
 		}
@@ -1311,8 +1392,12 @@ func (checker *Checker) compositeConstructorType(
 	argumentLabels []string,
 ) {
 
+	// The constructor of a generic composite type is generic,
+	// i.e. the type arguments may be inferred from the arguments
+
 	constructorFunctionType = &FunctionType{
 		IsConstructor:        true,
+		TypeParameters:       compositeType.typeParameters,
 		ReturnTypeAnnotation: NewTypeAnnotation(compositeType),
 	}
 
@@ -1478,7 +1563,15 @@ func (checker *Checker) defaultMembersAndOrigins(
 
 		identifier := function.Identifier.Identifier
 
-		functionType := checker.functionType(function.ParameterList, function.ReturnTypeAnnotation)
+		functionType := checker.functionDeclarationType(function)
+
+		// The type parameters declared for the function's block
+		// must be the type parameters of the member's function type,
+		// so record the function type, see visitFunctionDeclaration
+
+		if !function.TypeParameterList.IsEmpty() {
+			checker.Elaboration.FunctionDeclarationFunctionTypes[function] = functionType
+		}
 
 		argumentLabels := function.ParameterList.EffectiveArgumentLabels()
 
@@ -102,7 +102,16 @@ func (checker *Checker) VisitConditionalExpression(expression *ast.ConditionalEx
 		return thenType
 	}
 
-	return LeastCommonSuperType(thenType, elseType)
+	// The branches were checked without an expected type,
+	// so infer the least common supertype of the branches,
+	// and construct literals in the branches with it
+
+	superType := LeastCommonSuperType(thenType, elseType)
+
+	checker.propagateInferredType(expression.Then, superType)
+	checker.propagateInferredType(expression.Else, superType)
+
+	return superType
 }
 
 // visitConditional checks a conditional.
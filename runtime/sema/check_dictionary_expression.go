@@ -80,6 +80,20 @@ func (checker *Checker) VisitDictionaryExpression(expression *ast.DictionaryExpr
 
 			return InvalidType
 		}
+
+		// The entries were checked without an expected type,
+		// so nested literals must be constructed with the inferred key and value types
+		for i, entry := range expression.Entries {
+			entryKeyType := checker.propagateInferredType(entry.Key, keyType)
+			if entryKeyType != nil {
+				entryTypes[i].KeyType = entryKeyType
+			}
+
+			entryValueType := checker.propagateInferredType(entry.Value, valueType)
+			if entryValueType != nil {
+				entryTypes[i].ValueType = entryValueType
+			}
+		}
 	}
 
 	if !IsValidDictionaryKeyType(keyType) {
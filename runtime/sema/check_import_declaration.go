@@ -84,6 +84,20 @@ func (checker *Checker) resolveLocation(identifiers []ast.Identifier, location c
 	return checker.locationHandler(identifiers, location)
 }
 
+func (checker *Checker) importMemberOrigins(imp Import) {
+	elaborationImport, ok := imp.(ElaborationImport)
+	if !ok {
+		return
+	}
+
+	for ty, origins := range elaborationImport.Elaboration.MemberOrigins {
+		if _, ok := checker.memberOrigins[ty]; ok {
+			continue
+		}
+		checker.memberOrigins[ty] = origins
+	}
+}
+
 func (checker *Checker) importResolvedLocation(resolvedLocation ResolvedLocation, locationRange ast.Range) {
 
 	// First, get the Import for the resolved location
@@ -141,6 +155,13 @@ func (checker *Checker) importResolvedLocation(resolvedLocation ResolvedLocation
 		return
 	}
 
+	// Make the origins of the members of imported types available,
+	// so occurrences of imported members can be related to their declarations
+
+	if checker.positionInfoEnabled {
+		checker.importMemberOrigins(imp)
+	}
+
 	// Attempt to import the requested value declarations
 
 	allValueElements := imp.AllValueElements()
@@ -0,0 +1,129 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sema
+
+import "github.com/onflow/cadence/runtime/ast"
+
+// propagateInferredType records the given type, which was inferred for the given expression,
+// as the type of the array and dictionary literals of the expression.
+//
+// The literals are checked before the type of the enclosing expression is inferred,
+// e.g. the least common supertype of the branches of a conditional expression,
+// so they are recorded with the type they have on their own.
+// For example, in `cond ? [1] : ["a"]` the inferred type is `[AnyStruct]`,
+// so the array literals must be constructed as `[AnyStruct]` arrays,
+// instead of as `[Int]` and `[String]` arrays.
+//
+// Returns the new type of the expression, or nil if the type of the expression is unchanged.
+//
+func (checker *Checker) propagateInferredType(expression ast.Expression, inferredType Type) Type {
+
+	// The optionality of the value is not affected,
+	// the value is boxed when it is converted to the inferred type
+
+	inferredType = UnwrapOptionalType(inferredType)
+
+	switch expression := expression.(type) {
+	case *ast.ArrayExpression:
+		inferredArrayType, ok := inferredType.(*VariableSizedType)
+		if !ok {
+			return nil
+		}
+
+		arrayType, ok := checker.Elaboration.ArrayExpressionArrayType[expression].(*VariableSizedType)
+		if !ok || arrayType.Equal(inferredArrayType) {
+			return nil
+		}
+
+		argumentTypes := checker.Elaboration.ArrayExpressionArgumentTypes[expression]
+
+		for i, value := range expression.Values {
+			valueType := checker.propagateInferredType(value, inferredArrayType.Type)
+			if valueType != nil {
+				argumentTypes[i] = valueType
+			}
+		}
+
+		checker.Elaboration.ArrayExpressionArrayType[expression] = inferredArrayType
+
+		return inferredArrayType
+
+	case *ast.DictionaryExpression:
+		inferredDictionaryType, ok := inferredType.(*DictionaryType)
+		if !ok {
+			return nil
+		}
+
+		dictionaryType := checker.Elaboration.DictionaryExpressionType[expression]
+		if dictionaryType == nil || dictionaryType.Equal(inferredDictionaryType) {
+			return nil
+		}
+
+		entryTypes := checker.Elaboration.DictionaryExpressionEntryTypes[expression]
+
+		for i, entry := range expression.Entries {
+			keyType := checker.propagateInferredType(entry.Key, inferredDictionaryType.KeyType)
+			if keyType != nil {
+				entryTypes[i].KeyType = keyType
+			}
+
+			valueType := checker.propagateInferredType(entry.Value, inferredDictionaryType.ValueType)
+			if valueType != nil {
+				entryTypes[i].ValueType = valueType
+			}
+		}
+
+		checker.Elaboration.DictionaryExpressionType[expression] = inferredDictionaryType
+
+		return inferredDictionaryType
+
+	case *ast.ConditionalExpression:
+		// The type of the conditional expression itself is not recorded,
+		// only the literals of the branches are affected
+
+		checker.propagateInferredType(expression.Then, inferredType)
+		checker.propagateInferredType(expression.Else, inferredType)
+
+		return nil
+
+	case *ast.BinaryExpression:
+		if expression.Operation != ast.OperationNilCoalesce {
+			return nil
+		}
+
+		// Only the right-hand side may be a literal,
+		// the left-hand side is an optional
+
+		rightType := checker.propagateInferredType(expression.Right, inferredType)
+		if rightType != nil {
+			checker.Elaboration.BinaryExpressionRightTypes[expression] = rightType
+		}
+
+		return nil
+
+	case *ast.UnaryExpression:
+		if expression.Operation != ast.OperationMove {
+			return nil
+		}
+
+		return checker.propagateInferredType(expression.Expression, inferredType)
+
+	default:
+		return nil
+	}
+}
@@ -123,7 +123,7 @@ func (checker *Checker) VisitInterfaceDeclaration(declaration *ast.InterfaceDecl
 	return nil
 }
 
-// declareInterfaceNestedTypes declares the types nested in an interface.
+// declareInterfaceNestedTypes declares the types and type aliases nested in an interface.
 // It is used when declaring the interface's members (`declareInterfaceMembers`)
 // and checking the interface declaration (`VisitInterfaceDeclaration`).
 //
@@ -158,6 +158,8 @@ func (checker *Checker) declareInterfaceNestedTypes(
 		})
 		checker.report(err)
 	})
+
+	checker.declareNestedTypeAliases(declaration.Members)
 }
 
 func (checker *Checker) checkInterfaceFunctions(
@@ -277,6 +279,28 @@ func (checker *Checker) declareInterfaceType(declaration *ast.InterfaceDeclarati
 	return interfaceType
 }
 
+// resolveInterfaceNestedConformances resolves the conformances of the composite declarations
+// nested in the given interface declaration, e.g. of type requirements,
+// and recursively of all their nested composite declarations.
+//
+// NOTE: This function assumes that the interface type and the type aliases were previously declared
+// using `declareInterfaceType` and `declareInterfaceTypeAliases`.
+//
+func (checker *Checker) resolveInterfaceNestedConformances(declaration *ast.InterfaceDeclaration) {
+
+	interfaceType := checker.Elaboration.InterfaceDeclarationTypes[declaration]
+	if interfaceType == nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	checker.resolveNestedConformances(
+		interfaceType,
+		declaration.Members,
+		checker.Elaboration.InterfaceNestedDeclarations[declaration],
+		declaration.EndPosition,
+	)
+}
+
 // declareInterfaceMembers declares the members for the given interface declaration,
 // and recursively for all nested declarations.
 //
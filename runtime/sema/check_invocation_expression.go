@@ -403,12 +403,18 @@ func (checker *Checker) checkInvocation(
 
 	validTypeArguments := invocationExpression.TypeArguments[:validTypeArgumentCount]
 
+	explicitTypeArguments := NewTypeParameterTypeOrderedMap()
+
 	checker.checkAndBindGenericTypeParameterTypeArguments(
 		validTypeArguments,
 		functionType.TypeParameters,
-		typeArguments,
+		explicitTypeArguments,
 	)
 
+	explicitTypeArguments.Foreach(func(typeParameter *TypeParameter, ty Type) {
+		typeArguments.Set(typeParameter, ty)
+	})
+
 	// Check that the invocation's argument count matches the function's parameter count
 
 	argumentCount := len(invocationExpression.Arguments)
@@ -440,6 +446,7 @@ func (checker *Checker) checkInvocation(
 				functionType,
 				argumentTypes,
 				typeArguments,
+				explicitTypeArguments,
 			)
 	}
 
@@ -480,13 +487,59 @@ func (checker *Checker) checkInvocation(
 
 	// Save types in the elaboration
 
-	checker.Elaboration.InvocationExpressionTypeArguments[invocationExpression] = typeArguments
+	checker.Elaboration.InvocationExpressionTypeArguments[invocationExpression] =
+		functionTypeArguments(functionType, typeArguments)
 	checker.Elaboration.InvocationExpressionParameterTypes[invocationExpression] = parameterTypes
 	checker.Elaboration.InvocationExpressionReturnTypes[invocationExpression] = returnType
 
 	return argumentTypes, returnType
 }
 
+// bindOuterTypeParameters binds the type parameters which were unified,
+// but which are not type parameters of the given function type, to themselves.
+//
+// The types of the parameters of a function may refer to the type parameters
+// of an enclosing generic declaration, e.g. a member function of an array of type `[T]`
+// which is invoked in the generic function which declares `T`.
+// Such type parameters are not inferred by the invocation,
+// so arguments must have the type parameter's type
+//
+func bindOuterTypeParameters(functionType *FunctionType, typeArguments *TypeParameterTypeOrderedMap) {
+	typeArguments.Foreach(func(typeParameter *TypeParameter, _ Type) {
+		for _, functionTypeParameter := range functionType.TypeParameters {
+			if functionTypeParameter == typeParameter {
+				return
+			}
+		}
+
+		typeArguments.Set(
+			typeParameter,
+			&GenericType{
+				TypeParameter: typeParameter,
+			},
+		)
+	})
+}
+
+// functionTypeArguments returns the type arguments for the type parameters of the given function type,
+// in the order of the type parameters
+//
+func functionTypeArguments(
+	functionType *FunctionType,
+	typeArguments *TypeParameterTypeOrderedMap,
+) *TypeParameterTypeOrderedMap {
+
+	result := NewTypeParameterTypeOrderedMap()
+
+	for _, typeParameter := range functionType.TypeParameters {
+		if ty, ok := typeArguments.Get(typeParameter); ok {
+			result.Set(typeParameter, ty)
+		}
+	}
+
+	return result
+}
+
 // checkTypeParameterInference checks that all type parameters
 // of the given generic function type have been assigned a type.
 //
@@ -522,6 +575,7 @@ func (checker *Checker) checkInvocationRequiredArgument(
 	functionType *FunctionType,
 	argumentTypes []Type,
 	typeParameters *TypeParameterTypeOrderedMap,
+	explicitTypeArguments *TypeParameterTypeOrderedMap,
 ) (
 	parameterType Type,
 ) {
@@ -532,9 +586,20 @@ func (checker *Checker) checkInvocationRequiredArgument(
 
 	var argumentType Type
 
+	// If the function doesn't use generic types, then the
+	// param types can be used to infer the types for arguments.
+	// The same applies if the parameter type is fully instantiated
+	// by the explicit type arguments of the invocation, e.g. `firstOrNil<String>([])`
+
+	var instantiatedParameterType Type
 	if len(functionType.TypeParameters) == 0 {
-		// If the function doesn't use generic types, then the
-		// param types can be used to infer the types for arguments.
+		instantiatedParameterType = parameterType
+	} else {
+		instantiatedParameterType = parameterType.Resolve(explicitTypeArguments)
+	}
+
+	if instantiatedParameterType != nil {
+		parameterType = instantiatedParameterType
 		argumentType = checker.VisitExpression(argument.Expression, parameterType)
 	} else {
 		// TODO: pass the expected type to support for parameters
@@ -546,6 +611,7 @@ func (checker *Checker) checkInvocationRequiredArgument(
 		argumentRange := ast.NewRangeFromPositioned(argument.Expression)
 
 		if parameterType.Unify(argumentType, typeParameters, checker.report, argumentRange) {
+			bindOuterTypeParameters(functionType, typeParameters)
 			parameterType = parameterType.Resolve(typeParameters)
 			if parameterType == nil {
 				parameterType = InvalidType
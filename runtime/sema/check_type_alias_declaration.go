@@ -0,0 +1,464 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// VisitTypeAliasDeclaration checks the given type alias declaration.
+//
+// NOTE: This function assumes that the type alias was previously declared and resolved
+// using `declareAndResolveTypeAliases` and exists in `checker.Elaboration.TypeAliasDeclarationTypes`.
+//
+func (checker *Checker) VisitTypeAliasDeclaration(declaration *ast.TypeAliasDeclaration) ast.Repr {
+
+	if _, ok := checker.Elaboration.TypeAliasDeclarationTypes[declaration]; !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	// NOTE: the aliased type and the access modifier
+	// were already checked when the type alias was declared
+
+	return nil
+}
+
+// typeAliasResolver resolves the types aliased by the type aliases of a program.
+//
+// All type aliases, at the top-level and nested in contracts and contract interfaces,
+// are declared before any aliased type is resolved, and the aliased types are resolved on demand.
+// This way type aliases may refer to all type aliases, independent of their declaration order
+// and of the containers they are nested in, and cyclic type aliases can be detected.
+//
+type typeAliasResolver struct {
+	// resolutions are the resolutions of all type aliases, in declaration order
+	resolutions []*typeAliasResolution
+	// variableResolutions are the resolutions of the type aliases, by their variables
+	variableResolutions map[*Variable]*typeAliasResolution
+	// nestedResolutions are the resolutions of the nested type aliases, by their container type and name
+	nestedResolutions map[ContainerType]map[string]*typeAliasResolution
+	// scopes are the type activations of the scopes of the containers
+	scopes []typeAliasScope
+}
+
+type typeAliasScope struct {
+	typeActivations *VariableActivations
+	getEndPosition  func() ast.Position
+}
+
+type typeAliasResolution struct {
+	declaration *ast.TypeAliasDeclaration
+	// variable is the type variable declared for the type alias.
+	// Its type is nil until the aliased type is resolved
+	variable *Variable
+	// typeActivations are the type activations of the scope in which the type alias is declared
+	typeActivations *VariableActivations
+	// containerTypeAliases are the type aliases of the container type, if the type alias is nested
+	containerTypeAliases *StringTypeOrderedMap
+	resolving            bool
+	resolved             bool
+	cyclic               bool
+}
+
+// declareAndResolveTypeAliases declares the type aliases of the given program,
+// at the top-level and nested in composite and interface declarations,
+// and then resolves the aliased types.
+//
+// NOTE: This function assumes that the interface and composite types were previously declared
+// using `declareInterfaceType` and `declareCompositeType`.
+//
+func (checker *Checker) declareAndResolveTypeAliases(program *ast.Program) {
+
+	resolver := &typeAliasResolver{
+		variableResolutions: map[*Variable]*typeAliasResolution{},
+		nestedResolutions:   map[ContainerType]map[string]*typeAliasResolution{},
+	}
+
+	checker.typeAliasResolver = resolver
+	defer func() {
+		checker.typeAliasResolver = nil
+	}()
+
+	// Declare the type aliases
+
+	for _, declaration := range program.TypeAliasDeclarations() {
+		checker.declareTypeAlias(declaration, nil)
+	}
+
+	for _, declaration := range program.InterfaceDeclarations() {
+		checker.declareInterfaceTypeAliases(declaration)
+	}
+
+	for _, declaration := range program.CompositeDeclarations() {
+		checker.declareCompositeTypeAliases(declaration)
+	}
+
+	// Resolve the aliased types, in declaration order.
+	// Type aliases which are referred to by other type aliases are resolved on demand
+
+	for _, resolution := range resolver.resolutions {
+		checker.resolveTypeAlias(resolution)
+	}
+
+	// Record the resolved types of the nested type aliases in their container types,
+	// in declaration order
+
+	for _, resolution := range resolver.resolutions {
+		if resolution.containerTypeAliases == nil {
+			continue
+		}
+
+		resolution.containerTypeAliases.Set(
+			resolution.declaration.Identifier.Identifier,
+			resolution.variable.Type,
+		)
+	}
+
+	for _, scope := range resolver.scopes {
+		scope.typeActivations.Leave(scope.getEndPosition)
+	}
+}
+
+// declareTypeAlias declares the type alias of the given declaration in the current type activation.
+//
+// A type alias is not a new type: the alias name refers to the aliased type itself,
+// so the aliased type and the alias are interchangeable.
+// The aliased type is only resolved later, see `resolveTypeAlias`.
+//
+// If the type alias is nested, the given type aliases of the container type are the ones
+// in which the resolved type is recorded.
+//
+func (checker *Checker) declareTypeAlias(
+	declaration *ast.TypeAliasDeclaration,
+	containerTypeAliases *StringTypeOrderedMap,
+) *typeAliasResolution {
+
+	checker.checkDeclarationAccessModifier(
+		declaration.Access,
+		declaration.DeclarationKind(),
+		declaration.StartPos,
+		true,
+	)
+
+	identifier := declaration.Identifier
+
+	variable, err := checker.typeActivations.DeclareType(typeDeclaration{
+		identifier:               identifier,
+		ty:                       nil,
+		declarationKind:          declaration.DeclarationKind(),
+		access:                   declaration.Access,
+		docString:                declaration.DocString,
+		allowOuterScopeShadowing: false,
+	})
+	checker.report(err)
+
+	if checker.positionInfoEnabled {
+		checker.recordVariableDeclarationOccurrence(
+			identifier.Identifier,
+			variable,
+		)
+	}
+
+	resolution := &typeAliasResolution{
+		declaration:          declaration,
+		variable:             variable,
+		typeActivations:      checker.typeActivations,
+		containerTypeAliases: containerTypeAliases,
+	}
+
+	resolver := checker.typeAliasResolver
+	resolver.resolutions = append(resolver.resolutions, resolution)
+	resolver.variableResolutions[variable] = resolution
+
+	return resolution
+}
+
+// resolveTypeAlias resolves the type aliased by the type alias of the given resolution, if it is not resolved yet,
+// records it in the elaboration, and returns it.
+//
+// The aliased type is resolved in the scope in which the type alias is declared,
+// which is not necessarily the current scope, e.g. when a type alias refers to
+// a type alias declared after it, or to a type alias nested in a contract.
+//
+// If the type alias refers to itself, directly or through other type aliases, an error is reported
+// and the invalid type is returned.
+//
+func (checker *Checker) resolveTypeAlias(resolution *typeAliasResolution) Type {
+
+	if resolution.resolved {
+		return resolution.variable.Type
+	}
+
+	declaration := resolution.declaration
+
+	if resolution.resolving {
+		if !resolution.cyclic {
+			resolution.cyclic = true
+
+			checker.report(
+				&CyclicTypeAliasError{
+					Name:  declaration.Identifier.Identifier,
+					Range: ast.NewRangeFromPositioned(declaration.Identifier),
+				},
+			)
+		}
+
+		return InvalidType
+	}
+
+	resolution.resolving = true
+
+	typeActivations := checker.typeActivations
+	checker.typeActivations = resolution.typeActivations
+
+	ty := checker.ConvertType(declaration.Type)
+
+	checker.typeActivations = typeActivations
+
+	resolution.resolving = false
+	resolution.resolved = true
+
+	variable := resolution.variable
+	variable.Type = ty
+
+	checker.Elaboration.TypeAliasDeclarationTypes[declaration] = ty
+
+	// The origin of the type alias may have been recorded before the aliased type was resolved
+
+	if origin, ok := checker.variableOrigins[variable]; ok {
+		origin.Type = ty
+	}
+
+	return ty
+}
+
+// resolvedTypeAliasVariableType returns the type of the given type alias variable.
+// If the aliased type is not resolved yet, it is resolved
+//
+func (checker *Checker) resolvedTypeAliasVariableType(variable *Variable) Type {
+	if variable.Type != nil || checker.typeAliasResolver == nil {
+		return variable.Type
+	}
+
+	resolution, ok := checker.typeAliasResolver.variableResolutions[variable]
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return checker.resolveTypeAlias(resolution)
+}
+
+// getNestedTypeOrTypeAlias returns the type nested in the given container type with the given name,
+// or if there is none, the type aliased by the nested type alias with the given name, if any.
+// If the aliased type is not resolved yet, it is resolved.
+// It also returns whether the returned type is the type aliased by a type alias
+//
+func (checker *Checker) getNestedTypeOrTypeAlias(containerType ContainerType, name string) (ty Type, isTypeAlias bool) {
+	ty, isTypeAlias = getNestedTypeOrTypeAlias(containerType, name)
+	if ty != nil || checker.typeAliasResolver == nil {
+		return
+	}
+
+	resolution, ok := checker.typeAliasResolver.nestedResolutions[containerType][name]
+	if !ok {
+		return nil, false
+	}
+
+	return checker.resolveTypeAlias(resolution), true
+}
+
+// declareCompositeTypeAliases declares the type aliases nested in the given
+// composite declaration, and recursively the ones nested in its nested declarations.
+//
+// NOTE: This function assumes that the composite type was previously declared using
+// `declareCompositeType` and exists in `checker.Elaboration.CompositeDeclarationTypes`.
+//
+func (checker *Checker) declareCompositeTypeAliases(declaration *ast.CompositeDeclaration) {
+
+	compositeType := checker.Elaboration.CompositeDeclarationTypes[declaration]
+	if compositeType == nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	compositeType.TypeAliases = NewStringTypeOrderedMap()
+
+	checker.declareContainerTypeAliases(
+		compositeType,
+		compositeType.TypeAliases,
+		declaration.CompositeKind,
+		declaration.DeclarationKind(),
+		declaration.Members,
+		checker.Elaboration.CompositeNestedDeclarations[declaration],
+		declaration.EndPosition,
+	)
+}
+
+// declareInterfaceTypeAliases declares the type aliases nested in the given
+// interface declaration, and recursively the ones nested in its nested declarations.
+//
+// NOTE: This function assumes that the interface type was previously declared using
+// `declareInterfaceType` and exists in `checker.Elaboration.InterfaceDeclarationTypes`.
+//
+func (checker *Checker) declareInterfaceTypeAliases(declaration *ast.InterfaceDeclaration) {
+
+	interfaceType := checker.Elaboration.InterfaceDeclarationTypes[declaration]
+	if interfaceType == nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	interfaceType.TypeAliases = NewStringTypeOrderedMap()
+
+	checker.declareContainerTypeAliases(
+		interfaceType,
+		interfaceType.TypeAliases,
+		declaration.CompositeKind,
+		declaration.DeclarationKind(),
+		declaration.Members,
+		checker.Elaboration.InterfaceNestedDeclarations[declaration],
+		declaration.EndPosition,
+	)
+}
+
+// declareContainerTypeAliases declares the type aliases nested in a composite or interface declaration
+// with the given members, in a new scope in which the container's nested types are declared.
+// The resolved types are later recorded in the given type aliases of the container type.
+//
+// The scope is kept until the aliased types are resolved, see `declareAndResolveTypeAliases`.
+//
+func (checker *Checker) declareContainerTypeAliases(
+	containerType ContainerType,
+	typeAliases *StringTypeOrderedMap,
+	containerCompositeKind common.CompositeKind,
+	containerDeclarationKind common.DeclarationKind,
+	members *ast.Members,
+	nestedDeclarations map[string]ast.Declaration,
+	getEndPosition func() ast.Position,
+) {
+	resolver := checker.typeAliasResolver
+
+	// Activate a new scope for the nested types and type aliases
+
+	typeActivations := checker.typeActivations
+	checker.typeActivations = typeActivations.branch()
+	defer func() {
+		checker.typeActivations = typeActivations
+	}()
+
+	resolver.scopes = append(
+		resolver.scopes,
+		typeAliasScope{
+			typeActivations: checker.typeActivations,
+			getEndPosition:  getEndPosition,
+		},
+	)
+
+	// Declare the nested types, so the type aliases can refer to them
+
+	checker.redeclareNestedTypes(containerType, nestedDeclarations)
+
+	// Declare the type aliases.
+	// Only contracts and contract interfaces support nested type aliases
+
+	typeAliasDeclarations := members.TypeAliases()
+
+	if len(typeAliasDeclarations) > 0 {
+		resolutions := map[string]*typeAliasResolution{}
+		resolver.nestedResolutions[containerType] = resolutions
+
+		for _, declaration := range typeAliasDeclarations {
+
+			if containerCompositeKind != common.CompositeKindContract {
+				checker.report(
+					&InvalidNestedDeclarationError{
+						NestedDeclarationKind:    declaration.DeclarationKind(),
+						ContainerDeclarationKind: containerDeclarationKind,
+						Range:                    ast.NewRangeFromPositioned(declaration.Identifier),
+					},
+				)
+			}
+
+			resolutions[declaration.Identifier.Identifier] =
+				checker.declareTypeAlias(declaration, typeAliases)
+		}
+	}
+
+	// Declare the type aliases of the nested declarations,
+	// which may refer to the type aliases of this container
+
+	for _, nestedDeclaration := range members.Interfaces() {
+		checker.declareInterfaceTypeAliases(nestedDeclaration)
+	}
+
+	for _, nestedDeclaration := range members.Composites() {
+		checker.declareCompositeTypeAliases(nestedDeclaration)
+	}
+}
+
+// redeclareNestedTypes declares the previously declared nested types of the given container type
+// in the current type activation, so they can be referred to when resolving types in the container.
+//
+// NOTE: Errors are ignored, they were already reported
+// when the nested types were declared
+//
+func (checker *Checker) redeclareNestedTypes(
+	containerType ContainerType,
+	nestedDeclarations map[string]ast.Declaration,
+) {
+	containerType.GetNestedTypes().Foreach(func(name string, nestedType Type) {
+		nestedDeclaration := nestedDeclarations[name]
+
+		_, _ = checker.typeActivations.DeclareType(typeDeclaration{
+			identifier:               *nestedDeclaration.DeclarationIdentifier(),
+			ty:                       nestedType,
+			declarationKind:          nestedDeclaration.DeclarationKind(),
+			access:                   nestedDeclaration.DeclarationAccess(),
+			docString:                nestedDeclaration.DeclarationDocString(),
+			allowOuterScopeShadowing: true,
+		})
+	})
+}
+
+// declareNestedTypeAliases declares the previously resolved type aliases
+// nested in a composite or interface declaration in the current type activation.
+//
+// It is used when declaring the container's members and checking the container's declaration,
+// and assumes the type aliases were previously resolved using `declareAndResolveTypeAliases`.
+//
+func (checker *Checker) declareNestedTypeAliases(members *ast.Members) {
+	for _, declaration := range members.TypeAliases() {
+
+		ty, ok := checker.Elaboration.TypeAliasDeclarationTypes[declaration]
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		// NOTE: We allow the shadowing of types here, because the type alias was already previously
+		// declared without allowing shadowing before. This avoids a duplicate error message.
+
+		_, _ = checker.typeActivations.DeclareType(typeDeclaration{
+			identifier:               declaration.Identifier,
+			ty:                       ty,
+			declarationKind:          declaration.DeclarationKind(),
+			access:                   declaration.Access,
+			docString:                declaration.DocString,
+			allowOuterScopeShadowing: true,
+		})
+	}
+}
@@ -43,8 +43,12 @@ func (checker *Checker) visitVariableDeclaration(declaration *ast.VariableDeclar
 	var declarationType Type
 	var expectedValueType Type
 
+	// The declaration type as written in the program, if it refers to a type alias
+	var typeAlias string
+
 	if declaration.TypeAnnotation != nil {
-		typeAnnotation := checker.ConvertTypeAnnotation(declaration.TypeAnnotation)
+		var typeAnnotation *TypeAnnotation
+		typeAnnotation, typeAlias = checker.convertTypeAnnotationWithAlias(declaration.TypeAnnotation)
 		checker.checkTypeAnnotation(typeAnnotation, declaration.TypeAnnotation)
 		declarationType = typeAnnotation.Type
 
@@ -59,7 +63,12 @@ func (checker *Checker) visitVariableDeclaration(declaration *ast.VariableDeclar
 		}
 	}
 
-	valueType := checker.VisitExpression(declaration.Value, expectedValueType)
+	var valueType Type
+	if isOptionalBinding {
+		valueType = checker.VisitExpression(declaration.Value, expectedValueType)
+	} else {
+		valueType, _ = checker.visitExpressionWithForceType(declaration.Value, expectedValueType, typeAlias, true)
+	}
 
 	checker.Elaboration.VariableDeclarationValueTypes[declaration] = valueType
 
@@ -188,6 +197,10 @@ func (checker *Checker) visitVariableDeclaration(declaration *ast.VariableDeclar
 	})
 	checker.report(err)
 
+	if variable != nil {
+		variable.TypeAlias = typeAlias
+	}
+
 	if checker.positionInfoEnabled {
 		checker.recordVariableDeclarationOccurrence(identifier, variable)
 		checker.recordVariableDeclarationRange(declaration, identifier, declarationType)
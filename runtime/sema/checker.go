@@ -116,6 +116,10 @@ type Checker struct {
 	expectedType                       Type
 	memberAccountAccessHandler         MemberAccountAccessHandlerFunc
 	lintEnabled                        bool
+	conformancesPending                bool
+	deferredRestrictedTypeChecks       []func()
+	typeAliasReferenceCount            int
+	typeAliasResolver                  *typeAliasResolver
 }
 
 type Option func(*Checker) error
@@ -225,6 +229,7 @@ func WithPositionInfoEnabled(enabled bool) Option {
 		checker.positionInfoEnabled = enabled
 		if enabled {
 			checker.memberOrigins = map[Type]map[string]*Origin{}
+			checker.Elaboration.MemberOrigins = checker.memberOrigins
 			checker.variableOrigins = map[*Variable]*Origin{}
 			checker.Occurrences = NewOccurrences()
 			checker.MemberAccesses = NewMemberAccesses()
@@ -445,7 +450,12 @@ func (checker *Checker) VisitProgram(program *ast.Program) ast.Repr {
 		checker.declareImportDeclaration(declaration)
 	}
 
-	// Declare interface and composite types
+	// Declare interface and composite types.
+	//
+	// The conformances of the composite types are only resolved
+	// after the type aliases are declared, see below
+
+	checker.conformancesPending = true
 
 	registerInElaboration := func(ty Type) {
 		switch typedType := ty.(type) {
@@ -476,6 +486,33 @@ func (checker *Checker) VisitProgram(program *ast.Program) ast.Repr {
 		VisitThisAndNested(compositeType, registerInElaboration)
 	}
 
+	// Declare type aliases and resolve the aliased types.
+	// Type aliases may refer to all interface and composite types,
+	// and to all type aliases, independent of their order and nesting
+
+	checker.declareAndResolveTypeAliases(program)
+
+	// Resolve the conformances of the composite types and the raw types of enums.
+	// The conformances may refer to type aliases.
+	//
+	// Afterwards, check the restricted types which were deferred
+	// until the conformances of the composite types are known
+
+	for _, declaration := range program.InterfaceDeclarations() {
+		checker.resolveInterfaceNestedConformances(declaration)
+	}
+
+	for _, declaration := range program.CompositeDeclarations() {
+		checker.resolveCompositeConformances(declaration)
+	}
+
+	checker.conformancesPending = false
+
+	for _, check := range checker.deferredRestrictedTypeChecks {
+		check()
+	}
+	checker.deferredRestrictedTypeChecks = nil
+
 	// Declare interfaces' and composites' members
 
 	for _, declaration := range program.InterfaceDeclarations() {
@@ -561,7 +598,7 @@ func (checker *Checker) checkTopLevelDeclarationValidity(declarations []ast.Decl
 }
 
 func (checker *Checker) declareGlobalFunctionDeclaration(declaration *ast.FunctionDeclaration) {
-	functionType := checker.functionType(declaration.ParameterList, declaration.ReturnTypeAnnotation)
+	functionType := checker.functionDeclarationType(declaration)
 	checker.Elaboration.FunctionDeclarationFunctionTypes[declaration] = functionType
 	checker.declareFunctionDeclaration(declaration, functionType)
 }
@@ -903,7 +940,8 @@ func (checker *Checker) findAndCheckValueVariable(identifierExpression *ast.Iden
 func (checker *Checker) ConvertType(t ast.Type) Type {
 	switch t := t.(type) {
 	case *ast.NominalType:
-		return checker.convertNominalType(t)
+		ty := checker.convertNominalType(t)
+		return checker.checkGenericCompositeTypeInstantiated(ty, t)
 
 	case *ast.VariableSizedType:
 		return checker.convertVariableSizedType(t)
@@ -1149,13 +1187,33 @@ func (checker *Checker) convertRestrictedType(t *ast.RestrictedType) Type {
 		restrictions = append(restrictions, restrictionInterfaceType)
 	}
 
-	restrictedType = CheckRestrictedType(
-		restrictedType,
-		restrictions,
-		func(getError func(*ast.RestrictedType) error) {
-			checker.report(getError(t))
-		},
-	)
+	checkRestrictedType := func() Type {
+		return CheckRestrictedType(
+			restrictedType,
+			restrictions,
+			func(getError func(*ast.RestrictedType) error) {
+				checker.report(getError(t))
+			},
+		)
+	}
+
+	// The restrictions of a composite type must be its conformances.
+	// If the conformances of the composite types are not resolved yet,
+	// e.g. when the restricted type is declared in a type alias,
+	// defer the check until they are.
+	//
+	// NOTE: CheckRestrictedType returns a given composite type as-is
+
+	if _, ok := restrictedType.(*CompositeType); ok && checker.conformancesPending {
+		checker.deferredRestrictedTypeChecks = append(
+			checker.deferredRestrictedTypeChecks,
+			func() {
+				_ = checkRestrictedType()
+			},
+		)
+	} else {
+		restrictedType = checkRestrictedType()
+	}
 
 	return &RestrictedType{
 		Type:         restrictedType,
@@ -1307,12 +1365,17 @@ func (checker *Checker) convertNominalType(t *ast.NominalType) Type {
 	}
 
 	ty := variable.Type
+	isTypeAlias := variable.DeclarationKind == common.DeclarationKindTypeAlias
+
+	if isTypeAlias {
+		ty = checker.resolvedTypeAliasVariableType(variable)
+	}
 
 	var resolvedIdentifiers []ast.Identifier
 
 	for _, identifier := range t.NestedIdentifiers {
 		if containerType, ok := ty.(ContainerType); ok && containerType.IsContainerType() {
-			ty, _ = containerType.GetNestedTypes().Get(identifier.Identifier)
+			ty, isTypeAlias = checker.getNestedTypeOrTypeAlias(containerType, identifier.Identifier)
 		} else {
 			if !ty.IsInvalidType() {
 				checker.report(
@@ -1346,6 +1409,10 @@ func (checker *Checker) convertNominalType(t *ast.NominalType) Type {
 		}
 	}
 
+	if isTypeAlias {
+		checker.typeAliasReferenceCount++
+	}
+
 	return ty
 }
 
@@ -1362,6 +1429,25 @@ func (checker *Checker) ConvertTypeAnnotation(typeAnnotation *ast.TypeAnnotation
 	}
 }
 
+// convertTypeAnnotationWithAlias converts an AST type annotation representation
+// to a sema type annotation, like ConvertTypeAnnotation.
+//
+// If the type refers to a type alias, the type as written in the program is also returned,
+// e.g. `Quantity` or `[Quantity]`, so the type can be displayed using the alias.
+// Otherwise, the returned string is empty
+//
+func (checker *Checker) convertTypeAnnotationWithAlias(typeAnnotation *ast.TypeAnnotation) (*TypeAnnotation, string) {
+	typeAliasReferenceCount := checker.typeAliasReferenceCount
+
+	convertedTypeAnnotation := checker.ConvertTypeAnnotation(typeAnnotation)
+
+	if checker.typeAliasReferenceCount == typeAliasReferenceCount {
+		return convertedTypeAnnotation, ""
+	}
+
+	return convertedTypeAnnotation, typeAnnotation.Type.String()
+}
+
 func (checker *Checker) functionType(
 	parameterList *ast.ParameterList,
 	returnTypeAnnotation *ast.TypeAnnotation,
@@ -1400,6 +1486,122 @@ func (checker *Checker) parameters(parameterList *ast.ParameterList) []*Paramete
 	return parameters
 }
 
+// functionDeclarationType returns the function type of the given function declaration.
+//
+// The type parameters of a generic function are only declared
+// while converting the parameter types and the return type.
+//
+func (checker *Checker) functionDeclarationType(declaration *ast.FunctionDeclaration) *FunctionType {
+
+	if declaration.TypeParameterList.IsEmpty() {
+		return checker.functionType(declaration.ParameterList, declaration.ReturnTypeAnnotation)
+	}
+
+	checker.typeActivations.Enter()
+	defer checker.typeActivations.Leave(declaration.EndPosition)
+
+	typeParameters := checker.typeParameters(declaration.TypeParameterList)
+	checker.declareTypeParameters(declaration.TypeParameterList, typeParameters)
+
+	functionType := checker.functionType(declaration.ParameterList, declaration.ReturnTypeAnnotation)
+	functionType.TypeParameters = typeParameters
+
+	return functionType
+}
+
+// typeParameters converts the given type parameter list to type parameters.
+//
+// Type parameters without an explicit type bound are bound by `AnyStruct`,
+// i.e. type parameters which may be instantiated with resource types
+// must be declared with a resource type bound, e.g. `T: AnyResource`.
+//
+func (checker *Checker) typeParameters(typeParameterList *ast.TypeParameterList) []*TypeParameter {
+
+	if typeParameterList.IsEmpty() {
+		return nil
+	}
+
+	typeParameters := make([]*TypeParameter, len(typeParameterList.TypeParameters))
+
+	positions := make(map[string]ast.Position, len(typeParameterList.TypeParameters))
+
+	for i, typeParameter := range typeParameterList.TypeParameters {
+
+		name := typeParameter.Identifier.Identifier
+
+		if previousPos, ok := positions[name]; ok {
+			checker.report(
+				&RedeclarationError{
+					Kind:        common.DeclarationKindTypeParameter,
+					Name:        name,
+					Pos:         typeParameter.Identifier.Pos,
+					PreviousPos: &previousPos,
+				},
+			)
+		} else {
+			positions[name] = typeParameter.Identifier.Pos
+		}
+
+		var typeBound Type = AnyStructType
+
+		if typeParameter.TypeBound != nil {
+			typeBoundAnnotation := checker.ConvertTypeAnnotation(typeParameter.TypeBound)
+
+			// NOTE: the resource annotation is optional for type bounds,
+			// e.g. both `T: AnyResource` and `T: @AnyResource` are valid
+
+			if typeBoundAnnotation.TypeAnnotationState() == TypeAnnotationStateInvalidResourceAnnotation {
+				checker.report(
+					&InvalidResourceAnnotationError{
+						Range: ast.NewRangeFromPositioned(typeParameter.TypeBound),
+					},
+				)
+			}
+
+			checker.checkInvalidInterfaceAsType(typeBoundAnnotation.Type, typeParameter.TypeBound)
+
+			typeBound = typeBoundAnnotation.Type
+		}
+
+		typeParameters[i] = &TypeParameter{
+			Name:      name,
+			TypeBound: typeBound,
+		}
+	}
+
+	return typeParameters
+}
+
+// declareTypeParameters declares the given type parameters,
+// previously converted from the given type parameter list using `typeParameters`,
+// as generic types in the current type activation.
+//
+// Type parameters may be declared multiple times, e.g. for the function type and the function block,
+// so redeclarations are not reported here, but once when converting, see `typeParameters`.
+//
+func (checker *Checker) declareTypeParameters(
+	typeParameterList *ast.TypeParameterList,
+	typeParameters []*TypeParameter,
+) {
+	if typeParameterList.IsEmpty() {
+		return
+	}
+
+	for i, typeParameter := range typeParameters {
+		identifier := typeParameterList.TypeParameters[i].Identifier
+
+		_, _ = checker.typeActivations.DeclareType(typeDeclaration{
+			identifier: identifier,
+			ty: &GenericType{
+				TypeParameter: typeParameter,
+			},
+			declarationKind:          common.DeclarationKindTypeParameter,
+			access:                   ast.AccessNotSpecified,
+			allowOuterScopeShadowing: true,
+		})
+	}
+}
+
 func (checker *Checker) recordVariableReferenceOccurrence(startPos, endPos ast.Position, variable *Variable) {
 	if !checker.positionInfoEnabled {
 		return
@@ -1419,6 +1621,8 @@ func (checker *Checker) recordVariableReferenceOccurrence(startPos, endPos ast.P
 			StartPos:        startPos2,
 			EndPos:          endPos2,
 			DocString:       variable.DocString,
+			Location:        checker.Location,
+			TypeAlias:       variable.TypeAlias,
 		}
 		checker.variableOrigins[variable] = origin
 	}
@@ -1452,6 +1656,7 @@ func (checker *Checker) recordFieldDeclarationOrigin(
 		StartPos:        &startPosition,
 		EndPos:          &endPosition,
 		DocString:       docString,
+		Location:        checker.Location,
 	}
 
 	checker.Occurrences.Put(
@@ -1480,6 +1685,7 @@ func (checker *Checker) recordFunctionDeclarationOrigin(
 		StartPos:        &startPosition,
 		EndPos:          &endPosition,
 		DocString:       function.DocString,
+		Location:        checker.Location,
 	}
 
 	checker.Occurrences.Put(
@@ -2279,9 +2485,37 @@ func (checker *Checker) effectiveCompositeMemberAccess(access ast.Access) ast.Ac
 	}
 }
 
+// checkGenericCompositeTypeInstantiated reports an error if the given type
+// is a generic composite type which is used without type arguments
+//
+func (checker *Checker) checkGenericCompositeTypeInstantiated(ty Type, pos ast.HasPosition) Type {
+	compositeType, ok := ty.(*CompositeType)
+	if !ok || len(compositeType.typeParameters) == 0 {
+		return ty
+	}
+
+	checker.report(
+		&MissingTypeArgumentsError{
+			Type:               compositeType,
+			TypeParameterCount: len(compositeType.typeParameters),
+			Range:              ast.NewRangeFromPositioned(pos),
+		},
+	)
+
+	return InvalidType
+}
+
 func (checker *Checker) convertInstantiationType(t *ast.InstantiationType) Type {
 
-	ty := checker.ConvertType(t.Type)
+	// NOTE: convert nominal types directly,
+	// as generic composite types may only be used when they are instantiated
+
+	var ty Type
+	if nominalType, ok := t.Type.(*ast.NominalType); ok {
+		ty = checker.convertNominalType(nominalType)
+	} else {
+		ty = checker.ConvertType(t.Type)
+	}
 
 	// Always convert (check) the type arguments,
 	// even if the instantiated type
@@ -2296,7 +2530,7 @@ func (checker *Checker) convertInstantiationType(t *ast.InstantiationType) Type
 	}
 
 	parameterizedType, ok := ty.(ParameterizedType)
-	if !ok {
+	if !ok || parameterizedType.TypeParameters() == nil {
 
 		// The type is not parameterized,
 		// report an error for all type arguments
@@ -2372,11 +2606,11 @@ func (checker *Checker) VisitExpression(expr ast.Expression, expectedType Type)
 }
 
 func (checker *Checker) visitExpression(expr ast.Expression, expectedType Type) (visibleType Type, actualType Type) {
-	return checker.visitExpressionWithForceType(expr, expectedType, true)
+	return checker.visitExpressionWithForceType(expr, expectedType, "", true)
 }
 
 func (checker *Checker) VisitExpressionWithForceType(expr ast.Expression, expectedType Type, forceType bool) Type {
-	actualType, _ := checker.visitExpressionWithForceType(expr, expectedType, forceType)
+	actualType, _ := checker.visitExpressionWithForceType(expr, expectedType, "", forceType)
 	return actualType
 }
 
@@ -2384,9 +2618,11 @@ func (checker *Checker) VisitExpressionWithForceType(expr ast.Expression, expect
 //
 // Parameters:
 // expr         - Expression to check
-// expectedType - Contextually expected type of the expression
-// forceType    - Specifies whether to use the expected type as a hard requirement (forceType = true)
-//                or whether to use the expected type for type inferring only (forceType = false)
+// expectedType      - Contextually expected type of the expression
+// expectedTypeAlias - The expected type as written in the program, if it refers to a type alias,
+//                     used to report a type mismatch
+// forceType         - Specifies whether to use the expected type as a hard requirement (forceType = true)
+//                     or whether to use the expected type for type inferring only (forceType = false)
 //
 // Return types:
 // visibleType - The type that others should 'see' as the type of this expression. This could be
@@ -2396,6 +2632,7 @@ func (checker *Checker) VisitExpressionWithForceType(expr ast.Expression, expect
 func (checker *Checker) visitExpressionWithForceType(
 	expr ast.Expression,
 	expectedType Type,
+	expectedTypeAlias string,
 	forceType bool,
 ) (visibleType Type, actualType Type) {
 
@@ -2423,10 +2660,11 @@ func (checker *Checker) visitExpressionWithForceType(
 
 		checker.report(
 			&TypeMismatchError{
-				ExpectedType: expectedType,
-				ActualType:   actualType,
-				Expression:   expr,
-				Range:        expressionRange(expr),
+				ExpectedType:      expectedType,
+				ActualType:        actualType,
+				Expression:        expr,
+				Range:             expressionRange(expr),
+				ExpectedTypeAlias: expectedTypeAlias,
 			},
 		)
 
@@ -84,6 +84,11 @@ type Elaboration struct {
 	EffectivePredeclaredTypes           map[string]TypeDeclaration
 	isChecking                          bool
 	ReferenceExpressionBorrowTypes      map[*ast.ReferenceExpression]Type
+	TypeAliasDeclarationTypes           map[*ast.TypeAliasDeclaration]Type
+	// MemberOrigins are the origins of the members of the declared types,
+	// including the ones of imported types.
+	// Only recorded if position info is enabled, nil otherwise
+	MemberOrigins map[Type]map[string]*Origin
 }
 
 func NewElaboration() *Elaboration {
@@ -137,6 +142,7 @@ func NewElaboration() *Elaboration {
 		EffectivePredeclaredValues:          map[string]ValueDeclaration{},
 		EffectivePredeclaredTypes:           map[string]TypeDeclaration{},
 		ReferenceExpressionBorrowTypes:      map[*ast.ReferenceExpression]Type{},
+		TypeAliasDeclarationTypes:           map[*ast.TypeAliasDeclaration]Type{},
 	}
 }
 
@@ -224,6 +224,9 @@ type TypeMismatchError struct {
 	ActualType   Type
 	Expression   ast.Expression
 	ast.Range
+	// ExpectedTypeAlias is the expected type as written in the program,
+	// if it refers to a type alias, e.g. `Quantity`
+	ExpectedTypeAlias string
 }
 
 func (e *TypeMismatchError) Error() string {
@@ -233,6 +236,15 @@ func (e *TypeMismatchError) Error() string {
 func (*TypeMismatchError) isSemanticError() {}
 
 func (e *TypeMismatchError) SecondaryError() string {
+	if e.ExpectedTypeAlias != "" {
+		return fmt.Sprintf(
+			"expected `%s` (`%s`), got `%s`",
+			e.ExpectedTypeAlias,
+			e.ExpectedType.QualifiedString(),
+			e.ActualType.QualifiedString(),
+		)
+	}
+
 	return fmt.Sprintf(
 		"expected `%s`, got `%s`",
 		e.ExpectedType.QualifiedString(),
@@ -2866,6 +2878,46 @@ func (e *UnparameterizedTypeInstantiationError) SecondaryError() string {
 	)
 }
 
+// InvalidTypeParametersError
+
+type InvalidTypeParametersError struct {
+	DeclarationKind common.DeclarationKind
+	ast.Range
+}
+
+func (e *InvalidTypeParametersError) Error() string {
+	return fmt.Sprintf(
+		"%s declarations cannot have type parameters",
+		e.DeclarationKind.Name(),
+	)
+}
+
+func (*InvalidTypeParametersError) isSemanticError() {}
+
+// MissingTypeArgumentsError
+
+type MissingTypeArgumentsError struct {
+	Type               Type
+	TypeParameterCount int
+	ast.Range
+}
+
+func (e *MissingTypeArgumentsError) Error() string {
+	return fmt.Sprintf(
+		"missing type arguments for generic type `%s`",
+		e.Type.QualifiedString(),
+	)
+}
+
+func (e *MissingTypeArgumentsError) SecondaryError() string {
+	return fmt.Sprintf(
+		"expected %d type arguments",
+		e.TypeParameterCount,
+	)
+}
+
+func (*MissingTypeArgumentsError) isSemanticError() {}
+
 // TypeAnnotationRequiredError
 
 type TypeAnnotationRequiredError struct {
@@ -2906,6 +2958,19 @@ func (e *CyclicImportsError) Error() string {
 
 func (*CyclicImportsError) isSemanticError() {}
 
+// CyclicTypeAliasError
+
+type CyclicTypeAliasError struct {
+	Name string
+	ast.Range
+}
+
+func (e *CyclicTypeAliasError) Error() string {
+	return fmt.Sprintf("type alias `%s` refers to itself", e.Name)
+}
+
+func (*CyclicTypeAliasError) isSemanticError() {}
+
 // SwitchDefaultPositionError
 
 type SwitchDefaultPositionError struct {
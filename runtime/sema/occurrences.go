@@ -68,6 +68,11 @@ type Origin struct {
 	EndPos          *ast.Position
 	Occurrences     []ast.Range
 	DocString       string
+	// Location is the location of the program which contains the declaration
+	Location common.Location
+	// TypeAlias is the type as written in the program, if it refers to a type alias,
+	// e.g. `Quantity`. It is only used to display the type
+	TypeAlias string
 }
 
 type Occurrences struct {
@@ -32,6 +32,8 @@ const PublicAccountGetCapabilityField = "getCapability"
 const PublicAccountGetTargetLinkField = "getLinkTarget"
 const PublicAccountKeysField = "keys"
 const PublicAccountContractsField = "contracts"
+const PublicAccountPublicPathsField = "publicPaths"
+const PublicAccountForEachPublicField = "forEachPublic"
 
 // PublicAccountType represents the publicly accessible portion of an account.
 //
@@ -106,6 +108,18 @@ var PublicAccountType = func() *CompositeType {
 			PublicAccountContractsType,
 			accountTypeContractsFieldDocString,
 		),
+		NewPublicConstantFieldMember(
+			publicAccountType,
+			PublicAccountPublicPathsField,
+			AccountTypePublicPathsFieldType,
+			accountTypePublicPathsFieldDocString,
+		),
+		NewPublicFunctionMember(
+			publicAccountType,
+			PublicAccountForEachPublicField,
+			AccountTypeForEachPublicFunctionType,
+			accountTypeForEachPublicFunctionDocString,
+		),
 	}
 
 	publicAccountType.Members = GetMembersAsMap(members)
@@ -28,6 +28,17 @@ const StringTypeEncodeHexFunctionDocString = `
 Returns a hexadecimal string for the given byte array
 `
 
+const StringTypeJoinFunctionName = "join"
+const StringTypeJoinFunctionDocString = `
+Returns a string which contains the given strings, with the given separator between each of them
+`
+
+const StringTypeFromUTF8FunctionName = "fromUTF8"
+const StringTypeFromUTF8FunctionDocString = `
+Returns the string which is encoded by the given byte array using UTF-8,
+or nil if the byte array is not valid UTF-8
+`
+
 // StringType represents the string type
 //
 var StringType = &SimpleType{
@@ -120,6 +131,72 @@ func init() {
 					)
 				},
 			},
+			"toUpper": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeToUpperFunctionType,
+						stringTypeToUpperFunctionDocString,
+					)
+				},
+			},
+			"trim": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeTrimFunctionType,
+						stringTypeTrimFunctionDocString,
+					)
+				},
+			},
+			"split": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeSplitFunctionType,
+						stringTypeSplitFunctionDocString,
+					)
+				},
+			},
+			"contains": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeContainsFunctionType,
+						stringTypeContainsFunctionDocString,
+					)
+				},
+			},
+			"index": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeIndexFunctionType,
+						stringTypeIndexFunctionDocString,
+					)
+				},
+			},
+			"replaceAll": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeReplaceAllFunctionType,
+						stringTypeReplaceAllFunctionDocString,
+					)
+				},
+			},
 		}
 	}
 }
@@ -201,3 +278,106 @@ var StringTypeToLowerFunctionType = &FunctionType{
 const stringTypeToLowerFunctionDocString = `
 Returns the string with upper case letters replaced with lowercase
 `
+
+var StringTypeToUpperFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(StringType),
+}
+
+const stringTypeToUpperFunctionDocString = `
+Returns the string with lower case letters replaced with uppercase
+`
+
+var StringTypeTrimFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(StringType),
+}
+
+const stringTypeTrimFunctionDocString = `
+Returns the string with the leading and trailing whitespace characters removed
+`
+
+// StringArrayType represents the type [String]
+var StringArrayType = &VariableSizedType{
+	Type: StringType,
+}
+
+var StringTypeSplitFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     "separator",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringArrayType,
+	),
+}
+
+const stringTypeSplitFunctionDocString = `
+Returns an array containing the substrings of the string which are separated by the given separator.
+
+Only occurrences of the separator which start and end at character boundaries separate the string.
+If the separator is empty, the string is split into its characters
+`
+
+var StringTypeContainsFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "other",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		BoolType,
+	),
+}
+
+const stringTypeContainsFunctionDocString = `
+Returns true if the string contains the given string.
+
+Only occurrences of the given string which start and end at character boundaries are considered
+`
+
+var StringTypeIndexFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     "of",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: IntType,
+		},
+	),
+}
+
+const stringTypeIndexFunctionDocString = `
+Returns the index of the character at which the first occurrence of the given string starts, or nil if the string does not contain the given string.
+
+Only occurrences of the given string which start and end at character boundaries are considered
+`
+
+var StringTypeReplaceAllFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Identifier:     "of",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+		{
+			Identifier:     "with",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringType,
+	),
+}
+
+const stringTypeReplaceAllFunctionDocString = `
+Returns a new string in which all occurrences of the string ` + "`of`" + ` are replaced with the string ` + "`with`" + `.
+
+Only occurrences which start and end at character boundaries are replaced.
+If ` + "`of`" + ` is empty, the string is returned unchanged.
+It does not modify the original string
+`
@@ -203,6 +203,32 @@ type ContainerType interface {
 	GetNestedTypes() *StringTypeOrderedMap
 }
 
+// getNestedTypeOrTypeAlias returns the type nested in the given container type with the given name,
+// or if there is none, the type aliased by the nested type alias with the given name, if any.
+// It also returns whether the returned type is the type aliased by a type alias
+//
+func getNestedTypeOrTypeAlias(containerType ContainerType, name string) (ty Type, isTypeAlias bool) {
+	ty, ok := containerType.GetNestedTypes().Get(name)
+	if ok {
+		return ty, false
+	}
+
+	var typeAliases *StringTypeOrderedMap
+
+	switch containerType := containerType.(type) {
+	case *CompositeType:
+		typeAliases = containerType.TypeAliases
+	case *InterfaceType:
+		typeAliases = containerType.TypeAliases
+	}
+
+	if typeAliases == nil {
+		return nil, false
+	}
+
+	return typeAliases.Get(name)
+}
+
 func VisitThisAndNested(t Type, visit func(ty Type)) {
 	visit(t)
 
@@ -660,27 +686,36 @@ func (t *GenericType) Equal(other Type) bool {
 	if !ok {
 		return false
 	}
-	return t.TypeParameter == otherType.TypeParameter
+
+	// NOTE: type parameters of different declarations are equal if they have the same name and bound,
+	// so that e.g. a generic function of a composite can implement a generic function of an interface
+
+	return t.TypeParameter == otherType.TypeParameter ||
+		t.TypeParameter.Equal(otherType.TypeParameter)
 }
 
-func (*GenericType) IsResourceType() bool {
-	return false
+func (t *GenericType) IsResourceType() bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsResourceType()
 }
 
 func (*GenericType) IsInvalidType() bool {
 	return false
 }
 
-func (*GenericType) IsStorable(_ map[*Member]bool) bool {
-	return false
+func (t *GenericType) IsStorable(results map[*Member]bool) bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsStorable(results)
 }
 
-func (*GenericType) IsExternallyReturnable(_ map[*Member]bool) bool {
-	return false
+func (t *GenericType) IsExternallyReturnable(results map[*Member]bool) bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsExternallyReturnable(results)
 }
 
-func (t *GenericType) IsImportable(_ map[*Member]bool) bool {
-	return false
+func (t *GenericType) IsImportable(results map[*Member]bool) bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsImportable(results)
 }
 
 func (*GenericType) IsEquatable() bool {
@@ -745,6 +780,11 @@ func (t *GenericType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
 }
 
 func (t *GenericType) GetMembers() map[string]MemberResolver {
+	// The members of the type bound are available
+	typeBound := t.TypeParameter.TypeBound
+	if typeBound != nil {
+		return typeBound.GetMembers()
+	}
 	return withBuiltinMembers(t, nil)
 }
 
@@ -1602,6 +1642,49 @@ It does not modify the original array.
 If either of the parameters are out of the bounds of the array, or the indices are invalid (` + "`from > upTo`" + `), then the function will fail.
 `
 
+const arrayTypeMapFunctionDocString = `
+Returns a new array containing the results of calling the given function with each element of the array, in order.
+
+The result is a fixed-size array of the same size if the array is fixed-size, and a variable-sized array otherwise.
+It does not modify the original array.
+Available if the array element type is not resource-kinded.
+`
+
+const arrayTypeFilterFunctionDocString = `
+Returns a new variable-sized array containing only the elements of the array for which the given function returns true, in order.
+
+It does not modify the original array.
+Available if the array element type is not resource-kinded.
+`
+
+const arrayTypeReduceFunctionDocString = `
+Returns the result of combining the elements of the array using the given function, starting with the given initial value.
+
+The function is called with the accumulated value and each element of the array, in order.
+Available if the array element type is not resource-kinded.
+`
+
+const arrayTypeReverseFunctionDocString = `
+Returns a new array with the same type, containing the elements of the array in reverse order.
+
+It does not modify the original array.
+Available if the array element type is not resource-kinded.
+`
+
+const arrayTypeForEachFunctionDocString = `
+Calls the given function with each element of the array, in order.
+
+Available if the array element type is not resource-kinded.
+`
+
+const arrayTypeSortFunctionDocString = `
+Sorts the array in place, using the given function as the comparison between elements.
+
+The function must return true if its first argument should be ordered before its second argument.
+The sort is stable: elements which are ordered equally keep their relative order.
+Available if the array element type is not resource-kinded.
+`
+
 func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 
 	members := map[string]MemberResolver{
@@ -1692,6 +1775,154 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 				)
 			},
 		},
+		"map": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				// It is invalid for an array of resources to have a `map` function:
+				// the elements cannot be passed to the function without being moved out of the array
+
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           targetRange,
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayMapFunctionType(arrayType),
+					arrayTypeMapFunctionDocString,
+				)
+			},
+		},
+		"filter": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           targetRange,
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayFilterFunctionType(elementType),
+					arrayTypeFilterFunctionDocString,
+				)
+			},
+		},
+		"reduce": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           targetRange,
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayReduceFunctionType(elementType),
+					arrayTypeReduceFunctionDocString,
+				)
+			},
+		},
+		"reverse": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           targetRange,
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayReverseFunctionType(arrayType),
+					arrayTypeReverseFunctionDocString,
+				)
+			},
+		},
+		"forEach": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           targetRange,
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayForEachFunctionType(elementType),
+					arrayTypeForEachFunctionDocString,
+				)
+			},
+		},
+		"sort": {
+			Kind:     common.DeclarationKindFunction,
+			Mutating: true,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				elementType := arrayType.ElementType(false)
+
+				if elementType.IsResourceType() {
+					report(
+						&InvalidResourceArrayMemberError{
+							Name:            identifier,
+							DeclarationKind: common.DeclarationKindFunction,
+							Range:           targetRange,
+						},
+					)
+				}
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArraySortFunctionType(elementType),
+					arrayTypeSortFunctionDocString,
+				)
+			},
+		},
 	}
 
 	// TODO: maybe still return members but report a helpful error?
@@ -1950,26 +2181,201 @@ func ArrayContainsFunctionType(elementType Type) *FunctionType {
 	}
 }
 
-func ArrayAppendAllFunctionType(arrayType Type) *FunctionType {
+func ArrayAppendAllFunctionType(arrayType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "other",
+				TypeAnnotation: NewTypeAnnotation(arrayType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
+	}
+}
+
+func ArrayAppendFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "element",
+				TypeAnnotation: NewTypeAnnotation(elementType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			VoidType,
+		),
+	}
+}
+
+func ArraySliceFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Identifier:     "from",
+				TypeAnnotation: NewTypeAnnotation(IntType),
+			},
+			{
+				Identifier:     "upTo",
+				TypeAnnotation: NewTypeAnnotation(IntType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(&VariableSizedType{
+			Type: elementType,
+		}),
+	}
+}
+
+func ArrayMapFunctionType(arrayType ArrayType) *FunctionType {
+	typeParameter := &TypeParameter{
+		Name: "T",
+	}
+
+	resultElementType := &GenericType{
+		TypeParameter: typeParameter,
+	}
+
+	var resultType Type
+	if constantSizedType, ok := arrayType.(*ConstantSizedType); ok {
+		resultType = &ConstantSizedType{
+			Type: resultElementType,
+			Size: constantSizedType.Size,
+		}
+	} else {
+		resultType = &VariableSizedType{
+			Type: resultElementType,
+		}
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "transform",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "element",
+								TypeAnnotation: NewTypeAnnotation(arrayType.ElementType(false)),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(
+							resultElementType,
+						),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(resultType),
+	}
+}
+
+func ArrayFilterFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "isIncluded",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "element",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(
+							BoolType,
+						),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(&VariableSizedType{
+			Type: elementType,
+		}),
+	}
+}
+
+func ArrayReduceFunctionType(elementType Type) *FunctionType {
+	typeParameter := &TypeParameter{
+		Name: "T",
+	}
+
+	resultType := &GenericType{
+		TypeParameter: typeParameter,
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Identifier:     "initial",
+				TypeAnnotation: NewTypeAnnotation(resultType),
+			},
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "combine",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "accumulator",
+								TypeAnnotation: NewTypeAnnotation(resultType),
+							},
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "element",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(
+							resultType,
+						),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(resultType),
+	}
+}
+
+func ArrayReverseFunctionType(arrayType ArrayType) *FunctionType {
 	return &FunctionType{
-		Parameters: []*Parameter{
-			{
-				Label:          ArgumentLabelNotRequired,
-				Identifier:     "other",
-				TypeAnnotation: NewTypeAnnotation(arrayType),
-			},
-		},
-		ReturnTypeAnnotation: NewTypeAnnotation(VoidType),
+		ReturnTypeAnnotation: NewTypeAnnotation(arrayType),
 	}
 }
 
-func ArrayAppendFunctionType(elementType Type) *FunctionType {
+func ArrayForEachFunctionType(elementType Type) *FunctionType {
 	return &FunctionType{
 		Parameters: []*Parameter{
 			{
-				Label:          ArgumentLabelNotRequired,
-				Identifier:     "element",
-				TypeAnnotation: NewTypeAnnotation(elementType),
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "body",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "element",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(
+							VoidType,
+						),
+					},
+				),
 			},
 		},
 		ReturnTypeAnnotation: NewTypeAnnotation(
@@ -1978,21 +2384,36 @@ func ArrayAppendFunctionType(elementType Type) *FunctionType {
 	}
 }
 
-func ArraySliceFunctionType(elementType Type) *FunctionType {
+func ArraySortFunctionType(elementType Type) *FunctionType {
 	return &FunctionType{
 		Parameters: []*Parameter{
 			{
-				Identifier:     "from",
-				TypeAnnotation: NewTypeAnnotation(IntType),
-			},
-			{
-				Identifier:     "upTo",
-				TypeAnnotation: NewTypeAnnotation(IntType),
+				Label:      "by",
+				Identifier: "areInIncreasingOrder",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "a",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "b",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(
+							BoolType,
+						),
+					},
+				),
 			},
 		},
-		ReturnTypeAnnotation: NewTypeAnnotation(&VariableSizedType{
-			Type: elementType,
-		}),
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			VoidType,
+		),
 	}
 }
 
@@ -2831,7 +3252,24 @@ func (t *FunctionType) Unify(
 
 func (t *FunctionType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
 
-	// TODO: type parameters ?
+	// The function's own type parameters are not resolved,
+	// e.g. when resolving the type of a generic function of a generic composite type
+
+	if len(t.TypeParameters) > 0 {
+		functionTypeArguments := NewTypeParameterTypeOrderedMap()
+		typeArguments.Foreach(func(typeParameter *TypeParameter, ty Type) {
+			functionTypeArguments.Set(typeParameter, ty)
+		})
+		for _, typeParameter := range t.TypeParameters {
+			functionTypeArguments.Set(
+				typeParameter,
+				&GenericType{
+					TypeParameter: typeParameter,
+				},
+			)
+		}
+		typeArguments = functionTypeArguments
+	}
 
 	// parameters
 
@@ -2860,6 +3298,7 @@ func (t *FunctionType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type
 	}
 
 	return &FunctionType{
+		TypeParameters:        t.TypeParameters,
 		Parameters:            newParameters,
 		ReturnTypeAnnotation:  NewTypeAnnotation(newReturnType),
 		RequiredArgumentCount: t.RequiredArgumentCount,
@@ -3281,6 +3720,20 @@ func init() {
 		StringTypeEncodeHexFunctionDocString,
 	))
 
+	addMember(NewPublicFunctionMember(
+		functionType,
+		StringTypeJoinFunctionName,
+		StringTypeJoinFunctionType,
+		StringTypeJoinFunctionDocString,
+	))
+
+	addMember(NewPublicFunctionMember(
+		functionType,
+		StringTypeFromUTF8FunctionName,
+		StringTypeFromUTF8FunctionType,
+		StringTypeFromUTF8FunctionDocString,
+	))
+
 	BaseValueActivation.Set(
 		typeName,
 		baseFunctionVariable(
@@ -3306,6 +3759,44 @@ var StringTypeEncodeHexFunctionType = &FunctionType{
 	),
 }
 
+var StringTypeJoinFunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:      ArgumentLabelNotRequired,
+			Identifier: "strings",
+			TypeAnnotation: NewTypeAnnotation(
+				StringArrayType,
+			),
+		},
+		{
+			Identifier: "separator",
+			TypeAnnotation: NewTypeAnnotation(
+				StringType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringType,
+	),
+}
+
+var StringTypeFromUTF8FunctionType = &FunctionType{
+	Parameters: []*Parameter{
+		{
+			Label:      ArgumentLabelNotRequired,
+			Identifier: "bytes",
+			TypeAnnotation: NewTypeAnnotation(
+				ByteArrayType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: StringType,
+		},
+	),
+}
+
 func suggestIntegerLiteralConversionReplacement(
 	checker *Checker,
 	argument *ast.IntegerExpression,
@@ -3521,9 +4012,11 @@ type CompositeType struct {
 	// TODO: add support for overloaded initializers
 	ConstructorParameters []*Parameter
 	nestedTypes           *StringTypeOrderedMap
-	containerType         Type
-	EnumRawType           Type
-	hasComputedMembers    bool
+	// TypeAliases are the resolved types of the nested type aliases
+	TypeAliases        *StringTypeOrderedMap
+	containerType      Type
+	EnumRawType        Type
+	hasComputedMembers bool
 
 	// Only applicable for native composite types.
 	importable bool
@@ -3533,7 +4026,25 @@ type CompositeType struct {
 		QualifiedIdentifier string
 	}
 	cachedIdentifiersLock sync.RWMutex
-}
+
+	// typeParameters are the type parameters of a generic composite type
+	typeParameters []*TypeParameter
+	// genericType is the generic composite type
+	// of which this composite type is an instantiation
+	genericType *CompositeType
+	// typeArguments are the type arguments of an instantiated generic composite type
+	typeArguments []Type
+	// membersDeclared is true if the members of a generic composite type were declared,
+	// i.e. the members of its instantiations can be declared
+	membersDeclared     bool
+	membersDeclaredLock sync.RWMutex
+	// instantiatedMembersInitialized is true if the members of an instantiated generic composite type
+	// were declared, see `InitializeInstantiatedMembers`
+	instantiatedMembersInitialized bool
+	instantiatedMembersLock        sync.Mutex
+}
+
+var _ ParameterizedType = &CompositeType{}
 
 func (t *CompositeType) Tag() TypeTag {
 	return CompositeTypeTag
@@ -3564,11 +4075,31 @@ func (t *CompositeType) addImplicitTypeRequirementConformance(typeRequirement *C
 func (*CompositeType) IsType() {}
 
 func (t *CompositeType) String() string {
-	return t.Identifier
+	return t.Identifier + formatTypeArguments(t.typeArguments, ", ", Type.String)
 }
 
 func (t *CompositeType) QualifiedString() string {
-	return t.QualifiedIdentifier()
+	return t.QualifiedIdentifier() + formatTypeArguments(t.typeArguments, ", ", Type.QualifiedString)
+}
+
+// formatTypeArguments formats the given type arguments, e.g. `<Int, String>`,
+// or returns an empty string if there are no type arguments
+//
+func formatTypeArguments(typeArguments []Type, separator string, typeFormatter func(Type) string) string {
+	if len(typeArguments) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteRune('<')
+	for i, typeArgument := range typeArguments {
+		if i > 0 {
+			builder.WriteString(separator)
+		}
+		builder.WriteString(typeFormatter(typeArgument))
+	}
+	builder.WriteRune('>')
+	return builder.String()
 }
 
 func (t *CompositeType) GetContainerType() Type {
@@ -3637,6 +4168,13 @@ func (t *CompositeType) initializeIdentifiers() {
 		typeID = t.Location.TypeID(identifier)
 	}
 
+	// The type ID of an instantiated generic composite type includes the type arguments,
+	// e.g. `S.test.Pair<Int,String>`
+
+	if len(t.typeArguments) > 0 {
+		typeID = TypeID(string(typeID) + formatTypeArguments(t.typeArguments, ",", typeArgumentTypeID))
+	}
+
 	t.cachedIdentifiers = &struct {
 		TypeID              TypeID
 		QualifiedIdentifier string
@@ -3693,6 +4231,8 @@ func (t *CompositeType) IsStorable(results map[*Member]bool) bool {
 	// If this composite type has a member which is non-storable,
 	// then the composite type is not storable.
 
+	t.InitializeInstantiatedMembers()
+
 	for pair := t.Members.Oldest(); pair != nil; pair = pair.Next() {
 		if !pair.Value.IsStorable(results) {
 			return false
@@ -3721,6 +4261,8 @@ func (t *CompositeType) IsImportable(results map[*Member]bool) bool {
 	// If this composite type has a member which is not importable,
 	// then the composite type is not importable.
 
+	t.InitializeInstantiatedMembers()
+
 	for pair := t.Members.Oldest(); pair != nil; pair = pair.Next() {
 		if !pair.Value.IsImportable(results) {
 			return false
@@ -3745,6 +4287,8 @@ func (t *CompositeType) IsExternallyReturnable(results map[*Member]bool) bool {
 	// If this composite type has a member which is not externally returnable,
 	// then the composite type is not externally returnable.
 
+	t.InitializeInstantiatedMembers()
+
 	for p := t.Members.Oldest(); p != nil; p = p.Next() {
 		if !p.Value.IsExternallyReturnable(results) {
 			return false
@@ -3768,6 +4312,8 @@ func (t *CompositeType) RewriteWithRestrictedTypes() (result Type, rewritten boo
 }
 
 func (t *CompositeType) InterfaceType() *InterfaceType {
+	t.InitializeInstantiatedMembers()
+
 	return &InterfaceType{
 		Location:              t.Location,
 		Identifier:            t.Identifier,
@@ -3777,6 +4323,7 @@ func (t *CompositeType) InterfaceType() *InterfaceType {
 		InitializerParameters: t.ConstructorParameters,
 		containerType:         t.containerType,
 		nestedTypes:           t.nestedTypes,
+		TypeAliases:           t.TypeAliases,
 	}
 }
 
@@ -3803,13 +4350,236 @@ func (t *CompositeType) TypeRequirements() []*CompositeType {
 	return typeRequirements
 }
 
-func (*CompositeType) Unify(_ Type, _ *TypeParameterTypeOrderedMap, _ func(err error), _ ast.Range) bool {
-	// TODO:
-	return false
+func (t *CompositeType) Unify(
+	other Type,
+	typeParameters *TypeParameterTypeOrderedMap,
+	report func(err error),
+	outerRange ast.Range,
+) (
+	result bool,
+) {
+	// Only instantiations of the same generic composite type can be unified
+
+	if t.genericType == nil {
+		return false
+	}
+
+	otherComposite, ok := other.(*CompositeType)
+	if !ok || otherComposite.genericType != t.genericType {
+		return false
+	}
+
+	for i, typeArgument := range t.typeArguments {
+		typeArgumentUnified := typeArgument.Unify(
+			otherComposite.typeArguments[i],
+			typeParameters,
+			report,
+			outerRange,
+		)
+		result = result || typeArgumentUnified
+	}
+
+	return
 }
 
-func (t *CompositeType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
-	return t
+func (t *CompositeType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
+
+	switch {
+	case len(t.typeParameters) > 0:
+		// The generic composite type itself, e.g. the type of `self` in its functions,
+		// is only resolved if all its type parameters are bound
+
+		resolvedTypeArguments := make([]Type, len(t.typeParameters))
+		for i, typeParameter := range t.typeParameters {
+			ty, ok := typeArguments.Get(typeParameter)
+			if !ok || ty == nil {
+				return t
+			}
+			resolvedTypeArguments[i] = ty
+		}
+
+		return t.Instantiate(resolvedTypeArguments, nil)
+
+	case t.genericType != nil:
+		resolvedTypeArguments := make([]Type, len(t.typeArguments))
+		for i, typeArgument := range t.typeArguments {
+			resolvedTypeArgument := typeArgument.Resolve(typeArguments)
+			if resolvedTypeArgument == nil {
+				return nil
+			}
+			resolvedTypeArguments[i] = resolvedTypeArgument
+		}
+
+		return t.genericType.Instantiate(resolvedTypeArguments, nil)
+
+	default:
+		return t
+	}
+}
+
+// TypeParameters returns the type parameters of a generic composite type.
+// Instantiated generic composite types and non-generic composite types have no type parameters.
+//
+func (t *CompositeType) TypeParameters() []*TypeParameter {
+	return t.typeParameters
+}
+
+// SetTypeParameters sets the type parameters of a generic composite type.
+//
+func (t *CompositeType) SetTypeParameters(typeParameters []*TypeParameter) {
+	t.typeParameters = typeParameters
+}
+
+// Instantiate instantiates the generic composite type with the given type arguments.
+//
+// The members of the instantiated type are the members of the generic type,
+// with the type parameters substituted with the type arguments.
+//
+func (t *CompositeType) Instantiate(typeArguments []Type, _ func(err error)) Type {
+
+	// Instantiating the generic composite type with its own type parameters,
+	// e.g. `Pair<A, B>` in the declaration of `Pair`, results in the generic type itself
+
+	if t.isInstantiatedWithOwnTypeParameters(typeArguments) {
+		return t
+	}
+
+	return &CompositeType{
+		Location:                            t.Location,
+		Identifier:                          t.Identifier,
+		Kind:                                t.Kind,
+		ExplicitInterfaceConformances:       t.ExplicitInterfaceConformances,
+		ImplicitTypeRequirementConformances: t.ImplicitTypeRequirementConformances,
+		Members:                             NewStringMemberOrderedMap(),
+		nestedTypes:                         t.nestedTypes,
+		TypeAliases:                         t.TypeAliases,
+		containerType:                       t.containerType,
+		genericType:                         t,
+		typeArguments:                       typeArguments,
+	}
+}
+
+func (t *CompositeType) isInstantiatedWithOwnTypeParameters(typeArguments []Type) bool {
+	if len(typeArguments) != len(t.typeParameters) {
+		return false
+	}
+
+	for i, typeArgument := range typeArguments {
+		genericType, ok := typeArgument.(*GenericType)
+		if !ok || genericType.TypeParameter != t.typeParameters[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// setMembersDeclared must be called once the members of a generic composite type are declared,
+// so that the members of its instantiations can be declared
+//
+func (t *CompositeType) setMembersDeclared() {
+	t.membersDeclaredLock.Lock()
+	defer t.membersDeclaredLock.Unlock()
+
+	t.membersDeclared = true
+}
+
+func (t *CompositeType) isMembersDeclared() bool {
+	t.membersDeclaredLock.RLock()
+	defer t.membersDeclaredLock.RUnlock()
+
+	return t.membersDeclared
+}
+
+// InitializeInstantiatedMembers declares the members, fields, and constructor parameters
+// of an instantiated generic composite type, by substituting the type parameters
+// of the generic composite type with the type arguments.
+//
+// The members are declared lazily, as they may refer to further instantiations,
+// e.g. a function of `Pair<A, B>` may return a `Pair<B, A>`.
+// The function has no effect for composite types which are not instantiations,
+// and it must be called before accessing the members or fields directly.
+//
+func (t *CompositeType) InitializeInstantiatedMembers() {
+	genericType := t.genericType
+	if genericType == nil {
+		return
+	}
+
+	t.instantiatedMembersLock.Lock()
+	defer t.instantiatedMembersLock.Unlock()
+
+	// The members can only be declared once the members of the generic type are declared
+
+	if t.instantiatedMembersInitialized ||
+		!genericType.isMembersDeclared() {
+
+		return
+	}
+
+	t.instantiatedMembersInitialized = true
+
+	typeArguments := NewTypeParameterTypeOrderedMap()
+	for i, typeParameter := range genericType.typeParameters {
+		typeArguments.Set(typeParameter, t.typeArguments[i])
+	}
+
+	substitute := func(ty Type) Type {
+		resolvedType := ty.Resolve(typeArguments)
+		if resolvedType == nil {
+			return ty
+		}
+		return resolvedType
+	}
+
+	genericType.Members.Foreach(func(name string, member *Member) {
+		instantiatedMember := *member
+		instantiatedMember.ContainerType = t
+		instantiatedMember.TypeAnnotation = &TypeAnnotation{
+			IsResource: member.TypeAnnotation.IsResource,
+			Type:       substitute(member.TypeAnnotation.Type),
+		}
+		t.Members.Set(name, &instantiatedMember)
+	})
+
+	t.Fields = genericType.Fields
+
+	t.ConstructorParameters = make([]*Parameter, len(genericType.ConstructorParameters))
+	for i, parameter := range genericType.ConstructorParameters {
+		t.ConstructorParameters[i] = &Parameter{
+			Label:      parameter.Label,
+			Identifier: parameter.Identifier,
+			TypeAnnotation: &TypeAnnotation{
+				IsResource: parameter.TypeAnnotation.IsResource,
+				Type:       substitute(parameter.TypeAnnotation.Type),
+			},
+		}
+	}
+}
+
+// BaseType returns the generic composite type of an instantiated generic composite type,
+// or nil if the composite type is not an instantiation
+//
+func (t *CompositeType) BaseType() Type {
+	if t.genericType == nil {
+		return nil
+	}
+	return t.genericType
+}
+
+func (t *CompositeType) TypeArguments() []Type {
+	return t.typeArguments
+}
+
+// GenericType returns the generic composite type of an instantiated generic composite type,
+// or nil if the composite type is not an instantiation
+//
+func (t *CompositeType) GenericType() *CompositeType {
+	return t.genericType
+}
+
+func typeArgumentTypeID(ty Type) string {
+	return string(ty.ID())
 }
 
 func (t *CompositeType) IsContainerType() bool {
@@ -3821,6 +4591,8 @@ func (t *CompositeType) GetNestedTypes() *StringTypeOrderedMap {
 }
 
 func (t *CompositeType) initializeMemberResolvers() {
+	t.InitializeInstantiatedMembers()
+
 	t.memberResolversOnce.Do(func() {
 		members := make(map[string]MemberResolver, t.Members.Len())
 
@@ -4009,7 +4781,9 @@ type InterfaceType struct {
 	InitializerParameters []*Parameter
 	containerType         Type
 	nestedTypes           *StringTypeOrderedMap
-	cachedIdentifiers     *struct {
+	// TypeAliases are the resolved types of the nested type aliases
+	TypeAliases       *StringTypeOrderedMap
+	cachedIdentifiers *struct {
 		TypeID              TypeID
 		QualifiedIdentifier string
 	}
@@ -4718,14 +5492,30 @@ func (t *ReferenceType) IndexingType() Type {
 	return referencedType.IndexingType()
 }
 
-func (*ReferenceType) Unify(_ Type, _ *TypeParameterTypeOrderedMap, _ func(err error), _ ast.Range) bool {
-	// TODO:
-	return false
+func (t *ReferenceType) Unify(
+	other Type,
+	typeParameters *TypeParameterTypeOrderedMap,
+	report func(err error),
+	outerRange ast.Range,
+) bool {
+	otherReference, ok := other.(*ReferenceType)
+	if !ok || otherReference.Authorized != t.Authorized {
+		return false
+	}
+
+	return t.Type.Unify(otherReference.Type, typeParameters, report, outerRange)
 }
 
-func (t *ReferenceType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
-	// TODO:
-	return t
+func (t *ReferenceType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
+	newInnerType := t.Type.Resolve(typeArguments)
+	if newInnerType == nil {
+		return nil
+	}
+
+	return &ReferenceType{
+		Authorized: t.Authorized,
+		Type:       newInnerType,
+	}
 }
 
 const AddressTypeName = "Address"
@@ -4914,6 +5704,19 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 		return true
 	}
 
+	// A type parameter `T` with a type bound `B`
+	// is a subtype of a type `U`: if `B <: U`.
+	//
+	// Otherwise, e.g. when `U` is an optional type,
+	// the type parameter is treated like any other type below.
+
+	if genericSubType, ok := subType.(*GenericType); ok {
+		typeBound := genericSubType.TypeParameter.TypeBound
+		if typeBound != nil && IsSubType(typeBound, superType) {
+			return true
+		}
+	}
+
 	switch superType {
 	case AnyType:
 		return true
@@ -5419,6 +6222,12 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 					return true
 				}
 			}
+
+			// Instantiations of generic composite types are invariant in their type arguments,
+			// i.e. they are only subtypes if they are equal, which was already checked.
+			// In particular, an instantiation is not a subtype of the generic composite type
+
+			return false
 		}
 
 	case *InterfaceType:
@@ -393,7 +393,6 @@ func LeastCommonSuperType(types ...Type) Type {
 }
 
 var notNeverType = NeverTypeTag.Not()
-var notNilType = NilTypeTag.Not()
 
 func findCommonSuperType(joinedTypeTag TypeTag, types ...Type) Type {
 	var superType Type
@@ -418,20 +417,9 @@ func findCommonSuperType(joinedTypeTag TypeTag, types ...Type) Type {
 	}
 
 	// Optional types.
-	if joinedTypeTag.ContainsAny(NilTypeTag) {
-		// Get the type without the optional flag
-		innerTypeTag := joinedTypeTag.And(notNilType)
-		superType := findCommonSuperType(innerTypeTag, types...)
-
-		// If the common supertype of the rest of types contain nil,
-		// then do not wrap with optional again.
-		if superType.Tag().ContainsAny(NilTypeTag) {
-			return superType
-		}
-
-		return &OptionalType{
-			Type: superType,
-		}
+	// NOTE: The tag of some non-optional types also contains the nil type tag, e.g. 'AnyStruct'
+	if joinedTypeTag.ContainsAny(NilTypeTag) && containsOptionalType(types) {
+		return commonSuperTypeOfOptionals(types)
 	}
 
 	// NOTE: Below order is important!
@@ -617,9 +605,55 @@ func findSuperTypeFromUpperMask(joinedTypeTag TypeTag, types []Type) Type {
 
 	// All derived types goes here.
 	case capabilityTypeMask,
-		restrictedTypeMask,
 		transactionTypeMask:
+
+		// Not homogenous if there are also types of the lower mask,
+		// e.g. optionals. Return nil and continue on advanced checks.
+		if joinedTypeTag.lowerMask != noTypeMask {
+			return nil
+		}
+
 		return getSuperTypeOfDerivedTypes(types)
+
+	case restrictedTypeMask:
+		switch joinedTypeTag.lowerMask {
+		case noTypeMask:
+			// We reach here if all are restricted types.
+			// If they are all the same, then that's the common supertype.
+			// Otherwise, decide the common supertype based on the restrictions.
+
+			var prevType Type
+			for _, typ := range types {
+				// Ignore 'Never' type as it doesn't affect the supertype.
+				if typ == NeverType {
+					continue
+				}
+
+				if prevType == nil {
+					prevType = typ
+					continue
+				}
+
+				if !typ.Equal(prevType) {
+					return commonSuperTypeOfComposites(types)
+				}
+			}
+
+			if prevType == nil {
+				return InvalidType
+			}
+
+			return prevType
+
+		case compositeTypeMask:
+			// Composite types and restricted types may have interfaces in common,
+			// so decide the common supertype based on the conformances and the restrictions.
+			return commonSuperTypeOfComposites(types)
+
+		default:
+			return nil
+		}
+
 	default:
 		return nil
 	}
@@ -772,6 +806,50 @@ func commonSuperTypeOfDictionaries(types []Type) Type {
 	}
 }
 
+func commonSuperTypeOfOptionals(types []Type) Type {
+	// We reach here if at least one of the types is optional.
+	// Therefore, remove one level of optionality from the optional types,
+	// decide the common supertype of the inner types, and make it optional.
+
+	innerTypes := make([]Type, len(types))
+	join := NoTypeTag
+
+	for i, typ := range types {
+		if optionalType, ok := typ.(*OptionalType); ok {
+			typ = optionalType.Type
+		}
+
+		innerTypes[i] = typ
+		join = join.Or(typ.Tag())
+	}
+
+	innerSuperType := findCommonSuperType(join, innerTypes...)
+
+	switch innerSuperType {
+	case AnyType, InvalidType:
+		// There is no common supertype of the inner types,
+		// so there is also none of the optional types.
+		return innerSuperType
+
+	case AnyStructType:
+		// 'AnyStruct' is already a supertype of all optional types
+		return innerSuperType
+	}
+
+	return &OptionalType{
+		Type: innerSuperType,
+	}
+}
+
+func containsOptionalType(types []Type) bool {
+	for _, typ := range types {
+		if _, ok := typ.(*OptionalType); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func commonSuperTypeOfHeterogeneousTypes(types []Type) Type {
 	var hasStructs, hasResources bool
 	for _, typ := range types {
@@ -822,15 +900,27 @@ func commonSuperTypeOfComposites(types []Type) Type {
 			break
 		}
 
-		compositeType, ok := typ.(*CompositeType)
-		// this function is only called when all types are composites, so this cannot fail
-		if !ok {
+		// The interfaces of a composite type are its conformances,
+		// and the interfaces of a restricted type are its restrictions.
+
+		var interfaceTypes []*InterfaceType
+
+		switch typ := typ.(type) {
+		case *CompositeType:
+			interfaceTypes = typ.ExplicitInterfaceConformances
+
+		case *RestrictedType:
+			interfaceTypes = typ.Restrictions
+
+		default:
+			// this function is only called when all types are composites or restricted types,
+			// so this cannot fail
 			panic(errors.NewUnreachableError())
 		}
 
 		// NOTE: index 0 may not always be the first type, since there can be 'Never' types.
 		if firstType {
-			for _, interfaceType := range compositeType.ExplicitInterfaceConformances {
+			for _, interfaceType := range interfaceTypes {
 				commonInterfaces[interfaceType.QualifiedIdentifier()] = true
 				commonInterfacesList = append(commonInterfacesList, interfaceType)
 			}
@@ -839,7 +929,7 @@ func commonSuperTypeOfComposites(types []Type) Type {
 			intersection := map[string]bool{}
 			commonInterfacesList = make([]*InterfaceType, 0)
 
-			for _, interfaceType := range compositeType.ExplicitInterfaceConformances {
+			for _, interfaceType := range interfaceTypes {
 				if _, ok := commonInterfaces[interfaceType.QualifiedIdentifier()]; ok {
 					intersection[interfaceType.QualifiedIdentifier()] = true
 					commonInterfacesList = append(commonInterfacesList, interfaceType)
@@ -1401,6 +1401,31 @@ func TestCommonSuperType(t *testing.T) {
 			Restrictions: []*InterfaceType{interfaceType1},
 		}
 
+		interfaceType2 := &InterfaceType{
+			Location:      testLocation,
+			Identifier:    "I2",
+			CompositeKind: common.CompositeKindStructure,
+			Members:       NewStringMemberOrderedMap(),
+		}
+
+		compositeType := &CompositeType{
+			Location:                      testLocation,
+			Identifier:                    "S",
+			Kind:                          common.CompositeKindStructure,
+			ExplicitInterfaceConformances: []*InterfaceType{interfaceType1, interfaceType2},
+			Members:                       NewStringMemberOrderedMap(),
+		}
+
+		restrictedType3 := &RestrictedType{
+			Type:         compositeType,
+			Restrictions: []*InterfaceType{interfaceType1, interfaceType2},
+		}
+
+		restrictedType4 := &RestrictedType{
+			Type:         AnyStructType,
+			Restrictions: []*InterfaceType{interfaceType2},
+		}
+
 		tests := []testCase{
 			{
 				name: "homogenous",
@@ -1418,6 +1443,48 @@ func TestCommonSuperType(t *testing.T) {
 				},
 				expectedSuperType: InvalidType,
 			},
+			{
+				name: "common restrictions",
+				types: []Type{
+					restrictedType1,
+					restrictedType3,
+				},
+				expectedSuperType: &RestrictedType{
+					Type:         AnyStructType,
+					Restrictions: []*InterfaceType{interfaceType1},
+				},
+			},
+			{
+				name: "no common restrictions",
+				types: []Type{
+					restrictedType1,
+					restrictedType4,
+				},
+				expectedSuperType: AnyStructType,
+			},
+			{
+				name: "restricted and composite",
+				types: []Type{
+					restrictedType4,
+					compositeType,
+				},
+				expectedSuperType: &RestrictedType{
+					Type:         AnyStructType,
+					Restrictions: []*InterfaceType{interfaceType2},
+				},
+			},
+			{
+				name: "restricted and nil",
+				types: []Type{
+					restrictedType1,
+					&OptionalType{
+						Type: NeverType,
+					},
+				},
+				expectedSuperType: &OptionalType{
+					Type: restrictedType1,
+				},
+			},
 		}
 
 		testLeastCommonSuperType(t, tests)
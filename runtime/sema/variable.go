@@ -43,4 +43,7 @@ type Variable struct {
 	Pos *ast.Position
 	// DocString is the optional docstring
 	DocString string
+	// TypeAlias is the type of the variable as written in the program, if it refers to a type alias,
+	// e.g. `Quantity`. It is only used to display the type, which is the aliased type
+	TypeAlias string
 }
@@ -186,6 +186,21 @@ func (a *VariableActivations) Enter() {
 	a.pushNewWithParent(a.Current())
 }
 
+// branch returns a new activation stack with the activations of this stack
+// and a new empty activation on top, which has the current activation as its parent.
+//
+// Unlike Enter, this stack is not changed, so the new activation
+// can still be used after the current activation of this stack is left.
+//
+func (a *VariableActivations) branch() *VariableActivations {
+	activations := &VariableActivations{
+		activations: make([]*VariableActivation, len(a.activations), len(a.activations)+1),
+	}
+	copy(activations.activations, a.activations)
+	activations.Enter()
+	return activations
+}
+
 // Leave pops the top-most (current) activation
 // from the top of the activation stack.
 //
@@ -0,0 +1,546 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package runtime
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// TransactionSimulation is the result of a simulated transaction,
+// the effects the transaction would have if it was executed
+//
+type TransactionSimulation struct {
+	// RegisterChanges are the changed registers, sorted by owner and key
+	RegisterChanges []RegisterChange
+	// StorageChanges are the changed account storage entries, sorted by address and path
+	StorageChanges []StorageChange
+	// Events are the emitted events
+	Events []cadence.Event
+	// Logs are the logged messages
+	Logs []string
+	// ComputationUsed is the total metered computation
+	ComputationUsed uint64
+}
+
+// RegisterChange is a change of a register.
+// The old value is empty if the register was created
+//
+type RegisterChange struct {
+	Owner    []byte
+	Key      []byte
+	OldValue []byte
+	NewValue []byte
+}
+
+// StorageChange is a change of an account storage entry.
+//
+// The old value is nil if the entry was created,
+// and the new value is nil if the entry was removed.
+//
+// The domain of the path is the storage domain,
+// e.g. `storage`, `public`, `private`, or `contract` for contract values
+//
+type StorageChange struct {
+	Address  common.Address
+	Path     cadence.Path
+	OldValue cadence.Value
+	NewValue cadence.Value
+}
+
+// SimulateTransaction executes the given transaction,
+// but does not write any changes to the ledger and does not emit any events.
+//
+// All writes are performed in memory, and the changed registers and storage entries,
+// the emitted events, the logs, and the metered computation are returned.
+//
+// Storage indices allocated during the simulation are not allocated through the interface.
+// Operations which cannot be reverted, like creating accounts or changing account keys, are not supported.
+//
+func (r *interpreterRuntime) SimulateTransaction(script Script, context Context) (simulation *TransactionSimulation, err error) {
+	defer r.Recover(
+		func(internalErr error) {
+			err = internalErr
+		},
+		context,
+	)
+
+	context.InitializeCodesAndPrograms()
+
+	originalInterface := context.Interface
+
+	simulationInterface := newSimulationInterface(originalInterface, originalInterface)
+	context.Interface = simulationInterface
+
+	storage := NewStorage(simulationInterface)
+
+	inter, err := r.executeTransaction(script, context, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.commitStorage(storage, inter)
+	if err != nil {
+		return nil, newError(err, context)
+	}
+
+	registerChanges, err := simulationInterface.registerChanges()
+	if err != nil {
+		return nil, newError(err, context)
+	}
+
+	storageChanges, err := storageChanges(storage, inter, originalInterface)
+	if err != nil {
+		return nil, newError(err, context)
+	}
+
+	return &TransactionSimulation{
+		RegisterChanges: registerChanges,
+		StorageChanges:  storageChanges,
+		Events:          simulationInterface.events,
+		Logs:            simulationInterface.logs,
+		ComputationUsed: simulationInterface.computationUsed,
+	}, nil
+}
+
+// storageChanges returns the changed entries of the storage maps in the given storage,
+// compared to the storage maps in the given original ledger
+//
+func storageChanges(
+	storage *Storage,
+	inter *interpreter.Interpreter,
+	originalLedger atree.Ledger,
+) (
+	[]StorageChange,
+	error,
+) {
+	storageKeys := make([]interpreter.StorageKey, 0, len(storage.storageMaps))
+
+	// NOTE: ranging over maps is safe (deterministic),
+	// if it is side effect free and the keys are sorted afterwards
+
+	for storageKey := range storage.storageMaps { //nolint:maprangecheck
+		storageKeys = append(storageKeys, storageKey)
+	}
+
+	sort.Slice(storageKeys, func(i, j int) bool {
+		return storageKeys[i].IsLess(storageKeys[j])
+	})
+
+	// Read the original storage maps through a separate simulation,
+	// so reading them does not write to the original ledger
+
+	originalStorage := NewStorage(newSimulationInterface(nil, originalLedger))
+
+	var changes []StorageChange
+
+	for _, storageKey := range storageKeys {
+		address := storageKey.Address
+		domain := storageKey.Key
+
+		newStorageMap := storage.storageMaps[storageKey]
+
+		var originalStorageMap *interpreter.StorageMap
+
+		var storageIndex []byte
+		var err error
+		wrapPanic(func() {
+			storageIndex, err = originalLedger.GetValue(address[:], []byte(domain))
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(storageIndex) > 0 {
+			originalStorageMap = originalStorage.GetStorageMap(address, domain)
+		}
+
+		identifiers := storageMapIdentifiers(newStorageMap, originalStorageMap)
+
+		for _, identifier := range identifiers {
+
+			var originalValue interpreter.Value
+			if originalStorageMap != nil {
+				originalValue = originalStorageMap.ReadValue(identifier)
+			}
+
+			newValue := newStorageMap.ReadValue(identifier)
+
+			if originalValue != nil && newValue != nil &&
+				storedValuesEqual(inter, originalValue, newValue) {
+
+				continue
+			}
+
+			change := StorageChange{
+				Address: address,
+				Path: cadence.Path{
+					Domain:     domain,
+					Identifier: identifier,
+				},
+			}
+
+			if originalValue != nil {
+				change.OldValue, err = ExportValue(originalValue, inter)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if newValue != nil {
+				change.NewValue, err = ExportValue(newValue, inter)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+// storedValuesEqual returns true if the given stored values have the same static type
+// and are equal, e.g. the integers `1` and `UInt8(1)` are not equal
+//
+func storedValuesEqual(inter *interpreter.Interpreter, value, otherValue interpreter.Value) bool {
+	if !value.StaticType().Equal(otherValue.StaticType()) {
+		return false
+	}
+
+	equatableValue, ok := value.(interpreter.EquatableValue)
+	if !ok {
+		return false
+	}
+
+	return equatableValue.Equal(inter, interpreter.ReturnEmptyLocationRange, otherValue)
+}
+
+// storageMapIdentifiers returns the sorted keys of the given storage maps
+//
+func storageMapIdentifiers(storageMaps ...*interpreter.StorageMap) []string {
+	identifierSet := map[string]struct{}{}
+
+	for _, storageMap := range storageMaps {
+		if storageMap == nil {
+			continue
+		}
+
+		iterator := storageMap.Iterator()
+		for {
+			identifier := iterator.NextKey()
+			if identifier == "" {
+				break
+			}
+			identifierSet[identifier] = struct{}{}
+		}
+	}
+
+	identifiers := make([]string, 0, len(identifierSet))
+
+	// NOTE: ranging over maps is safe (deterministic),
+	// if it is side effect free and the keys are sorted afterwards
+
+	for identifier := range identifierSet { //nolint:maprangecheck
+		identifiers = append(identifiers, identifier)
+	}
+
+	sort.Strings(identifiers)
+
+	return identifiers
+}
+
+type simulatedRegisterKey struct {
+	owner string
+	key   string
+}
+
+type simulatedContractKey struct {
+	address common.Address
+	name    string
+}
+
+// simulationInterface is a runtime interface which performs all writes in memory.
+//
+// Reads are performed through the wrapped interface, if the data was not written.
+// Events, logs, and metered computation are recorded
+//
+type simulationInterface struct {
+	Interface
+	ledger          atree.Ledger
+	registers       map[simulatedRegisterKey][]byte
+	storageIndices  localStorageIndices
+	contractCodes   map[simulatedContractKey][]byte
+	programs        map[common.LocationID]*interpreter.Program
+	events          []cadence.Event
+	logs            []string
+	computationUsed uint64
+}
+
+var _ Interface = &simulationInterface{}
+
+func newSimulationInterface(runtimeInterface Interface, ledger atree.Ledger) *simulationInterface {
+	return &simulationInterface{
+		Interface:      runtimeInterface,
+		ledger:         ledger,
+		registers:      map[simulatedRegisterKey][]byte{},
+		storageIndices: localStorageIndices{},
+		contractCodes:  map[simulatedContractKey][]byte{},
+		programs:       map[common.LocationID]*interpreter.Program{},
+	}
+}
+
+func (i *simulationInterface) GetValue(owner, key []byte) ([]byte, error) {
+	registerKey := simulatedRegisterKey{
+		owner: string(owner),
+		key:   string(key),
+	}
+	if value, ok := i.registers[registerKey]; ok {
+		return value, nil
+	}
+
+	return i.ledger.GetValue(owner, key)
+}
+
+func (i *simulationInterface) SetValue(owner, key, value []byte) error {
+	registerKey := simulatedRegisterKey{
+		owner: string(owner),
+		key:   string(key),
+	}
+
+	// NOTE: copy the value, the caller may reuse it
+	i.registers[registerKey] = append([]byte{}, value...)
+
+	return nil
+}
+
+func (i *simulationInterface) ValueExists(owner, key []byte) (bool, error) {
+	registerKey := simulatedRegisterKey{
+		owner: string(owner),
+		key:   string(key),
+	}
+	if value, ok := i.registers[registerKey]; ok {
+		return len(value) > 0, nil
+	}
+
+	return i.ledger.ValueExists(owner, key)
+}
+
+// AllocateStorageIndex allocates storage indices locally, see localStorageIndices
+//
+func (i *simulationInterface) AllocateStorageIndex(owner []byte) (atree.StorageIndex, error) {
+	return i.storageIndices.allocate(owner), nil
+}
+
+func (i *simulationInterface) GetProgram(location Location) (*interpreter.Program, error) {
+	if program, ok := i.programs[location.ID()]; ok {
+		return program, nil
+	}
+
+	return i.Interface.GetProgram(location)
+}
+
+func (i *simulationInterface) SetProgram(location Location, program *interpreter.Program) error {
+	i.programs[location.ID()] = program
+	return nil
+}
+
+func (i *simulationInterface) UpdateAccountContractCode(address Address, name string, code []byte) error {
+	contractKey := simulatedContractKey{
+		address: address,
+		name:    name,
+	}
+	i.contractCodes[contractKey] = code
+	return nil
+}
+
+func (i *simulationInterface) GetAccountContractCode(address Address, name string) ([]byte, error) {
+	contractKey := simulatedContractKey{
+		address: address,
+		name:    name,
+	}
+	if code, ok := i.contractCodes[contractKey]; ok {
+		return code, nil
+	}
+
+	return i.Interface.GetAccountContractCode(address, name)
+}
+
+func (i *simulationInterface) RemoveAccountContractCode(address Address, name string) error {
+	contractKey := simulatedContractKey{
+		address: address,
+		name:    name,
+	}
+
+	// NOTE: do NOT delete the map entry,
+	// the nil code records the removal
+	i.contractCodes[contractKey] = nil
+	return nil
+}
+
+func (i *simulationInterface) GetAccountContractNames(address Address) ([]string, error) {
+	names, err := i.Interface.GetAccountContractNames(address)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(names))
+	existing := make(map[string]struct{}, len(names))
+
+	for _, name := range names {
+		existing[name] = struct{}{}
+
+		contractKey := simulatedContractKey{
+			address: address,
+			name:    name,
+		}
+		if code, ok := i.contractCodes[contractKey]; ok && code == nil {
+			continue
+		}
+
+		result = append(result, name)
+	}
+
+	var added []string
+
+	// NOTE: ranging over maps is safe (deterministic),
+	// if it is side effect free and the keys are sorted afterwards
+
+	for contractKey, code := range i.contractCodes { //nolint:maprangecheck
+		if contractKey.address != address || code == nil {
+			continue
+		}
+		if _, ok := existing[contractKey.name]; ok {
+			continue
+		}
+		added = append(added, contractKey.name)
+	}
+
+	sort.Strings(added)
+
+	return append(result, added...), nil
+}
+
+func (i *simulationInterface) ProgramLog(message string) error {
+	i.logs = append(i.logs, message)
+	return nil
+}
+
+func (i *simulationInterface) EmitEvent(event cadence.Event) error {
+	i.events = append(i.events, event)
+	return nil
+}
+
+func (i *simulationInterface) MeterComputation(operationType common.ComputationKind, intensity uint) error {
+	i.computationUsed += uint64(intensity)
+	return i.Interface.MeterComputation(operationType, intensity)
+}
+
+func (i *simulationInterface) CreateAccount(_ Address) (Address, error) {
+	return Address{}, UnsupportedSimulationOperationError{
+		Operation: "create account",
+	}
+}
+
+func (i *simulationInterface) AddEncodedAccountKey(_ Address, _ []byte) error {
+	return UnsupportedSimulationOperationError{
+		Operation: "add account key",
+	}
+}
+
+func (i *simulationInterface) RevokeEncodedAccountKey(_ Address, _ int) ([]byte, error) {
+	return nil, UnsupportedSimulationOperationError{
+		Operation: "revoke account key",
+	}
+}
+
+func (i *simulationInterface) AddAccountKey(
+	_ Address,
+	_ *PublicKey,
+	_ HashAlgorithm,
+	_ int,
+) (
+	*AccountKey,
+	error,
+) {
+	return nil, UnsupportedSimulationOperationError{
+		Operation: "add account key",
+	}
+}
+
+func (i *simulationInterface) RevokeAccountKey(_ Address, _ int) (*AccountKey, error) {
+	return nil, UnsupportedSimulationOperationError{
+		Operation: "revoke account key",
+	}
+}
+
+// registerChanges returns the registers which were written,
+// and which have a different value than in the wrapped ledger
+//
+func (i *simulationInterface) registerChanges() ([]RegisterChange, error) {
+	var changes []RegisterChange
+
+	// NOTE: ranging over maps is safe (deterministic),
+	// if it is side effect free and the keys are sorted afterwards
+
+	for registerKey, value := range i.registers { //nolint:maprangecheck
+		owner := []byte(registerKey.owner)
+		key := []byte(registerKey.key)
+
+		var oldValue []byte
+		var err error
+		wrapPanic(func() {
+			oldValue, err = i.ledger.GetValue(owner, key)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.Equal(oldValue, value) {
+			continue
+		}
+
+		changes = append(
+			changes,
+			RegisterChange{
+				Owner:    owner,
+				Key:      key,
+				OldValue: oldValue,
+				NewValue: value,
+			},
+		)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		a := changes[i]
+		b := changes[j]
+		ownerComparison := bytes.Compare(a.Owner, b.Owner)
+		if ownerComparison != 0 {
+			return ownerComparison < 0
+		}
+		return bytes.Compare(a.Key, b.Key) < 0
+	})
+
+	return changes, nil
+}
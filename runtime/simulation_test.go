@@ -0,0 +1,339 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestRuntimeSimulateTransaction(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	contract := []byte(`
+      pub contract Test {
+
+          pub event Saved(value: Int)
+
+          pub fun save(_ value: Int, account: AuthAccount) {
+              account.save(value, to: /storage/value)
+              emit Saved(value: value)
+          }
+      }
+    `)
+
+	newRuntimeInterface := func() (*testRuntimeInterface, *[]cadence.Event, *[]string) {
+		var accountCode []byte
+		var events []cadence.Event
+		var logs []string
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			resolveLocation: singleIdentifierLocationResolver(t),
+			getAccountContractCode: func(_ Address, _ string) (code []byte, err error) {
+				return accountCode, nil
+			},
+			updateAccountContractCode: func(_ Address, _ string, code []byte) error {
+				accountCode = code
+				return nil
+			},
+			emitEvent: func(event cadence.Event) error {
+				events = append(events, event)
+				return nil
+			},
+			log: func(message string) {
+				logs = append(logs, message)
+			},
+		}
+
+		return runtimeInterface, &events, &logs
+	}
+
+	setup := func(t *testing.T, runtime Runtime, runtimeInterface *testRuntimeInterface) {
+		nextTransactionLocation := newTransactionLocationGenerator()
+
+		for _, transaction := range [][]byte{
+			utils.DeploymentTransaction("Test", contract),
+			[]byte(`
+              import Test from 0x1
+
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      Test.save(1, account: signer)
+                  }
+              }
+            `),
+		} {
+			err := runtime.ExecuteTransaction(
+				Script{
+					Source: transaction,
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  nextTransactionLocation(),
+				},
+			)
+			require.NoError(t, err)
+		}
+	}
+
+	copyStoredValues := func(storedValues map[string][]byte) map[string][]byte {
+		result := make(map[string][]byte, len(storedValues))
+		for key, value := range storedValues {
+			result[key] = value
+		}
+		return result
+	}
+
+	t.Run("changes", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		runtimeInterface, events, logs := newRuntimeInterface()
+
+		setup(t, runtime, runtimeInterface)
+
+		storedValues := copyStoredValues(runtimeInterface.storage.storedValues)
+		eventCount := len(*events)
+		logCount := len(*logs)
+
+		simulation, err := runtime.SimulateTransaction(
+			Script{
+				Source: []byte(`
+                  import Test from 0x1
+
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          let value = signer.load<Int>(from: /storage/value)!
+                          log(value)
+                          Test.save(value + 1, account: signer)
+                          signer.save("new", to: /storage/other)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{0x2},
+			},
+		)
+		require.NoError(t, err)
+
+		// Nothing was written, emitted, or logged through the interface
+
+		require.Equal(t, storedValues, runtimeInterface.storage.storedValues)
+		require.Len(t, *events, eventCount)
+		require.Len(t, *logs, logCount)
+
+		require.Equal(t,
+			[]StorageChange{
+				{
+					Address: address,
+					Path: cadence.Path{
+						Domain:     "storage",
+						Identifier: "other",
+					},
+					NewValue: cadence.String("new"),
+				},
+				{
+					Address: address,
+					Path: cadence.Path{
+						Domain:     "storage",
+						Identifier: "value",
+					},
+					OldValue: cadence.NewInt(1),
+					NewValue: cadence.NewInt(2),
+				},
+			},
+			simulation.StorageChanges,
+		)
+
+		require.Len(t, simulation.Events, 1)
+		require.Equal(t,
+			"A.0000000000000001.Test.Saved",
+			simulation.Events[0].EventType.ID(),
+		)
+		require.Equal(t,
+			[]cadence.Value{cadence.NewInt(2)},
+			simulation.Events[0].Fields,
+		)
+
+		require.Equal(t, []string{"1"}, simulation.Logs)
+
+		require.NotEmpty(t, simulation.RegisterChanges)
+		for _, change := range simulation.RegisterChanges {
+			require.Equal(t, address[:], change.Owner)
+			require.NotEqual(t, change.OldValue, change.NewValue)
+		}
+
+		require.Greater(t, simulation.ComputationUsed, uint64(0))
+	})
+
+	t.Run("changed type", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		runtimeInterface, _, _ := newRuntimeInterface()
+
+		setup(t, runtime, runtimeInterface)
+
+		// The new value has the same string representation,
+		// but a different type
+
+		simulation, err := runtime.SimulateTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.load<Int>(from: /storage/value)
+                          signer.save(UInt8(1), to: /storage/value)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{0x2},
+			},
+		)
+		require.NoError(t, err)
+
+		require.Equal(t,
+			[]StorageChange{
+				{
+					Address: address,
+					Path: cadence.Path{
+						Domain:     "storage",
+						Identifier: "value",
+					},
+					OldValue: cadence.NewInt(1),
+					NewValue: cadence.NewUInt8(1),
+				},
+			},
+			simulation.StorageChanges,
+		)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		runtimeInterface, _, _ := newRuntimeInterface()
+
+		setup(t, runtime, runtimeInterface)
+
+		simulation, err := runtime.SimulateTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          let value = signer.load<Int>(from: /storage/value)!
+                          signer.save(value, to: /storage/value)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{0x2},
+			},
+		)
+		require.NoError(t, err)
+
+		require.Empty(t, simulation.StorageChanges)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		runtimeInterface, _, _ := newRuntimeInterface()
+
+		setup(t, runtime, runtimeInterface)
+
+		storedValues := copyStoredValues(runtimeInterface.storage.storedValues)
+
+		simulation, err := runtime.SimulateTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.save(2, to: /storage/other)
+                          panic("failed")
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{0x2},
+			},
+		)
+		require.Error(t, err)
+		require.Nil(t, simulation)
+
+		require.Equal(t, storedValues, runtimeInterface.storage.storedValues)
+	})
+
+	t.Run("unsupported operation", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		runtimeInterface, _, _ := newRuntimeInterface()
+
+		_, err := runtime.SimulateTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          AuthAccount(payer: signer)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{0x2},
+			},
+		)
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &UnsupportedSimulationOperationError{})
+	})
+}
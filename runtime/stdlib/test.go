@@ -0,0 +1,928 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib/contracts"
+)
+
+// TestFramework is the interface to be implemented by test runners,
+// to provide the blockchain related functionality of the `Test` contract.
+//
+type TestFramework interface {
+	RunScript(
+		inter *interpreter.Interpreter,
+		code string,
+		arguments []interpreter.Value,
+	) *ScriptResult
+
+	CreateAccount() (*Account, error)
+
+	AddTransaction(
+		inter *interpreter.Interpreter,
+		code string,
+		authorizers []common.Address,
+		arguments []interpreter.Value,
+	) error
+
+	ExecuteNextTransaction() *TransactionResult
+
+	CommitBlock() error
+
+	DeployContract(
+		inter *interpreter.Interpreter,
+		name string,
+		code string,
+		account *Account,
+		arguments []interpreter.Value,
+	) error
+}
+
+// ScriptResult is the result of a script executed by the test framework.
+//
+type ScriptResult struct {
+	Value interpreter.Value
+	Error error
+}
+
+// TransactionResult is the result of a transaction executed by the test framework.
+//
+type TransactionResult struct {
+	Error error
+}
+
+// Account is an account created by the test framework.
+//
+type Account struct {
+	Address common.Address
+}
+
+const testContractTypeName = "Test"
+const testBlockchainTypeName = "Blockchain"
+const testBlockchainBackendTypeName = "BlockchainBackend"
+const testEmulatorBackendTypeName = "EmulatorBackend"
+const testMatcherTypeName = "Matcher"
+const testResultStatusTypeName = "ResultStatus"
+const testScriptResultTypeName = "ScriptResult"
+const testTransactionResultTypeName = "TransactionResult"
+const testErrorTypeName = "Error"
+const testAccountTypeName = "Account"
+
+const testResultStatusSucceededCaseName = "succeeded"
+const testResultStatusFailedCaseName = "failed"
+
+const testMatcherTestFieldName = "test"
+
+const testAccountAddressFieldName = "address"
+
+const testTransactionCodeFieldName = "code"
+const testTransactionAuthorizersFieldName = "authorizers"
+const testTransactionArgumentsFieldName = "arguments"
+
+var TestContractLocation = common.IdentifierLocation(testContractTypeName)
+
+var TestContractChecker = func() *sema.Checker {
+
+	program, err := parser2.ParseProgram(contracts.Test)
+	if err != nil {
+		panic(err)
+	}
+
+	var checker *sema.Checker
+	checker, err = sema.NewChecker(
+		program,
+		TestContractLocation,
+		sema.WithPredeclaredValues(BuiltinFunctions.ToSemaValueDeclarations()),
+		sema.WithPredeclaredTypes(BuiltinTypes.ToTypeDeclarations()),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	err = checker.Check()
+	if err != nil {
+		panic(err)
+	}
+
+	return checker
+}()
+
+var testContractType = func() *sema.CompositeType {
+	variable, ok := TestContractChecker.Elaboration.GlobalTypes.Get(testContractTypeName)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+	return variable.Type.(*sema.CompositeType)
+}()
+
+func testNestedCompositeType(name string) *sema.CompositeType {
+	nestedType, ok := testContractType.GetNestedTypes().Get(name)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+	return nestedType.(*sema.CompositeType)
+}
+
+var testMatcherType = testNestedCompositeType(testMatcherTypeName)
+
+var testBlockchainType = testNestedCompositeType(testBlockchainTypeName)
+
+var testBlockchainBackendType = func() *sema.InterfaceType {
+	nestedType, ok := testContractType.GetNestedTypes().Get(testBlockchainBackendTypeName)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+	return nestedType.(*sema.InterfaceType)
+}()
+
+func init() {
+
+	// Enrich the `Test` contract with the natively implemented functions
+
+	testContractType.Members.Set(
+		testAssertFunctionName,
+		sema.NewPublicFunctionMember(
+			testContractType,
+			testAssertFunctionName,
+			testAssertFunctionType,
+			testAssertFunctionDocString,
+		),
+	)
+
+	testContractType.Members.Set(
+		testFailFunctionName,
+		sema.NewPublicFunctionMember(
+			testContractType,
+			testFailFunctionName,
+			testFailFunctionType,
+			testFailFunctionDocString,
+		),
+	)
+
+	testContractType.Members.Set(
+		testExpectFunctionName,
+		sema.NewPublicFunctionMember(
+			testContractType,
+			testExpectFunctionName,
+			testExpectFunctionType,
+			testExpectFunctionDocString,
+		),
+	)
+
+	testContractType.Members.Set(
+		testEqualFunctionName,
+		sema.NewPublicFunctionMember(
+			testContractType,
+			testEqualFunctionName,
+			testEqualFunctionType,
+			testEqualFunctionDocString,
+		),
+	)
+
+	testContractType.Members.Set(
+		testNewEmulatorBlockchainFunctionName,
+		sema.NewPublicFunctionMember(
+			testContractType,
+			testNewEmulatorBlockchainFunctionName,
+			testNewEmulatorBlockchainFunctionType,
+			testNewEmulatorBlockchainFunctionDocString,
+		),
+	)
+
+	// Enrich the `Test` contract elaboration with the natively implemented composite types,
+	// so the interpreter is able to load them
+
+	TestContractChecker.Elaboration.CompositeTypes[testEmulatorBackendType.ID()] = testEmulatorBackendType
+}
+
+var testContractInitializerTypes = func() (result []sema.Type) {
+	result = make([]sema.Type, len(testContractType.ConstructorParameters))
+	for i, parameter := range testContractType.ConstructorParameters {
+		result[i] = parameter.TypeAnnotation.Type
+	}
+	return result
+}()
+
+// NewTestContract instantiates the `Test` contract and injects the natively implemented functions.
+// The blockchain related functionality is delegated to the given test framework.
+//
+func NewTestContract(
+	inter *interpreter.Interpreter,
+	constructor interpreter.FunctionValue,
+	invocationRange ast.Range,
+	testFramework TestFramework,
+) (
+	*interpreter.CompositeValue,
+	error,
+) {
+	value, err := inter.InvokeFunctionValue(
+		constructor,
+		nil,
+		testContractInitializerTypes,
+		testContractInitializerTypes,
+		invocationRange,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	compositeValue := value.(*interpreter.CompositeValue)
+
+	compositeValue.Functions[testAssertFunctionName] = testAssertFunction
+	compositeValue.Functions[testFailFunctionName] = testFailFunction
+	compositeValue.Functions[testExpectFunctionName] = testExpectFunction
+	compositeValue.Functions[testEqualFunctionName] = testEqualFunction(compositeValue)
+	compositeValue.Functions[testNewEmulatorBlockchainFunctionName] =
+		testNewEmulatorBlockchainFunction(compositeValue, testFramework)
+
+	return compositeValue, nil
+}
+
+// Test.assert
+
+const testAssertFunctionDocString = `
+Fails the test-case if the given condition is false, and reports a message which explains how the condition is false.
+
+The message argument is optional.
+`
+
+const testAssertFunctionName = "assert"
+
+var testAssertFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "condition",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.BoolType),
+		},
+		{
+			Identifier:     "message",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+	RequiredArgumentCount: sema.RequiredArgumentCount(1),
+}
+
+var testAssertFunction = interpreter.NewHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		condition, ok := invocation.Arguments[0].(interpreter.BoolValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		var message string
+		if len(invocation.Arguments) > 1 {
+			messageValue, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+			message = messageValue.Str
+		}
+
+		if !condition {
+			panic(AssertionError{
+				Message:       message,
+				LocationRange: invocation.GetLocationRange(),
+			})
+		}
+
+		return interpreter.VoidValue{}
+	},
+	testAssertFunctionType,
+)
+
+// Test.fail
+
+const testFailFunctionDocString = `
+Fails the test-case with a message.
+
+The message argument is optional.
+`
+
+const testFailFunctionName = "fail"
+
+var testFailFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Identifier:     "message",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.StringType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.NeverType,
+	),
+	RequiredArgumentCount: sema.RequiredArgumentCount(0),
+}
+
+var testFailFunction = interpreter.NewHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		var message string
+		if len(invocation.Arguments) > 0 {
+			messageValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+			message = messageValue.Str
+		}
+
+		panic(AssertionError{
+			Message:       message,
+			LocationRange: invocation.GetLocationRange(),
+		})
+	},
+	testFailFunctionType,
+)
+
+// Test.expect
+
+const testExpectFunctionDocString = `
+Fails the test-case if the given value does not match the given matcher.
+`
+
+const testExpectFunctionName = "expect"
+
+var testExpectFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "value",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.AnyStructType),
+		},
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "matcher",
+			TypeAnnotation: sema.NewTypeAnnotation(testMatcherType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+var testExpectFunction = interpreter.NewHostFunctionValue(
+	func(invocation interpreter.Invocation) interpreter.Value {
+		value := invocation.Arguments[0]
+
+		matcher, ok := invocation.Arguments[1].(*interpreter.CompositeValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		inter := invocation.Interpreter
+		getLocationRange := invocation.GetLocationRange
+
+		testFunction, ok := matcher.GetField(
+			inter,
+			getLocationRange,
+			testMatcherTestFieldName,
+		).(interpreter.FunctionValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		result, err := inter.InvokeFunction(
+			testFunction,
+			interpreter.Invocation{
+				Arguments:        []interpreter.Value{value},
+				ArgumentTypes:    []sema.Type{sema.AnyStructType},
+				GetLocationRange: getLocationRange,
+				Interpreter:      inter,
+			},
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		matched, ok := result.(interpreter.BoolValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		if !matched {
+			panic(AssertionError{
+				Message:       fmt.Sprintf("given value is: %s", value),
+				LocationRange: getLocationRange(),
+			})
+		}
+
+		return interpreter.VoidValue{}
+	},
+	testExpectFunctionType,
+)
+
+// Test.equal
+
+const testEqualFunctionDocString = `
+Returns a matcher that succeeds if the tested value is equal to the given value.
+`
+
+const testEqualFunctionName = "equal"
+
+var testEqualFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "value",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.AnyStructType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		testMatcherType,
+	),
+}
+
+var testMatcherTestFunctionType = &sema.FunctionType{
+	Parameters: []*sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "value",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.AnyStructType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.BoolType,
+	),
+}
+
+func testEqualFunction(testContract *interpreter.CompositeValue) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			expected := invocation.Arguments[0]
+
+			testFunction := interpreter.NewHostFunctionValue(
+				func(invocation interpreter.Invocation) interpreter.Value {
+					actual := invocation.Arguments[0]
+
+					equatableValue, ok := expected.(interpreter.EquatableValue)
+					if !ok {
+						return interpreter.BoolValue(false)
+					}
+
+					return interpreter.BoolValue(
+						equatableValue.Equal(
+							invocation.Interpreter,
+							invocation.GetLocationRange,
+							actual,
+						),
+					)
+				},
+				testMatcherTestFunctionType,
+			)
+
+			return invokeTestConstructor(
+				invocation,
+				testContract,
+				testMatcherTypeName,
+				testFunction,
+			)
+		},
+		testEqualFunctionType,
+	)
+}
+
+// Test.newEmulatorBlockchain
+
+const testNewEmulatorBlockchainFunctionDocString = `
+Creates a blockchain which is backed by a new emulator instance.
+`
+
+const testNewEmulatorBlockchainFunctionName = "newEmulatorBlockchain"
+
+var testNewEmulatorBlockchainFunctionType = &sema.FunctionType{
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		testBlockchainType,
+	),
+}
+
+func testNewEmulatorBlockchainFunction(
+	testContract *interpreter.CompositeValue,
+	testFramework TestFramework,
+) *interpreter.HostFunctionValue {
+	return interpreter.NewHostFunctionValue(
+		func(invocation interpreter.Invocation) interpreter.Value {
+			if testFramework == nil {
+				panic(TestFrameworkNotProvidedError{})
+			}
+
+			emulatorBackend := newTestEmulatorBackend(testContract, testFramework)
+
+			// Wrap the emulator backend in a `Blockchain` value,
+			// by calling the constructor of `Blockchain`
+
+			return invokeTestConstructor(
+				invocation,
+				testContract,
+				testBlockchainTypeName,
+				emulatorBackend,
+			)
+		},
+		testNewEmulatorBlockchainFunctionType,
+	)
+}
+
+// EmulatorBackend
+//
+// EmulatorBackend is a natively implemented composite type,
+// which conforms to the `BlockchainBackend` interface,
+// and delegates all functionality to the test framework.
+
+var testEmulatorBackendType = func() *sema.CompositeType {
+
+	ty := &sema.CompositeType{
+		Identifier: testEmulatorBackendTypeName,
+		Kind:       common.CompositeKindStructure,
+		Location:   TestContractLocation,
+		ExplicitInterfaceConformances: []*sema.InterfaceType{
+			testBlockchainBackendType,
+		},
+	}
+
+	members := make([]*sema.Member, 0, testBlockchainBackendType.Members.Len())
+
+	testBlockchainBackendType.Members.Foreach(func(name string, member *sema.Member) {
+		members = append(
+			members,
+			sema.NewPublicFunctionMember(
+				ty,
+				name,
+				member.TypeAnnotation.Type.(*sema.FunctionType),
+				member.DocString,
+			),
+		)
+	})
+
+	ty.Members = sema.GetMembersAsMap(members)
+
+	ty.SetContainerType(testContractType)
+
+	return ty
+}()
+
+var testEmulatorBackendStaticType interpreter.StaticType = interpreter.CompositeStaticType{
+	Location:            TestContractLocation,
+	QualifiedIdentifier: testEmulatorBackendType.QualifiedIdentifier(),
+	TypeID:              testEmulatorBackendType.ID(),
+}
+
+var testEmulatorBackendDynamicType interpreter.DynamicType = interpreter.CompositeDynamicType{
+	StaticType: testEmulatorBackendType,
+}
+
+func testEmulatorBackendFunctionType(name string) *sema.FunctionType {
+	member, ok := testEmulatorBackendType.Members.Get(name)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+	return member.TypeAnnotation.Type.(*sema.FunctionType)
+}
+
+const testEmulatorBackendExecuteScriptFunctionName = "executeScript"
+const testEmulatorBackendCreateAccountFunctionName = "createAccount"
+const testEmulatorBackendAddTransactionFunctionName = "addTransaction"
+const testEmulatorBackendExecuteNextTransactionFunctionName = "executeNextTransaction"
+const testEmulatorBackendCommitBlockFunctionName = "commitBlock"
+const testEmulatorBackendDeployContractFunctionName = "deployContract"
+
+func newTestEmulatorBackend(
+	testContract *interpreter.CompositeValue,
+	testFramework TestFramework,
+) *interpreter.SimpleCompositeValue {
+
+	fields := map[string]interpreter.Value{
+		testEmulatorBackendExecuteScriptFunctionName: interpreter.NewHostFunctionValue(
+			func(invocation interpreter.Invocation) interpreter.Value {
+				script, ok := invocation.Arguments[0].(*interpreter.StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				arguments := testArrayElements(invocation.Arguments[1])
+
+				result := testFramework.RunScript(invocation.Interpreter, script.Str, arguments)
+
+				var returnValue interpreter.Value = interpreter.NilValue{}
+				if result.Value != nil {
+					returnValue = interpreter.NewSomeValueNonCopying(result.Value)
+				}
+
+				return invokeTestConstructor(
+					invocation,
+					testContract,
+					testScriptResultTypeName,
+					testResultStatus(invocation, testContract, result.Error),
+					returnValue,
+					newTestErrorValue(invocation, testContract, result.Error),
+				)
+			},
+			testEmulatorBackendFunctionType(testEmulatorBackendExecuteScriptFunctionName),
+		),
+
+		testEmulatorBackendCreateAccountFunctionName: interpreter.NewHostFunctionValue(
+			func(invocation interpreter.Invocation) interpreter.Value {
+				account, err := testFramework.CreateAccount()
+				if err != nil {
+					panic(err)
+				}
+
+				return newTestAccountValue(invocation, testContract, account)
+			},
+			testEmulatorBackendFunctionType(testEmulatorBackendCreateAccountFunctionName),
+		),
+
+		testEmulatorBackendAddTransactionFunctionName: interpreter.NewHostFunctionValue(
+			func(invocation interpreter.Invocation) interpreter.Value {
+				transaction, ok := invocation.Arguments[0].(*interpreter.CompositeValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				inter := invocation.Interpreter
+				getLocationRange := invocation.GetLocationRange
+
+				code, ok := transaction.GetField(
+					inter,
+					getLocationRange,
+					testTransactionCodeFieldName,
+				).(*interpreter.StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				authorizerValues := testArrayElements(
+					transaction.GetField(inter, getLocationRange, testTransactionAuthorizersFieldName),
+				)
+
+				authorizers := make([]common.Address, len(authorizerValues))
+				for i, authorizerValue := range authorizerValues {
+					address, ok := authorizerValue.(interpreter.AddressValue)
+					if !ok {
+						panic(errors.NewUnreachableError())
+					}
+					authorizers[i] = address.ToAddress()
+				}
+
+				arguments := testArrayElements(
+					transaction.GetField(inter, getLocationRange, testTransactionArgumentsFieldName),
+				)
+
+				err := testFramework.AddTransaction(inter, code.Str, authorizers, arguments)
+				if err != nil {
+					panic(err)
+				}
+
+				return interpreter.VoidValue{}
+			},
+			testEmulatorBackendFunctionType(testEmulatorBackendAddTransactionFunctionName),
+		),
+
+		testEmulatorBackendExecuteNextTransactionFunctionName: interpreter.NewHostFunctionValue(
+			func(invocation interpreter.Invocation) interpreter.Value {
+				result := testFramework.ExecuteNextTransaction()
+
+				// No transaction was scheduled
+				if result == nil {
+					return interpreter.NilValue{}
+				}
+
+				transactionResult := invokeTestConstructor(
+					invocation,
+					testContract,
+					testTransactionResultTypeName,
+					testResultStatus(invocation, testContract, result.Error),
+					newTestErrorValue(invocation, testContract, result.Error),
+				)
+
+				return interpreter.NewSomeValueNonCopying(transactionResult)
+			},
+			testEmulatorBackendFunctionType(testEmulatorBackendExecuteNextTransactionFunctionName),
+		),
+
+		testEmulatorBackendCommitBlockFunctionName: interpreter.NewHostFunctionValue(
+			func(invocation interpreter.Invocation) interpreter.Value {
+				err := testFramework.CommitBlock()
+				if err != nil {
+					panic(err)
+				}
+
+				return interpreter.VoidValue{}
+			},
+			testEmulatorBackendFunctionType(testEmulatorBackendCommitBlockFunctionName),
+		),
+
+		testEmulatorBackendDeployContractFunctionName: interpreter.NewHostFunctionValue(
+			func(invocation interpreter.Invocation) interpreter.Value {
+				name, ok := invocation.Arguments[0].(*interpreter.StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				code, ok := invocation.Arguments[1].(*interpreter.StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				accountValue, ok := invocation.Arguments[2].(*interpreter.CompositeValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				inter := invocation.Interpreter
+
+				address, ok := accountValue.GetField(
+					inter,
+					invocation.GetLocationRange,
+					testAccountAddressFieldName,
+				).(interpreter.AddressValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				account := &Account{
+					Address: address.ToAddress(),
+				}
+
+				arguments := testArrayElements(invocation.Arguments[3])
+
+				err := testFramework.DeployContract(
+					inter,
+					name.Str,
+					code.Str,
+					account,
+					arguments,
+				)
+				if err == nil {
+					return interpreter.NilValue{}
+				}
+
+				return newTestErrorValue(invocation, testContract, err)
+			},
+			testEmulatorBackendFunctionType(testEmulatorBackendDeployContractFunctionName),
+		),
+	}
+
+	return interpreter.NewSimpleCompositeValue(
+		testEmulatorBackendType.ID(),
+		testEmulatorBackendStaticType,
+		testEmulatorBackendDynamicType,
+		nil,
+		fields,
+		nil,
+		nil,
+		nil,
+	)
+}
+
+// invokeTestConstructor invokes the constructor of the given type nested in the `Test` contract.
+// The arguments must already have the types of the constructor's parameters.
+//
+func invokeTestConstructor(
+	invocation interpreter.Invocation,
+	testContract *interpreter.CompositeValue,
+	typeName string,
+	arguments ...interpreter.Value,
+) interpreter.Value {
+
+	variable, ok := testContract.NestedVariables[typeName]
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	constructor, ok := variable.GetValue().(*interpreter.HostFunctionValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	parameterTypes := make([]sema.Type, len(constructor.Type.Parameters))
+	for i, parameter := range constructor.Type.Parameters {
+		parameterTypes[i] = parameter.TypeAnnotation.Type
+	}
+
+	inter := invocation.Interpreter
+
+	value, err := inter.InvokeFunctionValue(
+		constructor,
+		arguments,
+		parameterTypes,
+		parameterTypes,
+		invocation.GetLocationRange().Range,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
+
+func testResultStatus(
+	invocation interpreter.Invocation,
+	testContract *interpreter.CompositeValue,
+	err error,
+) interpreter.Value {
+
+	variable, ok := testContract.NestedVariables[testResultStatusTypeName]
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	constructor, ok := variable.GetValue().(*interpreter.HostFunctionValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	caseName := testResultStatusSucceededCaseName
+	if err != nil {
+		caseName = testResultStatusFailedCaseName
+	}
+
+	caseVariable, ok := constructor.NestedVariables[caseName]
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return caseVariable.GetValue()
+}
+
+// newTestErrorValue returns an optional `Error` value for the given error.
+//
+func newTestErrorValue(
+	invocation interpreter.Invocation,
+	testContract *interpreter.CompositeValue,
+	err error,
+) interpreter.Value {
+	if err == nil {
+		return interpreter.NilValue{}
+	}
+
+	errorValue := invokeTestConstructor(
+		invocation,
+		testContract,
+		testErrorTypeName,
+		interpreter.NewStringValue(err.Error()),
+	)
+
+	return interpreter.NewSomeValueNonCopying(errorValue)
+}
+
+func newTestAccountValue(
+	invocation interpreter.Invocation,
+	testContract *interpreter.CompositeValue,
+	account *Account,
+) interpreter.Value {
+	return invokeTestConstructor(
+		invocation,
+		testContract,
+		testAccountTypeName,
+		interpreter.NewAddressValue(account.Address),
+	)
+}
+
+func testArrayElements(value interpreter.Value) []interpreter.Value {
+	array, ok := value.(*interpreter.ArrayValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	elements := make([]interpreter.Value, 0, array.Count())
+	array.Iterate(func(element interpreter.Value) (resume bool) {
+		elements = append(elements, element)
+		return true
+	})
+
+	return elements
+}
+
+// TestFrameworkNotProvidedError is reported when the `Test` contract
+// is used to create a blockchain, but no test framework was provided.
+//
+type TestFrameworkNotProvidedError struct{}
+
+func (TestFrameworkNotProvidedError) Error() string {
+	return "test framework not provided"
+}
@@ -0,0 +1,489 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func newTestContractInterpreter(
+	t *testing.T,
+	code string,
+	testFramework TestFramework,
+) *interpreter.Interpreter {
+
+	program, err := parser2.ParseProgram(code)
+	require.NoError(t, err)
+
+	checker, err := sema.NewChecker(
+		program,
+		utils.TestLocation,
+		sema.WithPredeclaredValues(BuiltinFunctions.ToSemaValueDeclarations()),
+		sema.WithImportHandler(
+			func(_ *sema.Checker, location common.Location, _ ast.Range) (sema.Import, error) {
+				require.Equal(t, TestContractLocation, location)
+
+				return sema.ElaborationImport{
+					Elaboration: TestContractChecker.Elaboration,
+				}, nil
+			},
+		),
+	)
+	require.NoError(t, err)
+
+	err = checker.Check()
+	require.NoError(t, err)
+
+	inter, err := interpreter.NewInterpreter(
+		interpreter.ProgramFromChecker(checker),
+		checker.Location,
+		interpreter.WithStorage(interpreter.NewInMemoryStorage()),
+		interpreter.WithPredeclaredValues(BuiltinFunctions.ToInterpreterValueDeclarations()),
+		interpreter.WithImportLocationHandler(
+			func(inter *interpreter.Interpreter, location common.Location) interpreter.Import {
+				require.Equal(t, TestContractLocation, location)
+
+				subInterpreter, err := inter.NewSubInterpreter(
+					interpreter.ProgramFromChecker(TestContractChecker),
+					location,
+				)
+				require.NoError(t, err)
+
+				return interpreter.InterpreterImport{
+					Interpreter: subInterpreter,
+				}
+			},
+		),
+		interpreter.WithContractValueHandler(
+			func(
+				inter *interpreter.Interpreter,
+				compositeType *sema.CompositeType,
+				constructorGenerator func(common.Address) *interpreter.HostFunctionValue,
+				invocationRange ast.Range,
+			) *interpreter.CompositeValue {
+
+				require.Equal(t, TestContractLocation, compositeType.Location)
+
+				contract, err := NewTestContract(
+					inter,
+					constructorGenerator(common.Address{}),
+					invocationRange,
+					testFramework,
+				)
+				require.NoError(t, err)
+
+				return contract
+			},
+		),
+		interpreter.WithUUIDHandler(func() (uint64, error) {
+			return 0, nil
+		}),
+	)
+	require.NoError(t, err)
+
+	err = inter.Interpret()
+	require.NoError(t, err)
+
+	return inter
+}
+
+func TestTestContract(t *testing.T) {
+	require.IsType(t, &sema.Checker{}, TestContractChecker)
+}
+
+func TestTestAssert(t *testing.T) {
+
+	t.Parallel()
+
+	inter := newTestContractInterpreter(t,
+		`
+          import Test
+
+          pub fun testSucceed() {
+              Test.assert(true)
+              Test.assert(true, message: "oops")
+          }
+
+          pub fun testFail() {
+              Test.assert(false, message: "oops")
+          }
+        `,
+		nil,
+	)
+
+	_, err := inter.Invoke("testSucceed")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testFail")
+	require.Error(t, err)
+
+	var assertionErr AssertionError
+	require.ErrorAs(t, err, &assertionErr)
+	assert.Equal(t, "oops", assertionErr.Message)
+}
+
+func TestTestFail(t *testing.T) {
+
+	t.Parallel()
+
+	inter := newTestContractInterpreter(t,
+		`
+          import Test
+
+          pub fun test() {
+              Test.fail(message: "oops")
+          }
+        `,
+		nil,
+	)
+
+	_, err := inter.Invoke("test")
+	require.Error(t, err)
+
+	var assertionErr AssertionError
+	require.ErrorAs(t, err, &assertionErr)
+	assert.Equal(t, "oops", assertionErr.Message)
+}
+
+func TestTestExpect(t *testing.T) {
+
+	t.Parallel()
+
+	inter := newTestContractInterpreter(t,
+		`
+          import Test
+
+          pub fun testEqual() {
+              Test.expect(1, Test.equal(1))
+              Test.expect("a", Test.equal("a"))
+              Test.expect([1, 2], Test.equal([1, 2]))
+          }
+
+          pub fun testNotEqual() {
+              Test.expect(1, Test.equal(2))
+          }
+
+          pub fun testCustomMatcher() {
+              let isPositive = Test.Matcher(test: fun (value: AnyStruct): Bool {
+                  return (value as! Int) > 0
+              })
+
+              Test.expect(1, isPositive)
+          }
+
+          pub fun testAnd() {
+              Test.expect(1, Test.equal(1).and(Test.equal(2)))
+          }
+
+          pub fun testOr() {
+              Test.expect(1, Test.equal(2).or(Test.equal(1)))
+          }
+        `,
+		nil,
+	)
+
+	_, err := inter.Invoke("testEqual")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testNotEqual")
+	require.Error(t, err)
+
+	var assertionErr AssertionError
+	require.ErrorAs(t, err, &assertionErr)
+	assert.Equal(t, "given value is: 1", assertionErr.Message)
+
+	_, err = inter.Invoke("testCustomMatcher")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testAnd")
+	require.ErrorAs(t, err, &assertionErr)
+
+	_, err = inter.Invoke("testOr")
+	require.NoError(t, err)
+}
+
+type testTestFramework struct {
+	runScript              func(inter *interpreter.Interpreter, code string, arguments []interpreter.Value) *ScriptResult
+	createAccount          func() (*Account, error)
+	addTransaction         func(inter *interpreter.Interpreter, code string, authorizers []common.Address, arguments []interpreter.Value) error
+	executeNextTransaction func() *TransactionResult
+	commitBlock            func() error
+	deployContract         func(inter *interpreter.Interpreter, name string, code string, account *Account, arguments []interpreter.Value) error
+}
+
+var _ TestFramework = &testTestFramework{}
+
+func (f *testTestFramework) RunScript(
+	inter *interpreter.Interpreter,
+	code string,
+	arguments []interpreter.Value,
+) *ScriptResult {
+	return f.runScript(inter, code, arguments)
+}
+
+func (f *testTestFramework) CreateAccount() (*Account, error) {
+	return f.createAccount()
+}
+
+func (f *testTestFramework) AddTransaction(
+	inter *interpreter.Interpreter,
+	code string,
+	authorizers []common.Address,
+	arguments []interpreter.Value,
+) error {
+	return f.addTransaction(inter, code, authorizers, arguments)
+}
+
+func (f *testTestFramework) ExecuteNextTransaction() *TransactionResult {
+	return f.executeNextTransaction()
+}
+
+func (f *testTestFramework) CommitBlock() error {
+	return f.commitBlock()
+}
+
+func (f *testTestFramework) DeployContract(
+	inter *interpreter.Interpreter,
+	name string,
+	code string,
+	account *Account,
+	arguments []interpreter.Value,
+) error {
+	return f.deployContract(inter, name, code, account, arguments)
+}
+
+func TestTestBlockchain(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("no test framework", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newTestContractInterpreter(t,
+			`
+              import Test
+
+              pub fun test() {
+                  Test.newEmulatorBlockchain()
+              }
+            `,
+			nil,
+		)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &TestFrameworkNotProvidedError{})
+	})
+
+	t.Run("execute script", func(t *testing.T) {
+
+		t.Parallel()
+
+		var executedCode string
+		var executedArguments []interpreter.Value
+
+		testFramework := &testTestFramework{
+			runScript: func(
+				_ *interpreter.Interpreter,
+				code string,
+				arguments []interpreter.Value,
+			) *ScriptResult {
+				executedCode = code
+				executedArguments = arguments
+
+				if len(arguments) == 0 {
+					return &ScriptResult{
+						Error: errors.New("missing argument"),
+					}
+				}
+
+				return &ScriptResult{
+					Value: interpreter.NewIntValueFromInt64(42),
+				}
+			},
+		}
+
+		inter := newTestContractInterpreter(t,
+			`
+              import Test
+
+              pub fun testSucceed() {
+                  let blockchain = Test.newEmulatorBlockchain()
+                  let result = blockchain.executeScript("script", [1])
+
+                  Test.assert(result.status == Test.ResultStatus.succeeded)
+                  Test.assert(result.error == nil)
+                  Test.expect(result.returnValue!, Test.equal(42))
+              }
+
+              pub fun testFail() {
+                  let blockchain = Test.newEmulatorBlockchain()
+                  let result = blockchain.executeScript("script", [])
+
+                  Test.assert(result.status == Test.ResultStatus.failed)
+                  Test.assert(result.returnValue == nil)
+                  Test.expect(result.error!.message, Test.equal("missing argument"))
+              }
+            `,
+			testFramework,
+		)
+
+		_, err := inter.Invoke("testSucceed")
+		require.NoError(t, err)
+
+		assert.Equal(t, "script", executedCode)
+		require.Len(t, executedArguments, 1)
+		assert.Equal(t, interpreter.NewIntValueFromInt64(1), executedArguments[0])
+
+		_, err = inter.Invoke("testFail")
+		require.NoError(t, err)
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+
+		t.Parallel()
+
+		var addedCode []string
+		var addedAuthorizers [][]common.Address
+		committed := false
+
+		testFramework := &testTestFramework{
+			createAccount: func() (*Account, error) {
+				return &Account{
+					Address: common.Address{0x1},
+				}, nil
+			},
+			addTransaction: func(
+				_ *interpreter.Interpreter,
+				code string,
+				authorizers []common.Address,
+				_ []interpreter.Value,
+			) error {
+				addedCode = append(addedCode, code)
+				addedAuthorizers = append(addedAuthorizers, authorizers)
+				return nil
+			},
+			executeNextTransaction: func() *TransactionResult {
+				return &TransactionResult{}
+			},
+			commitBlock: func() error {
+				committed = true
+				return nil
+			},
+		}
+
+		inter := newTestContractInterpreter(t,
+			`
+              import Test
+
+              pub fun test() {
+                  let blockchain = Test.newEmulatorBlockchain()
+                  let account = blockchain.createAccount()
+
+                  let result = blockchain.executeTransaction(
+                      Test.Transaction(
+                          code: "transaction",
+                          authorizers: [account.address],
+                          arguments: []
+                      )
+                  )
+
+                  Test.assert(result.status == Test.ResultStatus.succeeded)
+              }
+            `,
+			testFramework,
+		)
+
+		_, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"transaction"}, addedCode)
+		assert.Equal(t, [][]common.Address{{{0x1}}}, addedAuthorizers)
+		assert.True(t, committed)
+	})
+
+	t.Run("deploy contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		testFramework := &testTestFramework{
+			createAccount: func() (*Account, error) {
+				return &Account{
+					Address: common.Address{0x1},
+				}, nil
+			},
+			deployContract: func(
+				_ *interpreter.Interpreter,
+				name string,
+				_ string,
+				account *Account,
+				_ []interpreter.Value,
+			) error {
+				if name == "Bar" {
+					return errors.New("cannot deploy Bar")
+				}
+
+				assert.Equal(t, common.Address{0x1}, account.Address)
+				return nil
+			},
+		}
+
+		inter := newTestContractInterpreter(t,
+			`
+              import Test
+
+              pub fun test() {
+                  let blockchain = Test.newEmulatorBlockchain()
+                  let account = blockchain.createAccount()
+
+                  let err = blockchain.deployContract(
+                      name: "Foo",
+                      code: "pub contract Foo {}",
+                      account: account,
+                      arguments: []
+                  )
+                  Test.assert(err == nil)
+
+                  let err2 = blockchain.deployContract(
+                      name: "Bar",
+                      code: "pub contract Bar {}",
+                      account: account,
+                      arguments: []
+                  )
+                  Test.expect(err2!.message, Test.equal("cannot deploy Bar"))
+              }
+            `,
+			testFramework,
+		)
+
+		_, err := inter.Invoke("test")
+		require.NoError(t, err)
+	})
+}
@@ -19,7 +19,9 @@
 package runtime
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math"
 	"runtime"
 	"sort"
 
@@ -37,6 +39,11 @@ type Storage struct {
 	storageMaps     map[interpreter.StorageKey]*interpreter.StorageMap
 	contractUpdates map[interpreter.StorageKey]*interpreter.CompositeValue
 	Ledger          atree.Ledger
+	// readOnly is true if the storage rejects writes, see NewReadOnlyStorage
+	readOnly bool
+	// accountSlabsModified is true if slabs of accounts were stored or removed.
+	// It is only tracked for read-only storage
+	accountSlabsModified bool
 }
 
 var _ atree.SlabStorage = &Storage{}
@@ -60,6 +67,21 @@ func NewStorage(ledger atree.Ledger) *Storage {
 	}
 }
 
+// NewReadOnlyStorage returns a storage which rejects writes:
+// Values can be read and modified during execution,
+// but committing the storage fails if account storage was modified.
+//
+// Storage indices are allocated without writing to the given ledger.
+//
+func NewReadOnlyStorage(ledger atree.Ledger) *Storage {
+	storage := NewStorage(&readOnlyLedger{
+		Ledger:         ledger,
+		storageIndices: localStorageIndices{},
+	})
+	storage.readOnly = true
+	return storage
+}
+
 const storageIndexLength = 8
 
 func (s *Storage) GetStorageMap(address common.Address, domain string) (storageMap *interpreter.StorageMap) {
@@ -100,6 +122,10 @@ func (s *Storage) GetStorageMap(address common.Address, domain string) (storageM
 			var storageIndex atree.StorageIndex
 			copy(storageIndex[:], data[:])
 			storageMap = s.loadExistingStorageMap(atreeAddress, storageIndex)
+		} else if s.readOnly {
+			// Do not write a new storage map to the account,
+			// an empty temporary storage map is sufficient for reading
+			storageMap = interpreter.NewStorageMap(s, atree.Address{})
 		} else {
 			storageMap = s.storeNewStorageMap(atreeAddress, domain)
 		}
@@ -235,10 +261,43 @@ func sortWrites(writes []write) {
 	})
 }
 
+// Store stores the given slab.
+// Stores of account slabs are tracked for read-only storage.
+//
+func (s *Storage) Store(id atree.StorageID, slab atree.Slab) error {
+	if s.readOnly && id.Address != (atree.Address{}) {
+		s.accountSlabsModified = true
+	}
+	return s.PersistentSlabStorage.Store(id, slab)
+}
+
+// Remove removes the slab with the given ID.
+// Removals of account slabs are tracked for read-only storage.
+//
+func (s *Storage) Remove(id atree.StorageID) error {
+	if s.readOnly && id.Address != (atree.Address{}) {
+		s.accountSlabsModified = true
+	}
+	return s.PersistentSlabStorage.Remove(id)
+}
+
 // Commit serializes/saves all values in the readCache in storage (through the runtime interface).
 //
+// Committing a read-only storage fails with a ReadOnlyStorageWriteError
+// if account storage was modified, and otherwise does nothing.
+//
 func (s *Storage) Commit(inter *interpreter.Interpreter, commitContractUpdates bool) error {
 
+	if s.readOnly {
+		if len(s.writes) > 0 ||
+			len(s.contractUpdates) > 0 ||
+			s.accountSlabsModified {
+
+			return ReadOnlyStorageWriteError{}
+		}
+		return nil
+	}
+
 	if commitContractUpdates {
 		s.commitContractUpdates(inter)
 	}
@@ -337,6 +396,11 @@ func (s *Storage) CheckHealth() error {
 	})
 
 	for _, storageMapStorageID := range storageMapStorageIDs {
+		// Read-only storage uses temporary storage maps for missing domains
+		if storageMapStorageID.Address == (atree.Address{}) {
+			continue
+		}
+
 		if _, ok := accountRootSlabIDs[storageMapStorageID]; !ok {
 			return fmt.Errorf("account storage map points to non-existing slab %s", storageMapStorageID)
 		}
@@ -373,3 +437,38 @@ func (s *Storage) CheckHealth() error {
 
 	return nil
 }
+
+// readOnlyLedger is the ledger of a read-only storage.
+//
+// Storage indices are allocated locally, so the wrapped ledger is not modified
+// when slabs are created during execution.
+// Values are never written, as committing the read-only storage fails instead
+//
+type readOnlyLedger struct {
+	atree.Ledger
+	storageIndices localStorageIndices
+}
+
+func (l *readOnlyLedger) SetValue(_, _, _ []byte) error {
+	return ReadOnlyStorageWriteError{}
+}
+
+// AllocateStorageIndex allocates storage indices locally, see localStorageIndices
+//
+func (l *readOnlyLedger) AllocateStorageIndex(owner []byte) (atree.StorageIndex, error) {
+	return l.storageIndices.allocate(owner), nil
+}
+
+// localStorageIndices are the storage indices allocated without a ledger, per owner.
+//
+// Storage indices are allocated in descending order, starting with the largest storage index,
+// so they do not collide with the storage indices allocated by a ledger
+//
+type localStorageIndices map[string]uint64
+
+func (indices localStorageIndices) allocate(owner []byte) (result atree.StorageIndex) {
+	index := math.MaxUint64 - indices[string(owner)]
+	indices[string(owner)]++
+	binary.BigEndian.PutUint64(result[:], index)
+	return
+}
@@ -2977,3 +2977,135 @@ func TestRuntimeNoAtreeSendOnClosedChannelDuringCommit(t *testing.T) {
 		}
 	})
 }
+
+// TestRuntimeStorageGenericComposite tests that instances of generic composite types
+// are stored with their type arguments
+//
+func TestRuntimeStorageGenericComposite(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	generics := []byte(`
+      pub struct Pair<A, B> {
+          pub let first: A
+          pub let second: B
+
+          init(first: A, second: B) {
+              self.first = first
+              self.second = second
+          }
+
+          pub fun swap(): Pair<B, A> {
+              return Pair(first: self.second, second: self.first)
+          }
+      }
+
+      pub resource R {}
+
+      pub resource Box<T: AnyResource> {
+          pub let item: @T
+
+          init(item: @T) {
+              self.item <- item
+          }
+
+          destroy() {
+              destroy self.item
+          }
+      }
+
+      pub fun createBox(): @Box<@R> {
+          return <-create Box(item: <-create R())
+      }
+    `)
+
+	script1 := []byte(`
+      import "generics"
+
+      transaction {
+
+        prepare(signer: AuthAccount) {
+          signer.save(Pair(first: 1, second: "two"), to: /storage/pair)
+          signer.save(<-createBox(), to: /storage/box)
+        }
+      }
+    `)
+
+	script2 := []byte(`
+      import "generics"
+
+      transaction {
+        prepare(signer: AuthAccount) {
+          let pair = signer.copy<Pair<Int, String>>(from: /storage/pair)!
+          log(pair.getType().identifier)
+          log(pair.swap().first)
+
+          let any = signer.copy<AnyStruct>(from: /storage/pair)!
+          log(any as? Pair<String, Int>)
+
+          let box = signer.borrow<&Box<@R>>(from: /storage/box)!
+          log(box.getType().identifier)
+          log(box.item.getType().identifier)
+        }
+      }
+    `)
+
+	var loggedMessages []string
+
+	ledger := newTestLedger(nil, nil)
+
+	runtimeInterface := &testRuntimeInterface{
+		getCode: func(location Location) (bytes []byte, err error) {
+			switch location {
+			case common.StringLocation("generics"):
+				return generics, nil
+			default:
+				return nil, fmt.Errorf("unknown import location: %s", location)
+			}
+		},
+		storage: ledger,
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{{42}}, nil
+		},
+		log: func(message string) {
+			loggedMessages = append(loggedMessages, message)
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: script1,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: script2,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]string{
+			`"S.generics.Pair<Int,String>"`,
+			`"two"`,
+			"nil",
+			`"S.generics.Box<S.generics.R>"`,
+			`"S.generics.R"`,
+		},
+		loggedMessages,
+	)
+}
@@ -228,16 +228,14 @@ func TestCheckAccount_save(t *testing.T) {
 
 			if domain == common.PathDomainStorage {
 
-				errs := ExpectCheckerErrors(t, err, 2)
+				errs := ExpectCheckerErrors(t, err, 1)
 
-				require.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[0])
-				require.IsType(t, &sema.TypeMismatchError{}, errs[1])
+				require.IsType(t, &sema.TypeMismatchError{}, errs[0])
 			} else {
-				errs := ExpectCheckerErrors(t, err, 3)
+				errs := ExpectCheckerErrors(t, err, 2)
 
-				require.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[0])
+				require.IsType(t, &sema.TypeMismatchError{}, errs[0])
 				require.IsType(t, &sema.TypeMismatchError{}, errs[1])
-				require.IsType(t, &sema.TypeMismatchError{}, errs[2])
 			}
 		})
 
@@ -263,16 +261,14 @@ func TestCheckAccount_save(t *testing.T) {
 
 			if domain == common.PathDomainStorage {
 
-				errs := ExpectCheckerErrors(t, err, 2)
+				errs := ExpectCheckerErrors(t, err, 1)
 
-				require.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[0])
-				require.IsType(t, &sema.TypeMismatchError{}, errs[1])
+				require.IsType(t, &sema.TypeMismatchError{}, errs[0])
 			} else {
-				errs := ExpectCheckerErrors(t, err, 3)
+				errs := ExpectCheckerErrors(t, err, 2)
 
-				require.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[0])
+				require.IsType(t, &sema.TypeMismatchError{}, errs[0])
 				require.IsType(t, &sema.TypeMismatchError{}, errs[1])
-				require.IsType(t, &sema.TypeMismatchError{}, errs[2])
 			}
 		})
 	}
@@ -1508,3 +1504,80 @@ func TestPublicAccountContracts(t *testing.T) {
 	})
 
 }
+
+func TestCheckAccount_storageIteration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("AuthAccount", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+          let storagePaths: [StoragePath] = authAccount.storagePaths
+          let publicPaths: [PublicPath] = authAccount.publicPaths
+          let privatePaths: [PrivatePath] = authAccount.privatePaths
+
+          fun test() {
+              authAccount.forEachStored(fun (path: StoragePath, type: Type): Bool {
+                  return true
+              })
+              authAccount.forEachPublic(fun (path: PublicPath, type: Type): Bool {
+                  return true
+              })
+              authAccount.forEachPrivate(fun (path: PrivatePath, type: Type): Bool {
+                  return true
+              })
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("PublicAccount", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+          let publicPaths: [PublicPath] = publicAccount.publicPaths
+
+          fun test() {
+              publicAccount.forEachPublic(fun (path: PublicPath, type: Type): Bool {
+                  return true
+              })
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("PublicAccount, storage paths", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+          let storagePaths: [StoragePath] = publicAccount.storagePaths
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+	})
+
+	t.Run("invalid function, path type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckAccount(t, `
+          fun test() {
+              authAccount.forEachStored(fun (path: PublicPath, type: Type): Bool {
+                  return true
+              })
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
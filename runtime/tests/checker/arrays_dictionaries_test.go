@@ -1235,3 +1235,375 @@ func TestNilAssignmentToDictionary(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestCheckArrayMap(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("variable-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let xs = [1, 2, 3]
+          let ys = xs.map(fun (x: Int): String {
+              return x.toString()
+          })
+        `)
+
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: sema.StringType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "ys"),
+		)
+	})
+
+	t.Run("constant-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let xs: [Int; 3] = [1, 2, 3]
+          let ys = xs.map(fun (x: Int): Bool {
+              return x > 1
+          })
+        `)
+
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.ConstantSizedType{
+				Type: sema.BoolType,
+				Size: 3,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "ys"),
+		)
+	})
+
+	t.Run("invalid: function type mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs = [1, 2, 3]
+          let ys = xs.map(fun (x: String): String {
+              return x
+          })
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid: resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun test() {
+              let rs <- [<-create R()]
+              rs.map(fun (r: @R): Int {
+                  destroy r
+                  return 1
+              })
+              destroy rs
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+		assert.IsType(t, &sema.ResourceLossError{}, errs[1])
+	})
+}
+
+func TestCheckArrayFilter(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let xs: [Int; 3] = [1, 2, 3]
+          let ys = xs.filter(fun (x: Int): Bool {
+              return x % 2 == 1
+          })
+        `)
+
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: sema.IntType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "ys"),
+		)
+	})
+
+	t.Run("invalid: function return type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs = [1, 2, 3]
+          let ys = xs.filter(fun (x: Int): Int {
+              return x
+          })
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid: resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun test() {
+              let rs <- [<-create R()]
+              let filtered <- rs.filter(fun (r: @R): Bool {
+                  destroy r
+                  return true
+              })
+              destroy filtered
+              destroy rs
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+		assert.IsType(t, &sema.ResourceLossError{}, errs[1])
+	})
+}
+
+func TestCheckArrayReduce(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let xs = [1, 2, 3]
+          let sum = xs.reduce(initial: "", fun (acc: String, x: Int): String {
+              return acc.concat(x.toString())
+          })
+        `)
+
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.StringType,
+			RequireGlobalValue(t, checker.Elaboration, "sum"),
+		)
+	})
+
+	t.Run("invalid: initial value type mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs = [1, 2, 3]
+          let sum = xs.reduce(initial: true, fun (acc: Int, x: Int): Int {
+              return acc + x
+          })
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 3)
+
+		assert.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[0])
+		assert.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[1])
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[2])
+	})
+}
+
+func TestCheckArrayReverse(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let xs: [Int; 3] = [1, 2, 3]
+          let ys = xs.reverse()
+        `)
+
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.ConstantSizedType{
+				Type: sema.IntType,
+				Size: 3,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "ys"),
+		)
+	})
+
+	t.Run("invalid: resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun test() {
+              let rs <- [<-create R()]
+              let reversed <- rs.reverse()
+              destroy reversed
+              destroy rs
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+	})
+}
+
+func TestCheckArrayForEach(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): Int {
+              var sum = 0
+              [1, 2, 3].forEach(fun (x: Int) {
+                  sum = sum + x
+              })
+              return sum
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid: resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun test() {
+              let rs <- [<-create R()]
+              rs.forEach(fun (r: @R) {
+                  destroy r
+              })
+              destroy rs
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+	})
+}
+
+func TestCheckArraySort(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): [Int] {
+              let xs = [3, 1, 2]
+              xs.sort(by: fun (a: Int, b: Int): Bool {
+                  return a < b
+              })
+              return xs
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid: missing argument label", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let xs = [3, 1, 2]
+              xs.sort(fun (a: Int, b: Int): Bool {
+                  return a < b
+              })
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.MissingArgumentLabelError{}, errs[0])
+	})
+
+	t.Run("invalid: external mutation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          pub struct S {
+              pub let xs: [Int]
+
+              init() {
+                  self.xs = [3, 1, 2]
+              }
+          }
+
+          fun test() {
+              let s = S()
+              s.xs.sort(by: fun (a: Int, b: Int): Bool {
+                  return a < b
+              })
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ExternalMutationError{}, errs[0])
+	})
+
+	t.Run("invalid: resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun test() {
+              let rs <- [<-create R()]
+              rs.sort(by: fun (a: &R, b: &R): Bool {
+                  return true
+              })
+              destroy rs
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 3)
+
+		assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+		assert.IsType(t, &sema.ResourceLossError{}, errs[1])
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[2])
+	})
+}
@@ -145,4 +145,83 @@ func TestCheckConditionalExpressionTypeInferring(t *testing.T) {
 			xType,
 		)
 	})
+
+	t.Run("optional array", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+            let x = true ? [1] : nil
+        `)
+
+		require.NoError(t, err)
+
+		xType := RequireGlobalValue(t, checker.Elaboration, "x")
+		assert.Equal(
+			t,
+			&sema.OptionalType{
+				Type: &sema.VariableSizedType{
+					Type: sema.IntType,
+				},
+			},
+			xType,
+		)
+	})
+
+	t.Run("different array types", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+            let x = true ? [1] : ["a"]
+        `)
+
+		require.NoError(t, err)
+
+		xType := RequireGlobalValue(t, checker.Elaboration, "x")
+		assert.Equal(
+			t,
+			&sema.VariableSizedType{
+				Type: sema.AnyStructType,
+			},
+			xType,
+		)
+	})
+
+	t.Run("optional composite", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+            struct S {}
+
+            let x = true ? S() : nil
+        `)
+
+		require.NoError(t, err)
+
+		xType := RequireGlobalValue(t, checker.Elaboration, "x")
+		require.IsType(t, &sema.OptionalType{}, xType)
+		assert.Equal(t, "S", xType.(*sema.OptionalType).Type.QualifiedString())
+	})
+
+	t.Run("restricted types", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+            struct interface I {}
+
+            struct interface J {}
+
+            struct S: I, J {}
+
+            struct T: I {}
+
+            let s: S{I, J} = S()
+            let t: T{I} = T()
+            let x = true ? s : t
+        `)
+
+		require.NoError(t, err)
+
+		xType := RequireGlobalValue(t, checker.Elaboration, "x")
+		assert.Equal(t, "AnyStruct{I}", xType.QualifiedString())
+	})
 }
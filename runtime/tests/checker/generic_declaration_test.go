@@ -0,0 +1,643 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckGenericFunctionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("inferred type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun firstOrNil<T>(_ xs: [T]): T? {
+              if xs.length == 0 {
+                  return nil
+              }
+              return xs[0]
+          }
+
+          let x = firstOrNil([1, 2, 3])
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: sema.IntType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("explicit type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun firstOrNil<T>(_ xs: [T]): T? {
+              if xs.length == 0 {
+                  return nil
+              }
+              return xs[0]
+          }
+
+          let xs: [String] = []
+          let x = firstOrNil<String>(xs)
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: sema.StringType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("explicit type argument, empty array literal", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun firstOrNil<T>(_ xs: [T]): T? {
+              if xs.length == 0 {
+                  return nil
+              }
+              return xs[0]
+          }
+
+          let x = firstOrNil<String>([])
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: sema.StringType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("explicit type argument, nested generic invocation", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun firstOrNil<T>(_ xs: [T]): T? {
+              if xs.length == 0 {
+                  return nil
+              }
+              return xs[0]
+          }
+
+          fun identity<T>(_ x: T): T {
+              return x
+          }
+
+          let x = firstOrNil<UInt8>(identity<[UInt8]>([1, 2]))
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: sema.UInt8Type,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("multiple type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun second<A, B>(_ a: A, _ b: B): B {
+              return b
+          }
+
+          let x = second(1, "two")
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.StringType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("type bound", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun double<T: Integer>(_ x: T): Integer {
+              let y: Integer = x
+              return y
+          }
+
+          let x = double(UInt8(1))
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.IntegerType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("resource type bound", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun identity<T: AnyResource>(_ x: @T): @T {
+              return <-x
+          }
+
+          let r <- identity(<-create R())
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.TypeID("S.test.R"),
+			RequireGlobalValue(t, checker.Elaboration, "r").ID(),
+		)
+	})
+
+	t.Run("function member", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct S {
+              fun wrap<T>(_ x: T): [T] {
+                  return [x]
+              }
+          }
+
+          let xs = S().wrap(true)
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: sema.BoolType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "xs"),
+		)
+	})
+
+	t.Run("generic function invoked with type parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun wrap<T>(_ x: T?): [T]? {
+              return x.map(fun (y: T): [T] {
+                  return [y]
+              })
+          }
+
+          let one: Int? = 1
+          let xs = wrap(one)
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: &sema.VariableSizedType{
+					Type: sema.IntType,
+				},
+			},
+			RequireGlobalValue(t, checker.Elaboration, "xs"),
+		)
+	})
+
+	t.Run("invalid: type parameter of enclosing function is not inferred", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T>(_ x: T?): Int? {
+              return x.map(fun (y: String): Int {
+                  return 1
+              })
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid: type parameter is not a concrete type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T>(_ x: T): Int {
+              return x
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid: resource type argument for unbounded type parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun identity<T>(_ x: T): T {
+              return x
+          }
+
+          let r <- identity(<-create R())
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid: resource loss", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T: AnyResource>(_ x: @T) {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ResourceLossError{}, errs[0])
+	})
+
+	t.Run("invalid: missing resource annotation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T: AnyResource>(_ x: T): @T {
+              return <-x
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.MissingResourceAnnotationError{}, errs[0])
+	})
+
+	t.Run("invalid: type parameter not inferred", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T>(): [T] {
+              return []
+          }
+
+          let xs = test()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeParameterTypeInferenceError{}, errs[0])
+	})
+
+	t.Run("invalid: type parameter not declared outside of function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T>(_ x: T) {}
+
+          let x: T? = nil
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("invalid: duplicate type parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T, T>(_ x: T) {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.RedeclarationError{}, errs[0])
+	})
+}
+
+func TestCheckGenericCompositeDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	const pairDeclaration = `
+      struct Pair<A, B> {
+          let first: A
+          let second: B
+
+          init(first: A, second: B) {
+              self.first = first
+              self.second = second
+          }
+
+          fun swap(): Pair<B, A> {
+              return Pair(first: self.second, second: self.first)
+          }
+
+          fun withFirst<C>(_ first: C): Pair<C, B> {
+              return Pair(first: first, second: self.second)
+          }
+      }
+    `
+
+	t.Run("inferred type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, pairDeclaration+`
+          let pair = Pair(first: 1, second: "two")
+          let first = pair.first
+          let second = pair.second
+        `)
+		require.NoError(t, err)
+
+		pairType := RequireGlobalValue(t, checker.Elaboration, "pair")
+
+		assert.Equal(t,
+			sema.TypeID("S.test.Pair<Int,String>"),
+			pairType.ID(),
+		)
+		assert.Equal(t,
+			"Pair<Int, String>",
+			pairType.String(),
+		)
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalValue(t, checker.Elaboration, "first"),
+		)
+		assert.Equal(t,
+			sema.StringType,
+			RequireGlobalValue(t, checker.Elaboration, "second"),
+		)
+	})
+
+	t.Run("explicit type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, pairDeclaration+`
+          let first: Int8 = 1
+          let second: String? = nil
+          let pair: Pair<Int8, String?> = Pair<Int8, String?>(first: first, second: second)
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.TypeID("S.test.Pair<Int8,String?>"),
+			RequireGlobalValue(t, checker.Elaboration, "pair").ID(),
+		)
+	})
+
+	t.Run("functions", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, pairDeclaration+`
+          let pair = Pair(first: 1, second: "two")
+          let swapped: Pair<String, Int> = pair.swap()
+          let replaced = pair.withFirst(true)
+          let first: Bool = replaced.first
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.TypeID("S.test.Pair<Bool,String>"),
+			RequireGlobalValue(t, checker.Elaboration, "replaced").ID(),
+		)
+	})
+
+	t.Run("generic function", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, pairDeclaration+`
+          fun firstOf<T>(_ pair: Pair<T, String>): T {
+              return pair.first
+          }
+
+          let first = firstOf(Pair(first: [1], second: "two"))
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: sema.IntType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "first"),
+		)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource R {}
+
+          resource Box<T: AnyResource> {
+              let item: @T
+
+              init(item: @T) {
+                  self.item <- item
+              }
+
+              destroy() {
+                  destroy self.item
+              }
+          }
+
+          let box <- create Box(item: <-create R())
+          let box2: @Box<@R> <- create Box<@R>(item: <-create R())
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.TypeID("S.test.Box<S.test.R>"),
+			RequireGlobalValue(t, checker.Elaboration, "box").ID(),
+		)
+	})
+
+	t.Run("interface conformance", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct interface HasCount {
+              fun count(): Int
+          }
+
+          struct Wrapper<T>: HasCount {
+              let items: [T]
+
+              init(items: [T]) {
+                  self.items = items
+              }
+
+              fun count(): Int {
+                  return self.items.length
+              }
+          }
+
+          let wrapper: {HasCount} = Wrapper(items: ["a", "b"])
+        `)
+		require.NoError(t, err)
+
+		assert.IsType(t,
+			&sema.RestrictedType{},
+			RequireGlobalValue(t, checker.Elaboration, "wrapper"),
+		)
+	})
+
+	t.Run("invalid: missing type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, pairDeclaration+`
+          let pair: Pair = Pair(first: 1, second: 2)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.MissingTypeArgumentsError{}, errs[0])
+	})
+
+	t.Run("invalid: incorrect number of type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, pairDeclaration+`
+          let pair: Pair<Int> = Pair(first: 1, second: 2)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		require.IsType(t, &sema.InvalidTypeArgumentCountError{}, errs[0])
+		require.IsType(t, &sema.TypeMismatchError{}, errs[1])
+	})
+
+	t.Run("invalid: type arguments are invariant", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, pairDeclaration+`
+          let pair: Pair<Int, Int> = Pair(first: 1, second: 2)
+          let pair2: Pair<Integer, Int> = pair
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid: argument type mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, pairDeclaration+`
+          let pair = Pair<Int, String>(first: "one", second: 2)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+		require.IsType(t, &sema.TypeMismatchError{}, errs[1])
+	})
+
+	t.Run("invalid: type bound", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct Box<T: Integer> {
+              let item: T
+
+              init(item: T) {
+                  self.item = item
+              }
+          }
+
+          let box: Box<String> = Box(item: 1)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+		require.IsType(t, &sema.TypeMismatchError{}, errs[1])
+	})
+
+	t.Run("invalid: contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C<T> {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.InvalidTypeParametersError{}, errs[0])
+	})
+
+	t.Run("invalid: enum", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          enum E<T>: UInt8 {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.InvalidTypeParametersError{}, errs[0])
+	})
+}
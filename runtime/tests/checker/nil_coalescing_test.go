@@ -155,18 +155,67 @@ func TestCheckInvalidNilCoalescingRightSubtype(t *testing.T) {
 	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
 }
 
+func TestCheckNilCoalescingNonMatchingTypes(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let x: Int? = 1
+      let y = x ?? false
+   `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t, sema.AnyStructType, RequireGlobalValue(t, checker.Elaboration, "y"))
+}
+
+func TestCheckNilCoalescingNonMatchingSubtypes(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let x: Int? = 1
+      let y = x ?? UInt8(2)
+   `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t, sema.IntegerType, RequireGlobalValue(t, checker.Elaboration, "y"))
+}
+
+func TestCheckNilCoalescingNonMatchingOptionalRightHandSide(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let x: Int? = 1
+      let y: UInt8? = 2
+      let z = x ?? y
+   `)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.OptionalType{Type: sema.IntegerType},
+		RequireGlobalValue(t, checker.Elaboration, "z"),
+	)
+}
+
 func TestCheckInvalidNilCoalescingNonMatchingTypes(t *testing.T) {
 
 	t.Parallel()
 
 	_, err := ParseAndCheck(t, `
+      resource R {}
+
       let x: Int? = 1
-      let y = x ?? false
+      let y = x ?? <-create R()
    `)
 
-	errs := ExpectCheckerErrors(t, err, 1)
+	errs := ExpectCheckerErrors(t, err, 2)
 
-	assert.IsType(t, &sema.InvalidBinaryOperandError{}, errs[0])
+	assert.IsType(t, &sema.InvalidNilCoalescingRightResourceOperandError{}, errs[0])
+	assert.IsType(t, &sema.InvalidBinaryOperandError{}, errs[1])
 }
 
 func TestCheckNilCoalescingAny(t *testing.T) {
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/sema"
 	. "github.com/onflow/cadence/runtime/tests/utils"
@@ -359,3 +360,59 @@ nextMatcher:
 		assert.NotNil(t, checker.Occurrences.Find(matcher.EndPos))
 	}
 }
+
+func TestCheckOccurrencesImportedMember(t *testing.T) {
+
+	t.Parallel()
+
+	importedChecker, err := ParseAndCheckWithOptions(t,
+		`
+          pub struct S {
+              pub let x: Int
+              init() {
+                  self.x = 1
+              }
+          }
+        `,
+		ParseAndCheckOptions{
+			Location: ImportedLocation,
+			Options: []sema.Option{
+				sema.WithPositionInfoEnabled(true),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	checker, err := ParseAndCheckWithOptions(t,
+		`
+          import S from "imported"
+
+          fun test(s: S): Int {
+              return s.x
+          }
+        `,
+		ParseAndCheckOptions{
+			Options: []sema.Option{
+				sema.WithPositionInfoEnabled(true),
+				sema.WithImportHandler(
+					func(_ *sema.Checker, _ common.Location, _ ast.Range) (sema.Import, error) {
+						return sema.ElaborationImport{
+							Elaboration: importedChecker.Elaboration,
+						}, nil
+					},
+				),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	occurrence := checker.Occurrences.Find(sema.Position{Line: 5, Column: 23})
+	require.NotNil(t, occurrence)
+
+	// The occurrence of the imported member has the origin of the member declaration
+
+	importedOrigin := importedChecker.Elaboration.MemberOrigins[importedChecker.Elaboration.CompositeTypes["S.imported.S"]]["x"]
+	require.NotNil(t, importedOrigin)
+
+	assert.Same(t, importedOrigin, occurrence.Origin)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/sema"
 )
@@ -368,45 +369,7 @@ func TestCheckFunctionArgumentTypeInference(t *testing.T) {
 			},
 		)
 
-		errs := ExpectCheckerErrors(t, err, 2)
-
-		require.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[0])
-		typeParamMismatchErr := errs[0].(*sema.TypeParameterTypeMismatchError)
-		assert.Equal(
-			t,
-			&sema.VariableSizedType{
-				Type: sema.Int8Type,
-			},
-			typeParamMismatchErr.ExpectedType,
-		)
-
-		assert.Equal(
-			t,
-			&sema.VariableSizedType{
-				Type: sema.IntType,
-			},
-			typeParamMismatchErr.ActualType,
-		)
-
-		require.IsType(t, &sema.TypeMismatchError{}, errs[1])
-		typeMismatchErr := errs[1].(*sema.TypeMismatchError)
-
-		assert.Equal(
-			t,
-			&sema.VariableSizedType{
-				Type: sema.Int8Type,
-			},
-			typeMismatchErr.ExpectedType,
-		)
-
-		assert.Equal(
-			t,
-			&sema.VariableSizedType{
-				Type: sema.IntType,
-			},
-			typeMismatchErr.ActualType,
-		)
-
+		require.NoError(t, err)
 	})
 }
 
@@ -908,6 +871,94 @@ func TestCheckArraySupertypeInference(t *testing.T) {
 					},
 				},
 			},
+			{
+				name: "restricted values with common restrictions",
+				code: `
+                    let foo: Foo{I1, I2} = Foo()
+                    let bar: Bar{I2, I3} = Bar()
+                    let x = [foo, bar]
+
+                    pub struct interface I1 {}
+
+                    pub struct interface I2 {}
+
+                    pub struct interface I3 {}
+
+                    pub struct Foo: I1, I2 {}
+
+                    pub struct Bar: I2, I3 {}
+                `,
+				expectedElementType: &sema.RestrictedType{
+					Type: sema.AnyStructType,
+					Restrictions: []*sema.InterfaceType{
+						{
+							Location:      common.StringLocation("test"),
+							Identifier:    "I2",
+							CompositeKind: common.CompositeKindStructure,
+						},
+					},
+				},
+			},
+			{
+				name: "restricted and composite values",
+				code: `
+                    let foo: {I1} = Foo()
+                    let x = [foo, Bar()]
+
+                    pub struct interface I1 {}
+
+                    pub struct Foo: I1 {}
+
+                    pub struct Bar: I1 {}
+                `,
+				expectedElementType: &sema.RestrictedType{
+					Type: sema.AnyStructType,
+					Restrictions: []*sema.InterfaceType{
+						{
+							Location:      common.StringLocation("test"),
+							Identifier:    "I1",
+							CompositeKind: common.CompositeKindStructure,
+						},
+					},
+				},
+			},
+			{
+				name: "composite values with nil",
+				code: `
+                    let x = [Foo(), nil]
+
+                    pub struct Foo {}
+                `,
+				expectedElementType: &sema.OptionalType{
+					Type: &sema.CompositeType{
+						Location:   common.StringLocation("test"),
+						Identifier: "Foo",
+						Kind:       common.CompositeKindStructure,
+					},
+				},
+			},
+			{
+				name: "array values with nil",
+				code: `let x = [[1], nil]`,
+				expectedElementType: &sema.OptionalType{
+					Type: &sema.VariableSizedType{
+						Type: sema.IntType,
+					},
+				},
+			},
+			{
+				name: "optional and non-optional values",
+				code: `
+                    let one: Int? = 1
+                    let two: Int?? = 2
+                    let x = [one, two, 3]
+                `,
+				expectedElementType: &sema.OptionalType{
+					Type: &sema.OptionalType{
+						Type: sema.IntType,
+					},
+				},
+			},
 			{
 				name: "nested non-covariant constant sized",
 				code: `let x = [[[1, 2] as [Int; 2]], [["foo", "bar", "baz"] as [String; 3]], [[5.3, 6.4] as [Fix64; 2]]]`,
@@ -1183,3 +1234,111 @@ func TestCheckDictionarySupertypeInference(t *testing.T) {
 		require.IsType(t, &sema.TypeAnnotationRequiredError{}, checkerErr[0])
 	})
 }
+
+func TestCheckNestedLiteralSupertypeInference(t *testing.T) {
+
+	t.Parallel()
+
+	valueOf := func(t *testing.T, checker *sema.Checker) ast.Expression {
+		declarations := checker.Program.VariableDeclarations()
+		require.Len(t, declarations, 1)
+		return declarations[0].Value
+	}
+
+	anyStructArrayType := &sema.VariableSizedType{
+		Type: sema.AnyStructType,
+	}
+
+	t.Run("array literal", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+            let x = [[1], ["a"]]
+        `)
+		require.NoError(t, err)
+
+		value := valueOf(t, checker).(*ast.ArrayExpression)
+
+		for _, element := range value.Values {
+			assert.Equal(t,
+				anyStructArrayType,
+				checker.Elaboration.ArrayExpressionArrayType[element.(*ast.ArrayExpression)],
+			)
+		}
+
+		assert.Equal(t,
+			[]sema.Type{anyStructArrayType, anyStructArrayType},
+			checker.Elaboration.ArrayExpressionArgumentTypes[value],
+		)
+	})
+
+	t.Run("dictionary literal", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+            let x = {"a": {1: true}, "b": {2: "c"}}
+        `)
+		require.NoError(t, err)
+
+		value := valueOf(t, checker).(*ast.DictionaryExpression)
+
+		expectedType := &sema.DictionaryType{
+			KeyType:   sema.IntType,
+			ValueType: sema.AnyStructType,
+		}
+
+		for _, entry := range value.Entries {
+			assert.Equal(t,
+				expectedType,
+				checker.Elaboration.DictionaryExpressionType[entry.Value.(*ast.DictionaryExpression)],
+			)
+		}
+	})
+
+	t.Run("conditional", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+            let x = true ? [1] : ["a"]
+        `)
+		require.NoError(t, err)
+
+		value := valueOf(t, checker).(*ast.ConditionalExpression)
+
+		assert.Equal(t,
+			anyStructArrayType,
+			checker.Elaboration.ArrayExpressionArrayType[value.Then.(*ast.ArrayExpression)],
+		)
+		assert.Equal(t,
+			anyStructArrayType,
+			checker.Elaboration.ArrayExpressionArrayType[value.Else.(*ast.ArrayExpression)],
+		)
+	})
+
+	t.Run("optional conditional", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+            let x = [true ? [1] : nil, ["a"]]
+        `)
+		require.NoError(t, err)
+
+		xType := RequireGlobalValue(t, checker.Elaboration, "x")
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: &sema.OptionalType{
+					Type: anyStructArrayType,
+				},
+			},
+			xType,
+		)
+
+		value := valueOf(t, checker).(*ast.ArrayExpression)
+		conditional := value.Values[0].(*ast.ConditionalExpression)
+
+		assert.Equal(t,
+			anyStructArrayType,
+			checker.Elaboration.ArrayExpressionArrayType[conditional.Then.(*ast.ArrayExpression)],
+		)
+	})
+}
@@ -0,0 +1,604 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestCheckTypeAlias(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          typealias Quantity = Int
+
+          let x: Quantity = 1
+          let y: Int = x
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalType(t, checker.Elaboration, "Quantity"),
+		)
+
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("alias of alias", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          typealias Quantity = Int
+          typealias Quantities = [Quantity]
+
+          let xs: Quantities = [1, 2]
+          let ys: [Int] = xs
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{Type: sema.IntType},
+			RequireGlobalType(t, checker.Elaboration, "Quantities"),
+		)
+	})
+
+	t.Run("restricted reference type", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource interface Receiver {}
+          resource interface Balance {}
+          resource Vault: Receiver, Balance {}
+
+          typealias VaultRef = &Vault{Receiver, Balance}
+
+          fun test(ref: VaultRef): &Vault{Balance, Receiver} {
+              return ref
+          }
+        `)
+		require.NoError(t, err)
+
+		require.IsType(t,
+			&sema.ReferenceType{},
+			RequireGlobalType(t, checker.Elaboration, "VaultRef"),
+		)
+
+		testType := RequireGlobalValue(t, checker.Elaboration, "test").(*sema.FunctionType)
+
+		assert.True(t,
+			testType.Parameters[0].TypeAnnotation.Type.Equal(
+				testType.ReturnTypeAnnotation.Type,
+			),
+		)
+	})
+
+	t.Run("restriction", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource interface Receiver {}
+          resource Vault: Receiver {}
+
+          typealias R = Receiver
+
+          fun test(ref: &AnyResource{R}): &AnyResource{Receiver} {
+              return ref
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource interface Receiver {}
+          resource Vault: Receiver {}
+
+          typealias ReceiverRef = &Vault{Receiver}
+
+          let type = Type<ReceiverRef>()
+
+          fun test(capability: Capability<ReceiverRef>): ReceiverRef? {
+              return capability.borrow()
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("conformance", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource interface I {}
+          typealias J = I
+          resource R: J {}
+
+          fun test(r: @R): @R{I} {
+              return <-r
+          }
+        `)
+		require.NoError(t, err)
+
+		compositeType := RequireGlobalType(t, checker.Elaboration, "R").(*sema.CompositeType)
+
+		assert.Equal(t,
+			[]*sema.InterfaceType{
+				RequireGlobalType(t, checker.Elaboration, "I").(*sema.InterfaceType),
+			},
+			compositeType.ExplicitInterfaceConformances,
+		)
+	})
+
+	t.Run("enum raw type", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          typealias U = UInt8
+          enum E: U { case a }
+
+          let x: UInt8 = E.a.rawValue
+        `)
+		require.NoError(t, err)
+
+		compositeType := RequireGlobalType(t, checker.Elaboration, "E").(*sema.CompositeType)
+
+		assert.Equal(t, sema.UInt8Type, compositeType.EnumRawType)
+	})
+
+	t.Run("restricted type of conformance", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource interface I {}
+          typealias J = I
+          typealias Ref = &R{J}
+          resource R: J {}
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("restricted type of non-conformance", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource interface I {}
+          resource R {}
+          typealias Ref = &R{I}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidNonConformanceRestrictionError{}, errs[0])
+	})
+
+	t.Run("conformance nested in contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              resource interface I {}
+              pub typealias J = I
+              resource R: J {}
+
+              pub typealias Ref = &R{J}
+          }
+
+          fun test(ref: C.Ref): &C.R{C.I} {
+              return ref
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("nested in contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              resource interface I {}
+              resource R: I {}
+
+              pub typealias Ref = &R{I}
+
+              pub fun test(_ ref: Ref): &R{I} {
+                  return ref
+              }
+          }
+
+          fun test(ref: C.Ref): &C.R{C.I} {
+              return C.test(ref)
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("nested in contract interface", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract interface CI {
+              pub typealias Quantities = [Int]
+
+              pub fun test(_ numbers: Quantities)
+          }
+
+          contract C: CI {
+              pub fun test(_ numbers: [Int]) {}
+          }
+
+          let numbers: CI.Quantities = [1]
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("nested in contract, referring to top-level alias", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias Quantity = Int
+
+          contract C {
+              pub typealias Quantities = [Quantity]
+
+              pub let numbers: Quantities
+
+              init() {
+                  self.numbers = []
+              }
+          }
+
+          let numbers: [Int] = C.numbers
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("nested in structure", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              pub typealias Quantity = Int
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidNestedDeclarationError{}, errs[0])
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias Quantity = Int
+
+          let x: Quantity = "1"
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+
+		assert.Equal(t,
+			"expected `Quantity` (`Int`), got `String`",
+			errs[0].(*sema.TypeMismatchError).SecondaryError(),
+		)
+	})
+
+	t.Run("type mismatch, alias in type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias Quantity = Int
+
+          let xs: [Quantity] = "1"
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+
+		assert.Equal(t,
+			"expected `[Quantity]` (`[Int]`), got `String`",
+			errs[0].(*sema.TypeMismatchError).SecondaryError(),
+		)
+	})
+
+	t.Run("variable type alias", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          contract C {
+              pub typealias Quantity = Int
+          }
+
+          let x: C.Quantity = 1
+          let y: Int = 2
+        `)
+		require.NoError(t, err)
+
+		x, ok := checker.Elaboration.GlobalValues.Get("x")
+		require.True(t, ok)
+
+		// The type of the variable is the aliased type,
+		// the alias is only kept to display the type
+
+		assert.Equal(t, sema.IntType, x.Type)
+		assert.Equal(t, "C.Quantity", x.TypeAlias)
+
+		y, ok := checker.Elaboration.GlobalValues.Get("y")
+		require.True(t, ok)
+
+		assert.Equal(t, "", y.TypeAlias)
+	})
+
+	t.Run("undeclared type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias X = Y
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("self-referential", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias X = [X]
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.CyclicTypeAliasError{}, errs[0])
+	})
+
+	t.Run("cyclic", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias X = Y
+          typealias Y = {String: X}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.CyclicTypeAliasError{}, errs[0])
+		assert.Equal(t, "X", errs[0].(*sema.CyclicTypeAliasError).Name)
+	})
+
+	t.Run("cyclic, nested in contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias X = C.Y
+
+          contract C {
+              pub typealias Y = [X]
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.CyclicTypeAliasError{}, errs[0])
+	})
+
+	t.Run("forward reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          typealias E = F
+          typealias F = Int
+
+          let e: E = 1
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalValue(t, checker.Elaboration, "e"),
+		)
+	})
+
+	t.Run("referring to alias nested in contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          pub typealias CR = C.Ref
+
+          contract C {
+              pub resource interface I {}
+              pub resource R: I {}
+
+              pub typealias Ref = &R{I}
+          }
+
+          fun test(ref: CR): &C.R{C.I} {
+              return ref
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("nested in contract, forward reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              pub typealias Quantities = [Quantity]
+              pub typealias Quantity = D.Amount
+          }
+
+          contract D {
+              pub typealias Amount = UInt64
+          }
+
+          let quantities: C.Quantities = [1]
+          let amounts: [UInt64] = quantities
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("redeclaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          typealias S = Int
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.RedeclarationError{}, errs[0])
+	})
+
+	t.Run("private", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          priv typealias Quantity = Int
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAccessModifierError{}, errs[0])
+	})
+}
+
+func TestCheckTypeAliasImport(t *testing.T) {
+
+	t.Parallel()
+
+	importedChecker, err := ParseAndCheckWithOptions(t,
+		`
+          pub typealias Quantities = [Int]
+
+          pub contract C {
+              pub resource interface I {}
+              pub resource R: I {}
+
+              pub typealias Ref = &R{I}
+          }
+        `,
+		ParseAndCheckOptions{
+			Location: utils.ImportedLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = ParseAndCheckWithOptions(t,
+		`
+          import Quantities, C from "imported"
+
+          let numbers: Quantities = [1, 2]
+
+          fun test(ref: C.Ref): &C.R{C.I} {
+              return ref
+          }
+        `,
+		ParseAndCheckOptions{
+			Options: []sema.Option{
+				sema.WithImportHandler(
+					func(_ *sema.Checker, _ common.Location, _ ast.Range) (sema.Import, error) {
+						return sema.ElaborationImport{
+							Elaboration: importedChecker.Elaboration,
+						}, nil
+					},
+				),
+			},
+		},
+	)
+	require.NoError(t, err)
+}
+
+func TestCheckTypeAliasOccurrences(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheckWithOptions(t, `
+        /// The numbers
+        typealias Quantities = [Int]
+        let xs: Quantities = []
+        `,
+		ParseAndCheckOptions{
+			Options: []sema.Option{
+				sema.WithPositionInfoEnabled(true),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	occurrence := checker.Occurrences.Find(sema.Position{Line: 4, Column: 16})
+	require.NotNil(t, occurrence)
+
+	origin := occurrence.Origin
+	require.NotNil(t, origin)
+
+	assert.Equal(t, common.DeclarationKindTypeAlias, origin.DeclarationKind)
+	assert.Equal(t, &sema.VariableSizedType{Type: sema.IntType}, origin.Type)
+	assert.Equal(t, " The numbers", origin.DocString)
+	assert.Equal(t, 3, origin.StartPos.Line)
+	assert.Equal(t, 18, origin.StartPos.Column)
+}
@@ -1883,3 +1883,227 @@ func TestInterpretAccount_StorageFields(t *testing.T) {
 		}
 	}
 }
+
+func TestInterpretAccount_storageIteration(t *testing.T) {
+
+	t.Parallel()
+
+	const setupCode = `
+      struct S {}
+
+      resource R {}
+
+      fun setup() {
+          authAccount.save(1, to: /storage/a)
+          authAccount.save(S(), to: /storage/b)
+          authAccount.save(<-create R(), to: /storage/c)
+          authAccount.link<&R>(/public/c, target: /storage/c)
+          authAccount.link<&S>(/private/b, target: /storage/b)
+      }
+    `
+
+	address := interpreter.NewAddressValueFromBytes([]byte{42})
+
+	stringValues := func(t *testing.T, value interpreter.Value) []string {
+		array, ok := value.(*interpreter.ArrayValue)
+		require.True(t, ok)
+
+		var result []string
+		array.Iterate(func(element interpreter.Value) (resume bool) {
+			result = append(result, element.String())
+			return true
+		})
+		return result
+	}
+
+	t.Run("paths", func(t *testing.T) {
+
+		t.Parallel()
+
+		for _, auth := range []bool{true, false} {
+
+			code := setupCode + `
+              fun publicPaths(): [PublicPath] {
+                  return account.publicPaths
+              }
+            `
+			if auth {
+				code += `
+                  fun storagePaths(): [StoragePath] {
+                      return account.storagePaths
+                  }
+
+                  fun privatePaths(): [PrivatePath] {
+                      return account.privatePaths
+                  }
+                `
+			}
+
+			inter, _ := testAccount(t, address, auth, code)
+
+			_, err := inter.Invoke("setup")
+			require.NoError(t, err)
+
+			value, err := inter.Invoke("publicPaths")
+			require.NoError(t, err)
+			require.Equal(t, []string{"/public/c"}, stringValues(t, value))
+
+			if !auth {
+				continue
+			}
+
+			value, err = inter.Invoke("storagePaths")
+			require.NoError(t, err)
+			require.ElementsMatch(t,
+				[]string{"/storage/a", "/storage/b", "/storage/c"},
+				stringValues(t, value),
+			)
+
+			value, err = inter.Invoke("privatePaths")
+			require.NoError(t, err)
+			require.Equal(t, []string{"/private/b"}, stringValues(t, value))
+		}
+	})
+
+	t.Run("forEach", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, _ := testAccount(t, address, true, setupCode+`
+          fun stored(): [String] {
+              let entries: [String] = []
+              account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+                  entries.append(path.toString().concat(": ").concat(type.identifier))
+                  return true
+              })
+              return entries
+          }
+
+          fun public(): [String] {
+              let entries: [String] = []
+              account.forEachPublic(fun (path: PublicPath, type: Type): Bool {
+                  entries.append(path.toString().concat(": ").concat(type.identifier))
+                  return true
+              })
+              return entries
+          }
+
+          fun private(): [String] {
+              let entries: [String] = []
+              account.forEachPrivate(fun (path: PrivatePath, type: Type): Bool {
+                  entries.append(path.toString().concat(": ").concat(type.identifier))
+                  return true
+              })
+              return entries
+          }
+        `)
+
+		_, err := inter.Invoke("setup")
+		require.NoError(t, err)
+
+		value, err := inter.Invoke("stored")
+		require.NoError(t, err)
+		require.ElementsMatch(t,
+			[]string{
+				`"/storage/a: Int"`,
+				`"/storage/b: S.test.S"`,
+				`"/storage/c: S.test.R"`,
+			},
+			stringValues(t, value),
+		)
+
+		value, err = inter.Invoke("public")
+		require.NoError(t, err)
+		require.Equal(t,
+			[]string{`"/public/c: Capability<&S.test.R>"`},
+			stringValues(t, value),
+		)
+
+		value, err = inter.Invoke("private")
+		require.NoError(t, err)
+		require.Equal(t,
+			[]string{`"/private/b: Capability<&S.test.S>"`},
+			stringValues(t, value),
+		)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, _ := testAccount(t, address, true, setupCode+`
+          fun test(): Int {
+              var count = 0
+              account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+                  count = count + 1
+                  return false
+              })
+              return count
+          }
+        `)
+
+		_, err := inter.Invoke("setup")
+		require.NoError(t, err)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+		require.Equal(t, interpreter.NewIntValueFromInt64(1), value)
+	})
+
+	t.Run("mutation", func(t *testing.T) {
+
+		t.Parallel()
+
+		// Values which are removed or added during the iteration are not visited
+
+		inter, _ := testAccount(t, address, true, `
+          fun test(): Int {
+              account.save(1, to: /storage/a)
+              account.save(2, to: /storage/b)
+              account.save(3, to: /storage/c)
+
+              var count = 0
+              account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+                  count = count + 1
+                  for other in [/storage/a, /storage/b, /storage/c] {
+                      if other.toString() != path.toString() {
+                          account.load<Int>(from: other)
+                      }
+                  }
+                  account.save(4, to: /storage/d)
+                  return true
+              })
+              return count
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+		require.Equal(t, interpreter.NewIntValueFromInt64(1), value)
+	})
+
+	t.Run("metering", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, _ := testAccount(t, address, true, setupCode+`
+          fun test() {
+              account.storagePaths
+          }
+        `)
+
+		_, err := inter.Invoke("setup")
+		require.NoError(t, err)
+
+		var iterations uint
+		inter.SetOnMeterComputationHandler(func(compKind common.ComputationKind, intensity uint) {
+			if compKind == common.ComputationKindStorageIteration {
+				iterations += intensity
+			}
+		})
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+		require.Equal(t, uint(3), iterations)
+	})
+}
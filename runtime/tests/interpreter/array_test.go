@@ -19,7 +19,14 @@
 package interpreter_test
 
 import (
+	"testing"
+
+	"github.com/onflow/atree"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	. "github.com/onflow/cadence/runtime/tests/utils"
 )
 
 func arrayElements(inter *interpreter.Interpreter, array *interpreter.ArrayValue) []interpreter.Value {
@@ -44,3 +51,633 @@ func dictionaryKeyValues(dict *interpreter.DictionaryValue) []interpreter.Value
 	})
 	return result
 }
+
+func TestInterpretArrayMap(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("variable-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let xs = [1, 2, 3]
+          let ys = xs.map(fun (x: Int): String {
+              return x.toString()
+          })
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeString,
+				},
+				common.Address{},
+				interpreter.NewStringValue("1"),
+				interpreter.NewStringValue("2"),
+				interpreter.NewStringValue("3"),
+			),
+			inter.Globals["ys"].GetValue(),
+		)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeInt,
+				},
+				common.Address{},
+				interpreter.NewIntValueFromInt64(1),
+				interpreter.NewIntValueFromInt64(2),
+				interpreter.NewIntValueFromInt64(3),
+			),
+			inter.Globals["xs"].GetValue(),
+		)
+	})
+
+	t.Run("constant-sized", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let xs: [Int; 3] = [1, 2, 3]
+          let ys = xs.map(fun (x: Int): Bool {
+              return x > 1
+          })
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.ConstantSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeBool,
+					Size: 3,
+				},
+				common.Address{},
+				interpreter.BoolValue(false),
+				interpreter.BoolValue(true),
+				interpreter.BoolValue(true),
+			),
+			inter.Globals["ys"].GetValue(),
+		)
+	})
+
+	t.Run("generic function", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun wrap<T>(_ xs: [T]): [[T]] {
+              return xs.map(fun (x: T): [T] {
+                  return [x]
+              })
+          }
+
+          let ys = wrap(["a", "b"])
+        `)
+
+		stringArrayType := interpreter.VariableSizedStaticType{
+			Type: interpreter.PrimitiveStaticTypeString,
+		}
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: stringArrayType,
+				},
+				common.Address{},
+				interpreter.NewArrayValue(
+					inter,
+					stringArrayType,
+					common.Address{},
+					interpreter.NewStringValue("a"),
+				),
+				interpreter.NewArrayValue(
+					inter,
+					stringArrayType,
+					common.Address{},
+					interpreter.NewStringValue("b"),
+				),
+			),
+			inter.Globals["ys"].GetValue(),
+		)
+	})
+
+	t.Run("metering", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [Int] {
+              return [1, 2, 3].map(fun (x: Int): Int {
+                  return x * 2
+              })
+          }
+        `)
+
+		var iterations uint
+		inter.SetOnMeterComputationHandler(func(compKind common.ComputationKind, intensity uint) {
+			if compKind == common.ComputationKindLoop {
+				iterations += intensity
+			}
+		})
+
+		_, err := inter.Invoke("test")
+		require.NoError(t, err)
+		require.Equal(t, uint(3), iterations)
+	})
+}
+
+func TestInterpretArrayFilter(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs: [[Int]; 4] = [[1], [2], [3], [4]]
+      let ys = xs.filter(fun (x: [Int]): Bool {
+          return x[0] % 2 == 1
+      })
+    `)
+
+	intArrayType := interpreter.VariableSizedStaticType{
+		Type: interpreter.PrimitiveStaticTypeInt,
+	}
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewArrayValue(
+			inter,
+			interpreter.VariableSizedStaticType{
+				Type: intArrayType,
+			},
+			common.Address{},
+			interpreter.NewArrayValue(
+				inter,
+				intArrayType,
+				common.Address{},
+				interpreter.NewIntValueFromInt64(1),
+			),
+			interpreter.NewArrayValue(
+				inter,
+				intArrayType,
+				common.Address{},
+				interpreter.NewIntValueFromInt64(3),
+			),
+		),
+		inter.Globals["ys"].GetValue(),
+	)
+
+	require.Equal(t,
+		4,
+		inter.Globals["xs"].GetValue().(*interpreter.ArrayValue).Count(),
+	)
+}
+
+func TestInterpretArrayReduce(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = [1, 2, 3]
+
+      let sum = xs.reduce(initial: 0, fun (acc: Int, x: Int): Int {
+          return acc + x
+      })
+
+      let string = xs.reduce(initial: "", fun (acc: String, x: Int): String {
+          return acc.concat(x.toString())
+      })
+
+      let empty = ([] as [Int]).reduce(initial: 42, fun (acc: Int, x: Int): Int {
+          return acc + x
+      })
+    `)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(6),
+		inter.Globals["sum"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewStringValue("123"),
+		inter.Globals["string"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewIntValueFromInt64(42),
+		inter.Globals["empty"].GetValue(),
+	)
+}
+
+func TestInterpretArrayReverse(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = [1, 2, 3]
+      let ys = xs.reverse()
+
+      let constantSized: [String; 2] = ["a", "b"]
+      let reversedConstantSized = constantSized.reverse()
+    `)
+
+	intArrayType := interpreter.VariableSizedStaticType{
+		Type: interpreter.PrimitiveStaticTypeInt,
+	}
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewArrayValue(
+			inter,
+			intArrayType,
+			common.Address{},
+			interpreter.NewIntValueFromInt64(3),
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(1),
+		),
+		inter.Globals["ys"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewArrayValue(
+			inter,
+			intArrayType,
+			common.Address{},
+			interpreter.NewIntValueFromInt64(1),
+			interpreter.NewIntValueFromInt64(2),
+			interpreter.NewIntValueFromInt64(3),
+		),
+		inter.Globals["xs"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewArrayValue(
+			inter,
+			interpreter.ConstantSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeString,
+				Size: 2,
+			},
+			common.Address{},
+			interpreter.NewStringValue("b"),
+			interpreter.NewStringValue("a"),
+		),
+		inter.Globals["reversedConstantSized"].GetValue(),
+	)
+}
+
+func TestInterpretArrayForEach(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("sum", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): Int {
+              var sum = 0
+              [1, 2, 3].forEach(fun (x: Int) {
+                  sum = sum + x
+              })
+              return sum
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(6),
+			value,
+		)
+	})
+
+	t.Run("mutation during iteration", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let xs = [1, 2, 3]
+
+          fun test(): Int {
+              var count = 0
+              xs.forEach(fun (x: Int) {
+                  count = count + 1
+                  xs.append(x)
+              })
+              return count
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(3),
+			value,
+		)
+
+		require.Equal(t,
+			6,
+			inter.Globals["xs"].GetValue().(*interpreter.ArrayValue).Count(),
+		)
+	})
+
+	t.Run("element mutation", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let xs = [[1], [2]]
+
+          fun test() {
+              xs.forEach(fun (x: [Int]) {
+                  x.append(0)
+              })
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		intArrayType := interpreter.VariableSizedStaticType{
+			Type: interpreter.PrimitiveStaticTypeInt,
+		}
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: intArrayType,
+				},
+				common.Address{},
+				interpreter.NewArrayValue(
+					inter,
+					intArrayType,
+					common.Address{},
+					interpreter.NewIntValueFromInt64(1),
+				),
+				interpreter.NewArrayValue(
+					inter,
+					intArrayType,
+					common.Address{},
+					interpreter.NewIntValueFromInt64(2),
+				),
+			),
+			inter.Globals["xs"].GetValue(),
+		)
+	})
+}
+
+func TestInterpretArraySort(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("integers", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [Int] {
+              let xs = [3, 1, 4, 1, 5, 9, 2, 6]
+              xs.sort(by: fun (a: Int, b: Int): Bool {
+                  return a > b
+              })
+              return xs
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeInt,
+				},
+				common.Address{},
+				interpreter.NewIntValueFromInt64(9),
+				interpreter.NewIntValueFromInt64(6),
+				interpreter.NewIntValueFromInt64(5),
+				interpreter.NewIntValueFromInt64(4),
+				interpreter.NewIntValueFromInt64(3),
+				interpreter.NewIntValueFromInt64(2),
+				interpreter.NewIntValueFromInt64(1),
+				interpreter.NewIntValueFromInt64(1),
+			),
+			value,
+		)
+	})
+
+	t.Run("stable", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Entry {
+              let key: Int
+              let name: String
+
+              init(key: Int, name: String) {
+                  self.key = key
+                  self.name = name
+              }
+          }
+
+          fun test(): [String] {
+              let entries: [Entry; 5] = [
+                  Entry(key: 2, name: "a"),
+                  Entry(key: 1, name: "b"),
+                  Entry(key: 2, name: "c"),
+                  Entry(key: 1, name: "d"),
+                  Entry(key: 0, name: "e")
+              ]
+              entries.sort(by: fun (a: Entry, b: Entry): Bool {
+                  return a.key < b.key
+              })
+              let names: [String] = []
+              for entry in entries {
+                  names.append(entry.name)
+              }
+              return names
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeString,
+				},
+				common.Address{},
+				interpreter.NewStringValue("e"),
+				interpreter.NewStringValue("b"),
+				interpreter.NewStringValue("d"),
+				interpreter.NewStringValue("a"),
+				interpreter.NewStringValue("c"),
+			),
+			value,
+		)
+	})
+
+	t.Run("field", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {
+              var xs: [[Int]]
+
+              init() {
+                  self.xs = [[3], [1], [2]]
+              }
+
+              fun sort() {
+                  self.xs.sort(by: fun (a: [Int], b: [Int]): Bool {
+                      return a[0] < b[0]
+                  })
+              }
+          }
+
+          fun test(): [[Int]] {
+              let s = S()
+              s.sort()
+              return s.xs
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		intArrayType := interpreter.VariableSizedStaticType{
+			Type: interpreter.PrimitiveStaticTypeInt,
+		}
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: intArrayType,
+				},
+				common.Address{},
+				interpreter.NewArrayValue(
+					inter,
+					intArrayType,
+					common.Address{},
+					interpreter.NewIntValueFromInt64(1),
+				),
+				interpreter.NewArrayValue(
+					inter,
+					intArrayType,
+					common.Address{},
+					interpreter.NewIntValueFromInt64(2),
+				),
+				interpreter.NewArrayValue(
+					inter,
+					intArrayType,
+					common.Address{},
+					interpreter.NewIntValueFromInt64(3),
+				),
+			),
+			value,
+		)
+	})
+
+	t.Run("sorted", func(t *testing.T) {
+
+		t.Parallel()
+
+		storage := &slabWriteCountingStorage{
+			InMemoryStorage: interpreter.NewInMemoryStorage(),
+		}
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              let xs = [1, 2, 3]
+
+              fun sortAscending() {
+                  xs.sort(by: fun (a: Int, b: Int): Bool {
+                      return a < b
+                  })
+              }
+
+              fun sortDescending() {
+                  xs.sort(by: fun (a: Int, b: Int): Bool {
+                      return a > b
+                  })
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					interpreter.WithStorage(storage),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		// Sorting an already sorted array does not write any elements
+
+		storage.writes = 0
+
+		_, err = inter.Invoke("sortAscending")
+		require.NoError(t, err)
+
+		require.Equal(t, 0, storage.writes)
+
+		_, err = inter.Invoke("sortDescending")
+		require.NoError(t, err)
+
+		require.NotEqual(t, 0, storage.writes)
+	})
+}
+
+// slabWriteCountingStorage is an in-memory storage
+// which counts the number of slabs written to it
+//
+type slabWriteCountingStorage struct {
+	interpreter.InMemoryStorage
+	writes int
+}
+
+func (s *slabWriteCountingStorage) Store(id atree.StorageID, slab atree.Slab) error {
+	s.writes++
+	return s.InMemoryStorage.Store(id, slab)
+}
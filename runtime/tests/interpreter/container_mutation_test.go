@@ -928,3 +928,77 @@ func TestInterpretContainerMutationAfterNilCoalescing(t *testing.T) {
 		result,
 	)
 }
+
+func TestInterpretContainerMutationAfterSupertypeInference(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("conditional", func(t *testing.T) {
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [AnyStruct] {
+              let xs = true ? [1] : ["a"]
+              xs.append("b")
+              return xs
+          }
+        `)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.IsType(t, &interpreter.ArrayValue{}, result)
+		assert.Equal(t,
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeAnyStruct,
+			},
+			result.(*interpreter.ArrayValue).Type,
+		)
+	})
+
+	t.Run("nested array literal", func(t *testing.T) {
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [AnyStruct] {
+              let xs = [[1], ["a"]]
+              xs[0].append("b")
+              return xs[0]
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("nested dictionary literal", func(t *testing.T) {
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): {Int: AnyStruct} {
+              let xs = {"a": {1: true}, "b": {2: "c"}}
+              xs["a"]!.insert(key: 3, "d")
+              return xs["a"]!
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("nil-coalescing", func(t *testing.T) {
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [AnyStruct] {
+              let ys: [Int]? = nil
+              let xs = [ys ?? [1], ["a"]]
+              xs[0].append("b")
+              return xs[0]
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.NoError(t, err)
+	})
+}
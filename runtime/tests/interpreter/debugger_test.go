@@ -0,0 +1,317 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	. "github.com/onflow/cadence/runtime/tests/utils"
+)
+
+const debuggerTestCode = `
+pub struct S {
+    pub let x: Int
+    init(x: Int) {
+        self.x = x
+    }
+    pub fun double(): Int {
+        let y = self.x * 2
+        return y
+    }
+}
+
+pub fun helper(_ a: Int): Int {
+    let s = S(x: a)
+    return s.double()
+}
+
+pub fun test(): Int {
+    var i = 0
+    var sum = 0
+    while i < 3 {
+        sum = sum + helper(i)
+        i = i + 1
+    }
+    return sum
+}
+`
+
+type debuggerTestResult struct {
+	value interpreter.Value
+	err   error
+}
+
+// startDebuggedTest invokes the function `test` of debuggerTestCode with the given debugger,
+// and returns a channel which receives the result
+//
+func startDebuggedTest(t *testing.T, debugger *interpreter.Debugger) <-chan debuggerTestResult {
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		debuggerTestCode,
+		ParseCheckAndInterpretOptions{
+			Options: []interpreter.Option{
+				interpreter.WithDebugger(debugger),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	results := make(chan debuggerTestResult, 1)
+
+	go func() {
+		value, err := inter.Invoke("test")
+		results <- debuggerTestResult{
+			value: value,
+			err:   err,
+		}
+	}()
+
+	return results
+}
+
+func parseDebuggerExpression(t *testing.T, code string) ast.Expression {
+	expression, errs := parser2.ParseExpression(code)
+	require.Empty(t, errs)
+	return expression
+}
+
+func stopLine(stop interpreter.Stop) int {
+	return stop.Statement.StartPosition().Line
+}
+
+func frameNames(frames []interpreter.StackFrame) []string {
+	names := make([]string, 0, len(frames))
+	for _, frame := range frames {
+		names = append(names, frame.FunctionName)
+	}
+	return names
+}
+
+func TestInterpretDebugger(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("pause", func(t *testing.T) {
+
+		t.Parallel()
+
+		debugger := interpreter.NewDebugger()
+		debugger.RequestPause()
+
+		results := startDebuggedTest(t, debugger)
+
+		stop := <-debugger.Stops()
+		assert.Equal(t, 19, stopLine(stop))
+		assert.Nil(t, stop.Breakpoint)
+
+		assert.True(t, debugger.Continue())
+
+		result := <-results
+		require.NoError(t, result.err)
+		assert.Equal(t, interpreter.NewIntValueFromInt64(6), result.value)
+	})
+
+	t.Run("step while running", func(t *testing.T) {
+
+		t.Parallel()
+
+		debugger := interpreter.NewDebugger()
+
+		// The program is not stopped, so stepping stops at the next statement
+
+		stops := make(chan interpreter.Stop)
+		go func() {
+			stops <- debugger.Next()
+		}()
+
+		require.Eventually(t, debugger.PauseRequested, time.Second, time.Millisecond)
+
+		results := startDebuggedTest(t, debugger)
+
+		stop := <-stops
+		assert.Equal(t, 19, stopLine(stop))
+
+		assert.True(t, debugger.Continue())
+
+		result := <-results
+		require.NoError(t, result.err)
+		assert.Equal(t, interpreter.NewIntValueFromInt64(6), result.value)
+	})
+
+	t.Run("breakpoints and stepping", func(t *testing.T) {
+
+		t.Parallel()
+
+		debugger := interpreter.NewDebugger()
+
+		breakpoint := debugger.AddBreakpoint(
+			TestLocation,
+			22,
+			parseDebuggerExpression(t, "i == 2"),
+		)
+
+		results := startDebuggedTest(t, debugger)
+
+		// The breakpoint is only hit when the condition is true
+
+		stop := <-debugger.Stops()
+		assert.Equal(t, breakpoint, stop.Breakpoint)
+		assert.NoError(t, stop.ConditionError)
+		assert.Equal(t, 22, stopLine(stop))
+
+		frames := debugger.StackFrames(stop)
+		assert.Equal(t, []string{"test"}, frameNames(frames))
+
+		value, err := debugger.Evaluate(frames[0], parseDebuggerExpression(t, "i"))
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NewIntValueFromInt64(2), value)
+
+		// Step into the invoked function
+
+		stop = debugger.StepIn()
+		assert.Equal(t, 14, stopLine(stop))
+		assert.Nil(t, stop.Breakpoint)
+
+		frames = debugger.StackFrames(stop)
+		assert.Equal(t, []string{"helper", "test"}, frameNames(frames))
+
+		// The variables of the calling frame can be inspected
+
+		value, err = debugger.Evaluate(frames[1], parseDebuggerExpression(t, "sum + i"))
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NewIntValueFromInt64(4), value)
+
+		assert.Equal(t, 22, frames[1].Statement.StartPosition().Line)
+
+		stop = debugger.StepIn()
+		assert.Equal(t, 5, stopLine(stop))
+		assert.Equal(t,
+			[]string{"S.init", "helper", "test"},
+			frameNames(debugger.StackFrames(stop)),
+		)
+
+		// Step out of the initializer, into the invoking function
+
+		stop = debugger.StepOut()
+		assert.Equal(t, 15, stopLine(stop))
+		assert.Equal(t,
+			[]string{"helper", "test"},
+			frameNames(debugger.StackFrames(stop)),
+		)
+
+		// Step over the invocation of `double`, out of the function
+
+		stop = debugger.StepOver()
+		assert.Equal(t, 23, stopLine(stop))
+		assert.Equal(t,
+			[]string{"test"},
+			frameNames(debugger.StackFrames(stop)),
+		)
+
+		assert.True(t, debugger.RemoveBreakpoint(breakpoint.ID))
+		assert.Empty(t, debugger.Breakpoints())
+
+		assert.True(t, debugger.Continue())
+
+		result := <-results
+		require.NoError(t, result.err)
+		assert.Equal(t, interpreter.NewIntValueFromInt64(6), result.value)
+	})
+
+	t.Run("invalid condition", func(t *testing.T) {
+
+		t.Parallel()
+
+		debugger := interpreter.NewDebugger()
+
+		breakpoint := debugger.AddBreakpoint(
+			TestLocation,
+			22,
+			parseDebuggerExpression(t, "i + 1"),
+		)
+
+		results := startDebuggedTest(t, debugger)
+
+		// The execution is stopped if the condition cannot be evaluated to a boolean
+
+		stop := <-debugger.Stops()
+		assert.Equal(t, breakpoint, stop.Breakpoint)
+		assert.Error(t, stop.ConditionError)
+
+		debugger.ClearBreakpoints(TestLocation)
+
+		assert.True(t, debugger.Continue())
+
+		result := <-results
+		require.NoError(t, result.err)
+	})
+
+	t.Run("evaluate", func(t *testing.T) {
+
+		t.Parallel()
+
+		debugger := interpreter.NewDebugger()
+
+		debugger.AddBreakpoint(TestLocation, 9, nil)
+
+		results := startDebuggedTest(t, debugger)
+
+		stop := <-debugger.Stops()
+		assert.Equal(t, 9, stopLine(stop))
+
+		frames := debugger.StackFrames(stop)
+		assert.Equal(t,
+			[]string{"S.double", "helper", "test"},
+			frameNames(frames),
+		)
+
+		value, err := debugger.Evaluate(frames[0], parseDebuggerExpression(t, "self.x + y"))
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NewIntValueFromInt64(0), value)
+
+		// Functions can be invoked
+
+		value, err = debugger.Evaluate(frames[1], parseDebuggerExpression(t, "helper(21)"))
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NewIntValueFromInt64(42), value)
+
+		// Type errors are reported
+
+		_, err = debugger.Evaluate(frames[0], parseDebuggerExpression(t, "self.x + true"))
+		require.Error(t, err)
+
+		// Variables of other frames are not in scope
+
+		_, err = debugger.Evaluate(frames[0], parseDebuggerExpression(t, "sum"))
+		require.Error(t, err)
+
+		debugger.ClearBreakpoints(TestLocation)
+
+		assert.True(t, debugger.Continue())
+
+		result := <-results
+		require.NoError(t, result.err)
+	})
+}
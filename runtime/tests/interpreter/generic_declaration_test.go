@@ -0,0 +1,431 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	. "github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestInterpretGenericFunctionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("inferred type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun firstOrNil<T>(_ xs: [T]): T? {
+              if xs.length == 0 {
+                  return nil
+              }
+              return xs[0]
+          }
+
+          fun test(): [Int?] {
+              let empty: [Int] = []
+              return [firstOrNil([1, 2]), firstOrNil(empty)]
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.OptionalStaticType{
+						Type: interpreter.PrimitiveStaticTypeInt,
+					},
+				},
+				common.Address{},
+				interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(1)),
+				interpreter.NilValue{},
+			),
+			value,
+		)
+	})
+
+	t.Run("static types of values", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun wrap<T>(_ x: T): [T] {
+              return [x]
+          }
+
+          fun entry<V>(_ key: String, _ value: V): {String: V} {
+              return {key: value}
+          }
+
+          fun test(): [String] {
+              return [
+                  wrap("a").getType().identifier,
+                  wrap(wrap(true)).getType().identifier,
+                  entry("a", 1).getType().identifier
+              ]
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeString,
+				},
+				common.Address{},
+				interpreter.NewStringValue("[String]"),
+				interpreter.NewStringValue("[[Bool]]"),
+				interpreter.NewStringValue("{String:Int}"),
+			),
+			value,
+		)
+	})
+
+	t.Run("optional type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun some<T>(_ x: T): T? {
+              return x
+          }
+
+          fun test(): Int?? {
+              let x: Int? = 1
+              return some(x)
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewSomeValueNonCopying(
+				interpreter.NewSomeValueNonCopying(
+					interpreter.NewIntValueFromInt64(1),
+				),
+			),
+			value,
+		)
+	})
+
+	t.Run("closure", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun wrapper<T>(_ x: T): ((): [T]) {
+              return fun (): [T] {
+                  return [x]
+              }
+          }
+
+          fun test(): String {
+              let f = wrapper(1)
+              return f().getType().identifier
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewStringValue("[Int]"),
+			value,
+		)
+	})
+
+	t.Run("dynamic cast", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun cast<T>(_ x: AnyStruct): T? {
+              return x as? T
+          }
+
+          fun test(): [Int?] {
+              return [cast<Int>(1), cast<Int>("1")]
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.OptionalStaticType{
+						Type: interpreter.PrimitiveStaticTypeInt,
+					},
+				},
+				common.Address{},
+				interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(1)),
+				interpreter.NilValue{},
+			),
+			value,
+		)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {
+              let id: Int
+
+              init(id: Int) {
+                  self.id = id
+              }
+          }
+
+          fun identity<T: AnyResource>(_ x: @T): @T {
+              return <-x
+          }
+
+          fun test(): Int {
+              let r <- identity(<-create R(id: 42))
+              let id = r.id
+              destroy r
+              return id
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewIntValueFromInt64(42),
+			value,
+		)
+	})
+}
+
+func TestInterpretGenericCompositeDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	const pairDeclaration = `
+      struct Pair<A, B> {
+          let first: A
+          let second: B
+
+          init(first: A, second: B) {
+              self.first = first
+              self.second = second
+          }
+
+          fun swap(): Pair<B, A> {
+              return Pair(first: self.second, second: self.first)
+          }
+
+          fun withFirst<C>(_ first: C): Pair<C, B> {
+              return Pair(first: first, second: self.second)
+          }
+
+          fun firsts(): [A] {
+              return [self.first]
+          }
+      }
+    `
+
+	t.Run("fields and functions", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, pairDeclaration+`
+          fun test(): [String] {
+              let pair = Pair(first: 1, second: "two")
+              let swapped = pair.swap()
+              let replaced = pair.withFirst(true)
+              return [
+                  pair.second,
+                  swapped.first,
+                  pair.getType().identifier,
+                  swapped.getType().identifier,
+                  replaced.getType().identifier,
+                  pair.firsts().getType().identifier
+              ]
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeString,
+				},
+				common.Address{},
+				interpreter.NewStringValue("two"),
+				interpreter.NewStringValue("two"),
+				interpreter.NewStringValue("S.test.Pair<Int,String>"),
+				interpreter.NewStringValue("S.test.Pair<String,Int>"),
+				interpreter.NewStringValue("S.test.Pair<Bool,String>"),
+				interpreter.NewStringValue("[Int]"),
+			),
+			value,
+		)
+	})
+
+	t.Run("static type", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, pairDeclaration+`
+          let pair = Pair<Int8, String>(first: Int8(1), second: "two")
+        `)
+
+		variable, ok := inter.Globals.Get("pair")
+		require.True(t, ok)
+
+		pair := variable.GetValue()
+
+		require.Equal(t,
+			interpreter.CompositeStaticType{
+				Location:            TestLocation,
+				QualifiedIdentifier: "Pair",
+				TypeID:              "S.test.Pair",
+				TypeArguments: []interpreter.StaticType{
+					interpreter.PrimitiveStaticTypeInt8,
+					interpreter.PrimitiveStaticTypeString,
+				},
+			},
+			pair.StaticType(),
+		)
+	})
+
+	t.Run("dynamic cast", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, pairDeclaration+`
+          fun test(): [Bool] {
+              let pair: AnyStruct = Pair(first: 1, second: "two")
+              return [
+                  (pair as? Pair<Int, String>) != nil,
+                  (pair as? Pair<String, Int>) != nil,
+                  (pair as? Pair<Int, String?>) != nil
+              ]
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeBool,
+				},
+				common.Address{},
+				interpreter.BoolValue(true),
+				interpreter.BoolValue(false),
+				interpreter.BoolValue(false),
+			),
+			value,
+		)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {}
+
+          resource Box<T: AnyResource> {
+              let item: @T
+
+              init(item: @T) {
+                  self.item <- item
+              }
+
+              fun itemType(): Type {
+                  return Type<@T>()
+              }
+
+              destroy() {
+                  destroy self.item
+              }
+          }
+
+          fun test(): [String] {
+              let box <- create Box(item: <-create R())
+              let identifiers = [
+                  box.getType().identifier,
+                  box.itemType().identifier
+              ]
+              destroy box
+              return identifiers
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeString,
+				},
+				common.Address{},
+				interpreter.NewStringValue("S.test.Box<S.test.R>"),
+				interpreter.NewStringValue("S.test.R"),
+			),
+			value,
+		)
+	})
+}
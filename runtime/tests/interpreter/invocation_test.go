@@ -23,7 +23,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/interpreter"
+	. "github.com/onflow/cadence/runtime/tests/utils"
 )
 
 func TestInterpretFunctionInvocationCheckArgumentTypes(t *testing.T) {
@@ -41,3 +43,76 @@ func TestInterpretFunctionInvocationCheckArgumentTypes(t *testing.T) {
 
 	require.ErrorAs(t, err, &interpreter.ValueTransferTypeError{})
 }
+
+func TestInterpretErrorStackTrace(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+       fun fail() {
+           [1][1]
+       }
+
+       fun helper() {
+           fail()
+       }
+
+       fun test() {
+           helper()
+       }
+   `)
+
+	_, err := inter.Invoke("test")
+	require.Error(t, err)
+
+	var interpreterErr interpreter.Error
+	require.ErrorAs(t, err, &interpreterErr)
+
+	require.Equal(t,
+		[]interpreter.StackTraceFrame{
+			{
+				FunctionName:     "fail",
+				FunctionLocation: TestLocation,
+				LocationRange: interpreter.LocationRange{
+					Location: TestLocation,
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 82, Line: 7, Column: 11},
+						EndPos:   ast.Position{Offset: 87, Line: 7, Column: 16},
+					},
+				},
+			},
+			{
+				FunctionName:     "helper",
+				FunctionLocation: TestLocation,
+				LocationRange: interpreter.LocationRange{
+					Location: TestLocation,
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 130, Line: 11, Column: 11},
+						EndPos:   ast.Position{Offset: 137, Line: 11, Column: 18},
+					},
+				},
+			},
+			{
+				FunctionName:     "test",
+				FunctionLocation: TestLocation,
+			},
+		},
+		interpreterErr.StackTrace,
+	)
+
+	// The call stack is unwound when an error occurs
+
+	_, err = inter.Invoke("fail")
+	require.Error(t, err)
+
+	require.ErrorAs(t, err, &interpreterErr)
+	require.Equal(t,
+		[]interpreter.StackTraceFrame{
+			{
+				FunctionName:     "fail",
+				FunctionLocation: TestLocation,
+			},
+		},
+		interpreterErr.StackTrace,
+	)
+}
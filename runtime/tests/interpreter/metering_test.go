@@ -422,3 +422,213 @@ func TestInterpretFunctionInvocationHandler(t *testing.T) {
 		occurrences,
 	)
 }
+
+func TestInterpretMemoryMetering(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, code string) map[common.MemoryKind]uint {
+		inter := parseCheckAndInterpret(t, code)
+
+		usages := map[common.MemoryKind]uint{}
+		inter.SetOnMeterMemoryHandler(func(kind common.MemoryKind, amount uint) {
+			usages[kind] += amount
+		})
+
+		_, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		return usages
+	}
+
+	t.Run("string", func(t *testing.T) {
+
+		t.Parallel()
+
+		usages := test(t, `
+          fun test() {
+              let s = "abc"
+              let t = s.concat("de")
+              let u = t.slice(from: 1, upTo: 3)
+          }
+        `)
+
+		// "abc", "de", "abcde", "bc"
+		assert.Equal(t, uint(3+2+5+2), usages[common.MemoryKindString])
+		assert.Equal(t, uint(4), usages[common.MemoryKindStringValue])
+	})
+
+	t.Run("string functions", func(t *testing.T) {
+
+		t.Parallel()
+
+		usages := test(t, `
+          fun test() {
+              let s = " a,b "
+              let t = s.trim()
+              let u = t.split(separator: ",")
+              let v = String.join(u, separator: "--")
+              let w = v.replaceAll(of: "-", with: "")
+              let x = w.toUpper()
+              let y = String.fromUTF8([0x61])
+          }
+        `)
+
+		// " a,b ", "a,b", ",", "a,b" (split), "--", "a--b", "-", "", "ab", "AB", "a"
+		assert.Equal(t, uint(5+3+1+3+2+4+1+0+2+2+1), usages[common.MemoryKindString])
+		// " a,b ", "a,b", ",", "a", "b", "--", "a--b", "-", "", "ab", "AB", "a"
+		assert.Equal(t, uint(12), usages[common.MemoryKindStringValue])
+		// "a,b" (split), "a--b" (replaceAll)
+		assert.Equal(t, uint(4+5), usages[common.MemoryKindStringCharacterIndex])
+	})
+
+	t.Run("string search", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test() {
+              let s = "abcd"
+              let a = s.contains("bc")
+              let b = s.index(of: "d")
+              let c = s.split(separator: "")
+          }
+        `)
+
+		usages := map[common.MemoryKind]uint{}
+		inter.SetOnMeterMemoryHandler(func(kind common.MemoryKind, amount uint) {
+			usages[kind] += amount
+		})
+
+		computations := map[common.ComputationKind]uint{}
+		inter.SetOnMeterComputationHandler(func(compKind common.ComputationKind, intensity uint) {
+			computations[compKind] += intensity
+		})
+
+		_, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		// "abcd" (contains), "abcd" (index)
+		assert.Equal(t, uint(5+5), usages[common.MemoryKindStringCharacterIndex])
+		assert.Equal(t, uint(4+4), computations[common.ComputationKindSearchStringValue])
+
+		// "abcd", "bc", "d", "", "a", "b", "c", "d"
+		assert.Equal(t, uint(4+2+1+0+4), usages[common.MemoryKindString])
+		assert.Equal(t, uint(8), usages[common.MemoryKindStringValue])
+
+		// The split produces one string per character
+		assert.Equal(t, uint(4), computations[common.ComputationKindSplitStringValue])
+	})
+
+	t.Run("string result length", func(t *testing.T) {
+
+		t.Parallel()
+
+		usages := test(t, `
+          fun test() {
+              let s = "a-b-c"
+              let t = s.replaceAll(of: "-", with: "---")
+              let u = "\u{131}".toUpper()
+              let v = "\u{23A}".toLower()
+          }
+        `)
+
+		// "a-b-c", "-", "---", "a---b---c",
+		// "ı" (2 bytes), "I" (1 byte), "Ⱥ" (2 bytes), "ⱥ" (3 bytes)
+		assert.Equal(t, uint(5+1+3+9+2+1+2+3), usages[common.MemoryKindString])
+	})
+
+	t.Run("array", func(t *testing.T) {
+
+		t.Parallel()
+
+		usages := test(t, `
+          fun test() {
+              let a = [1, 2, 3]
+              a.append(4)
+              a.insert(at: 0, 0)
+          }
+        `)
+
+		// The array literal is copied when it is assigned to the variable
+		assert.Equal(t, uint(2), usages[common.MemoryKindArray])
+		assert.Equal(t, uint(3+3+2), usages[common.MemoryKindArrayElement])
+	})
+
+	t.Run("array copy", func(t *testing.T) {
+
+		t.Parallel()
+
+		usages := test(t, `
+          fun test() {
+              let a = [1, 2, 3]
+              let b = a
+          }
+        `)
+
+		assert.Equal(t, uint(3), usages[common.MemoryKindArray])
+		assert.Equal(t, uint(9), usages[common.MemoryKindArrayElement])
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		usages := test(t, `
+          fun test() {
+              let d = {"a": 1, "b": 2}
+              d["c"] = 3
+              d["a"] = 4
+          }
+        `)
+
+		// The dictionary literal is copied when it is assigned to the variable.
+		// Updating an existing entry does not use memory
+		assert.Equal(t, uint(2), usages[common.MemoryKindDictionary])
+		assert.Equal(t, uint(2+2+1), usages[common.MemoryKindDictionaryEntry])
+	})
+
+	t.Run("composite", func(t *testing.T) {
+
+		t.Parallel()
+
+		usages := test(t, `
+          resource R {
+              let a: Int
+              let b: Int
+
+              init() {
+                  self.a = 1
+                  self.b = 2
+              }
+          }
+
+          fun test() {
+              let r <- create R()
+              destroy r
+          }
+        `)
+
+		// The resource has an implicit uuid field
+		assert.Equal(t, uint(1), usages[common.MemoryKindComposite])
+		assert.Equal(t, uint(3), usages[common.MemoryKindCompositeField])
+	})
+
+	t.Run("big integer", func(t *testing.T) {
+
+		t.Parallel()
+
+		usages := test(t, `
+          fun test() {
+              let a: Int = 256
+              let b: UInt = 1
+              let c = a * a
+              let d: Int8 = 1
+              let e = d + d
+          }
+        `)
+
+		// 256: 2 bytes, 1: 1 byte, 65536: 3 bytes
+		assert.Equal(t, uint(2+1+3), usages[common.MemoryKindBigInt])
+	})
+}
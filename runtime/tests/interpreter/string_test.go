@@ -206,6 +206,309 @@ func TestInterpretStringToLower(t *testing.T) {
 	)
 }
 
+func TestInterpretStringToUpper(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): String {
+          return "Flowers".toUpper()
+      }
+    `)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	require.Equal(t,
+		interpreter.NewStringValue("FLOWERS"),
+		result,
+	)
+}
+
+func TestInterpretStringTrim(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let a = "  Flowers \t\n".trim()
+      let b = " \t ".trim()
+      let c = " \u{301}Flowers ".trim()
+    `)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewStringValue("Flowers"),
+		inter.Globals["a"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewStringValue(""),
+		inter.Globals["b"].GetValue(),
+	)
+
+	// The space followed by a combining mark is a single character, which is not whitespace
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewStringValue(" \u0301Flowers"),
+		inter.Globals["c"].GetValue(),
+	)
+}
+
+func TestInterpretStringSplit(t *testing.T) {
+
+	t.Parallel()
+
+	stringArray := func(inter *interpreter.Interpreter, strs ...string) *interpreter.ArrayValue {
+		values := make([]interpreter.Value, len(strs))
+		for i, str := range strs {
+			values[i] = interpreter.NewStringValue(str)
+		}
+		return interpreter.NewArrayValue(
+			inter,
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeString,
+			},
+			common.Address{},
+			values...,
+		)
+	}
+
+	inter := parseCheckAndInterpret(t, `
+      let a = "a, b,, c".split(separator: ",")
+      let b = "abc".split(separator: "")
+      let c = "".split(separator: ",")
+      let d = "e\u{301}e".split(separator: "e")
+      let e = "\u{1F1E9}\u{1F1EA}".split(separator: "")
+    `)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		stringArray(inter, "a", " b", "", " c"),
+		inter.Globals["a"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		stringArray(inter, "a", "b", "c"),
+		inter.Globals["b"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		stringArray(inter, ""),
+		inter.Globals["c"].GetValue(),
+	)
+
+	// The "e" followed by a combining acute accent is a different character,
+	// so it does not separate the string
+	AssertValuesEqual(
+		t,
+		inter,
+		stringArray(inter, "e\u0301", ""),
+		inter.Globals["d"].GetValue(),
+	)
+
+	// The regional indicators form a single flag character
+	AssertValuesEqual(
+		t,
+		inter,
+		stringArray(inter, "\U0001F1E9\U0001F1EA"),
+		inter.Globals["e"].GetValue(),
+	)
+}
+
+func TestInterpretStringContains(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let a = "Flowers".contains("owe")
+      let b = "Flowers".contains("Owe")
+      let c = "Flowers".contains("")
+      let d = "Cafe\u{301}".contains("Cafe")
+      let e = "Cafe\u{301}".contains("Cafe\u{301}")
+    `)
+
+	for name, expected := range map[string]bool{
+		"a": true,
+		"b": false,
+		"c": true,
+		"d": false,
+		"e": true,
+	} {
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.BoolValue(expected),
+			inter.Globals[name].GetValue(),
+		)
+	}
+}
+
+func TestInterpretStringIndex(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let a = "Flowers".index(of: "w")
+      let b = "Flowers".index(of: "x")
+      let c = "\u{1F490}\u{1F490} Flowers".index(of: "F")
+      let d = "e\u{301}e".index(of: "e")
+      let e = "Flowers".index(of: "")
+    `)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(3)),
+		inter.Globals["a"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NilValue{},
+		inter.Globals["b"].GetValue(),
+	)
+
+	// The index is the index of the character, not of the byte
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(3)),
+		inter.Globals["c"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(1)),
+		inter.Globals["d"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewSomeValueNonCopying(interpreter.NewIntValueFromInt64(0)),
+		inter.Globals["e"].GetValue(),
+	)
+}
+
+func TestInterpretStringReplaceAll(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let a = "abcabc".replaceAll(of: "b", with: "xy")
+      let b = "aaa".replaceAll(of: "aa", with: "b")
+      let c = "abc".replaceAll(of: "", with: "x")
+      let d = "e\u{301}e".replaceAll(of: "e", with: "a")
+    `)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewStringValue("axycaxyc"),
+		inter.Globals["a"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewStringValue("ba"),
+		inter.Globals["b"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewStringValue("abc"),
+		inter.Globals["c"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewStringValue("e\u0301a"),
+		inter.Globals["d"].GetValue(),
+	)
+}
+
+func TestInterpretStringJoin(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let a = String.join(["a", "b", "c"], separator: ", ")
+      let b = String.join([], separator: ", ")
+      let c = String.join(["a"], separator: ", ")
+    `)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewStringValue("a, b, c"),
+		inter.Globals["a"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewStringValue(""),
+		inter.Globals["b"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewStringValue("a"),
+		inter.Globals["c"].GetValue(),
+	)
+}
+
+func TestInterpretStringFromUTF8(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let a = String.fromUTF8([70, 108, 111, 119, 101, 114, 115, 32, 240, 159, 146, 144])
+      let b = String.fromUTF8([0xFF])
+      let c = String.fromUTF8("Flowers".utf8)
+    `)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewSomeValueNonCopying(
+			interpreter.NewStringValue("Flowers \U0001F490"),
+		),
+		inter.Globals["a"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NilValue{},
+		inter.Globals["b"].GetValue(),
+	)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewSomeValueNonCopying(
+			interpreter.NewStringValue("Flowers"),
+		),
+		inter.Globals["c"].GetValue(),
+	)
+}
+
 func TestInterpretStringAccess(t *testing.T) {
 
 	t.Parallel()